@@ -99,6 +99,7 @@ func initCommands(
 		RunningInAutomation: inAutomation,
 		CLIConfigDir:        configDir,
 		PluginCacheDir:      config.PluginCacheDir,
+		ModuleCacheDir:      config.ModuleCacheDir,
 
 		PluginCacheMayBreakDependencyLockFile: config.PluginCacheMayBreakDependencyLockFile,
 
@@ -398,6 +399,22 @@ func initCommands(
 			}, nil
 		},
 
+		"state history": func() (cli.Command, error) {
+			return &command.StateHistoryCommand{
+				StateMeta: command.StateMeta{
+					Meta: meta,
+				},
+			}, nil
+		},
+
+		"state rollback": func() (cli.Command, error) {
+			return &command.StateRollbackCommand{
+				StateMeta: command.StateMeta{
+					Meta: meta,
+				},
+			}, nil
+		},
+
 		"state show": func() (cli.Command, error) {
 			return &command.StateShowCommand{
 				Meta: meta,
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 
 	"github.com/apparentlymart/go-userdirs/userdirs"
 	"github.com/hashicorp/terraform-svchost/disco"
@@ -43,12 +44,20 @@ func explicitProviderSource(config *cliconfig.ProviderInstallation, services *di
 	var searchRules []getproviders.MultiSourceSelector
 
 	log.Printf("[DEBUG] Explicit provider installation configuration is set")
+	sigstoreVerifications, moreDiags := providerSigstoreVerifications(config.SigstoreVerifications)
+	diags = diags.Append(moreDiags)
 	for _, methodConfig := range config.Methods {
-		source, moreDiags := providerSourceForCLIConfigLocation(methodConfig.Location, services)
+		source, moreDiags := providerSourceForCLIConfigLocation(methodConfig.Location, services, sigstoreVerifications)
 		diags = diags.Append(moreDiags)
 		if moreDiags.HasErrors() {
 			continue
 		}
+		if methodConfig.Location != cliconfig.ProviderInstallationDirect {
+			// Only the direct installation method can fetch sigstore
+			// bundles, so the others must not install the providers
+			// that require them.
+			source = getproviders.NewSigstoreUnsupportedSource(source, sigstoreVerifications)
+		}
 
 		include, err := getproviders.ParseMultiSourceMatchingPatterns(methodConfig.Include)
 		if err != nil {
@@ -187,11 +196,11 @@ func implicitProviderSource(services *disco.Disco) getproviders.Source {
 	return getproviders.MultiSource(searchRules)
 }
 
-func providerSourceForCLIConfigLocation(loc cliconfig.ProviderInstallationLocation, services *disco.Disco) (getproviders.Source, tfdiags.Diagnostics) {
+func providerSourceForCLIConfigLocation(loc cliconfig.ProviderInstallationLocation, services *disco.Disco, sigstoreVerifications []getproviders.SigstoreVerification) (getproviders.Source, tfdiags.Diagnostics) {
 	if loc == cliconfig.ProviderInstallationDirect {
-		return getproviders.NewMemoizeSource(
-			getproviders.NewRegistrySource(services),
-		), nil
+		source := getproviders.NewRegistrySource(services)
+		source.SetSigstoreVerifications(sigstoreVerifications)
+		return getproviders.NewMemoizeSource(source), nil
 	}
 
 	switch loc := loc.(type) {
@@ -221,6 +230,20 @@ func providerSourceForCLIConfigLocation(loc cliconfig.ProviderInstallationLocati
 		}
 		return getproviders.NewHTTPMirrorSource(url, services.CredentialsSource()), nil
 
+	case cliconfig.ProviderInstallationOCIMirror:
+		if err := getproviders.ValidateOCIRepositoryTemplate(string(loc)); err != nil {
+			var diags tfdiags.Diagnostics
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid repository template for provider installation source",
+				fmt.Sprintf("Cannot use %q as the repository template for an OCI provider mirror: %s.", string(loc), err),
+			))
+			return nil, diags
+		}
+		return getproviders.NewMemoizeSource(
+			getproviders.NewOCIMirrorSource(string(loc), services.CredentialsSource()),
+		), nil
+
 	default:
 		// We should not get here because the set of cases above should
 		// be comprehensive for all of the
@@ -229,6 +252,119 @@ func providerSourceForCLIConfigLocation(loc cliconfig.ProviderInstallationLocati
 	}
 }
 
+// providerSigstoreVerifications loads the keys and certificates for the
+// sigstore_verification blocks in the CLI configuration.
+func providerSigstoreVerifications(configs []*cliconfig.ProviderInstallationSigstoreVerification) ([]getproviders.SigstoreVerification, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	var ret []getproviders.SigstoreVerification
+
+	for _, config := range configs {
+		include, err := getproviders.ParseMultiSourceMatchingPatterns(config.Include)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid provider sigstore verification inclusion patterns",
+				fmt.Sprintf("CLI config specifies invalid provider inclusion patterns: %s.", err),
+			))
+			continue
+		}
+		exclude, err := getproviders.ParseMultiSourceMatchingPatterns(config.Exclude)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid provider sigstore verification exclusion patterns",
+				fmt.Sprintf("CLI config specifies invalid provider exclusion patterns: %s.", err),
+			))
+			continue
+		}
+
+		verifier, moreDiags := providerSigstoreVerifier(config)
+		diags = diags.Append(moreDiags)
+		if moreDiags.HasErrors() {
+			continue
+		}
+
+		ret = append(ret, getproviders.SigstoreVerification{
+			Include:  include,
+			Exclude:  exclude,
+			Verifier: verifier,
+		})
+		log.Printf("[TRACE] Selected provider sigstore verification with includes %s and excludes %s", include, exclude)
+	}
+
+	return ret, diags
+}
+
+func providerSigstoreVerifier(config *cliconfig.ProviderInstallationSigstoreVerification) (*getproviders.SigstoreVerifier, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	readFile := func(filename, what string) []byte {
+		if filename == "" {
+			return nil
+		}
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Failed to read provider sigstore verification "+what,
+				fmt.Sprintf("Cannot read the %s for provider sigstore verification from %s: %s.", what, filename, err),
+			))
+		}
+		return src
+	}
+
+	if config.PublicKeyFile != "" {
+		src := readFile(config.PublicKeyFile, "public key")
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		verifier, err := getproviders.NewSigstoreKeyVerifier(src)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid provider sigstore verification public key",
+				fmt.Sprintf("Cannot use %s as the public key for provider sigstore verification: %s.", config.PublicKeyFile, err),
+			))
+			return nil, diags
+		}
+		return verifier, diags
+	}
+
+	roots := readFile(config.CertificateRootsFile, "certificate roots")
+	tlogKey := readFile(config.TransparencyLogPublicKeyFile, "transparency log public key")
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	var identity *regexp.Regexp
+	switch {
+	case config.CertificateIdentity != "":
+		identity = regexp.MustCompile("^" + regexp.QuoteMeta(config.CertificateIdentity) + "$")
+	case config.CertificateIdentityRegexp != "":
+		var err error
+		identity, err = regexp.Compile(config.CertificateIdentityRegexp)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid provider sigstore verification identity pattern",
+				fmt.Sprintf("Cannot use %q as a certificate identity pattern for provider sigstore verification: %s.", config.CertificateIdentityRegexp, err),
+			))
+			return nil, diags
+		}
+	}
+
+	verifier, err := getproviders.NewSigstoreCertificateVerifier(roots, identity, config.CertificateOIDCIssuer, tlogKey)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid provider sigstore verification certificates",
+			fmt.Sprintf("Cannot use the certificate roots in %s for provider sigstore verification: %s.", config.CertificateRootsFile, err),
+		))
+		return nil, diags
+	}
+	return verifier, diags
+}
+
 func providerDevOverrides(configs []*cliconfig.ProviderInstallation) map[addrs.Provider]getproviders.PackageLocalDir {
 	if len(configs) == 0 {
 		return nil
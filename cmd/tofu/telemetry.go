@@ -26,6 +26,15 @@ import (
 // better based on experience with this experiment.
 const openTelemetryExporterEnvVar = "OTEL_TRACES_EXPORTER"
 
+// If OTEL_TRACES_EXPORTER is set to "file" then we'll instead write each
+// span as a line of JSON to the file named in this environment variable,
+// for those who don't have an OTLP collector to hand. The same caveats apply.
+const openTelemetryFileEnvVar = "TF_OTEL_TRACES_FILE"
+
+// defaultOpenTelemetryFile is the file that spans are written to when
+// OTEL_TRACES_EXPORTER is "file" but TF_OTEL_TRACES_FILE isn't set.
+const defaultOpenTelemetryFile = "opentofu-traces.jsonl"
+
 // tracer is the OpenTelemetry tracer to use for traces in package main only.
 var tracer trace.Tracer
 
@@ -50,13 +59,36 @@ func init() {
 // OTLP has emerged as a de-facto standard and each other exporter we support
 // means another relatively-heavy external dependency. OTLP happens to use
 // protocol buffers and gRPC, which OpenTofu would depend on for other reasons
-// anyway.
+// anyway. The only exception is OTEL_TRACES_EXPORTER=file, which writes the
+// spans to a local file using our own trivial exporter.
 func openTelemetryInit() error {
 	// We'll check the environment variable ourselves first, because the
 	// "autoexport" helper we're about to use is built under the assumption
 	// that exporting should always be enabled and so will expect to find
 	// an OTLP server on localhost if no environment variables are set at all.
-	if os.Getenv(openTelemetryExporterEnvVar) != "otlp" {
+	var exp sdktrace.SpanExporter
+	switch os.Getenv(openTelemetryExporterEnvVar) {
+	case "otlp":
+		// If the environment variable was set to explicitly enable telemetry
+		// then we'll enable it, using the "autoexport" library to
+		// automatically handle the details based on the other OpenTelemetry
+		// standard environment variables.
+		var err error
+		exp, err = autoexport.NewSpanExporter(context.Background())
+		if err != nil {
+			return err
+		}
+	case "file":
+		filename := os.Getenv(openTelemetryFileEnvVar)
+		if filename == "" {
+			filename = defaultOpenTelemetryFile
+		}
+		var err error
+		exp, err = newFileSpanExporter(filename)
+		if err != nil {
+			return err
+		}
+	default:
 		return nil // By default we just discard all telemetry calls
 	}
 
@@ -66,14 +98,6 @@ func openTelemetryInit() error {
 		semconv.ServiceVersionKey.String(version.Version),
 	)
 
-	// If the environment variable was set to explicitly enable telemetry
-	// then we'll enable it, using the "autoexport" library to automatically
-	// handle the details based on the other OpenTelemetry standard environment
-	// variables.
-	exp, err := autoexport.NewSpanExporter(context.Background())
-	if err != nil {
-		return err
-	}
 	sp := sdktrace.NewSimpleSpanProcessor(exp)
 	provider := sdktrace.NewTracerProvider(
 		sdktrace.WithSpanProcessor(sp),
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// fileSpanExporter is a span exporter that writes each span as a single line
+// of JSON to a local file.
+//
+// As with the rest of our telemetry, the format of this file is not a
+// committed interface.
+type fileSpanExporter struct {
+	mu  sync.Mutex
+	f   *os.File
+	enc *json.Encoder
+}
+
+var _ sdktrace.SpanExporter = (*fileSpanExporter)(nil)
+
+func newFileSpanExporter(filename string) (*fileSpanExporter, error) {
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace file: %w", err)
+	}
+	return &fileSpanExporter{
+		f:   f,
+		enc: json.NewEncoder(f),
+	}, nil
+}
+
+// fileSpan is the JSON representation of a span in the trace file.
+type fileSpan struct {
+	Name         string                 `json:"name"`
+	TraceID      string                 `json:"trace_id"`
+	SpanID       string                 `json:"span_id"`
+	ParentSpanID string                 `json:"parent_span_id,omitempty"`
+	Start        time.Time              `json:"start"`
+	End          time.Time              `json:"end"`
+	DurationMS   float64                `json:"duration_ms"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	Status       string                 `json:"status,omitempty"`
+	Error        string                 `json:"error,omitempty"`
+}
+
+func (e *fileSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, span := range spans {
+		fs := fileSpan{
+			Name:       span.Name(),
+			TraceID:    span.SpanContext().TraceID().String(),
+			SpanID:     span.SpanContext().SpanID().String(),
+			Start:      span.StartTime(),
+			End:        span.EndTime(),
+			DurationMS: float64(span.EndTime().Sub(span.StartTime())) / float64(time.Millisecond),
+		}
+		if parent := span.Parent(); parent.IsValid() {
+			fs.ParentSpanID = parent.SpanID().String()
+		}
+		if attrs := span.Attributes(); len(attrs) > 0 {
+			fs.Attributes = make(map[string]interface{}, len(attrs))
+			for _, attr := range attrs {
+				fs.Attributes[string(attr.Key)] = attr.Value.AsInterface()
+			}
+		}
+		if status := span.Status(); status.Code != codes.Unset {
+			fs.Status = status.Code.String()
+			fs.Error = status.Description
+		}
+		if err := e.enc.Encode(fs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *fileSpanExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.f.Close()
+}
@@ -168,3 +168,7 @@ func (m Module) Ancestors() []Module {
 func (m Module) configMoveableSigil() {
 	// ModuleInstance is moveable
 }
+
+func (m Module) configRemovableSigil() {
+	// Module is removable
+}
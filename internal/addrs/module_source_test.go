@@ -235,6 +235,20 @@ func TestParseModuleSource(t *testing.T) {
 			},
 		},
 
+		"OCI registry": {
+			input: "oci://example.com/modules/network?tag=1.0.0",
+			want: ModuleSourceRemote{
+				Package: ModulePackage("oci://example.com/modules/network?tag=1.0.0"),
+			},
+		},
+		"OCI registry with subdir": {
+			input: "oci://example.com/modules/network//vpc?digest=sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+			want: ModuleSourceRemote{
+				Package: ModulePackage("oci://example.com/modules/network?digest=sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"),
+				Subdir:  "vpc",
+			},
+		},
+
 		"HTTP URL": {
 			input: "http://example.com/module",
 			want: ModuleSourceRemote{
@@ -0,0 +1,27 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package addrs
+
+// ConfigRemovable is an interface implemented by address types that can be
+// the target of a "removed" statement in configuration.
+//
+// Like ConfigMoveable, ConfigRemovable represents a static object in the
+// configuration, because a removed block always applies to all instances of
+// the object it refers to. It is an absolute address relative to the root
+// of the configuration, which is different than the direct representation
+// of these in configuration where the author gives an address relative to
+// the current module where the address is defined. The type RemoveEndpoint
+// represents the relative form given directly in configuration.
+type ConfigRemovable interface {
+	Targetable
+	configRemovableSigil()
+
+	String() string
+}
+
+// The following are all of the possible ConfigRemovable address types:
+var (
+	_ ConfigRemovable = ConfigResource{}
+	_ ConfigRemovable = Module(nil)
+)
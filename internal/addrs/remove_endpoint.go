@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package addrs
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// RemoveEndpoint is to ConfigRemovable what Target is to Targetable:
+// a wrapping struct that captures the result of decoding an HCL
+// traversal representing a relative path from the current module to
+// a removable object.
+//
+// Remove endpoints are always static addresses, because a "removed" block
+// applies to all instances of the object it refers to. To obtain a full
+// address from a RemoveEndpoint you must use the method ConfigRemovable.
+type RemoveEndpoint struct {
+	// SourceRange is the location of the physical endpoint address
+	// in configuration, if this RemoveEndpoint was decoded from a
+	// configuration expression.
+	SourceRange tfdiags.SourceRange
+
+	// RelSubject is the address of the object being removed, relative to
+	// the module where the RemoveEndpoint was declared. It's always either
+	// a Module or a ConfigResource.
+	RelSubject ConfigRemovable
+}
+
+func (e *RemoveEndpoint) String() string {
+	return e.RelSubject.String()
+}
+
+// ConfigRemovable transforms the reciever into a ConfigRemovable by
+// resolving it relative to the given base module, which should be the module
+// where the RemoveEndpoint expression was found.
+func (e *RemoveEndpoint) ConfigRemovable(baseModule Module) ConfigRemovable {
+	switch addr := e.RelSubject.(type) {
+	case Module:
+		ret := make(Module, 0, len(baseModule)+len(addr))
+		ret = append(ret, baseModule...)
+		ret = append(ret, addr...)
+		return ret
+	case ConfigResource:
+		moduleAddr := make(Module, 0, len(baseModule)+len(addr.Module))
+		moduleAddr = append(moduleAddr, baseModule...)
+		moduleAddr = append(moduleAddr, addr.Module...)
+		return ConfigResource{
+			Module:   moduleAddr,
+			Resource: addr.Resource,
+		}
+	default:
+		// The above should be exhaustive for all of the types
+		// that ParseRemoveEndpoint produces.
+		panic(fmt.Sprintf("unsupported address type %T", addr))
+	}
+}
+
+// ParseRemoveEndpoint attempts to interpret the given traversal as a
+// "remove endpoint" address, which is a relative path from the module
+// containing the traversal to a removable object in either the same module
+// or in some child module.
+//
+// Unlike move endpoints, remove endpoints must not include any instance keys,
+// and must refer to either a managed resource or a module call.
+//
+// This deals only with the syntactic element of a remove endpoint expression
+// in configuration. Before the result will be useful you'll need to combine
+// it with the address of the module where it was declared in order to get
+// an absolute address relative to the root module.
+func ParseRemoveEndpoint(traversal hcl.Traversal) (*RemoveEndpoint, tfdiags.Diagnostics) {
+	path, remain, diags := parseModuleInstancePrefix(traversal)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	rng := tfdiags.SourceRangeFromHCL(traversal.SourceRange())
+
+	if !isStaticModuleInstance(path) {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Module instance keys not allowed",
+			Detail:   "Removed blocks apply to all instances of a module, so the \"from\" address must not include module instance keys.",
+			Subject:  traversal.SourceRange().Ptr(),
+		})
+		return nil, diags
+	}
+
+	if len(remain) == 0 {
+		return &RemoveEndpoint{
+			RelSubject:  path.Module(),
+			SourceRange: rng,
+		}, diags
+	}
+
+	riAddr, moreDiags := parseResourceInstanceUnderModule(path, remain)
+	diags = diags.Append(moreDiags)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	if riAddr.Resource.Resource.Mode == DataResourceMode {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Data source address is not allowed",
+			Detail:   "Data sources cannot be removed from the state, so the \"from\" address must refer to a managed resource or a module.",
+			Subject:  traversal.SourceRange().Ptr(),
+		})
+		return nil, diags
+	}
+
+	if riAddr.Resource.Key != NoKey {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Resource instance keys not allowed",
+			Detail:   "Removed blocks apply to all instances of a resource, so the \"from\" address must not include resource instance keys.",
+			Subject:  traversal.SourceRange().Ptr(),
+		})
+		return nil, diags
+	}
+
+	return &RemoveEndpoint{
+		RelSubject:  riAddr.ConfigResource(),
+		SourceRange: rng,
+	}, diags
+}
+
+// isStaticModuleInstance returns true if none of the steps in the given
+// module instance address have instance keys.
+func isStaticModuleInstance(addr ModuleInstance) bool {
+	for _, step := range addr {
+		if step.InstanceKey != NoKey {
+			return false
+		}
+	}
+	return true
+}
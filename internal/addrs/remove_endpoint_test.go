@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package addrs
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestParseRemoveEndpoint(t *testing.T) {
+	tests := []struct {
+		Input   string
+		WantRel ConfigRemovable
+		WantErr string
+	}{
+		{
+			`foo.bar`,
+			ConfigResource{
+				Module: RootModule,
+				Resource: Resource{
+					Mode: ManagedResourceMode,
+					Type: "foo",
+					Name: "bar",
+				},
+			},
+			``,
+		},
+		{
+			`module.boop`,
+			Module{"boop"},
+			``,
+		},
+		{
+			`module.boop.module.beep`,
+			Module{"boop", "beep"},
+			``,
+		},
+		{
+			`module.boop.foo.bar`,
+			ConfigResource{
+				Module: Module{"boop"},
+				Resource: Resource{
+					Mode: ManagedResourceMode,
+					Type: "foo",
+					Name: "bar",
+				},
+			},
+			``,
+		},
+		{
+			`foo.bar[0]`,
+			nil,
+			`Resource instance keys not allowed: Removed blocks apply to all instances of a resource, so the "from" address must not include resource instance keys.`,
+		},
+		{
+			`module.boop["a"].foo.bar`,
+			nil,
+			`Module instance keys not allowed: Removed blocks apply to all instances of a module, so the "from" address must not include module instance keys.`,
+		},
+		{
+			`module.boop[1]`,
+			nil,
+			`Module instance keys not allowed: Removed blocks apply to all instances of a module, so the "from" address must not include module instance keys.`,
+		},
+		{
+			`data.foo.bar`,
+			nil,
+			`Data source address is not allowed: Data sources cannot be removed from the state, so the "from" address must refer to a managed resource or a module.`,
+		},
+		{
+			`foo`,
+			nil,
+			`Invalid address: Resource specification must include a resource type and name.`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Input, func(t *testing.T) {
+			traversal, hclDiags := hclsyntax.ParseTraversalAbs([]byte(test.Input), "", hcl.InitialPos)
+			if hclDiags.HasErrors() {
+				t.Fatalf("syntax error: %s", hclDiags.Error())
+			}
+
+			removeEp, diags := ParseRemoveEndpoint(traversal)
+
+			switch {
+			case test.WantErr != "":
+				if !diags.HasErrors() {
+					t.Fatalf("unexpected success\nwant error: %s", test.WantErr)
+				}
+				gotErr := diags.Err().Error()
+				if gotErr != test.WantErr {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", gotErr, test.WantErr)
+				}
+			default:
+				if diags.HasErrors() {
+					t.Fatalf("unexpected error: %s", diags.Err().Error())
+				}
+				if diff := cmp.Diff(test.WantRel, removeEp.RelSubject); diff != "" {
+					t.Errorf("wrong result\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestRemoveEndpointConfigRemovable(t *testing.T) {
+	traversal, hclDiags := hclsyntax.ParseTraversalAbs([]byte(`module.child.foo.bar`), "", hcl.InitialPos)
+	if hclDiags.HasErrors() {
+		t.Fatalf("syntax error: %s", hclDiags.Error())
+	}
+	ep, diags := ParseRemoveEndpoint(traversal)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected error: %s", diags.Err().Error())
+	}
+
+	got := ep.ConfigRemovable(Module{"parent"})
+	if got, want := got.String(), "module.parent.module.child.foo.bar"; got != want {
+		t.Errorf("wrong result\ngot:  %s\nwant: %s", got, want)
+	}
+}
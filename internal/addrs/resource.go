@@ -449,6 +449,10 @@ func (r ConfigResource) configMoveableSigil() {
 	// ConfigResource is moveable
 }
 
+func (r ConfigResource) configRemovableSigil() {
+	// ConfigResource is removable
+}
+
 func (r ConfigResource) configCheckableSigil() {
 	// ConfigResource represents a configuration object that declares checkable objects
 }
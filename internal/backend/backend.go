@@ -22,6 +22,7 @@ import (
 	"github.com/opentofu/opentofu/internal/configs/configload"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
 	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/plans/planfile"
 	"github.com/opentofu/opentofu/internal/states"
@@ -51,8 +52,9 @@ var (
 	ErrWorkspacesNotSupported = errors.New("workspaces not supported")
 )
 
-// InitFn is used to initialize a new backend.
-type InitFn func() Backend
+// InitFn is used to initialize a new backend. The given encryption should be
+// used by the backend for all state snapshots it stores.
+type InitFn func(encryption.StateEncryption) Backend
 
 // Backend is the minimal interface that must be implemented to enable OpenTofu.
 type Backend interface {
@@ -271,14 +273,24 @@ type Operation struct {
 	// plan and apply arguments but may not work for all backends.
 	PlanFile *planfile.WrappedPlanFile
 
+	// Encryption is the encryption configured for the working directory,
+	// which is used for any plan file written by the operation.
+	Encryption encryption.Encryption
+
 	// The options below are more self-explanatory and affect the runtime
 	// behavior of the operation.
 	PlanMode     plans.Mode
 	AutoApprove  bool
 	Targets      []addrs.Targetable
+	Excludes     []addrs.Targetable
 	ForceReplace []addrs.AbsResourceInstance
 	Variables    map[string]UnparsedVariableValue
 
+	// AllowDeferral, if set, defers the changes for resources whose count or
+	// for_each value isn't known yet to a later plan, along with the changes
+	// for everything that depends on them, instead of failing the plan.
+	AllowDeferral bool
+
 	// Some operations use root module variables only opportunistically or
 	// don't need them at all. If this flag is set, the backend must treat
 	// all variables as optional and provide an unknown value for any required
@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/terraform-svchost/disco"
 	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/zclconf/go-cty/cty"
 
@@ -24,6 +25,7 @@ import (
 	backendKubernetes "github.com/opentofu/opentofu/internal/backend/remote-state/kubernetes"
 	backendOSS "github.com/opentofu/opentofu/internal/backend/remote-state/oss"
 	backendPg "github.com/opentofu/opentofu/internal/backend/remote-state/pg"
+	backendPlugin "github.com/opentofu/opentofu/internal/backend/remote-state/plugin"
 	backendS3 "github.com/opentofu/opentofu/internal/backend/remote-state/s3"
 	backendCloud "github.com/opentofu/opentofu/internal/cloud"
 )
@@ -37,8 +39,9 @@ import (
 //
 // Backends are hardcoded into OpenTofu because the API for backends uses
 // complex structures and supporting that over the plugin system is currently
-// prohibitively difficult. For those wanting to implement a custom backend,
-// they can do so with recompilation.
+// prohibitively difficult. For those wanting to store state elsewhere, the
+// "plugin" backend delegates the storage of state snapshots and locks to an
+// external program, and otherwise a custom backend requires recompilation.
 var backends map[string]backend.InitFn
 var backendsLock sync.Mutex
 
@@ -52,24 +55,32 @@ func Init(services *disco.Disco) {
 	defer backendsLock.Unlock()
 
 	backends = map[string]backend.InitFn{
-		"local":  func() backend.Backend { return backendLocal.New() },
-		"remote": func() backend.Backend { return backendRemote.New(services) },
+		"local": func(enc encryption.StateEncryption) backend.Backend { return backendLocal.New(enc) },
+		"remote": func(enc encryption.StateEncryption) backend.Backend {
+			return backendRemote.NewWithStateEncryption(services, enc)
+		},
 
 		// Remote State backends.
-		"azurerm":    func() backend.Backend { return backendAzure.New() },
-		"consul":     func() backend.Backend { return backendConsul.New() },
-		"cos":        func() backend.Backend { return backendCos.New() },
-		"gcs":        func() backend.Backend { return backendGCS.New() },
-		"http":       func() backend.Backend { return backendHTTP.New() },
-		"inmem":      func() backend.Backend { return backendInmem.New() },
-		"kubernetes": func() backend.Backend { return backendKubernetes.New() },
-		"oss":        func() backend.Backend { return backendOSS.New() },
-		"pg":         func() backend.Backend { return backendPg.New() },
-		"s3":         func() backend.Backend { return backendS3.New() },
+		"azurerm":    func(enc encryption.StateEncryption) backend.Backend { return backendAzure.New(enc) },
+		"consul":     func(enc encryption.StateEncryption) backend.Backend { return backendConsul.New(enc) },
+		"cos":        func(enc encryption.StateEncryption) backend.Backend { return backendCos.New(enc) },
+		"gcs":        func(enc encryption.StateEncryption) backend.Backend { return backendGCS.New(enc) },
+		"http":       func(enc encryption.StateEncryption) backend.Backend { return backendHTTP.New(enc) },
+		"inmem":      func(enc encryption.StateEncryption) backend.Backend { return backendInmem.New(enc) },
+		"kubernetes": func(enc encryption.StateEncryption) backend.Backend { return backendKubernetes.New(enc) },
+		"oss":        func(enc encryption.StateEncryption) backend.Backend { return backendOSS.New(enc) },
+		"pg":         func(enc encryption.StateEncryption) backend.Backend { return backendPg.New(enc) },
+		"plugin":     func(enc encryption.StateEncryption) backend.Backend { return backendPlugin.New(enc) },
+		"s3":         func(enc encryption.StateEncryption) backend.Backend { return backendS3.New(enc) },
 
 		// Terraform Cloud 'backend'
-		// This is an implementation detail only, used for the cloud package
-		"cloud": func() backend.Backend { return backendCloud.New(services) },
+		// This is an implementation detail only, used for the cloud package.
+		// Like the "remote" backend, it doesn't support client-side state
+		// encryption because the remote service must be able to read the
+		// state, and so it refuses to be configured when it's enabled.
+		"cloud": func(enc encryption.StateEncryption) backend.Backend {
+			return backendCloud.NewWithStateEncryption(services, enc)
+		},
 	}
 
 	RemovedBackends = map[string]string{
@@ -5,7 +5,12 @@ package init
 
 import (
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/encryption"
 )
 
 func TestInit_backend(t *testing.T) {
@@ -24,6 +29,7 @@ func TestInit_backend(t *testing.T) {
 		{"gcs", "*gcs.Backend"},
 		{"inmem", "*inmem.Backend"},
 		{"pg", "*pg.Backend"},
+		{"plugin", "*plugin.Backend"},
 		{"s3", "*s3.Backend"},
 	}
 
@@ -34,10 +40,50 @@ func TestInit_backend(t *testing.T) {
 			if f == nil {
 				t.Fatalf("backend %q is not present; should be", b.Name)
 			}
-			bType := reflect.TypeOf(f()).String()
+			bType := reflect.TypeOf(f(encryption.StateEncryptionDisabled())).String()
 			if bType != b.Type {
 				t.Fatalf("expected backend %q to be %q, got: %q", b.Name, b.Type, bType)
 			}
 		})
 	}
 }
+
+func TestInit_backendStateEncryptionUnsupported(t *testing.T) {
+	// Initialize the backends map
+	Init(nil)
+
+	t.Setenv(encryption.ConfigEnvName, `
+key_provider "pbkdf2" "main" {
+  passphrase = "correct-horse-battery-staple"
+}
+method "aes_gcm" "main" {
+  keys = key_provider.pbkdf2.main
+}
+state {
+  method   = method.aes_gcm.main
+  enforced = true
+}
+`)
+	cfg, diags := encryption.ConfigFromEnv()
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	enc, diags := encryption.New(cfg)
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+
+	for _, name := range []string{"remote", "cloud"} {
+		t.Run(name, func(t *testing.T) {
+			b := Backend(name)(enc.State())
+			obj := cty.UnknownVal(b.ConfigSchema().ImpliedType())
+			diags := b.Configure(obj)
+			if !diags.HasErrors() {
+				t.Fatalf("backend %q accepted enforced state encryption", name)
+			}
+			if got, want := diags.Err().Error(), "State encryption is not supported"; !strings.Contains(got, want) {
+				t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
@@ -16,18 +16,21 @@ import (
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/command/views"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/logging"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/opentofu/opentofu/internal/tofu"
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/gocty"
 )
 
 const (
-	DefaultWorkspaceDir    = "terraform.tfstate.d"
-	DefaultWorkspaceFile   = "environment"
-	DefaultStateFilename   = "terraform.tfstate"
-	DefaultBackupExtension = ".backup"
+	DefaultWorkspaceDir     = "terraform.tfstate.d"
+	DefaultWorkspaceFile    = "environment"
+	DefaultStateFilename    = "terraform.tfstate"
+	DefaultBackupExtension  = ".backup"
+	DefaultHistoryExtension = ".history"
 )
 
 // Local is an implementation of EnhancedBackend that performs all operations
@@ -53,6 +56,12 @@ type Local struct {
 	StateBackupPath   string
 	StateWorkspaceDir string
 
+	// StateHistoryLimit is the number of prior state snapshots to keep in
+	// the history directory next to each state file, which is the state
+	// output path with DefaultHistoryExtension appended. History is disabled
+	// if this is zero.
+	StateHistoryLimit int
+
 	// The OverrideState* paths are set based on per-operation CLI arguments
 	// and will override what'd be built from the State* fields if non-empty.
 	// While the interpretation of the State* fields depends on the active
@@ -87,20 +96,26 @@ type Local struct {
 
 	// opLock locks operations
 	opLock sync.Mutex
+
+	// encryption is used for the state snapshots stored locally, when
+	// Backend is nil, and for any state written as a last resort when
+	// persisting state fails.
+	encryption encryption.StateEncryption
 }
 
 var _ backend.Backend = (*Local)(nil)
 
 // New returns a new initialized local backend.
-func New() *Local {
-	return NewWithBackend(nil)
+func New(enc encryption.StateEncryption) *Local {
+	return NewWithBackend(nil, enc)
 }
 
 // NewWithBackend returns a new local backend initialized with a
 // dedicated backend for non-enhanced behavior.
-func NewWithBackend(backend backend.Backend) *Local {
+func NewWithBackend(backend backend.Backend, enc encryption.StateEncryption) *Local {
 	return &Local{
-		Backend: backend,
+		Backend:    backend,
+		encryption: enc,
 	}
 }
 
@@ -118,6 +133,10 @@ func (b *Local) ConfigSchema() *configschema.Block {
 				Type:     cty.String,
 				Optional: true,
 			},
+			"history_limit": {
+				Type:     cty.Number,
+				Optional: true,
+			},
 		},
 	}
 }
@@ -153,6 +172,18 @@ func (b *Local) PrepareConfig(obj cty.Value) (cty.Value, tfdiags.Diagnostics) {
 		}
 	}
 
+	if val := obj.GetAttr("history_limit"); !val.IsNull() {
+		var limit int
+		if err := gocty.FromCtyValue(val, &limit); err != nil || limit < 0 {
+			diags = diags.Append(tfdiags.AttributeValue(
+				tfdiags.Error,
+				"Invalid local state history limit",
+				`The "history_limit" attribute value must be a whole number that is zero or greater.`,
+				cty.Path{cty.GetAttrStep{Name: "history_limit"}},
+			))
+		}
+	}
+
 	return obj, diags
 }
 
@@ -179,6 +210,14 @@ func (b *Local) Configure(obj cty.Value) tfdiags.Diagnostics {
 		b.StateWorkspaceDir = DefaultWorkspaceDir
 	}
 
+	if val := obj.GetAttr("history_limit"); !val.IsNull() {
+		if err := gocty.FromCtyValue(val, &b.StateHistoryLimit); err != nil {
+			diags = diags.Append(err)
+		}
+	} else {
+		b.StateHistoryLimit = 0
+	}
+
 	return diags
 }
 
@@ -255,10 +294,13 @@ func (b *Local) StateMgr(name string) (statemgr.Full, error) {
 	statePath, stateOutPath, backupPath := b.StatePaths(name)
 	log.Printf("[TRACE] backend/local: state manager for workspace %q will:\n - read initial snapshot from %s\n - write new snapshots to %s\n - create any backup at %s", name, statePath, stateOutPath, backupPath)
 
-	s := statemgr.NewFilesystemBetweenPaths(statePath, stateOutPath)
+	s := statemgr.NewFilesystemBetweenPaths(statePath, stateOutPath, b.encryption)
 	if backupPath != "" {
 		s.SetBackupPath(backupPath)
 	}
+	if b.StateHistoryLimit > 0 {
+		s.SetHistory(stateOutPath+DefaultHistoryExtension, b.StateHistoryLimit)
+	}
 
 	if b.states == nil {
 		b.states = map[string]statemgr.Full{}
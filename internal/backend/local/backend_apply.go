@@ -52,7 +52,7 @@ func (b *Local) opApply(
 	op.Hooks = append(op.Hooks, stateHook)
 
 	// Get our context
-	lr, _, opState, contextDiags := b.localRun(op)
+	lr, _, opState, contextDiags := b.localRun(stopCtx, op)
 	diags = diags.Append(contextDiags)
 	if contextDiags.HasErrors() {
 		op.ReportResult(runningOp, diags)
@@ -293,7 +293,7 @@ func (b *Local) backupStateForError(stateFile *statefile.File, err error, view v
 		fmt.Sprintf("Error saving state: %s", err),
 	))
 
-	local := statemgr.NewFilesystem("errored.tfstate")
+	local := statemgr.NewFilesystem("errored.tfstate", b.encryption)
 	writeErr := local.WriteStateForMigration(stateFile, true)
 	if writeErr != nil {
 		diags = diags.Append(tfdiags.Sourceless(
@@ -307,7 +307,7 @@ func (b *Local) backupStateForError(stateFile *statefile.File, err error, view v
 		// UX, so we should definitely avoid doing this if at all possible,
 		// but at least the user has _some_ path to recover if we end up
 		// here for some reason.
-		if dumpErr := view.EmergencyDumpState(stateFile); dumpErr != nil {
+		if dumpErr := view.EmergencyDumpState(stateFile, b.encryption); dumpErr != nil {
 			diags = diags.Append(tfdiags.Sourceless(
 				tfdiags.Error,
 				"Failed to serialize state",
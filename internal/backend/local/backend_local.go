@@ -10,11 +10,13 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configload"
+	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/plans/planfile"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -34,11 +36,14 @@ func (b *Local) LocalRun(op *backend.Operation) (*backend.LocalRun, statemgr.Ful
 
 	op.StateLocker = op.StateLocker.WithContext(context.Background())
 
-	lr, _, stateMgr, diags := b.localRun(op)
+	lr, _, stateMgr, diags := b.localRun(context.Background(), op)
 	return lr, stateMgr, diags
 }
 
-func (b *Local) localRun(op *backend.Operation) (*backend.LocalRun, *configload.Snapshot, statemgr.Full, tfdiags.Diagnostics) {
+// localRun prepares the given operation to run locally. The spans that
+// OpenTofu Core emits while running it are created under the span in ctx, if
+// any.
+func (b *Local) localRun(ctx context.Context, op *backend.Operation) (*backend.LocalRun, *configload.Snapshot, statemgr.Full, tfdiags.Diagnostics) {
 	var diags tfdiags.Diagnostics
 
 	// Get the latest state.
@@ -76,6 +81,7 @@ func (b *Local) localRun(op *backend.Operation) (*backend.LocalRun, *configload.
 	}
 	coreOpts.UIInput = op.UIIn
 	coreOpts.Hooks = op.Hooks
+	coreOpts.TraceContext = ctx
 
 	var ctxDiags tfdiags.Diagnostics
 	var configSnap *configload.Snapshot
@@ -199,7 +205,9 @@ func (b *Local) localRunDirect(op *backend.Operation, run *backend.LocalRun, cor
 	planOpts := &tofu.PlanOpts{
 		Mode:               op.PlanMode,
 		Targets:            op.Targets,
+		Excludes:           op.Excludes,
 		ForceReplace:       op.ForceReplace,
+		AllowDeferral:      op.AllowDeferral,
 		SetVariables:       variables,
 		SkipRefresh:        op.Type != backend.OperationTypeRefresh && !op.PlanRefresh,
 		GenerateConfigPath: op.GenerateConfigOut,
@@ -245,7 +253,21 @@ func (b *Local) localRunForPlanFile(op *backend.Operation, pf *planfile.Reader,
 		))
 		return nil, snap, diags
 	}
+	plan, err := pf.ReadPlan()
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			errSummary,
+			fmt.Sprintf("Failed to read plan from plan file: %s.", err),
+		))
+		return nil, snap, diags
+	}
+
 	loader := configload.NewLoaderFromSnapshot(snap)
+	// The configuration may refer to input variables in the parts that must
+	// be known before planning, such as module source addresses, so we
+	// evaluate those with the values the plan was created with.
+	loader.SetStaticInputs(planStaticInputs(plan))
 	config, configDiags := loader.LoadConfig(snap.Modules[""].Dir)
 	diags = diags.Append(configDiags)
 	if configDiags.HasErrors() {
@@ -339,15 +361,6 @@ func (b *Local) localRunForPlanFile(op *backend.Operation, pf *planfile.Reader,
 	// refreshing we did while building the plan.
 	run.InputState = priorStateFile.State
 
-	plan, err := pf.ReadPlan()
-	if err != nil {
-		diags = diags.Append(tfdiags.Sourceless(
-			tfdiags.Error,
-			errSummary,
-			fmt.Sprintf("Failed to read plan from plan file: %s.", err),
-		))
-		return nil, snap, diags
-	}
 	// When we're applying a saved plan, we populate Plan instead of PlanOpts,
 	// because a plan object incorporates the subset of data from PlanOps that
 	// we need to apply the plan.
@@ -362,6 +375,26 @@ func (b *Local) localRunForPlanFile(op *backend.Operation, pf *planfile.Reader,
 	return run, snap, diags
 }
 
+// planStaticInputs returns the values of the root module's input variables
+// recorded in the given plan, for static evaluation of its configuration.
+func planStaticInputs(plan *plans.Plan) configs.StaticInputs {
+	return func(v *configs.Variable) (cty.Value, hcl.Diagnostics) {
+		raw, exists := plan.VariableValues[v.Name]
+		if !exists {
+			return cty.NilVal, nil
+		}
+		val, err := raw.Decode(cty.DynamicPseudoType)
+		if err != nil {
+			return cty.NilVal, hcl.Diagnostics{{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid plan file",
+				Detail:   fmt.Sprintf("Failed to decode the value of input variable %q from the plan file: %s.", v.Name, err),
+			}}
+		}
+		return val, nil
+	}
+}
+
 // interactiveCollectVariables attempts to complete the given existing
 // map of variables by interactively prompting for any variables that are
 // declared as required but not yet present.
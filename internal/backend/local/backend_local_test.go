@@ -17,6 +17,7 @@ import (
 	"github.com/opentofu/opentofu/internal/command/views"
 	"github.com/opentofu/opentofu/internal/configs/configload"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/initwd"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/plans/planfile"
@@ -95,7 +96,7 @@ func TestLocalRun_cloudPlan(t *testing.T) {
 
 	planPath := "./testdata/plan-bookmark/bookmark.json"
 
-	planFile, err := planfile.OpenWrapped(planPath)
+	planFile, err := planfile.OpenWrapped(planPath, encryption.PlanEncryptionDisabled())
 	if err != nil {
 		t.Fatalf("unexpected error reading planfile: %s", err)
 	}
@@ -133,7 +134,7 @@ func TestLocalRun_stalePlan(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error creating state file %s: %s", b.StatePath, err)
 	}
-	if err := statefile.Write(statefile.New(states.NewState(), "boop", 3), sf); err != nil {
+	if err := statefile.Write(statefile.New(states.NewState(), "boop", 3), sf, encryption.StateEncryptionDisabled()); err != nil {
 		t.Fatalf("unexpected error writing state file: %s", err)
 	}
 
@@ -150,6 +151,7 @@ func TestLocalRun_stalePlan(t *testing.T) {
 	backendConfig := cty.ObjectVal(map[string]cty.Value{
 		"path":          cty.NullVal(cty.String),
 		"workspace_dir": cty.NullVal(cty.String),
+		"history_limit": cty.NullVal(cty.Number),
 	})
 	backendConfigRaw, err := plans.NewDynamicValue(backendConfig, backendConfig.Type())
 	if err != nil {
@@ -178,10 +180,10 @@ func TestLocalRun_stalePlan(t *testing.T) {
 		StateFile:            stateFile,
 		Plan:                 plan,
 	}
-	if err := planfile.Create(planPath, planfileArgs); err != nil {
+	if err := planfile.Create(planPath, planfileArgs, encryption.PlanEncryptionDisabled()); err != nil {
 		t.Fatalf("unexpected error writing planfile: %s", err)
 	}
-	planFile, err := planfile.OpenWrapped(planPath)
+	planFile, err := planfile.OpenWrapped(planPath, encryption.PlanEncryptionDisabled())
 	if err != nil {
 		t.Fatalf("unexpected error reading planfile: %s", err)
 	}
@@ -17,13 +17,14 @@ import (
 	"github.com/hashicorp/go-retryablehttp"
 
 	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/legacy/helper/schema"
 	"github.com/opentofu/opentofu/internal/logging"
 	"github.com/opentofu/opentofu/internal/states/remote"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
 )
 
-func New() backend.Backend {
+func New(enc encryption.StateEncryption) backend.Backend {
 	s := &schema.Backend{
 		Schema: map[string]*schema.Schema{
 			"address": &schema.Schema{
@@ -62,6 +63,18 @@ func New() backend.Backend {
 				DefaultFunc: schema.EnvDefaultFunc("TF_HTTP_UNLOCK_METHOD", "UNLOCK"),
 				Description: "The HTTP method to use when unlocking",
 			},
+			"lock_mode": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TF_HTTP_LOCK_MODE", lockModeMethods),
+				Description: `How to lock the state: "methods" to send lock_method and unlock_method requests, or "conditional" to write a lock object with conditional requests`,
+			},
+			"lock_ttl": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TF_HTTP_LOCK_TTL", int(defaultLockTTL/time.Second)),
+				Description: "The time in seconds after which a lock that is no longer refreshed expires, when lock_mode is \"conditional\"",
+			},
 			"username": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -119,13 +132,14 @@ func New() backend.Backend {
 		},
 	}
 
-	b := &Backend{Backend: s}
+	b := &Backend{Backend: s, encryption: enc}
 	b.Backend.ConfigureFunc = b.configure
 	return b
 }
 
 type Backend struct {
 	*schema.Backend
+	encryption encryption.StateEncryption
 
 	client *httpClient
 }
@@ -216,6 +230,28 @@ func (b *Backend) configure(ctx context.Context) error {
 
 	unlockMethod := data.Get("unlock_method").(string)
 
+	lockMode := data.Get("lock_mode").(string)
+	lockTTL := time.Duration(data.Get("lock_ttl").(int)) * time.Second
+	switch lockMode {
+	case lockModeMethods:
+	case lockModeConditional:
+		if lockTTL <= 0 {
+			return fmt.Errorf("lock_ttl must be a positive number of seconds")
+		}
+		if unlockURL != nil {
+			return fmt.Errorf("unlock_address cannot be used when lock_mode is %q", lockModeConditional)
+		}
+		// The lock object lives next to the state by default.
+		if lockURL == nil {
+			u := *updateURL
+			u.Path += ".lock"
+			u.RawPath = ""
+			lockURL = &u
+		}
+	default:
+		return fmt.Errorf("lock_mode must be %q or %q", lockModeMethods, lockModeConditional)
+	}
+
 	rClient := retryablehttp.NewClient()
 	rClient.RetryMax = data.Get("retry_max").(int)
 	rClient.RetryWaitMin = time.Duration(data.Get("retry_wait_min").(int)) * time.Second
@@ -233,6 +269,8 @@ func (b *Backend) configure(ctx context.Context) error {
 		LockMethod:   lockMethod,
 		UnlockURL:    unlockURL,
 		UnlockMethod: unlockMethod,
+		LockMode:     lockMode,
+		LockTTL:      lockTTL,
 
 		Username: data.Get("username").(string),
 		Password: data.Get("password").(string),
@@ -248,7 +286,7 @@ func (b *Backend) StateMgr(name string) (statemgr.Full, error) {
 		return nil, backend.ErrWorkspacesNotSupported
 	}
 
-	return &remote.State{Client: b.client}, nil
+	return remote.NewState(b.client, b.encryption), nil
 }
 
 func (b *Backend) Workspaces() ([]string, error) {
@@ -12,6 +12,7 @@ import (
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/encryption"
 )
 
 func TestBackend_impl(t *testing.T) {
@@ -24,7 +25,7 @@ func TestHTTPClientFactory(t *testing.T) {
 	conf := map[string]cty.Value{
 		"address": cty.StringVal("http://127.0.0.1:8888/foo"),
 	}
-	b := backend.TestBackendConfig(t, New(), configs.SynthBody("synth", conf)).(*Backend)
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), configs.SynthBody("synth", conf)).(*Backend)
 	client := b.client
 
 	if client == nil {
@@ -61,7 +62,7 @@ func TestHTTPClientFactory(t *testing.T) {
 		"retry_wait_max": cty.StringVal("150"),
 	}
 
-	b = backend.TestBackendConfig(t, New(), configs.SynthBody("synth", conf)).(*Backend)
+	b = backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), configs.SynthBody("synth", conf)).(*Backend)
 	client = b.client
 
 	if client == nil {
@@ -93,6 +94,37 @@ func TestHTTPClientFactory(t *testing.T) {
 	}
 }
 
+func TestHTTPClientFactoryConditionalLock(t *testing.T) {
+	conf := map[string]cty.Value{
+		"address":   cty.StringVal("http://127.0.0.1:8888/foo"),
+		"lock_mode": cty.StringVal("conditional"),
+	}
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), configs.SynthBody("synth", conf)).(*Backend)
+	client := b.client
+
+	if client.LockMode != "conditional" {
+		t.Fatalf("Expected lock_mode \"conditional\", got \"%s\"", client.LockMode)
+	}
+	if client.LockTTL != 5*time.Minute {
+		t.Fatalf("Expected lock_ttl \"%s\", got \"%s\"", 5*time.Minute, client.LockTTL)
+	}
+	if client.LockURL.String() != "http://127.0.0.1:8888/foo.lock" {
+		t.Fatalf("Expected default lock_address \"%s\", got \"%s\"", "http://127.0.0.1:8888/foo.lock", client.LockURL)
+	}
+
+	defer testWithEnv(t, "TF_HTTP_LOCK_TTL", "60")()
+	conf["lock_address"] = cty.StringVal("http://127.0.0.1:8888/bar")
+	b = backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), configs.SynthBody("synth", conf)).(*Backend)
+	client = b.client
+
+	if client.LockTTL != time.Minute {
+		t.Fatalf("Expected lock_ttl \"%s\", got \"%s\"", time.Minute, client.LockTTL)
+	}
+	if client.LockURL.String() != "http://127.0.0.1:8888/bar" {
+		t.Fatalf("Expected lock_address \"%s\", got \"%s\"", "http://127.0.0.1:8888/bar", client.LockURL)
+	}
+}
+
 func TestHTTPClientFactoryWithEnv(t *testing.T) {
 	// env
 	conf := map[string]string{
@@ -121,7 +153,7 @@ func TestHTTPClientFactoryWithEnv(t *testing.T) {
 	defer testWithEnv(t, "TF_HTTP_RETRY_WAIT_MIN", conf["retry_wait_min"])()
 	defer testWithEnv(t, "TF_HTTP_RETRY_WAIT_MAX", conf["retry_wait_max"])()
 
-	b := backend.TestBackendConfig(t, New(), nil).(*Backend)
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), nil).(*Backend)
 	client := b.client
 
 	if client == nil {
@@ -12,6 +12,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/opentofu/opentofu/internal/states/remote"
@@ -35,11 +36,33 @@ type httpClient struct {
 	Username string
 	Password string
 
+	// Conditional locking, used instead of LockMethod and UnlockMethod when
+	// LockMode is "conditional". See conditional_lock.go.
+	LockMode string
+	LockTTL  time.Duration
+
 	lockID       string
 	jsonLockInfo []byte
+
+	// heldLock is the lock object we currently hold in the conditional
+	// lock mode, if any.
+	heldLock *heldLock
+
+	// In the conditional lock mode, stateETag is the entity tag of the state
+	// as it was last read or written, and stateAbsent records that there was
+	// no state when it was last read. Put uses them to make sure that the
+	// state hasn't changed in the meantime.
+	stateETag   string
+	stateAbsent bool
 }
 
 func (c *httpClient) httpRequest(method string, url *url.URL, data *[]byte, what string) (*http.Response, error) {
+	return c.conditionalRequest(method, url, data, nil, what)
+}
+
+// conditionalRequest is like httpRequest, but additionally sets the given
+// headers on the request, such as If-Match.
+func (c *httpClient) conditionalRequest(method string, url *url.URL, data *[]byte, conditions http.Header, what string) (*http.Response, error) {
 	// If we have data we need a reader
 	var reader io.Reader = nil
 	if data != nil {
@@ -56,6 +79,12 @@ func (c *httpClient) httpRequest(method string, url *url.URL, data *[]byte, what
 		req.SetBasicAuth(c.Username, c.Password)
 	}
 
+	for name, values := range conditions {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+
 	// Work with data/body
 	if data != nil {
 		req.Header.Set("Content-Type", "application/json")
@@ -77,6 +106,9 @@ func (c *httpClient) httpRequest(method string, url *url.URL, data *[]byte, what
 }
 
 func (c *httpClient) Lock(info *statemgr.LockInfo) (string, error) {
+	if c.LockMode == lockModeConditional {
+		return c.lockConditional(info)
+	}
 	if c.LockURL == nil {
 		return "", nil
 	}
@@ -125,6 +157,9 @@ func (c *httpClient) Lock(info *statemgr.LockInfo) (string, error) {
 }
 
 func (c *httpClient) Unlock(id string) error {
+	if c.LockMode == lockModeConditional {
+		return c.unlockConditional(id)
+	}
 	if c.UnlockURL == nil {
 		return nil
 	}
@@ -154,9 +189,9 @@ func (c *httpClient) Get() (*remote.Payload, error) {
 	switch resp.StatusCode {
 	case http.StatusOK:
 		// Handled after
-	case http.StatusNoContent:
-		return nil, nil
-	case http.StatusNotFound:
+	case http.StatusNoContent, http.StatusNotFound:
+		c.stateETag = ""
+		c.stateAbsent = resp.StatusCode == http.StatusNotFound
 		return nil, nil
 	case http.StatusUnauthorized:
 		return nil, fmt.Errorf("HTTP remote state endpoint requires auth")
@@ -173,6 +208,8 @@ func (c *httpClient) Get() (*remote.Payload, error) {
 	if _, err := io.Copy(buf, resp.Body); err != nil {
 		return nil, fmt.Errorf("Failed to read remote state: %w", err)
 	}
+	c.stateETag = resp.Header.Get("ETag")
+	c.stateAbsent = false
 
 	// Create the payload
 	payload := &remote.Payload{
@@ -225,7 +262,24 @@ func (c *httpClient) Put(data []byte) error {
 	if c.UpdateMethod != "" {
 		method = c.UpdateMethod
 	}
-	resp, err := c.httpRequest(method, &base, &data, "upload state")
+
+	// In the conditional lock mode we also make sure that nobody else has
+	// changed the state since we last read or wrote it.
+	var conditions http.Header
+	if c.LockMode == lockModeConditional {
+		if c.heldLock != nil && c.heldLock.isLost() {
+			return fmt.Errorf("HTTP remote state lock %s was taken over by another process, so the state can't be written safely", c.heldLock.id)
+		}
+		conditions = make(http.Header)
+		switch {
+		case c.stateETag != "":
+			conditions.Set("If-Match", c.stateETag)
+		case c.stateAbsent:
+			conditions.Set("If-None-Match", "*")
+		}
+	}
+
+	resp, err := c.conditionalRequest(method, &base, &data, conditions, "upload state")
 	if err != nil {
 		return err
 	}
@@ -234,7 +288,11 @@ func (c *httpClient) Put(data []byte) error {
 	// Handle the error codes
 	switch resp.StatusCode {
 	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		c.stateETag = resp.Header.Get("ETag")
+		c.stateAbsent = false
 		return nil
+	case http.StatusPreconditionFailed:
+		return fmt.Errorf("HTTP remote state was changed by another process since it was read")
 	default:
 		return fmt.Errorf("HTTP error: %d", resp.StatusCode)
 	}
@@ -251,6 +309,8 @@ func (c *httpClient) Delete() error {
 	// Handle the error codes
 	switch resp.StatusCode {
 	case http.StatusOK:
+		c.stateETag = ""
+		c.stateAbsent = true
 		return nil
 	default:
 		return fmt.Errorf("HTTP error: %d", resp.StatusCode)
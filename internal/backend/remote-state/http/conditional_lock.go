@@ -0,0 +1,303 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+const (
+	// lockModeMethods locks the state by sending the lock information to
+	// the lock and unlock addresses using LockMethod and UnlockMethod. The
+	// server is responsible for deciding whether the lock can be taken.
+	lockModeMethods = "methods"
+
+	// lockModeConditional locks the state by writing a lock object to the
+	// lock address using conditional requests (If-Match and If-None-Match),
+	// so that it works with any server that supports entity tags. The lock
+	// object has an expiry time which is extended for as long as the lock is
+	// held, so that a lock left behind by a process that died expires by
+	// itself.
+	lockModeConditional = "conditional"
+
+	defaultLockTTL = 5 * time.Minute
+)
+
+// conditionalLock is the lock object stored at the lock address in the
+// conditional lock mode.
+type conditionalLock struct {
+	statemgr.LockInfo
+
+	// Expires is the time after which the lock is considered abandoned, and
+	// may be taken over by another process.
+	Expires time.Time
+}
+
+// heldLock tracks a lock object that we hold in the conditional lock mode,
+// and the heartbeat that keeps it from expiring.
+//
+// Only the heartbeat goroutine may access lock and etag while it's running.
+type heldLock struct {
+	id   string
+	lock conditionalLock
+	etag string
+
+	// lost is closed by the heartbeat if it finds that the lock was taken
+	// over by another process, after which the heartbeat stops.
+	lost chan struct{}
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// isLost returns true if the heartbeat found that the lock was taken over by
+// another process.
+func (h *heldLock) isLost() bool {
+	select {
+	case <-h.lost:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *httpClient) lockConditional(info *statemgr.LockInfo) (string, error) {
+	if c.heldLock != nil {
+		return "", fmt.Errorf("HTTP remote state is already locked by this process: ID=%s", c.heldLock.id)
+	}
+
+	existing, etag, err := c.getLockObject()
+	if err != nil {
+		return "", err
+	}
+
+	conditions := make(http.Header)
+	switch {
+	case existing == nil:
+		conditions.Set("If-None-Match", "*")
+	case time.Now().After(existing.Expires):
+		if etag == "" {
+			return "", fmt.Errorf("HTTP remote state lock endpoint did not return an ETag, which is required to take over an expired lock")
+		}
+		log.Printf("[INFO] HTTP remote state lock %s expired at %s, taking it over", existing.ID, existing.Expires)
+		conditions.Set("If-Match", etag)
+	default:
+		return "", &statemgr.LockError{
+			Info: &existing.LockInfo,
+			Err:  fmt.Errorf("HTTP remote state already locked: ID=%s, expires %s", existing.ID, existing.Expires.Format(time.RFC3339)),
+		}
+	}
+
+	lock := conditionalLock{
+		LockInfo: *info,
+		Expires:  time.Now().Add(c.LockTTL),
+	}
+	etag, err = c.putLockObject(&lock, conditions)
+	if err != nil {
+		return "", err
+	}
+
+	held := &heldLock{
+		id:   info.ID,
+		lock: lock,
+		etag: etag,
+		lost: make(chan struct{}),
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go c.heartbeat(held)
+
+	c.heldLock = held
+	c.lockID = info.ID
+	return info.ID, nil
+}
+
+func (c *httpClient) unlockConditional(id string) error {
+	if held := c.heldLock; held != nil && held.id == id {
+		close(held.stop)
+		<-held.done
+		c.heldLock = nil
+	}
+	c.lockID = ""
+
+	// We read the lock object again rather than trusting what we wrote, both
+	// because this might be a force-unlock from a different process and
+	// because the lock might have expired and been taken over.
+	existing, etag, err := c.getLockObject()
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return fmt.Errorf("HTTP remote state is not locked")
+	}
+	if existing.ID != id {
+		return &statemgr.LockError{
+			Info: &existing.LockInfo,
+			Err:  fmt.Errorf("HTTP remote state lock ID %q does not match existing lock", id),
+		}
+	}
+
+	var conditions http.Header
+	if etag != "" {
+		conditions = http.Header{"If-Match": []string{etag}}
+	}
+	resp, err := c.conditionalRequest("DELETE", c.LockURL, nil, conditions, "unlock")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	case http.StatusPreconditionFailed:
+		return fmt.Errorf("HTTP remote state lock was changed by another process while unlocking")
+	default:
+		return fmt.Errorf("Unexpected HTTP response code %d", resp.StatusCode)
+	}
+}
+
+// heartbeat extends the expiry time of the given lock every third of the
+// lock TTL, until the lock's stop channel is closed or the lock is taken over
+// by another process.
+func (c *httpClient) heartbeat(held *heldLock) {
+	defer close(held.done)
+
+	ticker := time.NewTicker(c.LockTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-held.stop:
+			return
+		case <-ticker.C:
+			lock := held.lock
+			lock.Expires = time.Now().Add(c.LockTTL)
+			conditions := make(http.Header)
+			if held.etag != "" {
+				conditions.Set("If-Match", held.etag)
+			}
+			etag, err := c.putLockObject(&lock, conditions)
+			var lockErr *statemgr.LockError
+			if errors.As(err, &lockErr) {
+				// The lock object was changed by someone else, so the lock
+				// was taken over after it expired or was force-unlocked.
+				// There's no point in extending it any further.
+				log.Printf("[ERROR] HTTP remote state lock %s was lost: %s", held.id, err)
+				close(held.lost)
+				return
+			}
+			if err != nil {
+				// We keep trying, because the server might only be
+				// unavailable for a moment.
+				log.Printf("[WARN] failed to extend HTTP remote state lock %s: %s", lock.ID, err)
+				continue
+			}
+			log.Printf("[TRACE] extended HTTP remote state lock %s until %s", lock.ID, lock.Expires)
+			held.lock = lock
+			held.etag = etag
+		}
+	}
+}
+
+// getLockObject reads the lock object from the lock address, returning nil
+// if there is none, along with its entity tag.
+func (c *httpClient) getLockObject() (*conditionalLock, string, error) {
+	resp, err := c.httpRequest("GET", c.LockURL, nil, "get lock")
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Handled after
+	case http.StatusNoContent, http.StatusNotFound:
+		return nil, "", nil
+	case http.StatusUnauthorized:
+		return nil, "", fmt.Errorf("HTTP remote state endpoint requires auth")
+	case http.StatusForbidden:
+		return nil, "", fmt.Errorf("HTTP remote state endpoint invalid auth")
+	default:
+		return nil, "", fmt.Errorf("Unexpected HTTP response code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("Failed to read HTTP remote state lock: %w", err)
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, "", nil
+	}
+
+	lock := &conditionalLock{}
+	if err := json.Unmarshal(body, lock); err != nil {
+		return nil, "", fmt.Errorf("Failed to unmarshal HTTP remote state lock: %w", err)
+	}
+	return lock, resp.Header.Get("ETag"), nil
+}
+
+// putLockObject writes the given lock object to the lock address if the given
+// conditions hold, returning the entity tag of the new lock object.
+func (c *httpClient) putLockObject(lock *conditionalLock, conditions http.Header) (string, error) {
+	data, err := json.Marshal(lock)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.conditionalRequest("PUT", c.LockURL, &data, conditions, "lock")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		// Handled after
+	case http.StatusPreconditionFailed:
+		// Someone else wrote the lock object since we read it.
+		existing, _, err := c.getLockObject()
+		if err != nil || existing == nil {
+			return "", &statemgr.LockError{
+				Err: fmt.Errorf("HTTP remote state was locked by another process"),
+			}
+		}
+		return "", &statemgr.LockError{
+			Info: &existing.LockInfo,
+			Err:  fmt.Errorf("HTTP remote state already locked: ID=%s", existing.ID),
+		}
+	case http.StatusUnauthorized:
+		return "", fmt.Errorf("HTTP remote state endpoint requires auth")
+	case http.StatusForbidden:
+		return "", fmt.Errorf("HTTP remote state endpoint invalid auth")
+	default:
+		return "", fmt.Errorf("Unexpected HTTP response code %d", resp.StatusCode)
+	}
+
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		return etag, nil
+	}
+
+	// Not all servers return the entity tag of a newly-written resource, so
+	// we may need to read it back. We also make sure that it's still ours.
+	existing, etag, err := c.getLockObject()
+	if err != nil {
+		return "", err
+	}
+	if existing == nil || existing.ID != lock.ID {
+		return "", &statemgr.LockError{
+			Err: fmt.Errorf("HTTP remote state was locked by another process"),
+		}
+	}
+	return etag, nil
+}
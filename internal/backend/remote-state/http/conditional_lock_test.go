@@ -0,0 +1,241 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/opentofu/opentofu/internal/states/remote"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+func TestHTTPClient_conditionalLock(t *testing.T) {
+	a, b := testConditionalClients(t, time.Minute)
+
+	remote.TestClient(t, a)
+	remote.TestRemoteLocks(t, a, b)
+}
+
+func TestHTTPClient_conditionalLockExpired(t *testing.T) {
+	a, b := testConditionalClients(t, 200*time.Millisecond)
+
+	infoA := statemgr.NewLockInfo()
+	infoA.Operation = "test"
+	idA, err := a.Lock(infoA)
+	if err != nil {
+		t.Fatal("unable to get initial lock:", err)
+	}
+
+	// Simulate client A being killed without releasing its lock, by stopping
+	// its heartbeat.
+	close(a.heldLock.stop)
+	<-a.heldLock.done
+	a.heldLock = nil
+
+	infoB := statemgr.NewLockInfo()
+	infoB.Operation = "test"
+	if _, err := b.Lock(infoB); err == nil {
+		t.Fatal("client B obtained lock before client A's lock expired")
+	}
+
+	time.Sleep(300 * time.Millisecond)
+
+	idB, err := b.Lock(infoB)
+	if err != nil {
+		t.Fatal("client B could not take over expired lock:", err)
+	}
+
+	var lockErr *statemgr.LockError
+	if err := a.Unlock(idA); !errors.As(err, &lockErr) {
+		t.Fatalf("expected a LockError when unlocking a lock that was taken over, got: %v", err)
+	}
+	if err := b.Unlock(idB); err != nil {
+		t.Fatal("error unlocking client B:", err)
+	}
+}
+
+func TestHTTPClient_conditionalLockHeartbeat(t *testing.T) {
+	a, b := testConditionalClients(t, 300*time.Millisecond)
+
+	infoA := statemgr.NewLockInfo()
+	infoA.Operation = "test"
+	idA, err := a.Lock(infoA)
+	if err != nil {
+		t.Fatal("unable to get initial lock:", err)
+	}
+
+	// The heartbeat must keep the lock alive for longer than its TTL.
+	time.Sleep(time.Second)
+
+	infoB := statemgr.NewLockInfo()
+	infoB.Operation = "test"
+	_, err = b.Lock(infoB)
+	if err == nil {
+		t.Fatal("client B obtained lock while held by client A")
+	}
+	var lockErr *statemgr.LockError
+	if !errors.As(err, &lockErr) {
+		t.Fatalf("expected a LockError, got: %s", err)
+	}
+	if lockErr.Info == nil || lockErr.Info.ID != idA {
+		t.Fatalf("expected the LockError to describe client A's lock, got: %#v", lockErr.Info)
+	}
+
+	if err := a.Unlock(idA); err != nil {
+		t.Fatal("error unlocking client A:", err)
+	}
+}
+
+func TestHTTPClient_conditionalLockLost(t *testing.T) {
+	a, b := testConditionalClients(t, 300*time.Millisecond)
+
+	infoA := statemgr.NewLockInfo()
+	infoA.Operation = "test"
+	if _, err := a.Lock(infoA); err != nil {
+		t.Fatal("unable to get initial lock:", err)
+	}
+
+	// Simulate client A's lock being force-unlocked and taken over by
+	// client B, by writing client B's lock object over it unconditionally.
+	infoB := statemgr.NewLockInfo()
+	infoB.Operation = "test"
+	if _, err := b.putLockObject(&conditionalLock{LockInfo: *infoB, Expires: time.Now().Add(time.Minute)}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// The next heartbeat must notice that the lock was lost and stop.
+	select {
+	case <-a.heldLock.done:
+	case <-time.After(time.Second):
+		t.Fatal("client A's heartbeat didn't stop after its lock was taken over")
+	}
+	if !a.heldLock.isLost() {
+		t.Fatal("client A's lock wasn't marked as lost")
+	}
+	if err := a.Put([]byte("from a")); err == nil {
+		t.Fatal("client A wrote state after its lock was taken over")
+	}
+}
+
+func TestHTTPClient_conditionalStateConflict(t *testing.T) {
+	a, b := testConditionalClients(t, time.Minute)
+
+	if err := a.Put([]byte("initial")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Get(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Get(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.Put([]byte("from b")); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Put([]byte("from a")); err == nil {
+		t.Fatal("client A overwrote state that was changed by client B")
+	}
+
+	payload, err := a.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(payload.Data), "from b"; got != want {
+		t.Fatalf("wrong state %q; want %q", got, want)
+	}
+	if err := a.Put([]byte("from a")); err != nil {
+		t.Fatal("client A could not write state after reading it again:", err)
+	}
+}
+
+func testConditionalClients(t *testing.T, ttl time.Duration) (*httpClient, *httpClient) {
+	handler := &testConditionalHTTPHandler{objects: make(map[string]testObject)}
+	ts := httptest.NewServer(http.HandlerFunc(handler.Handle))
+	t.Cleanup(ts.Close)
+
+	stateURL, err := url.Parse(ts.URL + "/state")
+	if err != nil {
+		t.Fatalf("Parse: %s", err)
+	}
+	lockURL, err := url.Parse(ts.URL + "/state.lock")
+	if err != nil {
+		t.Fatalf("Parse: %s", err)
+	}
+
+	newClient := func() *httpClient {
+		return &httpClient{
+			URL:          stateURL,
+			UpdateMethod: "PUT",
+			LockURL:      lockURL,
+			LockMode:     lockModeConditional,
+			LockTTL:      ttl,
+			Client:       retryablehttp.NewClient(),
+		}
+	}
+	return newClient(), newClient()
+}
+
+type testObject struct {
+	data []byte
+	etag string
+}
+
+// testConditionalHTTPHandler is a plain object store that supports entity
+// tags and conditional requests, but has no notion of locking.
+type testConditionalHTTPHandler struct {
+	mu      sync.Mutex
+	objects map[string]testObject
+	version int
+}
+
+func (h *testConditionalHTTPHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	obj, exists := h.objects[r.URL.Path]
+	if match := r.Header.Get("If-Match"); match != "" && (!exists || match != obj.etag) {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+	if r.Header.Get("If-None-Match") == "*" && exists {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", obj.etag)
+		w.Write(obj.data)
+	case "PUT":
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		h.version++
+		obj = testObject{data: data, etag: fmt.Sprintf(`"%d"`, h.version)}
+		h.objects[r.URL.Path] = obj
+		w.Header().Set("ETag", obj.etag)
+		w.WriteHeader(http.StatusOK)
+	case "DELETE":
+		delete(h.objects, r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
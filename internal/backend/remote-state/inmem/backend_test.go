@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/hcl/v2"
 
 	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/encryption"
 	statespkg "github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/remote"
 
@@ -34,7 +35,7 @@ func TestBackendConfig(t *testing.T) {
 		"lock_id": testID,
 	}
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(config)).(*Backend)
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(config)).(*Backend)
 
 	s, err := b.StateMgr(backend.DefaultStateName)
 	if err != nil {
@@ -53,14 +54,14 @@ func TestBackendConfig(t *testing.T) {
 
 func TestBackend(t *testing.T) {
 	defer Reset()
-	b := backend.TestBackendConfig(t, New(), hcl.EmptyBody()).(*Backend)
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), hcl.EmptyBody()).(*Backend)
 	backend.TestBackendStates(t, b)
 }
 
 func TestBackendLocked(t *testing.T) {
 	defer Reset()
-	b1 := backend.TestBackendConfig(t, New(), hcl.EmptyBody()).(*Backend)
-	b2 := backend.TestBackendConfig(t, New(), hcl.EmptyBody()).(*Backend)
+	b1 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), hcl.EmptyBody()).(*Backend)
+	b2 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), hcl.EmptyBody()).(*Backend)
 
 	backend.TestBackendStateLocks(t, b1, b2)
 }
@@ -68,7 +69,7 @@ func TestBackendLocked(t *testing.T) {
 // use the this backen to test the remote.State implementation
 func TestRemoteState(t *testing.T) {
 	defer Reset()
-	b := backend.TestBackendConfig(t, New(), hcl.EmptyBody())
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), hcl.EmptyBody())
 
 	workspace := "workspace"
 
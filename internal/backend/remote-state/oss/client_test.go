@@ -13,6 +13,7 @@ import (
 	"crypto/md5"
 
 	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/states/remote"
 	"github.com/opentofu/opentofu/internal/states/statefile"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
@@ -31,7 +32,7 @@ func TestRemoteClient(t *testing.T) {
 	bucketName := fmt.Sprintf("tf-remote-oss-test-%x", time.Now().Unix())
 	path := "testState"
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(map[string]interface{}{
 		"bucket":  bucketName,
 		"prefix":  path,
 		"encrypt": true,
@@ -54,7 +55,7 @@ func TestRemoteClientLocks(t *testing.T) {
 	tableName := fmt.Sprintf("tfRemoteTestForce%x", time.Now().Unix())
 	path := "testState"
 
-	b1 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b1 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(map[string]interface{}{
 		"bucket":              bucketName,
 		"prefix":              path,
 		"encrypt":             true,
@@ -62,7 +63,7 @@ func TestRemoteClientLocks(t *testing.T) {
 		"tablestore_endpoint": RemoteTestUsedOTSEndpoint,
 	})).(*Backend)
 
-	b2 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b2 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(map[string]interface{}{
 		"bucket":              bucketName,
 		"prefix":              path,
 		"encrypt":             true,
@@ -95,7 +96,7 @@ func TestRemoteClientLocks_multipleStates(t *testing.T) {
 	tableName := fmt.Sprintf("tfRemoteTestForce%x", time.Now().Unix())
 	path := "testState"
 
-	b1 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b1 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(map[string]interface{}{
 		"bucket":              bucketName,
 		"prefix":              path,
 		"encrypt":             true,
@@ -103,7 +104,7 @@ func TestRemoteClientLocks_multipleStates(t *testing.T) {
 		"tablestore_endpoint": RemoteTestUsedOTSEndpoint,
 	})).(*Backend)
 
-	b2 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b2 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(map[string]interface{}{
 		"bucket":              bucketName,
 		"prefix":              path,
 		"encrypt":             true,
@@ -141,7 +142,7 @@ func TestRemoteForceUnlock(t *testing.T) {
 	tableName := fmt.Sprintf("tfRemoteTestForce%x", time.Now().Unix())
 	path := "testState"
 
-	b1 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b1 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(map[string]interface{}{
 		"bucket":              bucketName,
 		"prefix":              path,
 		"encrypt":             true,
@@ -149,7 +150,7 @@ func TestRemoteForceUnlock(t *testing.T) {
 		"tablestore_endpoint": RemoteTestUsedOTSEndpoint,
 	})).(*Backend)
 
-	b2 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b2 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(map[string]interface{}{
 		"bucket":              bucketName,
 		"prefix":              path,
 		"encrypt":             true,
@@ -221,7 +222,7 @@ func TestRemoteClient_clientMD5(t *testing.T) {
 	tableName := fmt.Sprintf("tfRemoteTestForce%x", time.Now().Unix())
 	path := "testState"
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(map[string]interface{}{
 		"bucket":              bucketName,
 		"prefix":              path,
 		"tablestore_table":    tableName,
@@ -271,7 +272,7 @@ func TestRemoteClient_stateChecksum(t *testing.T) {
 	tableName := fmt.Sprintf("tfRemoteTestForce%x", time.Now().Unix())
 	path := "testState"
 
-	b1 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b1 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(map[string]interface{}{
 		"bucket":              bucketName,
 		"prefix":              path,
 		"tablestore_table":    tableName,
@@ -293,18 +294,18 @@ func TestRemoteClient_stateChecksum(t *testing.T) {
 	s := statemgr.TestFullInitialState()
 	sf := &statefile.File{State: s}
 	var oldState bytes.Buffer
-	if err := statefile.Write(sf, &oldState); err != nil {
+	if err := statefile.Write(sf, &oldState, encryption.StateEncryptionDisabled()); err != nil {
 		t.Fatal(err)
 	}
 	sf.Serial++
 	var newState bytes.Buffer
-	if err := statefile.Write(sf, &newState); err != nil {
+	if err := statefile.Write(sf, &newState, encryption.StateEncryptionDisabled()); err != nil {
 		t.Fatal(err)
 	}
 
 	// Use b2 without a tablestore_table to bypass the lock table to write the state directly.
 	// client2 will write the "incorrect" state, simulating oss eventually consistency delays
-	b2 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b2 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(map[string]interface{}{
 		"bucket": bucketName,
 		"prefix": path,
 	})).(*Backend)
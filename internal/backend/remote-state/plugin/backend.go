@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package plugin implements the "plugin" backend, which stores state using
+// an external program speaking the backend plugin protocol.
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	goplugin "github.com/hashicorp/go-plugin"
+
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/backendplugin"
+	"github.com/opentofu/opentofu/internal/backendplugin/backendplugin1"
+	"github.com/opentofu/opentofu/internal/command/cliconfig"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/legacy/helper/schema"
+	"github.com/opentofu/opentofu/internal/logging"
+	tfplugin "github.com/opentofu/opentofu/internal/plugin"
+	"github.com/opentofu/opentofu/internal/plugin/discovery"
+)
+
+// New creates a new backend for state stored by a backend plugin.
+func New(enc encryption.StateEncryption) backend.Backend {
+	s := &schema.Backend{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TF_BACKEND_PLUGIN_NAME", nil),
+				Description: "The name of the backend plugin, whose executable is named terraform-backend-NAME",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TF_BACKEND_PLUGIN_PATH", nil),
+				Description: "The path of the backend plugin executable, instead of discovering it by name",
+			},
+			"config": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Configuration passed to the backend plugin",
+			},
+		},
+	}
+
+	b := &Backend{Backend: s, encryption: enc, launch: launchPlugin}
+	b.Backend.ConfigureFunc = b.configure
+	return b
+}
+
+type Backend struct {
+	*schema.Backend
+	encryption encryption.StateEncryption
+
+	// launch starts the plugin executable at the given path. It is
+	// replaced in tests to serve a plugin in-process.
+	launch func(path string) (backendplugin.Storage1, error)
+
+	storage backendplugin.Storage1
+}
+
+func (b *Backend) configure(ctx context.Context) error {
+	data := schema.FromContextBackendConfig(ctx)
+
+	name := data.Get("name").(string)
+	path := data.Get("path").(string)
+	switch {
+	case name == "" && path == "":
+		return errors.New(`either "name" or "path" must be set`)
+	case name != "" && path != "":
+		return errors.New(`only one of "name" and "path" can be set`)
+	case name != "":
+		var err error
+		path, err = findPlugin(name, pluginDirs())
+		if err != nil {
+			return err
+		}
+	}
+
+	config := make(map[string]string)
+	for k, v := range data.Get("config").(map[string]interface{}) {
+		config[k] = v.(string)
+	}
+
+	storage, err := b.launch(path)
+	if err != nil {
+		return fmt.Errorf("failed to launch backend plugin %s: %w", path, err)
+	}
+	if err := storage.Configure(config); err != nil {
+		return fmt.Errorf("failed to configure backend plugin: %w", err)
+	}
+
+	b.storage = storage
+	return nil
+}
+
+// findPlugin returns the path of the newest backend plugin executable with
+// the given name in the given directories.
+func findPlugin(name string, dirs []string) (string, error) {
+	plugins, _ := discovery.FindPlugins(backendplugin.PluginName, dirs).WithName(name).ValidateVersions()
+	if plugins.Count() == 0 {
+		return "", fmt.Errorf(
+			"backend plugin %q not found; place an executable named terraform-%s-%s in one of the following directories, or set \"path\" instead:\n  %s",
+			name, backendplugin.PluginName, name, strings.Join(dirs, "\n  "),
+		)
+	}
+	return plugins.Newest().Path, nil
+}
+
+// pluginDirs returns the directories searched for backend plugins, which
+// are the same as for provisioner plugins except that the -plugin-dir
+// option of "tofu init" doesn't apply.
+func pluginDirs() []string {
+	machineDir := fmt.Sprintf("%s_%s", runtime.GOOS, runtime.GOARCH)
+
+	dirs := []string{"."}
+	if exePath, err := os.Executable(); err == nil {
+		dirs = append(dirs, filepath.Dir(exePath))
+	} else {
+		log.Printf("[ERROR] Error discovering exe directory: %s", err)
+	}
+	dirs = append(dirs, filepath.Join("terraform.d", "plugins", machineDir))
+	if dir, err := cliconfig.ConfigDir(); err == nil {
+		dirs = append(dirs, filepath.Join(dir, "plugins"), filepath.Join(dir, "plugins", machineDir))
+	} else {
+		log.Printf("[ERROR] Error finding global config directory: %s", err)
+	}
+	return dirs
+}
+
+// launchPlugin starts the backend plugin executable at the given path. The
+// plugin process is stopped when OpenTofu exits.
+func launchPlugin(path string) (backendplugin.Storage1, error) {
+	client := goplugin.NewClient(&goplugin.ClientConfig{
+		Cmd:              exec.Command(path),
+		HandshakeConfig:  tfplugin.Handshake,
+		VersionedPlugins: backendplugin1.VersionedPlugins,
+		Managed:          true,
+		Logger:           logging.NewLogger("backend-plugin"),
+		AllowedProtocols: []goplugin.Protocol{goplugin.ProtocolGRPC},
+		AutoMTLS:         os.Getenv("TF_DISABLE_PLUGIN_TLS") == "",
+		SyncStdout:       logging.PluginOutputMonitor(fmt.Sprintf("%s:stdout", filepath.Base(path))),
+		SyncStderr:       logging.PluginOutputMonitor(fmt.Sprintf("%s:stderr", filepath.Base(path))),
+	})
+
+	rpcClient, err := client.Client()
+	if err != nil {
+		client.Kill()
+		return nil, err
+	}
+	raw, err := rpcClient.Dispense(backendplugin.PluginName)
+	if err != nil {
+		client.Kill()
+		return nil, err
+	}
+	return raw.(backendplugin.Storage1), nil
+}
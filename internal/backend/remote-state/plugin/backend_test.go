@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	goplugin "github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/hcl/v2/hcldec"
+
+	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/backendplugin"
+	"github.com/opentofu/opentofu/internal/backendplugin/backendplugin1"
+	"github.com/opentofu/opentofu/internal/backendplugin/dirbackend"
+	"github.com/opentofu/opentofu/internal/encryption"
+)
+
+func TestBackend_impl(t *testing.T) {
+	var _ backend.Backend = new(Backend)
+}
+
+func TestBackend(t *testing.T) {
+	dir := t.TempDir()
+	b := testBackend(t, dir)
+	backend.TestBackendStates(t, b)
+}
+
+func TestBackendLocked(t *testing.T) {
+	dir := t.TempDir()
+	b1 := testBackend(t, dir)
+	b2 := testBackend(t, dir)
+
+	backend.TestBackendStateLocks(t, b1, b2)
+	backend.TestBackendStateForceUnlock(t, b1, b2)
+}
+
+func TestBackendConfig_invalid(t *testing.T) {
+	tests := map[string]struct {
+		config  map[string]interface{}
+		wantErr string
+	}{
+		"neither name nor path": {
+			config:  map[string]interface{}{},
+			wantErr: `either "name" or "path" must be set`,
+		},
+		"both name and path": {
+			config: map[string]interface{}{
+				"name": "example",
+				"path": "terraform-backend-example",
+			},
+			wantErr: `only one of "name" and "path" can be set`,
+		},
+		"plugin not found": {
+			config: map[string]interface{}{
+				"name": "nonexistent",
+			},
+			wantErr: `backend plugin "nonexistent" not found`,
+		},
+		"invalid plugin config": {
+			config: map[string]interface{}{
+				"path": "terraform-backend-dir",
+				"config": map[string]interface{}{
+					"bucket": "example",
+				},
+			},
+			wantErr: `failed to configure backend plugin: unsupported configuration option "bucket"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := New(encryption.StateEncryptionDisabled()).(*Backend)
+			b.launch = testLaunch(t)
+
+			schema := b.ConfigSchema()
+			spec := schema.DecoderSpec()
+			obj, decDiags := hcldec.Decode(backend.TestWrapConfig(test.config), spec, nil)
+			if decDiags.HasErrors() {
+				t.Fatal(decDiags.Error())
+			}
+			obj, diags := b.PrepareConfig(obj)
+			diags = diags.Append(b.Configure(obj))
+			if !diags.HasErrors() {
+				t.Fatal("expected error")
+			}
+			if got := diags.Err().Error(); !strings.Contains(got, test.wantErr) {
+				t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestFindPlugin(t *testing.T) {
+	dir1 := t.TempDir()
+	dir2 := t.TempDir()
+	for _, path := range []string{
+		filepath.Join(dir1, "terraform-backend-legacy"),
+		filepath.Join(dir1, "terraform-backend-example_v1.0.0"),
+		filepath.Join(dir2, "terraform-backend-example_v1.2.0"),
+		filepath.Join(dir2, "terraform-provisioner-example_v2.0.0"),
+	} {
+		if err := os.WriteFile(path, nil, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := map[string]string{
+		"legacy":  filepath.Join(dir1, "terraform-backend-legacy"),
+		"example": filepath.Join(dir2, "terraform-backend-example_v1.2.0"),
+	}
+	for name, want := range tests {
+		got, err := findPlugin(name, []string{dir1, dir2})
+		if err != nil {
+			t.Fatalf("unexpected error finding %q: %s", name, err)
+		}
+		if got != want {
+			t.Errorf("wrong path for %q\ngot:  %s\nwant: %s", name, got, want)
+		}
+	}
+
+	if _, err := findPlugin("missing", []string{dir1, dir2}); err == nil {
+		t.Fatal("expected error for missing plugin")
+	}
+}
+
+// testBackend returns a backend configured to store state in dir using the
+// reference backend plugin implementation, served in-process.
+func testBackend(t *testing.T, dir string) *Backend {
+	t.Helper()
+
+	b := New(encryption.StateEncryptionDisabled()).(*Backend)
+	b.launch = testLaunch(t)
+
+	config := map[string]interface{}{
+		"path": "terraform-backend-dir",
+		"config": map[string]interface{}{
+			"path": dir,
+		},
+	}
+	return backend.TestBackendConfig(t, b, backend.TestWrapConfig(config)).(*Backend)
+}
+
+// testLaunch returns a launch function which serves the reference backend
+// plugin implementation in-process over gRPC, instead of starting the
+// executable at the given path.
+func testLaunch(t *testing.T) func(string) (backendplugin.Storage1, error) {
+	return func(string) (backendplugin.Storage1, error) {
+		client, server := goplugin.TestPluginGRPCConn(t, map[string]goplugin.Plugin{
+			backendplugin.PluginName: &backendplugin1.GRPCBackendPlugin{
+				Impl: &dirbackend.Storage{},
+			},
+		})
+		t.Cleanup(func() {
+			client.Close()
+			server.Stop()
+		})
+
+		raw, err := client.Dispense(backendplugin.PluginName)
+		if err != nil {
+			return nil, err
+		}
+		return raw.(backendplugin.Storage1), nil
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plugin
+
+import (
+	"crypto/md5"
+
+	"github.com/opentofu/opentofu/internal/backendplugin"
+	"github.com/opentofu/opentofu/internal/states/remote"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+// RemoteClient is a remote client that stores the state of a workspace
+// using a backend plugin.
+type RemoteClient struct {
+	storage   backendplugin.Storage1
+	workspace string
+}
+
+var (
+	_ remote.Client       = (*RemoteClient)(nil)
+	_ remote.ClientLocker = (*RemoteClient)(nil)
+)
+
+func (c *RemoteClient) Get() (*remote.Payload, error) {
+	data, err := c.storage.GetState(c.workspace)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	md5 := md5.Sum(data)
+	return &remote.Payload{
+		Data: data,
+		MD5:  md5[:],
+	}, nil
+}
+
+func (c *RemoteClient) Put(data []byte) error {
+	return c.storage.PutState(c.workspace, data)
+}
+
+func (c *RemoteClient) Delete() error {
+	return c.storage.DeleteWorkspace(c.workspace)
+}
+
+func (c *RemoteClient) Lock(info *statemgr.LockInfo) (string, error) {
+	return c.storage.Lock(c.workspace, info)
+}
+
+func (c *RemoteClient) Unlock(id string) error {
+	return c.storage.Unlock(c.workspace, id)
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
 	"github.com/opentofu/opentofu/internal/configs/hcl2shim"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/remote"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -69,7 +70,7 @@ func TestBackendConfig_original(t *testing.T) {
 		"dynamodb_table": "dynamoTable",
 	}
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(config)).(*Backend)
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(config)).(*Backend)
 
 	if b.awsConfig.Region != "us-west-1" {
 		t.Fatalf("Incorrect region was populated")
@@ -133,7 +134,7 @@ func TestBackendConfig_InvalidRegion(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			b := New()
+			b := New(encryption.StateEncryptionDisabled())
 			configSchema := populateSchema(t, b.ConfigSchema(), hcl2shim.HCL2ValueFromConfigValue(tc.config))
 
 			configSchema, diags := b.PrepareConfig(configSchema)
@@ -185,7 +186,7 @@ func TestBackendConfig_RegionEnvVar(t *testing.T) {
 				}
 			})
 
-			b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(config)).(*Backend)
+			b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(config)).(*Backend)
 
 			if b.awsConfig.Region != "us-west-1" {
 				t.Fatalf("Incorrect region was populated")
@@ -244,7 +245,7 @@ func TestBackendConfig_DynamoDBEndpoint(t *testing.T) {
 				}
 			}
 
-			backend.TestBackendConfig(t, New(), backend.TestWrapConfig(config))
+			backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(config))
 		})
 	}
 }
@@ -299,7 +300,7 @@ func TestBackendConfig_S3Endpoint(t *testing.T) {
 				}
 			}
 
-			backend.TestBackendConfig(t, New(), backend.TestWrapConfig(config))
+			backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(config))
 		})
 	}
 }
@@ -372,7 +373,7 @@ func TestBackendConfig_STSEndpoint(t *testing.T) {
 				config["sts_endpoint"] = endpoint
 			}
 
-			b := New()
+			b := New(encryption.StateEncryptionDisabled())
 			configSchema := populateSchema(t, b.ConfigSchema(), hcl2shim.HCL2ValueFromConfigValue(config))
 
 			configSchema, diags := b.PrepareConfig(configSchema)
@@ -610,7 +611,7 @@ func TestBackendConfig_AssumeRole(t *testing.T) {
 
 			testCase.Config["sts_endpoint"] = endpoint
 
-			b := New()
+			b := New(encryption.StateEncryptionDisabled())
 			diags := b.Configure(populateSchema(t, b.ConfigSchema(), hcl2shim.HCL2ValueFromConfigValue(testCase.Config)))
 
 			if diags.HasErrors() {
@@ -793,7 +794,7 @@ func TestBackendConfig_PrepareConfigValidation(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			servicemocks.StashEnv(t)
 
-			b := New()
+			b := New(encryption.StateEncryptionDisabled())
 
 			_, valDiags := b.PrepareConfig(populateSchema(t, b.ConfigSchema(), tc.config))
 			if tc.expectedErr != "" {
@@ -832,7 +833,7 @@ func TestBackendConfig_PrepareConfigValidationWarnings(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			servicemocks.StashEnv(t)
 
-			b := New()
+			b := New(encryption.StateEncryptionDisabled())
 
 			_, diags := b.PrepareConfig(populateSchema(t, b.ConfigSchema(), tc.config))
 			if tc.expectedWarn != "" {
@@ -895,7 +896,7 @@ func TestBackendConfig_PrepareConfigWithEnvVars(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			servicemocks.StashEnv(t)
 
-			b := New()
+			b := New(encryption.StateEncryptionDisabled())
 
 			for k, v := range tc.vars {
 				os.Setenv(k, v)
@@ -1060,7 +1061,7 @@ func TestBackendConfig_proxy(t *testing.T) {
 				t.Setenv(k, v)
 			}
 
-			b := New()
+			b := New(encryption.StateEncryptionDisabled())
 
 			got := b.Configure(populateSchema(t, b.ConfigSchema(), tc.config))
 			if got.HasErrors() != (tc.wantErrSubstr != "") {
@@ -1094,7 +1095,7 @@ func TestBackend(t *testing.T) {
 	bucketName := fmt.Sprintf("%s-%x", testBucketPrefix, time.Now().Unix())
 	keyName := "testState"
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(map[string]interface{}{
 		"bucket":  bucketName,
 		"key":     keyName,
 		"encrypt": true,
@@ -1114,7 +1115,7 @@ func TestBackendLocked(t *testing.T) {
 	bucketName := fmt.Sprintf("%s-%x", testBucketPrefix, time.Now().Unix())
 	keyName := "test/state"
 
-	b1 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b1 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(map[string]interface{}{
 		"bucket":         bucketName,
 		"key":            keyName,
 		"encrypt":        true,
@@ -1122,7 +1123,7 @@ func TestBackendLocked(t *testing.T) {
 		"region":         "us-west-1",
 	})).(*Backend)
 
-	b2 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b2 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(map[string]interface{}{
 		"bucket":         bucketName,
 		"key":            keyName,
 		"encrypt":        true,
@@ -1173,7 +1174,7 @@ func TestBackendSSECustomerKeyConfig(t *testing.T) {
 				"region":           "us-west-1",
 			}
 
-			b := New().(*Backend)
+			b := New(encryption.StateEncryptionDisabled()).(*Backend)
 			diags := b.Configure(populateSchema(t, b.ConfigSchema(), hcl2shim.HCL2ValueFromConfigValue(config)))
 
 			if testCase.expectedErr != "" {
@@ -1240,7 +1241,7 @@ func TestBackendSSECustomerKeyEnvVar(t *testing.T) {
 				os.Unsetenv("AWS_SSE_CUSTOMER_KEY")
 			})
 
-			b := New().(*Backend)
+			b := New(encryption.StateEncryptionDisabled()).(*Backend)
 			diags := b.Configure(populateSchema(t, b.ConfigSchema(), hcl2shim.HCL2ValueFromConfigValue(config)))
 
 			if testCase.expectedErr != "" {
@@ -1276,7 +1277,7 @@ func TestBackendExtraPaths(t *testing.T) {
 	bucketName := fmt.Sprintf("%s-%x", testBucketPrefix, time.Now().Unix())
 	keyName := "test/state/tfstate"
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(map[string]interface{}{
 		"bucket":  bucketName,
 		"key":     keyName,
 		"encrypt": true,
@@ -1303,7 +1304,7 @@ func TestBackendExtraPaths(t *testing.T) {
 	}
 
 	// Write the first state
-	stateMgr := &remote.State{Client: client}
+	stateMgr := remote.NewState(client, b.encryption)
 	if err := stateMgr.WriteState(s1); err != nil {
 		t.Fatal(err)
 	}
@@ -1315,7 +1316,7 @@ func TestBackendExtraPaths(t *testing.T) {
 	// Note a new state manager - otherwise, because these
 	// states are equal, the state will not Put to the remote
 	client.path = b.path("s2")
-	stateMgr2 := &remote.State{Client: client}
+	stateMgr2 := remote.NewState(client, b.encryption)
 	if err := stateMgr2.WriteState(s2); err != nil {
 		t.Fatal(err)
 	}
@@ -1415,7 +1416,7 @@ func TestBackendPrefixInWorkspace(t *testing.T) {
 	testACC(t)
 	bucketName := fmt.Sprintf("%s-%x", testBucketPrefix, time.Now().Unix())
 
-	b := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(map[string]interface{}{
 		"bucket":               bucketName,
 		"key":                  "test-env.tfstate",
 		"workspace_key_prefix": "env",
@@ -1444,7 +1445,7 @@ func TestKeyEnv(t *testing.T) {
 	keyName := "some/paths/tfstate"
 
 	bucket0Name := fmt.Sprintf("%s-%x-0", testBucketPrefix, time.Now().Unix())
-	b0 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b0 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(map[string]interface{}{
 		"bucket":               bucket0Name,
 		"key":                  keyName,
 		"encrypt":              true,
@@ -1456,7 +1457,7 @@ func TestKeyEnv(t *testing.T) {
 	defer deleteS3Bucket(ctx, t, b0.s3Client, bucket0Name)
 
 	bucket1Name := fmt.Sprintf("%s-%x-1", testBucketPrefix, time.Now().Unix())
-	b1 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b1 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(map[string]interface{}{
 		"bucket":               bucket1Name,
 		"key":                  keyName,
 		"encrypt":              true,
@@ -1467,7 +1468,7 @@ func TestKeyEnv(t *testing.T) {
 	defer deleteS3Bucket(ctx, t, b1.s3Client, bucket1Name)
 
 	bucket2Name := fmt.Sprintf("%s-%x-2", testBucketPrefix, time.Now().Unix())
-	b2 := backend.TestBackendConfig(t, New(), backend.TestWrapConfig(map[string]interface{}{
+	b2 := backend.TestBackendConfig(t, New(encryption.StateEncryptionDisabled()), backend.TestWrapConfig(map[string]interface{}{
 		"bucket":  bucket2Name,
 		"key":     keyName,
 		"encrypt": true,
@@ -1597,7 +1598,7 @@ func TestBackend_schemaCoercionMinimal(t *testing.T) {
 		"bucket": cty.StringVal("my-bucket"),
 		"key":    cty.StringVal("state.tf"),
 	})
-	schema := New().ConfigSchema()
+	schema := New(encryption.StateEncryptionDisabled()).ConfigSchema()
 	_, err := schema.CoerceValue(example)
 	if err != nil {
 		t.Errorf("Unexpected error: %s", err.Error())
@@ -15,13 +15,17 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"net/http"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	dtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 	multierror "github.com/hashicorp/go-multierror"
 	uuid "github.com/hashicorp/go-uuid"
 
@@ -34,6 +38,10 @@ const (
 	s3EncryptionAlgorithm  = "AES256"
 	stateIDSuffix          = "-md5"
 	s3ErrCodeInternalError = "InternalError"
+
+	// lockFileSuffix is appended to the state key to name the object used
+	// for locking when use_lockfile is set.
+	lockFileSuffix = ".tflock"
 )
 
 type RemoteClient struct {
@@ -46,6 +54,7 @@ type RemoteClient struct {
 	acl                   string
 	kmsKeyID              string
 	ddbTable              string
+	useLockFile           bool
 
 	skipS3Checksum bool
 }
@@ -188,6 +197,32 @@ func (c *RemoteClient) get(ctx context.Context) (*remote.Payload, error) {
 }
 
 func (c *RemoteClient) Put(data []byte) error {
+	i := c.putObjectInput(c.path, data)
+
+	log.Printf("[DEBUG] Uploading remote state to S3: %#v", i)
+
+	ctx := context.TODO()
+	ctx, _ = attachLoggerToContext(ctx)
+
+	_, err := c.s3Client.PutObject(ctx, i)
+	if err != nil {
+		return fmt.Errorf("failed to upload state: %w", err)
+	}
+
+	sum := md5.Sum(data)
+	if err := c.putMD5(ctx, sum[:]); err != nil {
+		// if this errors out, we unfortunately have to error out altogether,
+		// since the next Get will inevitably fail.
+		return fmt.Errorf("failed to store state MD5: %w", err)
+
+	}
+
+	return nil
+}
+
+// putObjectInput builds the request to upload the given JSON data to key,
+// applying the checksum, encryption and ACL settings of the backend.
+func (c *RemoteClient) putObjectInput(key string, data []byte) *s3.PutObjectInput {
 	contentType := "application/json"
 	contentLength := int64(len(data))
 
@@ -196,7 +231,7 @@ func (c *RemoteClient) Put(data []byte) error {
 		ContentLength: aws.Int64(contentLength),
 		Body:          bytes.NewReader(data),
 		Bucket:        &c.bucketName,
-		Key:           &c.path,
+		Key:           &key,
 	}
 
 	if !c.skipS3Checksum {
@@ -228,25 +263,7 @@ func (c *RemoteClient) Put(data []byte) error {
 		i.ACL = types.ObjectCannedACL(c.acl)
 	}
 
-	log.Printf("[DEBUG] Uploading remote state to S3: %#v", i)
-
-	ctx := context.TODO()
-	ctx, _ = attachLoggerToContext(ctx)
-
-	_, err := c.s3Client.PutObject(ctx, i)
-	if err != nil {
-		return fmt.Errorf("failed to upload state: %w", err)
-	}
-
-	sum := md5.Sum(data)
-	if err := c.putMD5(ctx, sum[:]); err != nil {
-		// if this errors out, we unfortunately have to error out altogether,
-		// since the next Get will inevitably fail.
-		return fmt.Errorf("failed to store state MD5: %w", err)
-
-	}
-
-	return nil
+	return i
 }
 
 func (c *RemoteClient) Delete() error {
@@ -270,7 +287,7 @@ func (c *RemoteClient) Delete() error {
 }
 
 func (c *RemoteClient) Lock(info *statemgr.LockInfo) (string, error) {
-	if c.ddbTable == "" {
+	if c.ddbTable == "" && !c.useLockFile {
 		return "", nil
 	}
 
@@ -285,6 +302,34 @@ func (c *RemoteClient) Lock(info *statemgr.LockInfo) (string, error) {
 		info.ID = lockID
 	}
 
+	ctx := context.TODO()
+	ctx, _ = attachLoggerToContext(ctx)
+
+	// When both locking mechanisms are enabled, which is how a configuration
+	// migrates from DynamoDB to S3 locking, the lock is only held once both
+	// have been acquired.
+	if c.useLockFile {
+		if err := c.s3Lock(ctx, info); err != nil {
+			return "", err
+		}
+	}
+	if c.ddbTable != "" {
+		if err := c.dynamoDBLock(ctx, info); err != nil {
+			if c.useLockFile {
+				if unlockErr := c.s3Unlock(ctx, info.ID); unlockErr != nil {
+					log.Printf("[WARN] failed to release S3 lock file after DynamoDB lock failure: %s", unlockErr)
+				}
+			}
+			return "", err
+		}
+	}
+
+	return info.ID, nil
+}
+
+// dynamoDBLock acquires the lock by creating an item in the DynamoDB table,
+// failing if the item already exists.
+func (c *RemoteClient) dynamoDBLock(ctx context.Context, info *statemgr.LockInfo) error {
 	putParams := &dynamodb.PutItemInput{
 		Item: map[string]dtypes.AttributeValue{
 			"LockID": &dtypes.AttributeValueMemberS{Value: c.lockPath()},
@@ -294,7 +339,6 @@ func (c *RemoteClient) Lock(info *statemgr.LockInfo) (string, error) {
 		ConditionExpression: aws.String("attribute_not_exists(LockID)"),
 	}
 
-	ctx := context.TODO()
 	_, err := c.dynClient.PutItem(ctx, putParams)
 	if err != nil {
 		lockInfo, infoErr := c.getLockInfo(ctx)
@@ -306,10 +350,123 @@ func (c *RemoteClient) Lock(info *statemgr.LockInfo) (string, error) {
 			Err:  err,
 			Info: lockInfo,
 		}
-		return "", lockErr
+		return lockErr
 	}
 
-	return info.ID, nil
+	return nil
+}
+
+// s3Lock acquires the lock by creating the lock file next to the state
+// object. The upload is conditional on the lock file not existing yet, so
+// S3 rejects it if another client already holds the lock.
+func (c *RemoteClient) s3Lock(ctx context.Context, info *statemgr.LockInfo) error {
+	input := c.putObjectInput(c.lockFilePath(), info.Marshal())
+
+	log.Printf("[DEBUG] Creating S3 lock file: %s", c.lockFilePath())
+	_, err := c.s3Client.PutObject(ctx, input, func(o *s3.Options) {
+		o.APIOptions = append(o.APIOptions, smithyhttp.AddHeaderValue("If-None-Match", "*"))
+	})
+	if err == nil {
+		return nil
+	}
+
+	lockErr := &statemgr.LockError{
+		Err: err,
+	}
+	if !isS3PreconditionFailed(err) {
+		lockErr.Err = fmt.Errorf("failed to create S3 lock file: %w", err)
+		return lockErr
+	}
+
+	lockInfo, infoErr := c.getS3LockInfo(ctx)
+	if infoErr != nil {
+		lockErr.Err = multierror.Append(err, infoErr)
+	}
+	lockErr.Info = lockInfo
+	return lockErr
+}
+
+// getS3LockInfo returns the lock information stored in the S3 lock file.
+func (c *RemoteClient) getS3LockInfo(ctx context.Context) (*statemgr.LockInfo, error) {
+	input := &s3.GetObjectInput{
+		Bucket: &c.bucketName,
+		Key:    aws.String(c.lockFilePath()),
+	}
+
+	if c.serverSideEncryption && c.customerEncryptionKey != nil {
+		input.SSECustomerKey = aws.String(base64.StdEncoding.EncodeToString(c.customerEncryptionKey))
+		input.SSECustomerAlgorithm = aws.String(s3EncryptionAlgorithm)
+		input.SSECustomerKeyMD5 = aws.String(c.getSSECustomerKeyMD5())
+	}
+
+	output, err := c.s3Client.GetObject(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve S3 lock file: %w", err)
+	}
+	defer output.Body.Close()
+
+	data, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read S3 lock file: %w", err)
+	}
+
+	lockInfo := &statemgr.LockInfo{}
+	if err := json.Unmarshal(data, lockInfo); err != nil {
+		return nil, fmt.Errorf("failed to decode S3 lock file: %w", err)
+	}
+
+	return lockInfo, nil
+}
+
+// s3Unlock removes the S3 lock file, after checking that it records the
+// given lock ID.
+func (c *RemoteClient) s3Unlock(ctx context.Context, id string) error {
+	lockErr := &statemgr.LockError{}
+
+	lockInfo, err := c.getS3LockInfo(ctx)
+	if err != nil {
+		lockErr.Err = err
+		return lockErr
+	}
+	lockErr.Info = lockInfo
+
+	if lockInfo.ID != id {
+		lockErr.Err = fmt.Errorf("lock id %q does not match existing lock", id)
+		return lockErr
+	}
+
+	_, err = c.s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: &c.bucketName,
+		Key:    aws.String(c.lockFilePath()),
+	})
+	if err != nil {
+		lockErr.Err = fmt.Errorf("failed to delete S3 lock file: %w", err)
+		return lockErr
+	}
+
+	return nil
+}
+
+// isS3PreconditionFailed reports whether err is S3 rejecting a conditional
+// request, which for the lock file means that it already exists.
+func isS3PreconditionFailed(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "PreconditionFailed", "ConditionalRequestConflict":
+			return true
+		}
+	}
+
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) {
+		switch respErr.HTTPStatusCode() {
+		case http.StatusPreconditionFailed, http.StatusConflict:
+			return true
+		}
+	}
+
+	return false
 }
 
 func (c *RemoteClient) getMD5(ctx context.Context) ([]byte, error) {
@@ -421,12 +578,51 @@ func (c *RemoteClient) getLockInfo(ctx context.Context) (*statemgr.LockInfo, err
 }
 
 func (c *RemoteClient) Unlock(id string) error {
-	if c.ddbTable == "" {
+	if c.ddbTable == "" && !c.useLockFile {
 		return nil
 	}
 
-	lockErr := &statemgr.LockError{}
 	ctx := context.TODO()
+	ctx, _ = attachLoggerToContext(ctx)
+
+	var errs error
+	var lockInfo *statemgr.LockInfo
+	if c.useLockFile {
+		if err := c.s3Unlock(ctx, id); err != nil {
+			var lockErr *statemgr.LockError
+			if errors.As(err, &lockErr) {
+				lockInfo = lockErr.Info
+				err = lockErr.Err
+			}
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if c.ddbTable != "" {
+		if err := c.dynamoDBUnlock(ctx, id); err != nil {
+			var lockErr *statemgr.LockError
+			if errors.As(err, &lockErr) {
+				if lockInfo == nil {
+					lockInfo = lockErr.Info
+				}
+				err = lockErr.Err
+			}
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	if errs != nil {
+		return &statemgr.LockError{
+			Err:  errs,
+			Info: lockInfo,
+		}
+	}
+	return nil
+}
+
+// dynamoDBUnlock removes the lock item from the DynamoDB table, after
+// checking that it records the given lock ID.
+func (c *RemoteClient) dynamoDBUnlock(ctx context.Context, id string) error {
+	lockErr := &statemgr.LockError{}
 
 	// TODO: store the path and lock ID in separate fields, and have proper
 	// projection expression only delete the lock if both match, rather than
@@ -462,6 +658,11 @@ func (c *RemoteClient) lockPath() string {
 	return fmt.Sprintf("%s/%s", c.bucketName, c.path)
 }
 
+// lockFilePath returns the key of the S3 lock file for this state.
+func (c *RemoteClient) lockFilePath() string {
+	return c.path + lockFileSuffix
+}
+
 func (c *RemoteClient) getSSECustomerKeyMD5() string {
 	b := md5.Sum(c.customerEncryptionKey)
 	return base64.StdEncoding.EncodeToString(b[:])
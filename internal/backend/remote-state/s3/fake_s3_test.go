@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package s3
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// fakeS3Server is a minimal in-memory stand-in for the S3 API, supporting
+// just enough of the object operations, including conditional uploads with
+// If-None-Match, to exercise RemoteClient without AWS credentials.
+type fakeS3Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func newFakeS3Server(t *testing.T) *fakeS3Server {
+	t.Helper()
+
+	srv := &fakeS3Server{
+		objects: make(map[string][]byte),
+	}
+	srv.Server = httptest.NewServer(http.HandlerFunc(srv.handle))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// client returns an S3 client which sends its requests to the fake server.
+func (s *fakeS3Server) client() *s3.Client {
+	return s3.New(s3.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(s.URL),
+		UsePathStyle: true,
+		Credentials:  credentials.NewStaticCredentialsProvider("access", "secret", ""),
+	})
+}
+
+// object returns the content of the object with the given key in any bucket.
+func (s *fakeS3Server) object(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k, v := range s.objects {
+		if strings.HasSuffix(k, "/"+key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func (s *fakeS3Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	data, exists := s.objects[key]
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		if !exists {
+			writeFakeS3Error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	case http.MethodPut:
+		if r.Header.Get("If-None-Match") == "*" && exists {
+			writeFakeS3Error(w, http.StatusPreconditionFailed, "PreconditionFailed")
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeFakeS3Error(w, http.StatusInternalServerError, "InternalError")
+			return
+		}
+		s.objects[key] = body
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeFakeS3Error(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+func writeFakeS3Error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/mitchellh/colorstring"
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/httpclient"
 	"github.com/opentofu/opentofu/internal/logging"
 	"github.com/opentofu/opentofu/internal/states/remote"
@@ -58,6 +59,11 @@ type Remote struct {
 	// client is the remote backend API client.
 	client *tfe.Client
 
+	// stateEncryption is the state encryption configured for the working
+	// directory, which this backend doesn't support. See
+	// NewWithStateEncryption.
+	stateEncryption encryption.StateEncryption
+
 	// lastRetry is set to the last time a request was retried.
 	lastRetry time.Time
 
@@ -106,6 +112,16 @@ func New(services *disco.Disco) *Remote {
 	}
 }
 
+// NewWithStateEncryption is like New, but also takes the state encryption
+// configured for the working directory. The remote service must be able to
+// read the state, so Configure returns an error if state encryption is
+// enabled rather than silently storing plaintext state.
+func NewWithStateEncryption(services *disco.Disco, enc encryption.StateEncryption) *Remote {
+	b := New(services)
+	b.stateEncryption = enc
+	return b
+}
+
 // ConfigSchema implements backend.Enhanced.
 func (b *Remote) ConfigSchema() *configschema.Block {
 	return &configschema.Block{
@@ -223,6 +239,14 @@ func (b *Remote) ServiceDiscoveryAliases() ([]backend.HostAlias, error) {
 // Configure implements backend.Enhanced.
 func (b *Remote) Configure(obj cty.Value) tfdiags.Diagnostics {
 	var diags tfdiags.Diagnostics
+	if b.stateEncryption != nil && b.stateEncryption.Enabled() {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"State encryption is not supported by the remote backend",
+			"The remote backend stores state in a remote service that must be able to read it, so it cannot be used with client-side state encryption. Remove the \"state\" block from the encryption configuration to use this backend.",
+		))
+		return diags
+	}
 	if obj.IsNull() {
 		return diags
 	}
@@ -372,7 +396,7 @@ func (b *Remote) Configure(obj cty.Value) tfdiags.Diagnostics {
 	}
 
 	// Configure a local backend for when we need to run operations locally.
-	b.local = backendLocal.NewWithBackend(b)
+	b.local = backendLocal.NewWithBackend(b, encryption.StateEncryptionDisabled())
 	b.forceLocal = b.forceLocal || !entitlements.Operations
 
 	// Enable retries for server errors as the backend is now fully configured.
@@ -702,18 +726,20 @@ func (b *Remote) StateMgr(name string) (statemgr.Full, error) {
 		runID: os.Getenv("TFE_RUN_ID"),
 	}
 
-	return &remote.State{
-		Client: client,
+	// The remote service needs to be able to read the state, so client-side
+	// encryption is not supported for this backend.
+	state := remote.NewState(client, encryption.StateEncryptionDisabled())
 
-		// client.runID will be set if we're running a Terraform Cloud
-		// or Terraform Enterprise remote execution environment, in which
-		// case we'll disable intermediate snapshots to avoid extra storage
-		// costs for Terraform Enterprise customers.
-		// Other implementations of the remote state protocol should not run
-		// in contexts where there's a "TFE Run ID" and so are not affected
-		// by this special case.
-		DisableIntermediateSnapshots: client.runID != "",
-	}, nil
+	// client.runID will be set if we're running a Terraform Cloud
+	// or Terraform Enterprise remote execution environment, in which
+	// case we'll disable intermediate snapshots to avoid extra storage
+	// costs for Terraform Enterprise customers.
+	// Other implementations of the remote state protocol should not run
+	// in contexts where there's a "TFE Run ID" and so are not affected
+	// by this special case.
+	state.DisableIntermediateSnapshots = client.runID != ""
+
+	return state, nil
 }
 
 func isLocalExecutionMode(execMode string) bool {
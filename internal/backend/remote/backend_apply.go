@@ -159,6 +159,24 @@ func (b *Remote) opApply(stopCtx, cancelCtx context.Context, op *backend.Operati
 		}
 	}
 
+	if len(op.Excludes) != 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Resource exclusion is not supported",
+			`The "remote" backend does not support the -exclude option for `+
+				`remote plans.`,
+		))
+	}
+
+	if op.AllowDeferral {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Deferred changes are not supported",
+			`The "remote" backend does not support the -allow-deferral option `+
+				`for remote plans.`,
+		))
+	}
+
 	// Return if there are any errors.
 	if diags.HasErrors() {
 		return nil, diags.Err()
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backendplugin1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/opentofu/opentofu/internal/backendplugin"
+	"github.com/opentofu/opentofu/internal/backendplugin/backendproto1"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+// GRPCBackendClient is the client side of the backend plugin protocol, which
+// calls a plugin process over gRPC.
+type GRPCBackendClient struct {
+	client  backendproto1.BackendClient
+	context context.Context
+}
+
+// Proof that GRPCBackendClient fulfills the backend plugin interface
+var _ backendplugin.Storage1 = (*GRPCBackendClient)(nil)
+
+func (c *GRPCBackendClient) Configure(config map[string]string) error {
+	_, err := c.client.Configure(c.context, &backendproto1.Configure_Request{
+		Config: config,
+	})
+	return grpcErr(err)
+}
+
+func (c *GRPCBackendClient) Workspaces() ([]string, error) {
+	resp, err := c.client.Workspaces(c.context, &backendproto1.Workspaces_Request{})
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+	return resp.Names, nil
+}
+
+func (c *GRPCBackendClient) DeleteWorkspace(workspace string) error {
+	_, err := c.client.DeleteWorkspace(c.context, &backendproto1.DeleteWorkspace_Request{
+		Workspace: workspace,
+	})
+	return grpcErr(err)
+}
+
+func (c *GRPCBackendClient) GetState(workspace string) ([]byte, error) {
+	resp, err := c.client.GetState(c.context, &backendproto1.GetState_Request{
+		Workspace: workspace,
+	})
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+	if !resp.Exists {
+		return nil, nil
+	}
+	if resp.Data == nil {
+		// An existing but empty snapshot must still be distinguishable
+		// from a missing one.
+		return []byte{}, nil
+	}
+	return resp.Data, nil
+}
+
+func (c *GRPCBackendClient) PutState(workspace string, data []byte) error {
+	_, err := c.client.PutState(c.context, &backendproto1.PutState_Request{
+		Workspace: workspace,
+		Data:      data,
+	})
+	return grpcErr(err)
+}
+
+func (c *GRPCBackendClient) Lock(workspace string, info *statemgr.LockInfo) (string, error) {
+	resp, err := c.client.Lock(c.context, &backendproto1.Lock_Request{
+		Workspace: workspace,
+		Info:      info.Marshal(),
+	})
+	if err != nil {
+		return "", grpcErr(err)
+	}
+	if resp.Conflict != nil {
+		return "", lockConflictError(resp.Conflict)
+	}
+	return resp.Id, nil
+}
+
+func (c *GRPCBackendClient) Unlock(workspace string, id string) error {
+	resp, err := c.client.Unlock(c.context, &backendproto1.Unlock_Request{
+		Workspace: workspace,
+		Id:        id,
+	})
+	if err != nil {
+		return grpcErr(err)
+	}
+	if resp.Conflict != nil {
+		return lockConflictError(resp.Conflict)
+	}
+	return nil
+}
+
+// lockConflictError converts a lock conflict reported by a plugin into the
+// *statemgr.LockError that OpenTofu expects from a lock that is held already.
+func lockConflictError(conflict *backendproto1.LockConflict) error {
+	lockErr := &statemgr.LockError{
+		Err: errors.New(conflict.Message),
+	}
+	if len(conflict.Info) != 0 {
+		info := &statemgr.LockInfo{}
+		if err := json.Unmarshal(conflict.Info, info); err != nil {
+			return fmt.Errorf("backend plugin returned invalid lock info: %w", err)
+		}
+		lockErr.Info = info
+	}
+	return lockErr
+}
+
+// grpcErr returns the message of an error returned by the plugin, without
+// the gRPC status code, or a more helpful message if the plugin has crashed
+// or doesn't support the called method.
+func grpcErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch status.Code(err) {
+	case codes.Unavailable:
+		return errors.New("the backend plugin did not respond; the plugin logs may contain more details")
+	case codes.Unimplemented:
+		return fmt.Errorf("the backend plugin doesn't support this operation: %s", status.Convert(err).Message())
+	default:
+		return errors.New(status.Convert(err).Message())
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backendplugin1
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+
+	"github.com/opentofu/opentofu/internal/backendplugin"
+	"github.com/opentofu/opentofu/internal/backendplugin/backendproto1"
+)
+
+// ProtocolVersion is the go-plugin protocol version of the backend plugin
+// protocol implemented by this package.
+const ProtocolVersion = 1
+
+// GRPCBackendPlugin is the go-plugin implementation of backend plugins.
+// Impl is used only on the plugin side, to serve requests.
+type GRPCBackendPlugin struct {
+	plugin.Plugin
+	Impl backendplugin.Storage1
+}
+
+// VersionedPlugins is the plugin set used by OpenTofu to launch backend
+// plugins.
+var VersionedPlugins = map[int]plugin.PluginSet{
+	ProtocolVersion: {
+		backendplugin.PluginName: &GRPCBackendPlugin{},
+	},
+}
+
+// GRPCClient returns a new GRPC client for interacting with the backend plugin
+// server.
+func (p *GRPCBackendPlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return &GRPCBackendClient{
+		client:  backendproto1.NewBackendClient(c),
+		context: ctx,
+	}, nil
+}
+
+// GRPCServer registers a server for p.Impl.
+func (p *GRPCBackendPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	backendproto1.RegisterBackendServer(s, &GRPCBackendServer{impl: p.Impl})
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backendplugin1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/backendplugin"
+	"github.com/opentofu/opentofu/internal/backendplugin/backendproto1"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+// GRPCBackendServer is the server side of the backend plugin protocol, which
+// serves requests from OpenTofu using a Storage1 implementation.
+type GRPCBackendServer struct {
+	impl backendplugin.Storage1
+}
+
+var _ backendproto1.BackendServer = (*GRPCBackendServer)(nil)
+
+func (s *GRPCBackendServer) Configure(_ context.Context, req *backendproto1.Configure_Request) (*backendproto1.Configure_Response, error) {
+	if err := s.impl.Configure(req.Config); err != nil {
+		return nil, err
+	}
+	return &backendproto1.Configure_Response{}, nil
+}
+
+func (s *GRPCBackendServer) Workspaces(_ context.Context, _ *backendproto1.Workspaces_Request) (*backendproto1.Workspaces_Response, error) {
+	names, err := s.impl.Workspaces()
+	if err != nil {
+		return nil, err
+	}
+	return &backendproto1.Workspaces_Response{Names: names}, nil
+}
+
+func (s *GRPCBackendServer) DeleteWorkspace(_ context.Context, req *backendproto1.DeleteWorkspace_Request) (*backendproto1.DeleteWorkspace_Response, error) {
+	if err := s.impl.DeleteWorkspace(req.Workspace); err != nil {
+		return nil, err
+	}
+	return &backendproto1.DeleteWorkspace_Response{}, nil
+}
+
+func (s *GRPCBackendServer) GetState(_ context.Context, req *backendproto1.GetState_Request) (*backendproto1.GetState_Response, error) {
+	data, err := s.impl.GetState(req.Workspace)
+	if err != nil {
+		return nil, err
+	}
+	return &backendproto1.GetState_Response{
+		Exists: data != nil,
+		Data:   data,
+	}, nil
+}
+
+func (s *GRPCBackendServer) PutState(_ context.Context, req *backendproto1.PutState_Request) (*backendproto1.PutState_Response, error) {
+	if err := s.impl.PutState(req.Workspace, req.Data); err != nil {
+		return nil, err
+	}
+	return &backendproto1.PutState_Response{}, nil
+}
+
+func (s *GRPCBackendServer) Lock(_ context.Context, req *backendproto1.Lock_Request) (*backendproto1.Lock_Response, error) {
+	info := &statemgr.LockInfo{}
+	if err := json.Unmarshal(req.Info, info); err != nil {
+		return nil, fmt.Errorf("invalid lock info: %w", err)
+	}
+
+	id, err := s.impl.Lock(req.Workspace, info)
+	if conflict := lockConflict(err); conflict != nil {
+		return &backendproto1.Lock_Response{Conflict: conflict}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &backendproto1.Lock_Response{Id: id}, nil
+}
+
+func (s *GRPCBackendServer) Unlock(_ context.Context, req *backendproto1.Unlock_Request) (*backendproto1.Unlock_Response, error) {
+	err := s.impl.Unlock(req.Workspace, req.Id)
+	if conflict := lockConflict(err); conflict != nil {
+		return &backendproto1.Unlock_Response{Conflict: conflict}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &backendproto1.Unlock_Response{}, nil
+}
+
+// lockConflict returns the protocol representation of the given error if it
+// is a *statemgr.LockError, or nil otherwise.
+func lockConflict(err error) *backendproto1.LockConflict {
+	var lockErr *statemgr.LockError
+	if !errors.As(err, &lockErr) {
+		return nil
+	}
+
+	conflict := &backendproto1.LockConflict{}
+	if lockErr.Err != nil {
+		conflict.Message = lockErr.Err.Error()
+	}
+	if lockErr.Info != nil {
+		conflict.Info = lockErr.Info.Marshal()
+	}
+	return conflict
+}
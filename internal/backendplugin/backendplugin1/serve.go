@@ -0,0 +1,26 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backendplugin1
+
+import (
+	"github.com/hashicorp/go-plugin"
+
+	"github.com/opentofu/opentofu/internal/backendplugin"
+	tfplugin "github.com/opentofu/opentofu/internal/plugin"
+)
+
+// Serve serves the given backend plugin implementation. This function never
+// returns and should be the final function called in the main function of
+// the plugin.
+func Serve(impl backendplugin.Storage1) {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: tfplugin.Handshake,
+		VersionedPlugins: map[int]plugin.PluginSet{
+			ProtocolVersion: {
+				backendplugin.PluginName: &GRPCBackendPlugin{Impl: impl},
+			},
+		},
+		GRPCServer: plugin.DefaultGRPCServer,
+	})
+}
@@ -0,0 +1,16 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// terraform-backend-dir is a backend plugin which stores state in a local
+// directory, for use with the "plugin" backend. It is the reference
+// implementation of the backend plugin protocol.
+package main
+
+import (
+	"github.com/opentofu/opentofu/internal/backendplugin/backendplugin1"
+	"github.com/opentofu/opentofu/internal/backendplugin/dirbackend"
+)
+
+func main() {
+	backendplugin1.Serve(&dirbackend.Storage{})
+}
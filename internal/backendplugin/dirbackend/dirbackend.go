@@ -0,0 +1,217 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package dirbackend is the reference implementation of a backend plugin,
+// which stores state snapshots and locks as files in a directory.
+//
+// It is intended as a starting point for plugins storing state elsewhere,
+// and to test the plugin backend, rather than for production use.
+package dirbackend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/backendplugin"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+const (
+	stateFileExt = ".tfstate"
+	lockFileExt  = ".tflock"
+)
+
+// Storage stores the state of each workspace in a file named
+// WORKSPACE.tfstate in the directory given by the "path" configuration
+// option, and locks it by creating the file WORKSPACE.tflock next to it.
+type Storage struct {
+	dir string
+
+	// mu serializes the calls of a single plugin process. Calls from
+	// separate processes are serialized by the lock files.
+	mu sync.Mutex
+}
+
+var _ backendplugin.Storage1 = (*Storage)(nil)
+
+func (s *Storage) Configure(config map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k := range config {
+		if k != "path" {
+			return fmt.Errorf("unsupported configuration option %q", k)
+		}
+	}
+	dir := config["path"]
+	if dir == "" {
+		return errors.New(`the "path" configuration option is required`)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	s.dir = dir
+	return nil
+}
+
+func (s *Storage) Workspaces() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state directory: %w", err)
+	}
+
+	var ret []string
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), stateFileExt); ok && !entry.IsDir() {
+			ret = append(ret, name)
+		}
+	}
+	return ret, nil
+}
+
+func (s *Storage) DeleteWorkspace(workspace string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path, err := s.path(workspace, stateFileExt)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete state: %w", err)
+	}
+	return nil
+}
+
+func (s *Storage) GetState(workspace string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path, err := s.path(workspace, stateFileExt)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state: %w", err)
+	}
+	return data, nil
+}
+
+func (s *Storage) PutState(workspace string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path, err := s.path(workspace, stateFileExt)
+	if err != nil {
+		return err
+	}
+
+	// We write a temporary file and rename it so that readers never see
+	// a partially-written snapshot.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	return nil
+}
+
+func (s *Storage) Lock(workspace string, info *statemgr.LockInfo) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path, err := s.path(workspace, lockFileExt)
+	if err != nil {
+		return "", err
+	}
+
+	info.Path = path
+	info.Created = time.Now().UTC()
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if os.IsExist(err) {
+		return "", &statemgr.LockError{
+			Err:  errors.New("state locked"),
+			Info: readLockInfo(path),
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to create lock file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(info.Marshal()); err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("failed to write lock file: %w", err)
+	}
+	return info.ID, nil
+}
+
+func (s *Storage) Unlock(workspace string, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path, err := s.path(workspace, lockFileExt)
+	if err != nil {
+		return err
+	}
+
+	info := readLockInfo(path)
+	if info == nil {
+		return &statemgr.LockError{
+			Err: errors.New("state not locked"),
+		}
+	}
+	if info.ID != id {
+		return &statemgr.LockError{
+			Err:  fmt.Errorf("lock ID %q does not match existing lock", id),
+			Info: info,
+		}
+	}
+
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove lock file: %w", err)
+	}
+	return nil
+}
+
+// path returns the path of the file with the given extension for the given
+// workspace.
+func (s *Storage) path(workspace, ext string) (string, error) {
+	if s.dir == "" {
+		return "", errors.New("backend plugin is not configured")
+	}
+	if workspace == "" || strings.ContainsAny(workspace, `/\`) || workspace == "." || workspace == ".." {
+		return "", fmt.Errorf("invalid workspace name %q", workspace)
+	}
+	return filepath.Join(s.dir, workspace+ext), nil
+}
+
+// readLockInfo returns the lock info saved in the given lock file, or nil if
+// it can't be read.
+func readLockInfo(path string) *statemgr.LockInfo {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	info := &statemgr.LockInfo{}
+	if err := json.Unmarshal(data, info); err != nil {
+		return nil
+	}
+	return info
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package backendplugin contains the interface between the "plugin" backend
+// and external programs that store state on its behalf.
+package backendplugin
+
+import (
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+// PluginName is the name under which backend plugins are dispensed by
+// go-plugin, and the kind used to discover their executables, which are
+// named terraform-backend-NAME.
+const PluginName = "backend"
+
+// Storage1 is the interface implemented by backend plugins speaking protocol
+// version 1.
+//
+// OpenTofu serializes, encrypts and validates state snapshots itself, so
+// a Storage1 implementation only stores opaque snapshots and lock records
+// for each workspace.
+type Storage1 interface {
+	// Configure receives the "config" argument of the backend block. It is
+	// called once, before any other method.
+	Configure(config map[string]string) error
+
+	// Workspaces returns the names of the workspaces that have a state
+	// snapshot, in any order.
+	Workspaces() ([]string, error)
+
+	// DeleteWorkspace deletes the state snapshot of the given workspace.
+	DeleteWorkspace(workspace string) error
+
+	// GetState returns the state snapshot of the given workspace, or nil
+	// if there is none.
+	GetState(workspace string) ([]byte, error)
+
+	// PutState replaces the state snapshot of the given workspace.
+	PutState(workspace string, data []byte) error
+
+	// Lock acquires the lock of the given workspace for the operation
+	// described by info, and returns an ID for it which is later passed to
+	// Unlock. If the lock is held already, Lock returns a
+	// *statemgr.LockError including the info of the current holder.
+	Lock(workspace string, info *statemgr.LockInfo) (string, error)
+
+	// Unlock releases the lock of the given workspace if it has the given
+	// ID, or returns a *statemgr.LockError otherwise.
+	Unlock(workspace string, id string) error
+}
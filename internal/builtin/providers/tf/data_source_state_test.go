@@ -6,11 +6,14 @@ package tf
 import (
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/apparentlymart/go-dump/dump"
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/zclconf/go-cty/cty"
@@ -288,12 +291,12 @@ func TestState_basic(t *testing.T) {
 				t.Fatalf("unexpected error: %s", err)
 			}
 
-			diags := dataSourceRemoteStateValidate(config)
+			diags := dataSourceRemoteStateValidate(config, encryption.StateEncryptionDisabled())
 
 			var got cty.Value
 			if !diags.HasErrors() && config.IsWhollyKnown() {
 				var moreDiags tfdiags.Diagnostics
-				got, moreDiags = dataSourceRemoteStateRead(config)
+				got, moreDiags = dataSourceRemoteStateRead(config, encryption.StateEncryptionDisabled())
 				diags = diags.Append(moreDiags)
 			}
 
@@ -312,13 +315,77 @@ func TestState_basic(t *testing.T) {
 	}
 }
 
+func TestState_encrypted(t *testing.T) {
+	t.Setenv(encryption.ConfigEnvName, `
+key_provider "pbkdf2" "test" {
+  passphrase = "correct-horse-battery-staple"
+  iterations = 200000
+}
+method "aes_gcm" "test" {
+  keys = key_provider.pbkdf2.test
+}
+state {
+  method = method.aes_gcm.test
+}
+`)
+	encCfg, hclDiags := encryption.ConfigFromEnv()
+	if hclDiags.HasErrors() {
+		t.Fatal(hclDiags.Error())
+	}
+	enc, hclDiags := encryption.New(encCfg)
+	if hclDiags.HasErrors() {
+		t.Fatal(hclDiags.Error())
+	}
+
+	src, err := os.ReadFile("./testdata/basic.tfstate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := enc.State().EncryptState(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statePath := filepath.Join(t.TempDir(), "encrypted.tfstate")
+	if err := os.WriteFile(statePath, encrypted, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	schema := dataSourceRemoteStateGetSchema().Block
+	config, err := schema.CoerceValue(cty.ObjectVal(map[string]cty.Value{
+		"backend": cty.StringVal("local"),
+		"config": cty.ObjectVal(map[string]cty.Value{
+			"path": cty.StringVal(statePath),
+		}),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got, diags := dataSourceRemoteStateRead(config, enc.RemoteState())
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+	want := cty.ObjectVal(map[string]cty.Value{
+		"foo": cty.StringVal("bar"),
+	})
+	if got := got.GetAttr("outputs"); !want.RawEquals(got) {
+		t.Errorf("wrong outputs\ngot:  %#v\nwant: %#v", got, want)
+	}
+
+	// Without the encryption configuration the state can't be read.
+	_, diags = dataSourceRemoteStateRead(config, encryption.StateEncryptionDisabled())
+	if !diags.HasErrors() {
+		t.Fatal("succeeded without encryption configuration; want error")
+	}
+}
+
 func TestState_validation(t *testing.T) {
 	// The main test TestState_basic covers both validation and reading of
 	// state snapshots, so this additional test is here only to verify that
 	// the validation step in isolation does not attempt to configure
 	// the backend.
 	overrideBackendFactories = map[string]backend.InitFn{
-		"failsconfigure": func() backend.Backend {
+		"failsconfigure": func(enc encryption.StateEncryption) backend.Backend {
 			return backendFailsConfigure{}
 		},
 	}
@@ -336,7 +403,7 @@ func TestState_validation(t *testing.T) {
 		t.Fatalf("unexpected error: %s", err)
 	}
 
-	diags := dataSourceRemoteStateValidate(config)
+	diags := dataSourceRemoteStateValidate(config, encryption.StateEncryptionDisabled())
 	if diags.HasErrors() {
 		t.Fatalf("unexpected errors\n%s", diags.Err().Error())
 	}
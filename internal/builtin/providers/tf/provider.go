@@ -7,15 +7,27 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/providers"
 )
 
 // Provider is an implementation of providers.Interface
-type Provider struct{}
+type Provider struct {
+	// stateEncryption decrypts the state snapshots that the
+	// terraform_remote_state data source reads.
+	stateEncryption encryption.StateEncryption
+}
 
 // NewProvider returns a new tofu provider
 func NewProvider() providers.Interface {
-	return &Provider{}
+	return NewProviderWithStateEncryption(encryption.StateEncryptionDisabled())
+}
+
+// NewProviderWithStateEncryption returns a new tofu provider whose
+// terraform_remote_state data source decrypts state using the given
+// encryption, such as the result of encryption.Encryption.RemoteState.
+func NewProviderWithStateEncryption(enc encryption.StateEncryption) providers.Interface {
+	return &Provider{stateEncryption: enc}
 }
 
 // GetSchema returns the complete schema for the provider.
@@ -27,6 +39,9 @@ func (p *Provider) GetProviderSchema() providers.GetProviderSchemaResponse {
 		ResourceTypes: map[string]providers.Schema{
 			"terraform_data": dataStoreResourceSchema(),
 		},
+		ServerCapabilities: providers.ServerCapabilities{
+			MoveResourceState: true,
+		},
 	}
 }
 
@@ -52,7 +67,7 @@ func (p *Provider) ValidateDataResourceConfig(req providers.ValidateDataResource
 		return res
 	}
 
-	diags := dataSourceRemoteStateValidate(req.Config)
+	diags := dataSourceRemoteStateValidate(req.Config, p.stateEncryption)
 	res.Diagnostics = diags
 
 	return res
@@ -77,7 +92,7 @@ func (p *Provider) ReadDataSource(req providers.ReadDataSourceRequest) providers
 		return res
 	}
 
-	newState, diags := dataSourceRemoteStateRead(req.Config)
+	newState, diags := dataSourceRemoteStateRead(req.Config, p.stateEncryption)
 
 	res.State = newState
 	res.Diagnostics = diags
@@ -130,11 +145,36 @@ func (p *Provider) ImportResourceState(req providers.ImportResourceStateRequest)
 	panic("unimplemented - terraform_remote_state has no resources")
 }
 
+// MoveResourceState translates the state of a resource of another type
+// into the state of a terraform_data resource.
+func (p *Provider) MoveResourceState(req providers.MoveResourceStateRequest) providers.MoveResourceStateResponse {
+	if req.TargetTypeName == "terraform_data" {
+		return moveDataStoreResourceState(req)
+	}
+
+	var res providers.MoveResourceStateResponse
+	res.Diagnostics = res.Diagnostics.Append(fmt.Errorf("unsupported resource type %s", req.TargetTypeName))
+	return res
+}
+
 // ValidateResourceConfig is used to to validate the resource configuration values.
 func (p *Provider) ValidateResourceConfig(req providers.ValidateResourceConfigRequest) providers.ValidateResourceConfigResponse {
 	return validateDataStoreResourceConfig(req)
 }
 
+// GetFunctions returns no functions, since the terraform provider doesn't
+// contribute any.
+func (p *Provider) GetFunctions() providers.GetFunctionsResponse {
+	return providers.GetFunctionsResponse{}
+}
+
+// CallFunction always fails, since the terraform provider has no functions.
+func (p *Provider) CallFunction(req providers.CallFunctionRequest) providers.CallFunctionResponse {
+	var res providers.CallFunctionResponse
+	res.Diagnostics = res.Diagnostics.Append(fmt.Errorf("unsupported function %q", req.Name))
+	return res
+}
+
 // Close is a noop for this provider, since it's run in-process.
 func (p *Provider) Close() error {
 	return nil
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/go-uuid"
+	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
 	"github.com/opentofu/opentofu/internal/providers"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -170,3 +171,47 @@ func importDataStore(req providers.ImportResourceStateRequest) (resp providers.I
 	}
 	return resp
 }
+
+// moveDataStoreResourceState translates the state of a null_resource from the
+// hashicorp/null provider into the equivalent terraform_data state, so that
+// configurations can replace one with the other using a moved block.
+func moveDataStoreResourceState(req providers.MoveResourceStateRequest) (resp providers.MoveResourceStateResponse) {
+	sourceProvider, diags := addrs.ParseProviderSourceString(req.SourceProviderAddress)
+	resp.Diagnostics = resp.Diagnostics.Append(diags)
+	if diags.HasErrors() {
+		return resp
+	}
+	if req.SourceTypeName != "null_resource" || sourceProvider.Namespace != "hashicorp" || sourceProvider.Type != "null" {
+		resp.Diagnostics = resp.Diagnostics.Append(fmt.Errorf("cannot move %s from provider %s to terraform_data; only null_resource from the hashicorp/null provider can be moved", req.SourceTypeName, req.SourceProviderAddress))
+		return resp
+	}
+	if req.SourceSchemaVersion != 0 {
+		resp.Diagnostics = resp.Diagnostics.Append(fmt.Errorf("unsupported null_resource schema version %d", req.SourceSchemaVersion))
+		return resp
+	}
+
+	nullTy := cty.Object(map[string]cty.Type{
+		"id":       cty.String,
+		"triggers": cty.Map(cty.String),
+	})
+	nullVal, err := ctyjson.Unmarshal(req.SourceStateJSON, nullTy)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+
+	triggers := nullVal.GetAttr("triggers")
+	if triggers.IsNull() || triggers.LengthInt() == 0 {
+		// terraform_data compares triggers_replace exactly, so a missing
+		// triggers map must stay null to avoid planning a replacement.
+		triggers = cty.NullVal(cty.DynamicPseudoType)
+	}
+
+	resp.TargetState = cty.ObjectVal(map[string]cty.Value{
+		"id":               nullVal.GetAttr("id"),
+		"input":            cty.NullVal(cty.DynamicPseudoType),
+		"output":           cty.NullVal(cty.DynamicPseudoType),
+		"triggers_replace": triggers,
+	})
+	return resp
+}
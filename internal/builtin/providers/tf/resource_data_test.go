@@ -103,6 +103,54 @@ func TestManagedDataRead(t *testing.T) {
 	}
 }
 
+func TestManagedDataMove(t *testing.T) {
+	req := providers.MoveResourceStateRequest{
+		SourceProviderAddress: "registry.opentofu.org/hashicorp/null",
+		SourceTypeName:        "null_resource",
+		SourceStateJSON:       []byte(`{"id":"not-quite-unique","triggers":{"a":"b"}}`),
+		TargetTypeName:        "terraform_data",
+	}
+
+	resp := moveDataStoreResourceState(req)
+	if resp.Diagnostics.HasErrors() {
+		t.Fatal("unexpected error", resp.Diagnostics.ErrWithWarnings())
+	}
+
+	expected := cty.ObjectVal(map[string]cty.Value{
+		"input":  cty.NullVal(cty.DynamicPseudoType),
+		"output": cty.NullVal(cty.DynamicPseudoType),
+		"triggers_replace": cty.MapVal(map[string]cty.Value{
+			"a": cty.StringVal("b"),
+		}),
+		"id": cty.StringVal("not-quite-unique"),
+	})
+	if !resp.TargetState.RawEquals(expected) {
+		t.Errorf("expected state:\n%#v\ngot:\n%#v\n", expected, resp.TargetState)
+	}
+
+	// without triggers
+	req.SourceStateJSON = []byte(`{"id":"not-quite-unique","triggers":null}`)
+	resp = moveDataStoreResourceState(req)
+	if resp.Diagnostics.HasErrors() {
+		t.Fatal("unexpected error", resp.Diagnostics.ErrWithWarnings())
+	}
+	if !resp.TargetState.GetAttr("triggers_replace").IsNull() {
+		t.Errorf("expected null triggers_replace, got %#v", resp.TargetState.GetAttr("triggers_replace"))
+	}
+
+	// unsupported source type
+	req.SourceProviderAddress = "registry.opentofu.org/hashicorp/random"
+	req.SourceTypeName = "random_id"
+	resp = moveDataStoreResourceState(req)
+	if !resp.Diagnostics.HasErrors() {
+		t.Fatal("expected error")
+	}
+	msg := resp.Diagnostics.Err().Error()
+	if !strings.Contains(msg, "only null_resource from the hashicorp/null provider can be moved") {
+		t.Error("unexpected error", msg)
+	}
+}
+
 func TestManagedDataPlan(t *testing.T) {
 	schema := dataStoreResourceSchema().Block
 	ty := schema.ImpliedType()
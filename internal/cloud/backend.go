@@ -28,6 +28,7 @@ import (
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/command/jsonformat"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/httpclient"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
@@ -63,6 +64,11 @@ type Cloud struct {
 	// client is the cloud backend API client.
 	client *tfe.Client
 
+	// stateEncryption is the state encryption configured for the working
+	// directory, which this backend doesn't support. See
+	// NewWithStateEncryption.
+	stateEncryption encryption.StateEncryption
+
 	// lastRetry is set to the last time a request was retried.
 	lastRetry time.Time
 
@@ -118,6 +124,16 @@ func New(services *disco.Disco) *Cloud {
 	}
 }
 
+// NewWithStateEncryption is like New, but also takes the state encryption
+// configured for the working directory. The remote service must be able to
+// read the state, so Configure returns an error if state encryption is
+// enabled rather than silently storing plaintext state.
+func NewWithStateEncryption(services *disco.Disco, enc encryption.StateEncryption) *Cloud {
+	b := New(services)
+	b.stateEncryption = enc
+	return b
+}
+
 // ConfigSchema implements backend.Enhanced.
 func (b *Cloud) ConfigSchema() *configschema.Block {
 	return &configschema.Block{
@@ -255,6 +271,14 @@ func (b *Cloud) ServiceDiscoveryAliases() ([]backend.HostAlias, error) {
 // Configure implements backend.Enhanced.
 func (b *Cloud) Configure(obj cty.Value) tfdiags.Diagnostics {
 	var diags tfdiags.Diagnostics
+	if b.stateEncryption != nil && b.stateEncryption.Enabled() {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"State encryption is not supported by cloud integration",
+			"Cloud integration stores state in a remote service that must be able to read it, so it cannot be used with client-side state encryption. Remove the \"state\" block from the encryption configuration to use cloud integration.",
+		))
+		return diags
+	}
 	if obj.IsNull() {
 		return diags
 	}
@@ -407,7 +431,7 @@ func (b *Cloud) Configure(obj cty.Value) tfdiags.Diagnostics {
 	}
 
 	// Configure a local backend for when we need to run operations locally.
-	b.local = backendLocal.NewWithBackend(b)
+	b.local = backendLocal.NewWithBackend(b, encryption.StateEncryptionDisabled())
 	b.forceLocal = b.forceLocal || !entitlements.Operations
 
 	// Enable retries for server errors as the backend is now fully configured.
@@ -76,6 +76,22 @@ func (b *Cloud) opApply(stopCtx, cancelCtx context.Context, op *backend.Operatio
 		))
 	}
 
+	if len(op.Excludes) != 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Resource exclusion is not supported",
+			`Cloud backend does not support the -exclude option at this time.`,
+		))
+	}
+
+	if op.AllowDeferral {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Deferred changes are not supported",
+			`Cloud backend does not support the -allow-deferral option at this time.`,
+		))
+	}
+
 	// Return if there are any errors.
 	if diags.HasErrors() {
 		return nil, diags.Err()
@@ -95,6 +95,10 @@ func (c *ApplyCommand) Run(rawArgs []string) int {
 	// object state for now.
 	c.Meta.parallelism = args.Operation.Parallelism
 
+	// The backend configuration may refer to input variables, so their
+	// values must be available before the backend is prepared.
+	c.Meta.setVariableArgs(args.Vars)
+
 	// Prepare the backend, passing the plan file if present, and the
 	// backend-specific arguments
 	be, beDiags := c.PrepareBackend(planFile, args.State, args.ViewType)
@@ -271,7 +275,9 @@ func (c *ApplyCommand) OperationRequest(
 	opReq.PlanFile = planFile
 	opReq.PlanRefresh = args.Refresh
 	opReq.Targets = args.Targets
+	opReq.Excludes = args.Excludes
 	opReq.ForceReplace = args.ForceReplace
+	opReq.AllowDeferral = args.AllowDeferral
 	opReq.Type = backend.OperationTypeApply
 	opReq.View = view.Operation()
 
@@ -295,13 +301,7 @@ func (c *ApplyCommand) GatherVariables(opReq *backend.Operation, args *arguments
 	// structure, we could move the variable gathering code to the arguments
 	// package directly, removing this shim layer.
 
-	varArgs := args.All()
-	items := make([]rawFlag, len(varArgs))
-	for i := range varArgs {
-		items[i].Name = varArgs[i].Name
-		items[i].Value = varArgs[i].Value
-	}
-	c.Meta.variableArgs = rawFlags{items: &items}
+	c.Meta.setVariableArgs(args)
 	opReq.Variables, diags = c.collectVariableValues()
 
 	return diags
@@ -14,6 +14,7 @@ import (
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/providers"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/statefile"
@@ -90,7 +91,7 @@ func TestApply_destroy(t *testing.T) {
 	}
 	defer f.Close()
 
-	stateFile, err := statefile.Read(f)
+	stateFile, err := statefile.Read(f, encryption.StateEncryptionDisabled())
 	if err != nil {
 		t.Fatalf("err: %s", err)
 	}
@@ -110,7 +111,7 @@ func TestApply_destroy(t *testing.T) {
 		t.Fatalf("err: %s", err)
 	}
 
-	backupStateFile, err := statefile.Read(f)
+	backupStateFile, err := statefile.Read(f, encryption.StateEncryptionDisabled())
 	f.Close()
 	if err != nil {
 		t.Fatalf("err: %s", err)
@@ -486,7 +487,7 @@ func TestApply_destroyTargetedDependencies(t *testing.T) {
 	}
 	defer f.Close()
 
-	stateFile, err := statefile.Read(f)
+	stateFile, err := statefile.Read(f, encryption.StateEncryptionDisabled())
 	if err != nil {
 		t.Fatalf("err: %s", err)
 	}
@@ -505,7 +506,7 @@ func TestApply_destroyTargetedDependencies(t *testing.T) {
 		t.Fatalf("err: %s", err)
 	}
 
-	backupStateFile, err := statefile.Read(f)
+	backupStateFile, err := statefile.Read(f, encryption.StateEncryptionDisabled())
 	f.Close()
 	if err != nil {
 		t.Fatalf("err: %s", err)
@@ -637,7 +638,7 @@ func TestApply_destroyTargeted(t *testing.T) {
 	}
 	defer f.Close()
 
-	stateFile, err := statefile.Read(f)
+	stateFile, err := statefile.Read(f, encryption.StateEncryptionDisabled())
 	if err != nil {
 		t.Fatalf("err: %s", err)
 	}
@@ -657,7 +658,7 @@ func TestApply_destroyTargeted(t *testing.T) {
 		t.Fatalf("err: %s", err)
 	}
 
-	backupStateFile, err := statefile.Read(f)
+	backupStateFile, err := statefile.Read(f, encryption.StateEncryptionDisabled())
 	f.Close()
 	if err != nil {
 		t.Fatalf("err: %s", err)
@@ -22,9 +22,11 @@ import (
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/providers"
 	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/states/statefile"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/opentofu/opentofu/internal/tofu"
@@ -67,6 +69,67 @@ func TestApply(t *testing.T) {
 		t.Fatal("state should not be nil")
 	}
 }
+func TestApply_encryption(t *testing.T) {
+	// Create a temporary working directory that is empty
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("apply-encryption"), td)
+	defer testChdir(t, td)()
+
+	statePath := testTempFile(t)
+
+	p := applyFixtureProvider()
+
+	view, done := testView(t)
+	c := &ApplyCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			View:             view,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-auto-approve",
+	}
+	code := c.Run(args)
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, output.Stderr())
+	}
+
+	raw, err := os.ReadFile(statePath)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !strings.Contains(string(raw), "encrypted_data") || strings.Contains(string(raw), "test_instance") {
+		t.Fatalf("state was not encrypted:\n%s", raw)
+	}
+
+	// The state can't be read without the encryption configuration.
+	f, err := os.Open(statePath)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer f.Close()
+	if _, err := statefile.Read(f, encryption.StateEncryptionDisabled()); err == nil {
+		t.Fatal("expected error reading encrypted state without encryption")
+	}
+
+	// A subsequent plan in the same directory must be able to decrypt it.
+	planView, planDone := testView(t)
+	pc := &PlanCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			View:             planView,
+		},
+	}
+	code = pc.Run([]string{"-state", statePath, "-detailed-exitcode"})
+	planOutput := planDone(t)
+	if code != 0 {
+		t.Fatalf("bad: %d\n\n%s%s", code, planOutput.Stdout(), planOutput.Stderr())
+	}
+}
+
 func TestApply_conditionalSensitive(t *testing.T) {
 	// Create a temporary working directory that is empty
 	td := t.TempDir()
@@ -448,7 +511,7 @@ func TestApply_defaultState(t *testing.T) {
 	}
 
 	// create an existing state file
-	localState := statemgr.NewFilesystem(statePath)
+	localState := statemgr.NewFilesystem(statePath, encryption.StateEncryptionDisabled())
 	if err := localState.WriteState(states.NewState()); err != nil {
 		t.Fatal(err)
 	}
@@ -737,7 +800,7 @@ func TestApply_plan_backup(t *testing.T) {
 	}
 
 	// create a state file that needs to be backed up
-	fs := statemgr.NewFilesystem(statePath)
+	fs := statemgr.NewFilesystem(statePath, encryption.StateEncryptionDisabled())
 	fs.StateSnapshotMeta()
 	err := fs.WriteState(states.NewState())
 	if err != nil {
@@ -827,6 +890,8 @@ func TestApply_plan_remoteState(t *testing.T) {
 		"unlock_address":            cty.NullVal(cty.String),
 		"lock_method":               cty.NullVal(cty.String),
 		"unlock_method":             cty.NullVal(cty.String),
+		"lock_mode":                 cty.NullVal(cty.String),
+		"lock_ttl":                  cty.NullVal(cty.Number),
 		"username":                  cty.NullVal(cty.String),
 		"password":                  cty.NullVal(cty.String),
 		"skip_cert_verification":    cty.NullVal(cty.Bool),
@@ -67,6 +67,10 @@ type Operation struct {
 	// their dependencies.
 	Targets []addrs.Targetable
 
+	// Excludes allow limiting an operation to everything except a set of
+	// resource addresses and the objects that depend on them.
+	Excludes []addrs.Targetable
+
 	// ForceReplace addresses cause OpenTofu to force a particular set of
 	// resource instances to generate "replace" actions in any plan where they
 	// would normally have generated "no-op" or "update" actions.
@@ -79,45 +83,39 @@ type Operation struct {
 	// learn a use-case for broader matching.
 	ForceReplace []addrs.AbsResourceInstance
 
+	// AllowDeferral causes OpenTofu to defer the changes for resources whose
+	// count or for_each value isn't known yet to a later plan, along with the
+	// changes for everything that depends on them, instead of failing.
+	AllowDeferral bool
+
 	// These private fields are used only temporarily during decoding. Use
 	// method Parse to populate the exported fields from these, validating
 	// the raw values in the process.
 	targetsRaw      []string
+	excludesRaw     []string
 	forceReplaceRaw []string
 	destroyRaw      bool
 	refreshOnlyRaw  bool
 }
 
 // Parse must be called on Operation after initial flag parse. This processes
-// the raw target flags into addrs.Targetable values, returning diagnostics if
-// invalid.
+// the raw target and exclude flags into addrs.Targetable values, returning
+// diagnostics if invalid.
 func (o *Operation) Parse() tfdiags.Diagnostics {
 	var diags tfdiags.Diagnostics
 
-	o.Targets = nil
-
-	for _, tr := range o.targetsRaw {
-		traversal, syntaxDiags := hclsyntax.ParseTraversalAbs([]byte(tr), "", hcl.Pos{Line: 1, Column: 1})
-		if syntaxDiags.HasErrors() {
-			diags = diags.Append(tfdiags.Sourceless(
-				tfdiags.Error,
-				fmt.Sprintf("Invalid target %q", tr),
-				syntaxDiags[0].Detail,
-			))
-			continue
-		}
+	var targetDiags, excludeDiags tfdiags.Diagnostics
+	o.Targets, targetDiags = parseTargetables(o.targetsRaw, "target")
+	diags = diags.Append(targetDiags)
+	o.Excludes, excludeDiags = parseTargetables(o.excludesRaw, "exclude")
+	diags = diags.Append(excludeDiags)
 
-		target, targetDiags := addrs.ParseTarget(traversal)
-		if targetDiags.HasErrors() {
-			diags = diags.Append(tfdiags.Sourceless(
-				tfdiags.Error,
-				fmt.Sprintf("Invalid target %q", tr),
-				targetDiags[0].Description().Detail,
-			))
-			continue
-		}
-
-		o.Targets = append(o.Targets, target.Subject)
+	if len(o.targetsRaw) > 0 && len(o.excludesRaw) > 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Incompatible targeting options",
+			"The -target and -exclude options are mutually-exclusive.",
+		))
 	}
 
 	for _, raw := range o.forceReplaceRaw {
@@ -180,6 +178,39 @@ func (o *Operation) Parse() tfdiags.Diagnostics {
 	return diags
 }
 
+// parseTargetables parses the given raw -target or -exclude flag values,
+// identified by what in error messages.
+func parseTargetables(raws []string, what string) ([]addrs.Targetable, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	var ret []addrs.Targetable
+
+	for _, raw := range raws {
+		traversal, syntaxDiags := hclsyntax.ParseTraversalAbs([]byte(raw), "", hcl.Pos{Line: 1, Column: 1})
+		if syntaxDiags.HasErrors() {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				fmt.Sprintf("Invalid %s %q", what, raw),
+				syntaxDiags[0].Detail,
+			))
+			continue
+		}
+
+		target, targetDiags := addrs.ParseTarget(traversal)
+		if targetDiags.HasErrors() {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				fmt.Sprintf("Invalid %s %q", what, raw),
+				targetDiags[0].Description().Detail,
+			))
+			continue
+		}
+
+		ret = append(ret, target.Subject)
+	}
+
+	return ret, diags
+}
+
 // Vars describes arguments which specify non-default variable values. This
 // interfce is unfortunately obscure, because the order of the CLI arguments
 // determines the final value of the gathered variables. In future it might be
@@ -228,7 +259,9 @@ func extendedFlagSet(name string, state *State, operation *Operation, vars *Vars
 		f.BoolVar(&operation.destroyRaw, "destroy", false, "destroy")
 		f.BoolVar(&operation.refreshOnlyRaw, "refresh-only", false, "refresh-only")
 		f.Var((*flagStringSlice)(&operation.targetsRaw), "target", "target")
+		f.Var((*flagStringSlice)(&operation.excludesRaw), "exclude", "exclude")
 		f.Var((*flagStringSlice)(&operation.forceReplaceRaw), "replace", "replace")
+		f.BoolVar(&operation.AllowDeferral, "allow-deferral", false, "allow-deferral")
 	}
 
 	// Gather all -var and -var-file arguments into one heterogenous structure
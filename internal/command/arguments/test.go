@@ -20,6 +20,15 @@ type Test struct {
 	// ViewType specifies which output format to use: human or JSON.
 	ViewType ViewType
 
+	// JUnitXMLFile is the path of a file to write a JUnit XML report of the
+	// test results to, in addition to the output selected by ViewType. If
+	// empty, no report is written.
+	JUnitXMLFile string
+
+	// Parallelism is the maximum number of test files to execute at the same
+	// time. Defaults to 1, which executes the test files one after another.
+	Parallelism int
+
 	// You can specify common variables for all tests from the command line.
 	Vars *Vars
 
@@ -41,7 +50,9 @@ func ParseTest(args []string) (*Test, tfdiags.Diagnostics) {
 	cmdFlags.Var((*flagStringSlice)(&test.Filter), "filter", "filter")
 	cmdFlags.StringVar(&test.TestDirectory, "test-directory", configs.DefaultTestDirectory, "test-directory")
 	cmdFlags.BoolVar(&jsonOutput, "json", false, "json")
+	cmdFlags.StringVar(&test.JUnitXMLFile, "junit-xml", "", "junit-xml")
 	cmdFlags.BoolVar(&test.Verbose, "verbose", false, "verbose")
+	cmdFlags.IntVar(&test.Parallelism, "parallelism", 1, "parallelism")
 
 	if err := cmdFlags.Parse(args); err != nil {
 		diags = diags.Append(tfdiags.Sourceless(
@@ -50,6 +61,13 @@ func ParseTest(args []string) (*Test, tfdiags.Diagnostics) {
 			err.Error()))
 	}
 
+	if test.Parallelism < 1 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid parallelism",
+			"The -parallelism option must be at least 1."))
+	}
+
 	switch {
 	case jsonOutput:
 		test.ViewType = ViewJSON
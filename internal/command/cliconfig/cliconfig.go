@@ -29,6 +29,7 @@ import (
 
 const pluginCacheDirEnvVar = "TF_PLUGIN_CACHE_DIR"
 const pluginCacheMayBreakLockFileEnvVar = "TF_PLUGIN_CACHE_MAY_BREAK_DEPENDENCY_LOCK_FILE"
+const moduleCacheDirEnvVar = "TF_MODULE_CACHE_DIR"
 
 // Config is the structure of the configuration for the OpenTofu CLI.
 //
@@ -53,6 +54,11 @@ type Config struct {
 	// over the requirements of the dependency lock file.
 	PluginCacheMayBreakDependencyLockFile bool `hcl:"plugin_cache_may_break_dependency_lock_file"`
 
+	// If set, enables caching of remote module packages in this directory,
+	// shared between all working directories, to avoid repeatedly
+	// re-downloading them over the Internet.
+	ModuleCacheDir string `hcl:"module_cache_dir"`
+
 	Hosts map[string]*ConfigHost `hcl:"host"`
 
 	Credentials        map[string]map[string]interface{}   `hcl:"credentials"`
@@ -188,6 +194,9 @@ func loadConfigFile(path string) (*Config, tfdiags.Diagnostics) {
 	if result.PluginCacheDir != "" {
 		result.PluginCacheDir = os.ExpandEnv(result.PluginCacheDir)
 	}
+	if result.ModuleCacheDir != "" {
+		result.ModuleCacheDir = os.ExpandEnv(result.ModuleCacheDir)
+	}
 
 	return result, diags
 }
@@ -241,6 +250,10 @@ func envConfig(env map[string]string) *Config {
 		config.PluginCacheDir = envPluginCacheDir
 	}
 
+	if envModuleCacheDir := env[moduleCacheDirEnvVar]; envModuleCacheDir != "" {
+		config.ModuleCacheDir = envModuleCacheDir
+	}
+
 	if envMayBreak := env[pluginCacheMayBreakLockFileEnvVar]; envMayBreak != "" && envMayBreak != "0" {
 		// This is an environment variable analog to the
 		// plugin_cache_may_break_dependency_lock_file setting. If either this
@@ -329,6 +342,15 @@ func (c *Config) Validate() tfdiags.Diagnostics {
 		}
 	}
 
+	if c.ModuleCacheDir != "" {
+		_, err := os.Stat(c.ModuleCacheDir)
+		if err != nil {
+			diags = diags.Append(
+				fmt.Errorf("The specified module cache dir %s cannot be opened: %w", c.ModuleCacheDir, err),
+			)
+		}
+	}
+
 	return diags
 }
 
@@ -362,6 +384,11 @@ func (c *Config) Merge(c2 *Config) *Config {
 		result.PluginCacheDir = c2.PluginCacheDir
 	}
 
+	result.ModuleCacheDir = c.ModuleCacheDir
+	if result.ModuleCacheDir == "" {
+		result.ModuleCacheDir = c2.ModuleCacheDir
+	}
+
 	if c.PluginCacheMayBreakDependencyLockFile || c2.PluginCacheMayBreakDependencyLockFile {
 		// This setting saturates to "on"; once either configuration sets it,
 		// there is no way to override it back to off again.
@@ -101,6 +101,14 @@ func TestEnvConfig(t *testing.T) {
 				PluginCacheDir: "boop",
 			},
 		},
+		"TF_MODULE_CACHE_DIR=boop": {
+			map[string]string{
+				"TF_MODULE_CACHE_DIR": "boop",
+			},
+			&Config{
+				ModuleCacheDir: "boop",
+			},
+		},
 		"TF_PLUGIN_CACHE_MAY_BREAK_DEPENDENCY_LOCK_FILE=anything_except_zero": {
 			map[string]string{
 				"TF_PLUGIN_CACHE_MAY_BREAK_DEPENDENCY_LOCK_FILE": "anything_except_zero",
@@ -363,6 +371,12 @@ func TestConfigValidate(t *testing.T) {
 			},
 			1, // The specified plugin cache dir %s cannot be opened
 		},
+		"module_cache_dir does not exist": {
+			&Config{
+				ModuleCacheDir: "fake",
+			},
+			1, // The specified module cache dir %s cannot be opened
+		},
 	}
 
 	for name, test := range tests {
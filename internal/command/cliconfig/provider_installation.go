@@ -36,6 +36,11 @@ type ProviderInstallation struct {
 	// providers, because they are still subject to version constraints and
 	// checksum verification.
 	DevOverrides map[addrs.Provider]getproviders.PackageLocalDir
+
+	// SigstoreVerifications require the packages for particular providers
+	// to be signed by a particular publisher using sigstore, in addition to
+	// the usual checks, when installing them from their origin registries.
+	SigstoreVerifications []*ProviderInstallationSigstoreVerification
 }
 
 // decodeProviderInstallationFromConfig uses the HCL AST API directly to
@@ -213,6 +218,33 @@ func decodeProviderInstallationFromConfig(hclFile *hclast.File) ([]*ProviderInst
 				location = ProviderInstallationNetworkMirror(bodyContent.URL)
 				include = bodyContent.Include
 				exclude = bodyContent.Exclude
+			case "oci_mirror":
+				type BodyContent struct {
+					RepositoryTemplate string   `hcl:"repository_template"`
+					Include            []string `hcl:"include"`
+					Exclude            []string `hcl:"exclude"`
+				}
+				var bodyContent BodyContent
+				err := hcl.DecodeObject(&bodyContent, methodBody)
+				if err != nil {
+					diags = diags.Append(tfdiags.Sourceless(
+						tfdiags.Error,
+						"Invalid provider_installation method block",
+						fmt.Sprintf("Invalid %s block at %s: %s.", methodTypeStr, block.Pos(), err),
+					))
+					continue
+				}
+				if bodyContent.RepositoryTemplate == "" {
+					diags = diags.Append(tfdiags.Sourceless(
+						tfdiags.Error,
+						"Invalid provider_installation method block",
+						fmt.Sprintf("Invalid %s block at %s: \"repository_template\" argument is required.", methodTypeStr, block.Pos()),
+					))
+					continue
+				}
+				location = ProviderInstallationOCIMirror(bodyContent.RepositoryTemplate)
+				include = bodyContent.Include
+				exclude = bodyContent.Exclude
 			case "dev_overrides":
 				if len(pi.Methods) > 0 {
 					// We require dev_overrides to appear first if it's present,
@@ -260,6 +292,59 @@ func decodeProviderInstallationFromConfig(hclFile *hclast.File) ([]*ProviderInst
 
 				continue // We won't add anything to pi.Methods for this one
 
+			case "sigstore_verification":
+				type BodyContent struct {
+					Include                      []string `hcl:"include"`
+					Exclude                      []string `hcl:"exclude"`
+					PublicKeyFile                string   `hcl:"public_key_file"`
+					CertificateRootsFile         string   `hcl:"certificate_roots_file"`
+					CertificateIdentity          string   `hcl:"certificate_identity"`
+					CertificateIdentityRegexp    string   `hcl:"certificate_identity_regexp"`
+					CertificateOIDCIssuer        string   `hcl:"certificate_oidc_issuer"`
+					TransparencyLogPublicKeyFile string   `hcl:"transparency_log_public_key_file"`
+				}
+				var bodyContent BodyContent
+				err := hcl.DecodeObject(&bodyContent, methodBody)
+				if err != nil {
+					diags = diags.Append(tfdiags.Sourceless(
+						tfdiags.Error,
+						"Invalid provider_installation method block",
+						fmt.Sprintf("Invalid %s block at %s: %s.", methodTypeStr, block.Pos(), err),
+					))
+					continue
+				}
+				var problem string
+				switch {
+				case len(bodyContent.Include) == 0:
+					problem = `"include" argument is required`
+				case (bodyContent.PublicKeyFile == "") == (bodyContent.CertificateRootsFile == ""):
+					problem = `exactly one of the "public_key_file" and "certificate_roots_file" arguments is required`
+				case bodyContent.PublicKeyFile != "" && (bodyContent.CertificateIdentity != "" || bodyContent.CertificateIdentityRegexp != "" || bodyContent.CertificateOIDCIssuer != "" || bodyContent.TransparencyLogPublicKeyFile != ""):
+					problem = `the certificate verification arguments can only be used with "certificate_roots_file"`
+				case bodyContent.CertificateIdentity != "" && bodyContent.CertificateIdentityRegexp != "":
+					problem = `only one of the "certificate_identity" and "certificate_identity_regexp" arguments may be set`
+				}
+				if problem != "" {
+					diags = diags.Append(tfdiags.Sourceless(
+						tfdiags.Error,
+						"Invalid provider_installation method block",
+						fmt.Sprintf("Invalid %s block at %s: %s.", methodTypeStr, methodBlock.Pos(), problem),
+					))
+					continue
+				}
+
+				pi.SigstoreVerifications = append(pi.SigstoreVerifications, &ProviderInstallationSigstoreVerification{
+					Include:                      bodyContent.Include,
+					Exclude:                      bodyContent.Exclude,
+					PublicKeyFile:                bodyContent.PublicKeyFile,
+					CertificateRootsFile:         bodyContent.CertificateRootsFile,
+					CertificateIdentity:          bodyContent.CertificateIdentity,
+					CertificateIdentityRegexp:    bodyContent.CertificateIdentityRegexp,
+					CertificateOIDCIssuer:        bodyContent.CertificateOIDCIssuer,
+					TransparencyLogPublicKeyFile: bodyContent.TransparencyLogPublicKeyFile,
+				})
+				continue // This isn't an installation method either
+
 			default:
 				diags = diags.Append(tfdiags.Sourceless(
 					tfdiags.Error,
@@ -294,6 +379,36 @@ type ProviderInstallationMethod struct {
 	Exclude  []string `hcl:"exclude"`
 }
 
+// ProviderInstallationSigstoreVerification represents a sigstore_verification
+// block inside a provider_installation block, which requires the packages for
+// the selected providers to be authenticated by a sigstore bundle.
+//
+// Exactly one of PublicKeyFile and CertificateRootsFile is set, and the other
+// certificate-related fields can be set only with CertificateRootsFile.
+type ProviderInstallationSigstoreVerification struct {
+	Include []string
+	Exclude []string
+
+	// PublicKeyFile is the path of a PEM-encoded public key whose signatures
+	// are trusted.
+	PublicKeyFile string
+
+	// CertificateRootsFile is the path of one or more PEM-encoded
+	// certificates of authorities whose code signing certificates are
+	// trusted, optionally restricted to a particular identity from a
+	// particular OpenID Connect issuer using CertificateIdentity or
+	// CertificateIdentityRegexp, and CertificateOIDCIssuer.
+	CertificateRootsFile      string
+	CertificateIdentity       string
+	CertificateIdentityRegexp string
+	CertificateOIDCIssuer     string
+
+	// TransparencyLogPublicKeyFile is the path of the PEM-encoded public key
+	// of a transparency log that must have recorded each signature, for use
+	// with short-lived certificates.
+	TransparencyLogPublicKeyFile string
+}
+
 // ProviderInstallationLocation is an interface type representing the
 // different installation location types. The concrete implementations of
 // this interface are:
@@ -301,6 +416,7 @@ type ProviderInstallationMethod struct {
 //   - [ProviderInstallationDirect]:                 install from the provider's origin registry
 //   - [ProviderInstallationFilesystemMirror] (dir): install from a local filesystem mirror
 //   - [ProviderInstallationNetworkMirror] (host):   install from a network mirror
+//   - [ProviderInstallationOCIMirror] (template):   install from repositories in an OCI registry
 type ProviderInstallationLocation interface {
 	providerInstallationLocation()
 }
@@ -339,3 +455,15 @@ func (i ProviderInstallationNetworkMirror) providerInstallationLocation() {}
 func (i ProviderInstallationNetworkMirror) GoString() string {
 	return fmt.Sprintf("cliconfig.ProviderInstallationNetworkMirror(%q)", i)
 }
+
+// ProviderInstallationOCIMirror is a ProviderInstallationSourceLocation
+// representing installation from repositories in an OCI registry. The string
+// value is the template for the repository address of each provider, exactly
+// as written in the configuration.
+type ProviderInstallationOCIMirror string
+
+func (i ProviderInstallationOCIMirror) providerInstallationLocation() {}
+
+func (i ProviderInstallationOCIMirror) GoString() string {
+	return fmt.Sprintf("cliconfig.ProviderInstallationOCIMirror(%q)", i)
+}
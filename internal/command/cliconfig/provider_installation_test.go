@@ -36,6 +36,10 @@ func TestLoadConfig_providerInstallation(t *testing.T) {
 							{
 								Location: ProviderInstallationFilesystemMirror("/tmp/example2"),
 							},
+							{
+								Location: ProviderInstallationOCIMirror("example.net/opentofu-providers/${namespace}/${type}"),
+								Include:  []string{"registry.opentofu.org/hashicorp/*"},
+							},
 							{
 								Location: ProviderInstallationDirect,
 								Exclude:  []string{"example.com/*/*"},
@@ -46,6 +50,21 @@ func TestLoadConfig_providerInstallation(t *testing.T) {
 							addrs.MustParseProviderSourceString("hashicorp/boop"):  getproviders.PackageLocalDir(filepath.FromSlash("/tmp/boop")),
 							addrs.MustParseProviderSourceString("hashicorp/blorp"): getproviders.PackageLocalDir(filepath.FromSlash("/tmp/blorp")),
 						},
+
+						SigstoreVerifications: []*ProviderInstallationSigstoreVerification{
+							{
+								Include:       []string{"registry.opentofu.org/awesomesauce/*"},
+								PublicKeyFile: "/tmp/awesomesauce.pub",
+							},
+							{
+								Include:                      []string{"registry.opentofu.org/happycorp/*"},
+								Exclude:                      []string{"registry.opentofu.org/happycorp/legacy"},
+								CertificateRootsFile:         "/tmp/fulcio.pem",
+								CertificateIdentityRegexp:    "^https://github.com/happycorp/",
+								CertificateOIDCIssuer:        "https://token.actions.githubusercontent.com",
+								TransparencyLogPublicKeyFile: "/tmp/rekor.pub",
+							},
+						},
 					},
 				},
 			}
@@ -59,15 +78,19 @@ func TestLoadConfig_providerInstallation(t *testing.T) {
 
 func TestLoadConfig_providerInstallationErrors(t *testing.T) {
 	_, diags := loadConfigFile(filepath.Join(fixtureDir, "provider-installation-errors"))
-	want := `7 problems:
+	want := `11 problems:
 
 - Invalid provider_installation method block: Unknown provider installation method "not_a_thing" at 2:3.
 - Invalid provider_installation method block: Invalid filesystem_mirror block at 1:1: "path" argument is required.
 - Invalid provider_installation method block: Invalid network_mirror block at 1:1: "url" argument is required.
+- Invalid provider_installation method block: Invalid oci_mirror block at 1:1: "repository_template" argument is required.
 - Invalid provider_installation method block: The items inside the provider_installation block at 1:1 must all be blocks.
 - Invalid provider_installation method block: The blocks inside the provider_installation block at 1:1 may not have any labels.
-- Invalid provider_installation block: The provider_installation block at 9:1 must not have any labels.
-- Invalid provider_installation block: The provider_installation block at 11:1 must not be introduced with an equals sign.`
+- Invalid provider_installation method block: Invalid sigstore_verification block at 8:3: "include" argument is required.
+- Invalid provider_installation method block: Invalid sigstore_verification block at 11:3: exactly one of the "public_key_file" and "certificate_roots_file" arguments is required.
+- Invalid provider_installation method block: Invalid sigstore_verification block at 16:3: the certificate verification arguments can only be used with "certificate_roots_file".
+- Invalid provider_installation block: The provider_installation block at 23:1 must not have any labels.
+- Invalid provider_installation block: The provider_installation block at 25:1 must not be introduced with an equals sign.`
 
 	// The above error messages include only line/column location information
 	// and not file location information because HCL 1 does not store
@@ -157,6 +157,9 @@ func (c *ConsoleCommand) Run(args []string) int {
 	session := &repl.Session{
 		Scope: scope,
 	}
+	if lr.Config != nil {
+		session.Config = lr.Config.Module
+	}
 
 	// Determine if stdin is a pipe. If so, we evaluate directly.
 	if c.StdinPiped() {
@@ -168,9 +171,19 @@ func (c *ConsoleCommand) Run(args []string) int {
 
 func (c *ConsoleCommand) modePiped(session *repl.Session, ui cli.Ui) int {
 	var lastResult string
+	var input []string
 	scanner := bufio.NewScanner(os.Stdin)
 	for scanner.Scan() {
-		result, exit, diags := session.Handle(strings.TrimSpace(scanner.Text()))
+		// As in interactive mode, an expression with unclosed brackets
+		// continues on the following lines.
+		input = append(input, strings.TrimSpace(scanner.Text()))
+		expr := strings.Join(input, "\n")
+		if repl.ExpressionIncomplete(expr) {
+			continue
+		}
+		input = nil
+
+		result, exit, diags := session.Handle(expr)
 		if diags.HasErrors() {
 			// In piped mode we'll exit immediately on error.
 			c.showDiagnostics(diags)
@@ -184,6 +197,17 @@ func (c *ConsoleCommand) modePiped(session *repl.Session, ui cli.Ui) int {
 		lastResult = result
 	}
 
+	// If the input ended in the middle of an expression, we evaluate what
+	// we have so that the error is reported.
+	if len(input) != 0 {
+		result, _, diags := session.Handle(strings.Join(input, "\n"))
+		if diags.HasErrors() {
+			c.showDiagnostics(diags)
+			return 1
+		}
+		lastResult = result
+	}
+
 	// Output the final result
 	ui.Output(lastResult)
 
@@ -12,24 +12,35 @@ package command
 import (
 	"fmt"
 	"io"
+	"log"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/opentofu/opentofu/internal/command/cliconfig"
 	"github.com/opentofu/opentofu/internal/repl"
 
 	"github.com/chzyer/readline"
 	"github.com/mitchellh/cli"
 )
 
+// consoleHistoryFilename is the name of the file in the CLI configuration
+// directory where the interactive console keeps its input history.
+const consoleHistoryFilename = "console_history"
+
 func (c *ConsoleCommand) modeInteractive(session *repl.Session, ui cli.Ui) int {
 	// Configure input
 	l, err := readline.NewEx(&readline.Config{
-		Prompt:            "> ",
-		InterruptPrompt:   "^C",
-		EOFPrompt:         "exit",
-		HistorySearchFold: true,
-		Stdin:             os.Stdin,
-		Stdout:            os.Stdout,
-		Stderr:            os.Stderr,
+		Prompt:                 "> ",
+		InterruptPrompt:        "^C",
+		EOFPrompt:              "exit",
+		HistoryFile:            consoleHistoryPath(),
+		HistorySearchFold:      true,
+		DisableAutoSaveHistory: true,
+		AutoComplete:           consoleCompleter{session: session},
+		Stdin:                  os.Stdin,
+		Stdout:                 os.Stdout,
+		Stderr:                 os.Stderr,
 	})
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf(
@@ -39,20 +50,42 @@ func (c *ConsoleCommand) modeInteractive(session *repl.Session, ui cli.Ui) int {
 	}
 	defer l.Close()
 
+	var input []string
 	for {
 		// Read a line
 		line, err := l.Readline()
 		if err == readline.ErrInterrupt {
-			if len(line) == 0 {
+			if len(line) == 0 && len(input) == 0 {
 				break
-			} else {
-				continue
 			}
+			// Interrupting a multi-line expression discards it.
+			input = nil
+			l.SetPrompt("> ")
+			continue
 		} else if err == io.EOF {
 			break
 		}
 
-		out, exit, diags := session.Handle(line)
+		// Keep reading lines until the expression is complete.
+		input = append(input, line)
+		expr := strings.Join(input, "\n")
+		if repl.ExpressionIncomplete(expr) {
+			l.SetPrompt(". ")
+			continue
+		}
+		input = nil
+		l.SetPrompt("> ")
+
+		// The history file has one entry per line, and a multi-line
+		// expression can't be joined into a single line without changing
+		// its meaning, such as in heredocs, so we only save single lines.
+		if strings.TrimSpace(expr) != "" && !strings.Contains(expr, "\n") {
+			if err := l.SaveHistory(expr); err != nil {
+				log.Printf("[WARN] Failed to save console history: %s", err)
+			}
+		}
+
+		out, exit, diags := session.Handle(expr)
 		if diags.HasErrors() {
 			c.showDiagnostics(diags)
 		}
@@ -65,3 +98,34 @@ func (c *ConsoleCommand) modeInteractive(session *repl.Session, ui cli.Ui) int {
 
 	return 0
 }
+
+// consoleHistoryPath returns the path of the file where the interactive
+// console keeps its input history, or an empty string to disable history
+// if the CLI configuration directory can't be determined.
+func consoleHistoryPath() string {
+	dir, err := cliconfig.ConfigDir()
+	if err != nil {
+		log.Printf("[WARN] Console history is disabled: %s", err)
+		return ""
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		log.Printf("[WARN] Console history is disabled: %s", err)
+		return ""
+	}
+	return filepath.Join(dir, consoleHistoryFilename)
+}
+
+// consoleCompleter adapts the completions of a REPL session to the
+// readline.AutoCompleter interface.
+type consoleCompleter struct {
+	session *repl.Session
+}
+
+func (cc consoleCompleter) Do(line []rune, pos int) ([][]rune, int) {
+	partial, candidates := cc.session.Completions(string(line[:pos]))
+	ret := make([][]rune, 0, len(candidates))
+	for _, candidate := range candidates {
+		ret = append(ret, []rune(candidate[len(partial):]))
+	}
+	return ret, len([]rune(partial))
+}
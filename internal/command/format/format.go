@@ -28,6 +28,8 @@ func DiffActionSymbol(action plans.Action) string {
 		return "  [red]-[reset]"
 	case plans.Read:
 		return " [cyan]<=[reset]"
+	case plans.Forget:
+		return "  [light_gray].[reset]"
 	case plans.Update:
 		return "  [yellow]~[reset]"
 	case plans.NoOp:
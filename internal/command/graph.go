@@ -5,8 +5,10 @@ package command
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/command/arguments"
 	"github.com/opentofu/opentofu/internal/dag"
@@ -28,6 +30,10 @@ func (c *GraphCommand) Run(args []string) int {
 	var moduleDepth int
 	var verbose bool
 	var planPath string
+	var format string
+	var addrStr string
+	var depth int
+	var excludeKinds FlagStringSlice
 
 	args = c.Meta.process(args)
 	cmdFlags := c.Meta.defaultFlagSet("graph")
@@ -36,6 +42,10 @@ func (c *GraphCommand) Run(args []string) int {
 	cmdFlags.IntVar(&moduleDepth, "module-depth", -1, "module-depth")
 	cmdFlags.BoolVar(&verbose, "verbose", false, "verbose")
 	cmdFlags.StringVar(&planPath, "plan", "", "plan")
+	cmdFlags.StringVar(&format, "format", "dot", "format")
+	cmdFlags.StringVar(&addrStr, "address", "", "address")
+	cmdFlags.IntVar(&depth, "depth", -1, "depth")
+	cmdFlags.Var(&excludeKinds, "exclude-type", "exclude-type")
 	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
 	if err := cmdFlags.Parse(args); err != nil {
 		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
@@ -48,6 +58,44 @@ func (c *GraphCommand) Run(args []string) int {
 		return 1
 	}
 
+	var diags tfdiags.Diagnostics
+
+	switch format {
+	case "dot", "json", "mermaid":
+	default:
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Unsupported graph format",
+			`The -format=... argument must be either "dot", "json", or "mermaid".`,
+		))
+	}
+
+	filter := &tofu.GraphFilter{
+		Depth:        depth,
+		ExcludeKinds: excludeKinds,
+	}
+	if addrStr != "" {
+		target, targetDiags := addrs.ParseTargetStr(addrStr)
+		diags = diags.Append(targetDiags)
+		if target != nil {
+			filter.Address = target.Subject
+		}
+	}
+	for _, kind := range excludeKinds {
+		if !slices.Contains(tofu.GraphNodeKinds, kind) {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Unsupported node type",
+				fmt.Sprintf("The -exclude-type=... argument must be one of: %s.", strings.Join(tofu.GraphNodeKinds, ", ")),
+			))
+			break
+		}
+	}
+	if diags.HasErrors() {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
 	// Check for user-supplied plugin path
 	if c.pluginPath, err = c.loadPluginPath(); err != nil {
 		c.Ui.Error(fmt.Sprintf("Error loading plugin path: %s", err))
@@ -64,8 +112,6 @@ func (c *GraphCommand) Run(args []string) int {
 		}
 	}
 
-	var diags tfdiags.Diagnostics
-
 	backendConfig, backendDiags := c.loadBackendConfig(configPath)
 	diags = diags.Append(backendDiags)
 	if diags.HasErrors() {
@@ -171,11 +217,23 @@ func (c *GraphCommand) Run(args []string) int {
 		return 1
 	}
 
-	graphStr, err := tofu.GraphDot(g, &dag.DotOpts{
-		DrawCycles: drawCycles,
-		MaxDepth:   moduleDepth,
-		Verbose:    verbose,
-	})
+	if filter.Address != nil || len(filter.ExcludeKinds) != 0 {
+		g = tofu.FilterGraph(g, filter)
+	}
+
+	var graphStr string
+	switch format {
+	case "json":
+		graphStr, err = tofu.GraphJSON(g)
+	case "mermaid":
+		graphStr, err = tofu.GraphMermaid(g)
+	default:
+		graphStr, err = tofu.GraphDot(g, &dag.DotOpts{
+			DrawCycles: drawCycles,
+			MaxDepth:   moduleDepth,
+			Verbose:    verbose,
+		})
+	}
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error converting graph: %s", err))
 		return 1
@@ -184,7 +242,7 @@ func (c *GraphCommand) Run(args []string) int {
 	if diags.HasErrors() {
 		// For this command we only show diagnostics if there are errors,
 		// because printing out naked warnings could upset a naive program
-		// consuming our dot output.
+		// consuming our graph output.
 		c.showDiagnostics(diags)
 		return 1
 	}
@@ -201,9 +259,10 @@ Usage: tofu [global options] graph [options]
   Produces a representation of the dependency graph between different
   objects in the current configuration and state.
 
-  The graph is presented in the DOT language. The typical program that can
-  read this format is GraphViz, but many web services are also available
-  to read this format.
+  By default the graph is presented in the DOT language. The typical program
+  that can read this format is GraphViz, but many web services are also
+  available to read this format. The graph can also be presented as JSON, or
+  as a Mermaid flowchart.
 
 Options:
 
@@ -217,6 +276,22 @@ Options:
                    plan-destroy, or apply. By default OpenTofu chooses
 				   "plan", or "apply" if you also set the -plan=... option.
 
+  -format=dot      Format of the graph to output. Can be: dot, json, or
+                   mermaid. Defaults to dot.
+
+  -address=ADDR    Only include the objects within the given module or
+                   resource address, along with their dependencies and
+                   dependents.
+
+  -depth=n         Used with -address, the maximum number of steps to follow
+                   from the selected objects to their dependencies and
+                   dependents. By default there is no limit.
+
+  -exclude-type=T  Leave the objects of the given type out of the graph,
+                   keeping the dependencies through them. Can be: resource,
+                   data, provider, output, variable, local, module, or
+                   check. Can be set multiple times.
+
   -module-depth=n  (deprecated) In prior versions of OpenTofu, specified the
 				   depth of modules to show in the output.
 `
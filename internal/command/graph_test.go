@@ -4,6 +4,7 @@
 package command
 
 import (
+	"encoding/json"
 	"os"
 	"strings"
 	"testing"
@@ -160,3 +161,150 @@ func TestGraph_plan(t *testing.T) {
 		t.Fatalf("doesn't look like digraph: %s", output)
 	}
 }
+
+func TestGraph_formatJSON(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("graph"), td)
+	defer testChdir(t, td)()
+
+	ui := new(cli.MockUi)
+	c := &GraphCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(applyFixtureProvider()),
+			Ui:               ui,
+		},
+	}
+
+	args := []string{"-format=json"}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	var got struct {
+		Nodes []struct {
+			ID   string `json:"id"`
+			Kind string `json:"kind"`
+		} `json:"nodes"`
+		Edges []struct {
+			Source string `json:"source"`
+			Target string `json:"target"`
+		} `json:"edges"`
+	}
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &got); err != nil {
+		t.Fatalf("invalid JSON output: %s", err)
+	}
+
+	kinds := make(map[string]string)
+	for _, node := range got.Nodes {
+		kinds[node.ID] = node.Kind
+	}
+	if got, want := kinds["test_instance.foo (expand)"], "resource"; got != want {
+		t.Errorf("wrong kind for test_instance.foo %q; want %q", got, want)
+	}
+	if got, want := kinds[`provider["registry.opentofu.org/hashicorp/test"]`], "provider"; got != want {
+		t.Errorf("wrong kind for provider %q; want %q", got, want)
+	}
+	if len(got.Edges) == 0 {
+		t.Errorf("no edges in output")
+	}
+}
+
+func TestGraph_formatMermaid(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("graph"), td)
+	defer testChdir(t, td)()
+
+	ui := new(cli.MockUi)
+	c := &GraphCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(applyFixtureProvider()),
+			Ui:               ui,
+		},
+	}
+
+	args := []string{"-format=mermaid", "-exclude-type=provider"}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	output := ui.OutputWriter.String()
+	if !strings.HasPrefix(output, "flowchart LR\n") {
+		t.Fatalf("doesn't look like a mermaid flowchart: %s", output)
+	}
+	if !strings.Contains(output, `["test_instance.foo"]:::resource`) {
+		t.Errorf("missing resource node: %s", output)
+	}
+	if strings.Contains(output, "provider") {
+		t.Errorf("output includes excluded provider nodes: %s", output)
+	}
+}
+
+func TestGraph_address(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("graph"), td)
+	defer testChdir(t, td)()
+
+	ui := new(cli.MockUi)
+	c := &GraphCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(applyFixtureProvider()),
+			Ui:               ui,
+		},
+	}
+
+	args := []string{"-address=test_instance.foo", "-depth=0"}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+	}
+
+	output := ui.OutputWriter.String()
+	if !strings.Contains(output, `"[root] test_instance.foo (expand)"`) {
+		t.Errorf("missing resource node: %s", output)
+	}
+	if strings.Contains(output, "provider") {
+		t.Errorf("output includes provider nodes beyond the depth: %s", output)
+	}
+}
+
+func TestGraph_invalidOptions(t *testing.T) {
+	tests := map[string]struct {
+		args    []string
+		wantErr string
+	}{
+		"format": {
+			[]string{"-format=png"},
+			"Unsupported graph format",
+		},
+		"address": {
+			[]string{"-address=foo"},
+			"Invalid address",
+		},
+		"exclude-type": {
+			[]string{"-exclude-type=foo"},
+			"Unsupported node type",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			td := t.TempDir()
+			testCopyDir(t, testFixturePath("graph"), td)
+			defer testChdir(t, td)()
+
+			ui := new(cli.MockUi)
+			c := &GraphCommand{
+				Meta: Meta{
+					testingOverrides: metaOverridesForProvider(applyFixtureProvider()),
+					Ui:               ui,
+				},
+			}
+
+			if code := c.Run(test.args); code != 1 {
+				t.Fatalf("wrong exit code %d; want 1\n%s", code, ui.OutputWriter.String())
+			}
+			if got := ui.ErrorWriter.String(); !strings.Contains(got, test.wantErr) {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", got, test.wantErr)
+			}
+		})
+	}
+}
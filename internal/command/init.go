@@ -26,6 +26,7 @@ import (
 	"github.com/opentofu/opentofu/internal/command/arguments"
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/getproviders"
 	"github.com/opentofu/opentofu/internal/providercache"
 	"github.com/opentofu/opentofu/internal/states"
@@ -235,7 +236,7 @@ func (c *InitCommand) Run(args []string) int {
 	}
 
 	if flagGet {
-		modsOutput, modsAbort, modsDiags := c.getModules(ctx, path, testsDirectory, rootModEarly, flagUpgrade)
+		modsOutput, modsAbort, modsDiags := c.getModules(ctx, path, testsDirectory, rootModEarly, flagUpgrade, flagLockfile)
 		diags = diags.Append(modsDiags)
 		if modsAbort || modsDiags.HasErrors() {
 			c.showDiagnostics(diags)
@@ -357,7 +358,7 @@ func (c *InitCommand) Run(args []string) int {
 	return 0
 }
 
-func (c *InitCommand) getModules(ctx context.Context, path, testsDir string, earlyRoot *configs.Module, upgrade bool) (output bool, abort bool, diags tfdiags.Diagnostics) {
+func (c *InitCommand) getModules(ctx context.Context, path, testsDir string, earlyRoot *configs.Module, upgrade bool, flagLockfile string) (output bool, abort bool, diags tfdiags.Diagnostics) {
 	testModules := false // We can also have modules buried in test files.
 	for _, file := range earlyRoot.Tests {
 		for _, run := range file.Runs {
@@ -388,7 +389,7 @@ func (c *InitCommand) getModules(ctx context.Context, path, testsDir string, ear
 		ShowLocalPaths: true,
 	}
 
-	installAbort, installDiags := c.installModules(ctx, path, testsDir, upgrade, false, hooks)
+	installAbort, installDiags := c.installModules(ctx, path, testsDir, upgrade, false, flagLockfile, hooks)
 	diags = diags.Append(installDiags)
 
 	// At this point, installModules may have generated error diags or been
@@ -476,7 +477,8 @@ func (c *InitCommand) initBackend(ctx context.Context, root *configs.Module, ext
 			return nil, true, diags
 		}
 
-		b := bf()
+		// Only the schema is needed here, so encryption is irrelevant.
+		b := bf(encryption.StateEncryptionDisabled())
 		backendSchema := b.ConfigSchema()
 		backendConfig = root.Backend
 
@@ -822,6 +824,16 @@ func (c *InitCommand) getProviders(ctx context.Context, config *configs.Config,
 					))
 				}
 
+			case getproviders.ErrSigstoreUnsupported:
+				diags = diags.Append(tfdiags.Sourceless(
+					tfdiags.Error,
+					"Provider requires sigstore verification",
+					fmt.Sprintf(
+						"The CLI configuration requires %s v%s to be verified using a sigstore bundle, but the package is only available from %s, which cannot provide sigstore bundles.\n\nTo install this provider, use the \"direct\" installation method for it in the provider_installation block.",
+						err.Provider.ForDisplay(), err.Version, err.Source,
+					),
+				))
+
 			case getproviders.ErrRequestCanceled:
 				// We don't attribute cancellation to any particular operation,
 				// but rather just emit a single general message about it at
@@ -852,11 +864,15 @@ func (c *InitCommand) getProviders(ctx context.Context, config *configs.Config,
 			if keyID != "" {
 				keyID = c.Colorize().Color(fmt.Sprintf(", key ID [reset][bold]%s[reset]", keyID))
 			}
+			var identity string
+			if authResult != nil && authResult.Identity != "" {
+				identity = c.Colorize().Color(fmt.Sprintf(", identity [reset][bold]%s[reset]", authResult.Identity))
+			}
 
 			if authResult != nil && authResult.SigningSkipped() {
 				c.Ui.Warn(fmt.Sprintf("- Installed %s v%s. Signature validation was skipped due to the registry not containing GPG keys for this provider", provider.ForDisplay(), version))
 			} else {
-				c.Ui.Info(fmt.Sprintf("- Installed %s v%s (%s%s)", provider.ForDisplay(), version, authResult, keyID))
+				c.Ui.Info(fmt.Sprintf("- Installed %s v%s (%s%s%s)", provider.ForDisplay(), version, authResult, keyID, identity))
 			}
 		},
 		ProvidersLockUpdated: func(provider addrs.Provider, version getproviders.Version, localHashes []getproviders.Hash, signedHashes []getproviders.Hash, priorHashes []getproviders.Hash) {
@@ -939,11 +955,8 @@ func (c *InitCommand) getProviders(ctx context.Context, config *configs.Config,
 
 	// If the provider dependencies have changed since the last run then we'll
 	// say a little about that in case the reader wasn't expecting a change.
-	// (When we later integrate module dependencies into the lock file we'll
-	// probably want to refactor this so that we produce one lock-file related
-	// message for all changes together, but this is here for now just because
-	// it's the smallest change relative to what came before it, which was
-	// a hidden JSON file specifically for tracking providers.)
+	// (Module locks are saved separately by installModules, before we get
+	// here, so this message is only about the provider selections.)
 	if !newLocks.Equal(previousLocks) {
 		// if readonly mode
 		if flagLockfile == "readonly" {
@@ -983,8 +996,8 @@ func (c *InitCommand) getProviders(ctx context.Context, config *configs.Config,
 					getproviders.CurrentPlatform.String())))
 		}
 
-		if previousLocks.Empty() {
-			// A change from empty to non-empty is special because it suggests
+		if len(previousLocks.AllProviders()) == 0 {
+			// A change from no provider locks to some is special because it suggests
 			// we're running "tofu init" for the first time against a
 			// new configuration. In that case we'll take the opportunity to
 			// say a little about what the dependency lock file is, for new
@@ -1,11 +1,14 @@
 package command
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -22,6 +25,7 @@ import (
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
 	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/getproviders"
 	"github.com/opentofu/opentofu/internal/providercache"
 	"github.com/opentofu/opentofu/internal/states"
@@ -329,7 +333,7 @@ func TestInit_backendConfigFile(t *testing.T) {
 
 		// Read our saved backend config and verify we have our settings
 		state := testDataStateRead(t, filepath.Join(DefaultDataDir, DefaultStateFilename))
-		if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"path":"hello","workspace_dir":null}`; got != want {
+		if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"history_limit":null,"path":"hello","workspace_dir":null}`; got != want {
 			t.Errorf("wrong config\ngot:  %s\nwant: %s", got, want)
 		}
 	})
@@ -412,7 +416,7 @@ func TestInit_backendConfigFile(t *testing.T) {
 
 		// Read our saved backend config and verify the backend config is empty
 		state := testDataStateRead(t, filepath.Join(DefaultDataDir, DefaultStateFilename))
-		if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"path":null,"workspace_dir":null}`; got != want {
+		if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"history_limit":null,"path":null,"workspace_dir":null}`; got != want {
 			t.Errorf("wrong config\ngot:  %s\nwant: %s", got, want)
 		}
 	})
@@ -547,7 +551,7 @@ func TestInit_backendConfigFileChange(t *testing.T) {
 
 	// Read our saved backend config and verify we have our settings
 	state := testDataStateRead(t, filepath.Join(DefaultDataDir, DefaultStateFilename))
-	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"path":"hello","workspace_dir":null}`; got != want {
+	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"history_limit":null,"path":"hello","workspace_dir":null}`; got != want {
 		t.Errorf("wrong config\ngot:  %s\nwant: %s", got, want)
 	}
 }
@@ -662,7 +666,7 @@ func TestInit_backendConfigKV(t *testing.T) {
 
 	// Read our saved backend config and verify we have our settings
 	state := testDataStateRead(t, filepath.Join(DefaultDataDir, DefaultStateFilename))
-	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"path":"hello","workspace_dir":null}`; got != want {
+	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"history_limit":null,"path":"hello","workspace_dir":null}`; got != want {
 		t.Errorf("wrong config\ngot:  %s\nwant: %s", got, want)
 	}
 }
@@ -840,7 +844,7 @@ func TestInit_backendReinitWithExtra(t *testing.T) {
 
 	// Read our saved backend config and verify we have our settings
 	state := testDataStateRead(t, filepath.Join(DefaultDataDir, DefaultStateFilename))
-	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"path":"hello","workspace_dir":null}`; got != want {
+	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"history_limit":null,"path":"hello","workspace_dir":null}`; got != want {
 		t.Errorf("wrong config\ngot:  %s\nwant: %s", got, want)
 	}
 
@@ -853,7 +857,7 @@ func TestInit_backendReinitWithExtra(t *testing.T) {
 		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
 	}
 	state = testDataStateRead(t, filepath.Join(DefaultDataDir, DefaultStateFilename))
-	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"path":"hello","workspace_dir":null}`; got != want {
+	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"history_limit":null,"path":"hello","workspace_dir":null}`; got != want {
 		t.Errorf("wrong config\ngot:  %s\nwant: %s", got, want)
 	}
 	if state.Backend.Hash != uint64(cHash) {
@@ -883,7 +887,7 @@ func TestInit_backendReinitConfigToExtra(t *testing.T) {
 
 	// Read our saved backend config and verify we have our settings
 	state := testDataStateRead(t, filepath.Join(DefaultDataDir, DefaultStateFilename))
-	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"path":"foo","workspace_dir":null}`; got != want {
+	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"history_limit":null,"path":"foo","workspace_dir":null}`; got != want {
 		t.Errorf("wrong config\ngot:  %s\nwant: %s", got, want)
 	}
 
@@ -910,7 +914,7 @@ func TestInit_backendReinitConfigToExtra(t *testing.T) {
 		t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
 	}
 	state = testDataStateRead(t, filepath.Join(DefaultDataDir, DefaultStateFilename))
-	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"path":"foo","workspace_dir":null}`; got != want {
+	if got, want := normalizeJSON(t, state.Backend.ConfigRaw), `{"history_limit":null,"path":"foo","workspace_dir":null}`; got != want {
 		t.Errorf("wrong config after moving to arg\ngot:  %s\nwant: %s", got, want)
 	}
 
@@ -1257,7 +1261,7 @@ func TestInit_inputFalse(t *testing.T) {
 			false, // not sensitive
 		)
 	})
-	if err := statemgr.NewFilesystem("foo").WriteState(fooState); err != nil {
+	if err := statemgr.NewFilesystem("foo", encryption.StateEncryptionDisabled()).WriteState(fooState); err != nil {
 		t.Fatal(err)
 	}
 	barState := states.BuildState(func(s *states.SyncState) {
@@ -1267,7 +1271,7 @@ func TestInit_inputFalse(t *testing.T) {
 			false, // not sensitive
 		)
 	})
-	if err := statemgr.NewFilesystem("bar").WriteState(barState); err != nil {
+	if err := statemgr.NewFilesystem("bar", encryption.StateEncryptionDisabled()).WriteState(barState); err != nil {
 		t.Fatal(err)
 	}
 
@@ -2368,6 +2372,132 @@ provider "registry.opentofu.org/hashicorp/test" {
 	}
 }
 
+func TestInit_moduleLockFileReadonly(t *testing.T) {
+	var pkg bytes.Buffer
+	w := zip.NewWriter(&pkg)
+	if f, err := w.Create("main.tf"); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.Write([]byte(`output "greeting" { value = "hello" }`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		w.Write(pkg.Bytes())
+	}))
+	defer server.Close()
+	source := server.URL + "/child.zip"
+
+	lockFile := func(source, hash string) string {
+		return strings.TrimSpace(fmt.Sprintf(`
+# This file is maintained automatically by "tofu init".
+# Manual edits may be lost in future updates.
+
+module "child" {
+  source = %q
+  hash   = %q
+}
+`, source, hash))
+	}
+	// This is the hash of the package served above, so it'll change if the
+	// content of the package changes.
+	const goodHash = "h1:J4SvuQhDEW3c429M0sEeWvhu6KCLUOLE5HMDyWX3Ktk="
+	const badHash = "h1:Sp4UHnIZjzMc8iKU1zaEMDyMyqXhW8x3wt6dpH4lk3w="
+
+	cases := []struct {
+		desc    string
+		input   string
+		args    []string
+		ok      bool
+		want    string
+		wantErr string
+	}{
+		{
+			desc:  "default",
+			input: "",
+			args:  []string{},
+			ok:    true,
+			want:  lockFile(source, goodHash),
+		},
+		{
+			desc:  "readonly",
+			input: lockFile(source, goodHash),
+			args:  []string{"-lockfile=readonly"},
+			ok:    true,
+			want:  lockFile(source, goodHash),
+		},
+		{
+			desc:    "checksum mismatch",
+			input:   lockFile(source, badHash),
+			args:    []string{},
+			ok:      false,
+			want:    lockFile(source, badHash),
+			wantErr: "Module package doesn't match the dependency lock file",
+		},
+		{
+			desc:  "changed source",
+			input: lockFile(server.URL+"/old.zip", badHash),
+			args:  []string{},
+			ok:    true,
+			want:  lockFile(source, goodHash),
+		},
+		{
+			desc:    "changed source readonly",
+			input:   lockFile(server.URL+"/old.zip", badHash),
+			args:    []string{"-lockfile=readonly"},
+			ok:      false,
+			want:    lockFile(server.URL+"/old.zip", badHash),
+			wantErr: "Module dependency changes detected",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			td := t.TempDir()
+			defer testChdir(t, td)()
+			if err := os.WriteFile("main.tf", []byte(fmt.Sprintf(`module "child" { source = %q }`, source)), 0644); err != nil {
+				t.Fatal(err)
+			}
+			lockFilename := ".terraform.lock.hcl"
+			if tc.input != "" {
+				if err := os.WriteFile(lockFilename, []byte(tc.input), 0644); err != nil {
+					t.Fatalf("failed to write input lockfile: %s", err)
+				}
+			}
+
+			ui := new(cli.MockUi)
+			c := &InitCommand{
+				Meta: Meta{
+					testingOverrides: metaOverridesForProvider(testProvider()),
+					Ui:               ui,
+				},
+			}
+
+			code := c.Run(tc.args)
+			if tc.ok && code != 0 {
+				t.Fatalf("bad: \n%s", ui.ErrorWriter.String())
+			}
+			if !tc.ok && code == 0 {
+				t.Fatalf("expected error, got output: \n%s", ui.OutputWriter.String())
+			}
+			if !strings.Contains(ui.ErrorWriter.String(), tc.wantErr) {
+				t.Errorf("missing error %q in output:\n%s", tc.wantErr, ui.ErrorWriter.String())
+			}
+
+			buf, err := os.ReadFile(lockFilename)
+			if err != nil {
+				t.Fatalf("failed to read dependency lock file %s: %s", lockFilename, err)
+			}
+			buf = bytes.TrimSpace(buf)
+			if diff := cmp.Diff(tc.want, string(buf)); diff != "" {
+				t.Errorf("wrong dependency lock file contents\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestInit_pluginDirReset(t *testing.T) {
 	td := testTempDir(t)
 	defer os.RemoveAll(td)
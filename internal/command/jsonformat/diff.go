@@ -9,6 +9,7 @@ import (
 	"github.com/opentofu/opentofu/internal/command/jsonformat/structured"
 	"github.com/opentofu/opentofu/internal/command/jsonformat/structured/attribute_path"
 	"github.com/opentofu/opentofu/internal/command/jsonplan"
+	"github.com/opentofu/opentofu/internal/command/jsonprovider"
 	"github.com/opentofu/opentofu/internal/plans"
 )
 
@@ -55,10 +56,9 @@ func precomputeDiffs(plan Plan, mode plans.Mode) diffs {
 
 	for _, change := range plan.ResourceChanges {
 		schema := plan.getSchema(change)
-		structuredChange := structured.FromJsonChange(change.Change, attribute_path.AlwaysMatcher())
 		diffs.changes = append(diffs.changes, diff{
 			change: change,
-			diff:   differ.ComputeDiffForBlock(structuredChange, schema.Block),
+			diff:   computeResourceChangeDiff(change, schema.Block),
 		})
 	}
 
@@ -70,6 +70,18 @@ func precomputeDiffs(plan Plan, mode plans.Mode) diffs {
 	return diffs
 }
 
+// computeResourceChangeDiff computes the diff to render for a single planned
+// resource instance change.
+func computeResourceChangeDiff(change jsonplan.ResourceChange, block *jsonprovider.Block) computed.Diff {
+	structuredChange := structured.FromJsonChange(change.Change, attribute_path.AlwaysMatcher())
+	if jsonplan.UnmarshalActions(change.Change.Actions) == plans.Forget {
+		// Forgetting leaves the remote object untouched, so we show the
+		// object as it currently is rather than as if it were deleted.
+		structuredChange = structuredChange.AsNoOp()
+	}
+	return differ.ComputeDiffForBlock(structuredChange, block)
+}
+
 type diffs struct {
 	drift   []diff
 	changes []diff
@@ -78,7 +90,7 @@ type diffs struct {
 
 func (d diffs) Empty() bool {
 	for _, change := range d.changes {
-		if change.diff.Action != plans.NoOp || change.Moved() {
+		if change.diff.Action != plans.NoOp || change.Moved() || change.Forgotten() {
 			return false
 		}
 	}
@@ -104,3 +116,7 @@ func (d diff) Moved() bool {
 func (d diff) Importing() bool {
 	return d.change.Change.Importing != nil
 }
+
+func (d diff) Forgotten() bool {
+	return jsonplan.UnmarshalActions(d.change.Change.Actions) == plans.Forget
+}
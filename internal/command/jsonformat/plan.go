@@ -25,11 +25,12 @@ const (
 )
 
 type Plan struct {
-	PlanFormatVersion  string                     `json:"plan_format_version"`
-	OutputChanges      map[string]jsonplan.Change `json:"output_changes"`
-	ResourceChanges    []jsonplan.ResourceChange  `json:"resource_changes"`
-	ResourceDrift      []jsonplan.ResourceChange  `json:"resource_drift"`
-	RelevantAttributes []jsonplan.ResourceAttr    `json:"relevant_attributes"`
+	PlanFormatVersion  string                      `json:"plan_format_version"`
+	OutputChanges      map[string]jsonplan.Change  `json:"output_changes"`
+	ResourceChanges    []jsonplan.ResourceChange   `json:"resource_changes"`
+	ResourceDrift      []jsonplan.ResourceChange   `json:"resource_drift"`
+	RelevantAttributes []jsonplan.ResourceAttr     `json:"relevant_attributes"`
+	DeferredResources  []jsonplan.DeferredResource `json:"deferred_resources"`
 
 	ProviderFormatVersion string                            `json:"provider_format_version"`
 	ProviderSchemas       map[string]*jsonprovider.Provider `json:"provider_schemas"`
@@ -56,6 +57,10 @@ func (plan Plan) renderHuman(renderer Renderer, mode plans.Mode, opts ...plans.Q
 		return false
 	}
 
+	// The deferred resources come last, whichever of the cases below we
+	// end up in.
+	defer renderHumanDeferred(renderer, plan.DeferredResources)
+
 	diffs := precomputeDiffs(plan, mode)
 	haveRefreshChanges := renderHumanDiffDrift(renderer, diffs, mode)
 
@@ -202,6 +207,9 @@ func (plan Plan) renderHuman(renderer Renderer, mode plans.Mode, opts ...plans.Q
 		if counts[plans.Read] > 0 {
 			renderer.Streams.Println(renderer.Colorize.Color(actionDescription(plans.Read)))
 		}
+		if counts[plans.Forget] > 0 {
+			renderer.Streams.Println(renderer.Colorize.Color(actionDescription(plans.Forget)))
+		}
 	}
 
 	if len(changes) > 0 {
@@ -219,19 +227,27 @@ func (plan Plan) renderHuman(renderer Renderer, mode plans.Mode, opts ...plans.Q
 			}
 		}
 
+		// The "to forget" count is only included when there's something to
+		// forget, so that the summary is unchanged for all other plans.
+		var forgetSummary string
+		if counts[plans.Forget] > 0 {
+			forgetSummary = fmt.Sprintf(", %d to forget", counts[plans.Forget])
+		}
 		if importingCount > 0 {
 			renderer.Streams.Printf(
-				renderer.Colorize.Color("\n[bold]Plan:[reset] %d to import, %d to add, %d to change, %d to destroy.\n"),
+				renderer.Colorize.Color("\n[bold]Plan:[reset] %d to import, %d to add, %d to change, %d to destroy%s.\n"),
 				importingCount,
 				counts[plans.Create]+counts[plans.DeleteThenCreate]+counts[plans.CreateThenDelete],
 				counts[plans.Update],
-				counts[plans.Delete]+counts[plans.DeleteThenCreate]+counts[plans.CreateThenDelete])
+				counts[plans.Delete]+counts[plans.DeleteThenCreate]+counts[plans.CreateThenDelete],
+				forgetSummary)
 		} else {
 			renderer.Streams.Printf(
-				renderer.Colorize.Color("\n[bold]Plan:[reset] %d to add, %d to change, %d to destroy.\n"),
+				renderer.Colorize.Color("\n[bold]Plan:[reset] %d to add, %d to change, %d to destroy%s.\n"),
 				counts[plans.Create]+counts[plans.DeleteThenCreate]+counts[plans.CreateThenDelete],
 				counts[plans.Update],
-				counts[plans.Delete]+counts[plans.DeleteThenCreate]+counts[plans.CreateThenDelete])
+				counts[plans.Delete]+counts[plans.DeleteThenCreate]+counts[plans.CreateThenDelete],
+				forgetSummary)
 		}
 	}
 
@@ -251,6 +267,33 @@ func (plan Plan) renderHuman(renderer Renderer, mode plans.Mode, opts ...plans.Q
 	}
 }
 
+func renderHumanDeferred(renderer Renderer, deferred []jsonplan.DeferredResource) {
+	if len(deferred) == 0 {
+		return
+	}
+
+	renderer.Streams.Println(format.WordWrap(
+		"\nOpenTofu deferred the changes for the following resources, because it can't yet determine which instances they should have:",
+		renderer.Streams.Stdout.Columns()))
+	for _, dr := range deferred {
+		var reason string
+		switch dr.Reason {
+		case jsonplan.DeferredReasonCountUnknown:
+			reason = "the count value isn't known yet"
+		case jsonplan.DeferredReasonForEachUnknown:
+			reason = "the for_each value isn't known yet"
+		case jsonplan.DeferredReasonDependency:
+			reason = "it depends on a deferred resource"
+		default:
+			reason = "unknown reason"
+		}
+		renderer.Streams.Println(renderer.Colorize.Color(fmt.Sprintf("  [bold]# %s[reset] (%s)", dr.Address, reason)))
+	}
+	renderer.Streams.Println(format.WordWrap(
+		"\nCreate and apply another plan after applying this one to plan the deferred changes.",
+		renderer.Streams.Stdout.Columns()))
+}
+
 func renderHumanDiffOutputs(renderer Renderer, outputs map[string]computed.Diff) string {
 	var rendered []string
 
@@ -455,6 +498,8 @@ func resourceChangeComment(resource jsonplan.ResourceChange, action plans.Action
 			// Some extra context about this unusual situation.
 			buf.WriteString("\n  # (left over from a partially-failed replacement of this instance)")
 		}
+	case plans.Forget:
+		buf.WriteString(fmt.Sprintf("[bold]  # %s[reset] will be removed from the OpenTofu state [bold]but will not be destroyed[reset]", dispAddr))
 	case plans.NoOp:
 		if len(resource.PreviousAddress) > 0 && resource.PreviousAddress != resource.Address {
 			buf.WriteString(fmt.Sprintf("[bold]  # %s[reset] has moved to [bold]%s[reset]", resource.PreviousAddress, dispAddr))
@@ -523,6 +568,8 @@ func actionDescription(action plans.Action) string {
 		return "[red]-[reset]/[green]+[reset] destroy and then create replacement"
 	case plans.Read:
 		return " [cyan]<=[reset] read (data resources)"
+	case plans.Forget:
+		return "  [light_gray].[reset] forget (remove from state without destroying)"
 	default:
 		panic(fmt.Sprintf("unrecognized change type: %s", action.String()))
 	}
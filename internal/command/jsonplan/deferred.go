@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package jsonplan
+
+import (
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/command/jsonstate"
+	"github.com/opentofu/opentofu/internal/plans"
+)
+
+const (
+	DeferredReasonCountUnknown   = "count_unknown"
+	DeferredReasonForEachUnknown = "for_each_unknown"
+	DeferredReasonDependency     = "dependency"
+)
+
+// DeferredResource is a resource whose changes were left out of the plan
+// because OpenTofu couldn't yet determine which instances it should have.
+type DeferredResource struct {
+	// Address is the absolute resource address
+	Address string `json:"address"`
+
+	// ModuleAddress is the module portion of the above address. Omitted if the
+	// resource is in the root module.
+	ModuleAddress string `json:"module_address,omitempty"`
+
+	// "managed" or "data"
+	Mode string `json:"mode"`
+
+	Type string `json:"type"`
+	Name string `json:"name"`
+
+	// Reason is one of "count_unknown", "for_each_unknown" or "dependency".
+	Reason string `json:"reason"`
+}
+
+// MarshalDeferredResources returns the JSON representation of the given
+// deferred resources.
+func MarshalDeferredResources(deferred []*plans.DeferredResource) []DeferredResource {
+	if len(deferred) == 0 {
+		return nil
+	}
+
+	ret := make([]DeferredResource, 0, len(deferred))
+	for _, dr := range deferred {
+		r := DeferredResource{
+			Address: dr.Addr.String(),
+			Type:    dr.Addr.Resource.Type,
+			Name:    dr.Addr.Resource.Name,
+		}
+		if !dr.Addr.Module.IsRoot() {
+			r.ModuleAddress = dr.Addr.Module.String()
+		}
+		switch dr.Addr.Resource.Mode {
+		case addrs.ManagedResourceMode:
+			r.Mode = jsonstate.ManagedResourceMode
+		case addrs.DataResourceMode:
+			r.Mode = jsonstate.DataResourceMode
+		}
+		switch dr.Reason {
+		case plans.DeferredReasonCountUnknown:
+			r.Reason = DeferredReasonCountUnknown
+		case plans.DeferredReasonForEachUnknown:
+			r.Reason = DeferredReasonForEachUnknown
+		case plans.DeferredReasonDependency:
+			r.Reason = DeferredReasonDependency
+		}
+		ret = append(ret, r)
+	}
+	return ret
+}
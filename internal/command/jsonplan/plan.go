@@ -55,15 +55,17 @@ type Plan struct {
 	PlannedValues    StateValues `json:"planned_values,omitempty"`
 	// ResourceDrift and ResourceChanges are sorted in a user-friendly order
 	// that is undefined at this time, but consistent.
-	ResourceDrift      []ResourceChange  `json:"resource_drift,omitempty"`
-	ResourceChanges    []ResourceChange  `json:"resource_changes,omitempty"`
-	OutputChanges      map[string]Change `json:"output_changes,omitempty"`
-	PriorState         json.RawMessage   `json:"prior_state,omitempty"`
-	Config             json.RawMessage   `json:"configuration,omitempty"`
-	RelevantAttributes []ResourceAttr    `json:"relevant_attributes,omitempty"`
-	Checks             json.RawMessage   `json:"checks,omitempty"`
-	Timestamp          string            `json:"timestamp,omitempty"`
-	Errored            bool              `json:"errored"`
+	ResourceDrift      []ResourceChange   `json:"resource_drift,omitempty"`
+	ResourceChanges    []ResourceChange   `json:"resource_changes,omitempty"`
+	OutputChanges      map[string]Change  `json:"output_changes,omitempty"`
+	PriorState         json.RawMessage    `json:"prior_state,omitempty"`
+	Config             json.RawMessage    `json:"configuration,omitempty"`
+	RelevantAttributes []ResourceAttr     `json:"relevant_attributes,omitempty"`
+	Checks             json.RawMessage    `json:"checks,omitempty"`
+	PolicyResults      []PolicyResult     `json:"policy_results,omitempty"`
+	DeferredResources  []DeferredResource `json:"deferred_resources,omitempty"`
+	Timestamp          string             `json:"timestamp,omitempty"`
+	Errored            bool               `json:"errored"`
 }
 
 func newPlan() *Plan {
@@ -90,6 +92,7 @@ type Change struct {
 	//    ["delete", "create"]
 	//    ["create", "delete"]
 	//    ["delete"]
+	//    ["forget"]
 	// The two "replace" actions are represented in this way to allow callers to
 	// e.g. just scan the list for "delete" to recognize all three situations
 	// where the object will be deleted, allowing for any new deletion
@@ -280,6 +283,12 @@ func MarshalForLog(
 		output.Checks = jsonchecks.MarshalCheckStates(p.Checks)
 	}
 
+	// output.PolicyResults
+	output.PolicyResults = marshalPolicyResults(p.PolicyResults)
+
+	// output.DeferredResources
+	output.DeferredResources = MarshalDeferredResources(p.DeferredResources)
+
 	// output.PriorState
 	if sf != nil && !sf.State.Empty() {
 		output.PriorState, err = jsonstate.Marshal(sf, schemas)
@@ -839,6 +848,8 @@ func actionString(action string) []string {
 		return []string{"read"}
 	case action == "DeleteThenCreate":
 		return []string{"delete", "create"}
+	case action == "Forget":
+		return []string{"forget"}
 	default:
 		return []string{action}
 	}
@@ -868,6 +879,8 @@ func UnmarshalActions(actions []string) plans.Action {
 			return plans.Read
 		case "no-op":
 			return plans.NoOp
+		case "forget":
+			return plans.Forget
 		}
 	}
 
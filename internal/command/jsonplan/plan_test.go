@@ -470,3 +470,45 @@ func BenchmarkUnknownAsBool_9(b *testing.B) {
 		unknownAsBool(value)
 	}
 }
+
+func TestMarshalDeferredResources(t *testing.T) {
+	deferred := []*plans.DeferredResource{
+		{
+			Addr: addrs.Resource{
+				Mode: addrs.ManagedResourceMode,
+				Type: "test_thing",
+				Name: "a",
+			}.Absolute(addrs.RootModuleInstance),
+			Reason: plans.DeferredReasonCountUnknown,
+		},
+		{
+			Addr: addrs.Resource{
+				Mode: addrs.DataResourceMode,
+				Type: "test_thing",
+				Name: "b",
+			}.Absolute(addrs.RootModuleInstance.Child("child", addrs.StringKey("x"))),
+			Reason: plans.DeferredReasonDependency,
+		},
+	}
+
+	want := []DeferredResource{
+		{
+			Address: "test_thing.a",
+			Mode:    "managed",
+			Type:    "test_thing",
+			Name:    "a",
+			Reason:  "count_unknown",
+		},
+		{
+			Address:       `module.child["x"].data.test_thing.b`,
+			ModuleAddress: `module.child["x"]`,
+			Mode:          "data",
+			Type:          "test_thing",
+			Name:          "b",
+			Reason:        "dependency",
+		},
+	}
+	if diff := cmp.Diff(want, MarshalDeferredResources(deferred)); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package jsonplan
+
+import (
+	"github.com/opentofu/opentofu/internal/checks"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// PolicyResult is the result of evaluating a policy block from the root
+// module against the changes in the plan.
+type PolicyResult struct {
+	Name string `json:"name"`
+
+	// Severity is "error" if a failure of the policy makes the plan
+	// unapplyable, or "warning" otherwise.
+	Severity string `json:"severity"`
+
+	// Status is one of "pass", "fail", "error" or "unknown".
+	Status string `json:"status"`
+
+	FailureMessages []string `json:"failure_messages,omitempty"`
+}
+
+func marshalPolicyResults(results []*plans.PolicyResult) []PolicyResult {
+	if len(results) == 0 {
+		return nil
+	}
+
+	ret := make([]PolicyResult, 0, len(results))
+	for _, result := range results {
+		r := PolicyResult{
+			Name:            result.Name,
+			Severity:        "error",
+			FailureMessages: result.FailureMessages,
+		}
+		if result.Severity == tfdiags.Warning {
+			r.Severity = "warning"
+		}
+		switch result.Status {
+		case checks.StatusPass:
+			r.Status = "pass"
+		case checks.StatusFail:
+			r.Status = "fail"
+		case checks.StatusError:
+			r.Status = "error"
+		default:
+			r.Status = "unknown"
+		}
+		ret = append(ret, r)
+	}
+	return ret
+}
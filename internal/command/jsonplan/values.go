@@ -104,10 +104,10 @@ func marshalPlannedValues(changes *plans.Changes, schemas *tofu.Schemas) (Module
 	seenModules := make(map[string]bool)
 
 	for _, resource := range changes.Resources {
-		// If the resource is being deleted, skip over it.
+		// If the resource is being deleted or forgotten, skip over it.
 		// Deposed instances are always conceptually a destroy, but if they
 		// were gone during refresh then the change becomes a noop.
-		if resource.Action != plans.Delete && resource.DeposedKey == states.NotDeposed {
+		if resource.Action != plans.Delete && resource.Action != plans.Forget && resource.DeposedKey == states.NotDeposed {
 			containingModule := resource.Addr.Module.String()
 			moduleResourceMap[containingModule] = append(moduleResourceMap[containingModule], resource.Addr)
 
@@ -171,7 +171,7 @@ func marshalPlanResources(changes *plans.Changes, ris []addrs.AbsResourceInstanc
 
 	for _, ri := range ris {
 		r := changes.ResourceInstance(ri)
-		if r.Action == plans.Delete {
+		if r.Action == plans.Delete || r.Action == plans.Forget {
 			continue
 		}
 
@@ -13,6 +13,7 @@ import (
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/command/jsonchecks"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
 	"github.com/opentofu/opentofu/internal/lang/marks"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/statefile"
@@ -357,34 +358,11 @@ func marshalResources(resources map[string]*states.Resource, module addrs.Module
 		for _, k := range sortedKeys {
 			ri := r.Instances[k]
 
-			var err error
-
 			resAddr := r.Addr.Resource
 
-			current := Resource{
-				Address:      r.Addr.Instance(k).String(),
-				Type:         resAddr.Type,
-				Name:         resAddr.Name,
-				ProviderName: r.ProviderConfig.Provider.String(),
-			}
-
-			if k != nil {
-				index := k.Value()
-				if current.Index, err = ctyjson.Marshal(index, index.Type()); err != nil {
-					return nil, err
-				}
-			}
-
-			switch resAddr.Mode {
-			case addrs.ManagedResourceMode:
-				current.Mode = ManagedResourceMode
-			case addrs.DataResourceMode:
-				current.Mode = DataResourceMode
-			default:
-				return ret, fmt.Errorf("resource %s has an unsupported mode %s",
-					resAddr.String(),
-					resAddr.Mode.String(),
-				)
+			current, err := newResource(r, k)
+			if err != nil {
+				return ret, err
 			}
 
 			schema, version := schemas.ResourceTypeConfig(
@@ -493,6 +471,122 @@ func marshalResources(resources map[string]*states.Resource, module addrs.Module
 	return ret, nil
 }
 
+// newResource returns a Resource describing the given instance of r, with
+// only the fields that don't depend on the instance's objects populated.
+func newResource(r *states.Resource, k addrs.InstanceKey) (Resource, error) {
+	resAddr := r.Addr.Resource
+
+	ret := Resource{
+		Address:      r.Addr.Instance(k).String(),
+		Type:         resAddr.Type,
+		Name:         resAddr.Name,
+		ProviderName: r.ProviderConfig.Provider.String(),
+	}
+
+	if k != nil {
+		index := k.Value()
+		var err error
+		if ret.Index, err = ctyjson.Marshal(index, index.Type()); err != nil {
+			return ret, err
+		}
+	}
+
+	switch resAddr.Mode {
+	case addrs.ManagedResourceMode:
+		ret.Mode = ManagedResourceMode
+	case addrs.DataResourceMode:
+		ret.Mode = DataResourceMode
+	default:
+		return ret, fmt.Errorf("resource %s has an unsupported mode %s",
+			resAddr.String(),
+			resAddr.Mode.String(),
+		)
+	}
+
+	return ret, nil
+}
+
+// MarshalResourceInstanceSummary returns the JSON representation of the
+// current object of the given resource instance, without its attribute
+// values. Unlike MarshalResourceInstance it doesn't require provider schemas.
+//
+// An instance that only has deposed objects is still described, but without
+// the fields that describe its current object.
+func MarshalResourceInstanceSummary(s *states.State, addr addrs.AbsResourceInstance) (Resource, error) {
+	rs := s.Resource(addr.ContainingResource())
+	is := s.ResourceInstance(addr)
+	if rs == nil || is == nil {
+		return Resource{}, fmt.Errorf("no resource instance %s in state", addr)
+	}
+
+	ret, err := newResource(rs, addr.Resource.Key)
+	if err != nil {
+		return ret, err
+	}
+	if is.HasCurrent() {
+		ret.SchemaVersion = is.Current.SchemaVersion
+		if is.Current.Status == states.ObjectTainted {
+			ret.Tainted = true
+		}
+	}
+	return ret, nil
+}
+
+// MarshalResourceInstance returns the JSON representation of the current
+// object of the given resource instance, in the same form as the resources
+// of a full state, including its sensitive value mask. Unlike in a full
+// state, the sensitive attribute values are redacted by replacing them with
+// null, as in the human-readable output of "tofu state show".
+func MarshalResourceInstance(s *states.State, addr addrs.AbsResourceInstance, schemas *tofu.Schemas) (Resource, error) {
+	rs := s.Resource(addr.ContainingResource())
+	is := s.ResourceInstance(addr)
+	if rs == nil || !is.HasCurrent() {
+		return Resource{}, fmt.Errorf("no current object for %s in state", addr)
+	}
+
+	// Marshal a copy of the resource containing only the current object of
+	// the requested instance, so that deposed objects are left out.
+	single := &states.Resource{
+		Addr:           rs.Addr,
+		ProviderConfig: rs.ProviderConfig,
+		Instances: map[addrs.InstanceKey]*states.ResourceInstance{
+			addr.Resource.Key: {Current: is.Current},
+		},
+	}
+	resources, err := marshalResources(map[string]*states.Resource{rs.Addr.String(): single}, addr.Module, schemas)
+	if err != nil {
+		return Resource{}, err
+	}
+	ret := resources[0]
+
+	// marshalResources already made sure that we have a schema that can
+	// decode the object.
+	schema, _ := schemas.ResourceTypeConfig(rs.ProviderConfig.Provider, rs.Addr.Resource.Mode, rs.Addr.Resource.Type)
+	obj, err := is.Current.Decode(schema.ImpliedType())
+	if err != nil {
+		return Resource{}, err
+	}
+	ret.AttributeValues = marshalAttributeValues(redactSensitiveValues(obj.Value, schema))
+	return ret, nil
+}
+
+// redactSensitiveValues replaces each value within the given object that is
+// sensitive, either because it's marked as such or because its schema says
+// so, with a null value of the same type.
+func redactSensitiveValues(val cty.Value, schema *configschema.Block) cty.Value {
+	val, pvm := val.UnmarkDeepWithPaths()
+	if schema.ContainsSensitive() {
+		pvm = append(pvm, schema.ValueMarks(val, nil)...)
+	}
+	ret, _ := cty.Transform(val.MarkWithPaths(pvm), func(_ cty.Path, v cty.Value) (cty.Value, error) {
+		if v.HasMark(marks.Sensitive) {
+			return cty.NullVal(v.Type()), nil
+		}
+		return v, nil
+	})
+	return ret
+}
+
 func SensitiveAsBool(val cty.Value) cty.Value {
 	if val.HasMark(marks.Sensitive) {
 		return cty.True
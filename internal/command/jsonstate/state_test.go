@@ -841,6 +841,58 @@ func testSchemas() *tofu.Schemas {
 	}
 }
 
+func TestRedactSensitiveValues(t *testing.T) {
+	schema := &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"id":   {Type: cty.String, Computed: true},
+			"tags": {Type: cty.List(cty.String), Optional: true},
+		},
+		BlockTypes: map[string]*configschema.NestedBlock{
+			"login": {
+				Nesting: configschema.NestingList,
+				Block: configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"user":     {Type: cty.String, Optional: true},
+						"password": {Type: cty.String, Optional: true, Sensitive: true},
+					},
+				},
+			},
+		},
+	}
+	val := cty.ObjectVal(map[string]cty.Value{
+		"id": cty.StringVal("foo"),
+		"tags": cty.ListVal([]cty.Value{
+			cty.StringVal("public"),
+			cty.StringVal("secret").Mark(marks.Sensitive),
+		}),
+		"login": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"user":     cty.StringVal("admin"),
+				"password": cty.StringVal("hunter2"),
+			}),
+		}),
+	})
+
+	got := redactSensitiveValues(val, schema)
+	want := cty.ObjectVal(map[string]cty.Value{
+		"id": cty.StringVal("foo"),
+		"tags": cty.ListVal([]cty.Value{
+			cty.StringVal("public"),
+			cty.NullVal(cty.String),
+		}),
+		"login": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"user":     cty.StringVal("admin"),
+				"password": cty.NullVal(cty.String),
+			}),
+		}),
+	})
+	got, _ = got.UnmarkDeep()
+	if !got.RawEquals(want) {
+		t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
+
 func TestSensitiveAsBool(t *testing.T) {
 	tests := []struct {
 		Input cty.Value
@@ -32,6 +32,7 @@ import (
 	"github.com/opentofu/opentofu/internal/command/workdir"
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configload"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/getproviders"
 	legacy "github.com/opentofu/opentofu/internal/legacy/tofu"
 	"github.com/opentofu/opentofu/internal/providers"
@@ -106,6 +107,11 @@ type Meta struct {
 	// into the given directory.
 	PluginCacheDir string
 
+	// ModuleCacheDir, if non-empty, enables caching of downloaded remote
+	// module packages into the given directory, shared between working
+	// directories.
+	ModuleCacheDir string
+
 	// PluginCacheMayBreakDependencyLockFile is a temporary CLI configuration-based
 	// opt out for the behavior of only using the plugin cache dir if its
 	// contents match checksums recorded in the dependency lock file.
@@ -203,6 +209,10 @@ type Meta struct {
 	// backendState is the currently active backend state
 	backendState *legacy.BackendState
 
+	// encryption is the encryption configured for the working directory.
+	// It is initialized on first use by the Encryption method.
+	encryption encryption.Encryption
+
 	// Variables for the context (private)
 	variableArgs rawFlags
 	input        bool
@@ -475,7 +485,7 @@ func (m *Meta) RunOperation(b backend.Enhanced, opReq *backend.Operation) (*back
 		opReq.ConfigDir = m.normalizePath(opReq.ConfigDir)
 	}
 
-	op, err := b.Operation(context.Background(), opReq)
+	op, err := b.Operation(m.CommandContext(), opReq)
 	if err != nil {
 		return nil, fmt.Errorf("error starting operation: %w", err)
 	}
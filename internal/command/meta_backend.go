@@ -28,6 +28,7 @@ import (
 	"github.com/opentofu/opentofu/internal/command/clistate"
 	"github.com/opentofu/opentofu/internal/command/views"
 	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -92,6 +93,12 @@ type BackendWithRemoteTerraformVersion interface {
 func (m *Meta) Backend(opts *BackendOpts) (backend.Enhanced, tfdiags.Diagnostics) {
 	var diags tfdiags.Diagnostics
 
+	enc, encDiags := m.Encryption()
+	diags = diags.Append(encDiags)
+	if encDiags.HasErrors() {
+		return nil, diags
+	}
+
 	// If no opts are set, then initialize
 	if opts == nil {
 		opts = &BackendOpts{}
@@ -179,7 +186,7 @@ func (m *Meta) Backend(opts *BackendOpts) (backend.Enhanced, tfdiags.Diagnostics
 	}
 
 	// Build the local backend
-	local := backendLocal.NewWithBackend(b)
+	local := backendLocal.NewWithBackend(b, enc.State())
 	if err := local.CLIInit(cliOpts); err != nil {
 		// Local backend isn't allowed to fail. It would be a bug.
 		panic(err)
@@ -305,12 +312,18 @@ func (m *Meta) selectWorkspace(b backend.Backend) error {
 func (m *Meta) BackendForLocalPlan(settings plans.Backend) (backend.Enhanced, tfdiags.Diagnostics) {
 	var diags tfdiags.Diagnostics
 
+	enc, encDiags := m.Encryption()
+	diags = diags.Append(encDiags)
+	if encDiags.HasErrors() {
+		return nil, diags
+	}
+
 	f := backendInit.Backend(settings.Type)
 	if f == nil {
 		diags = diags.Append(fmt.Errorf(strings.TrimSpace(errBackendSavedUnknown), settings.Type))
 		return nil, diags
 	}
-	b := f()
+	b := f(enc.State())
 	log.Printf("[TRACE] Meta.BackendForLocalPlan: instantiated backend of type %T", b)
 
 	schema := b.ConfigSchema()
@@ -369,7 +382,7 @@ func (m *Meta) BackendForLocalPlan(settings plans.Backend) (backend.Enhanced, tf
 		return nil, diags
 	}
 	cliOpts.Validation = false // don't validate here in case config contains file(...) calls where the file doesn't exist
-	local := backendLocal.NewWithBackend(b)
+	local := backendLocal.NewWithBackend(b, enc.State())
 	if err := local.CLIInit(cliOpts); err != nil {
 		// Local backend should never fail, so this is always a bug.
 		panic(err)
@@ -437,6 +450,15 @@ func (m *Meta) Operation(b backend.Backend, vt arguments.ViewType) *backend.Oper
 		log.Printf("[WARN] Failed to load dependency locks while preparing backend operation (ignored): %s", diags.Err().Error())
 	}
 
+	enc, encDiags := m.Encryption()
+	if encDiags.HasErrors() {
+		// As with the dependency locks above, the encryption configuration
+		// must already have been loaded successfully in order to create the
+		// backend, so we should never get here in practice.
+		log.Printf("[WARN] Failed to load encryption configuration while preparing backend operation (ignored): %s", encDiags.Err().Error())
+		enc = encryption.Disabled()
+	}
+
 	return &backend.Operation{
 		PlanOutBackend:  planOutBackend,
 		Targets:         m.targets,
@@ -445,6 +467,7 @@ func (m *Meta) Operation(b backend.Backend, vt arguments.ViewType) *backend.Oper
 		Workspace:       workspace,
 		StateLocker:     stateLocker,
 		DependencyLocks: depLocks,
+		Encryption:      enc,
 	}
 }
 
@@ -491,10 +514,20 @@ func (m *Meta) backendConfig(opts *BackendOpts) (*configs.Backend, int, tfdiags.
 		})
 		return nil, 0, diags
 	}
-	b := bf()
+	// Only the schema is needed here, so encryption is irrelevant.
+	b := bf(encryption.StateEncryptionDisabled())
 
 	configSchema := b.ConfigSchema()
 	configBody := c.Config
+
+	// The backend configuration may refer to input variables and local
+	// values, so we make sure here that it can be evaluated. Otherwise the
+	// problem would be reported only as a change to the configuration below.
+	_, decDiags := hcldec.Decode(configBody, configSchema.NoneRequired().DecoderSpec(), nil)
+	diags = diags.Append(decDiags)
+	if decDiags.HasErrors() {
+		return nil, 0, diags
+	}
 	configHash := c.Hash(configSchema)
 
 	// If we have an override configuration body then we must apply it now.
@@ -776,6 +809,11 @@ func (m *Meta) determineInitReason(previousBackendType string, currentBackendTyp
 // there is no backend state or no backend configured.
 func (m *Meta) backendFromState(ctx context.Context) (backend.Backend, tfdiags.Diagnostics) {
 	var diags tfdiags.Diagnostics
+	enc, encDiags := m.Encryption()
+	diags = diags.Append(encDiags)
+	if encDiags.HasErrors() {
+		return nil, diags
+	}
 	// Get the path to where we store a local cache of backend configuration
 	// if we're using a remote backend. This may not yet exist which means
 	// we haven't used a non-local backend before. That is okay.
@@ -789,25 +827,25 @@ func (m *Meta) backendFromState(ctx context.Context) (backend.Backend, tfdiags.D
 	if s == nil {
 		// no state, so return a local backend
 		log.Printf("[TRACE] Meta.Backend: backend has not previously been initialized in this working directory")
-		return backendLocal.New(), diags
+		return backendLocal.New(enc.State()), diags
 	}
 	if s.Backend == nil {
 		// s.Backend is nil, so return a local backend
 		log.Printf("[TRACE] Meta.Backend: working directory was previously initialized but has no backend (is using legacy remote state?)")
-		return backendLocal.New(), diags
+		return backendLocal.New(enc.State()), diags
 	}
 	log.Printf("[TRACE] Meta.Backend: working directory was previously initialized for %q backend", s.Backend.Type)
 
 	//backend init function
 	if s.Backend.Type == "" {
-		return backendLocal.New(), diags
+		return backendLocal.New(enc.State()), diags
 	}
 	f := backendInit.Backend(s.Backend.Type)
 	if f == nil {
 		diags = diags.Append(fmt.Errorf(strings.TrimSpace(errBackendSavedUnknown), s.Backend.Type))
 		return nil, diags
 	}
-	b := f()
+	b := f(enc.State())
 
 	// The configuration saved in the working directory state file is used
 	// in this case, since it will contain any additional values that
@@ -1264,7 +1302,12 @@ func (m *Meta) savedBackend(sMgr *clistate.LocalState) (backend.Backend, tfdiags
 		diags = diags.Append(fmt.Errorf(strings.TrimSpace(errBackendSavedUnknown), s.Backend.Type))
 		return nil, diags
 	}
-	b := f()
+	enc, encDiags := m.Encryption()
+	diags = diags.Append(encDiags)
+	if encDiags.HasErrors() {
+		return nil, diags
+	}
+	b := f(enc.State())
 
 	// The configuration saved in the working directory state file is used
 	// in this case, since it will contain any additional values that
@@ -1352,7 +1395,7 @@ func (m *Meta) backendConfigNeedsMigration(c *configs.Backend, s *legacy.Backend
 		log.Printf("[TRACE] backendConfigNeedsMigration: no backend of type %q, which migration codepath must handle", c.Type)
 		return true // let the migration codepath deal with the missing backend
 	}
-	b := f()
+	b := f(encryption.StateEncryptionDisabled())
 
 	schema := b.ConfigSchema()
 	decSpec := schema.NoneRequired().DecoderSpec()
@@ -1389,7 +1432,12 @@ func (m *Meta) backendInitFromConfig(c *configs.Backend) (backend.Backend, cty.V
 		diags = diags.Append(fmt.Errorf(strings.TrimSpace(errBackendNewUnknown), c.Type))
 		return nil, cty.NilVal, diags
 	}
-	b := f()
+	enc, encDiags := m.Encryption()
+	diags = diags.Append(encDiags)
+	if encDiags.HasErrors() {
+		return nil, cty.NilVal, diags
+	}
+	b := f(enc.State())
 
 	schema := b.ConfigSchema()
 	decSpec := schema.NoneRequired().DecoderSpec()
@@ -19,6 +19,7 @@ import (
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/copy"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/statefile"
@@ -219,7 +220,7 @@ func TestMetaBackend_emptyWithExplicitState(t *testing.T) {
 	}
 }
 
-// Verify that interpolations result in an error
+// Verify that interpolations of non-static variables result in an error
 func TestMetaBackend_configureInterpolation(t *testing.T) {
 	// Create a temporary working directory that is empty
 	td := t.TempDir()
@@ -230,10 +231,47 @@ func TestMetaBackend_configureInterpolation(t *testing.T) {
 	m := testMetaBackend(t, nil)
 
 	// Get the backend
-	_, err := m.Backend(&BackendOpts{Init: true})
-	if err == nil {
+	_, diags := m.Backend(&BackendOpts{Init: true})
+	if !diags.HasErrors() {
+		t.Fatal("should error")
+	}
+	if got, want := diags.Err().Error(), "Non-static variable in static context"; !strings.Contains(got, want) {
+		t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestMetaBackend_configureInterpolationStatic(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("backend-new-interp-static"), td)
+	defer testChdir(t, td)()
+
+	m := testMetaBackend(t, nil)
+
+	b, diags := m.Backend(&BackendOpts{Init: true})
+	if diags.HasErrors() {
+		t.Fatal(diags.Err())
+	}
+
+	// The path is taken from the default value of the variable
+	if got, want := b.(*backendLocal.Local).StatePath, "bar"; got != want {
+		t.Fatalf("wrong state path %q; want %q", got, want)
+	}
+}
+
+func TestMetaBackend_configureInterpolationDynamic(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("backend-new-interp-dynamic"), td)
+	defer testChdir(t, td)()
+
+	m := testMetaBackend(t, nil)
+
+	_, diags := m.Backend(&BackendOpts{Init: true})
+	if !diags.HasErrors() {
 		t.Fatal("should error")
 	}
+	if got, want := diags.Err().Error(), "Dynamic value in static context"; !strings.Contains(got, want) {
+		t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
 }
 
 // Newly configured backend
@@ -279,7 +317,7 @@ func TestMetaBackend_configureNew(t *testing.T) {
 		if err != nil {
 			t.Fatalf("err: %s", err)
 		}
-		actual, err := statefile.Read(f)
+		actual, err := statefile.Read(f, encryption.StateEncryptionDisabled())
 		f.Close()
 		if err != nil {
 			t.Fatalf("err: %s", err)
@@ -353,7 +391,7 @@ func TestMetaBackend_configureNewWithState(t *testing.T) {
 		if err != nil {
 			t.Fatalf("err: %s", err)
 		}
-		actual, err := statefile.Read(f)
+		actual, err := statefile.Read(f, encryption.StateEncryptionDisabled())
 		f.Close()
 		if err != nil {
 			t.Fatalf("err: %s", err)
@@ -402,7 +440,7 @@ func TestMetaBackend_configureNewWithoutCopy(t *testing.T) {
 	if err != nil {
 		t.Fatalf("err: %s", err)
 	}
-	actual, err := statefile.Read(f)
+	actual, err := statefile.Read(f, encryption.StateEncryptionDisabled())
 	f.Close()
 	if err != nil {
 		t.Fatalf("err: %s", err)
@@ -519,7 +557,7 @@ func TestMetaBackend_configureNewWithStateExisting(t *testing.T) {
 		if err != nil {
 			t.Fatalf("err: %s", err)
 		}
-		actual, err := statefile.Read(f)
+		actual, err := statefile.Read(f, encryption.StateEncryptionDisabled())
 		f.Close()
 		if err != nil {
 			t.Fatalf("err: %s", err)
@@ -590,7 +628,7 @@ func TestMetaBackend_configureNewWithStateExistingNoMigrate(t *testing.T) {
 		if err != nil {
 			t.Fatalf("err: %s", err)
 		}
-		actual, err := statefile.Read(f)
+		actual, err := statefile.Read(f, encryption.StateEncryptionDisabled())
 		f.Close()
 		if err != nil {
 			t.Fatalf("err: %s", err)
@@ -709,7 +747,7 @@ func TestMetaBackend_configuredChange(t *testing.T) {
 		if err != nil {
 			t.Fatalf("err: %s", err)
 		}
-		actual, err := statefile.Read(f)
+		actual, err := statefile.Read(f, encryption.StateEncryptionDisabled())
 		f.Close()
 		if err != nil {
 			t.Fatalf("err: %s", err)
@@ -771,7 +809,7 @@ func TestMetaBackend_reconfigureChange(t *testing.T) {
 	}
 
 	// verify that the old state is still there
-	s = statemgr.NewFilesystem("local-state.tfstate")
+	s = statemgr.NewFilesystem("local-state.tfstate", encryption.StateEncryptionDisabled())
 	if err := s.RefreshState(); err != nil {
 		t.Fatal(err)
 	}
@@ -1535,6 +1573,7 @@ func TestMetaBackend_planLocal(t *testing.T) {
 	backendConfigBlock := cty.ObjectVal(map[string]cty.Value{
 		"path":          cty.NullVal(cty.String),
 		"workspace_dir": cty.NullVal(cty.String),
+		"history_limit": cty.NullVal(cty.Number),
 	})
 	backendConfigRaw, err := plans.NewDynamicValue(backendConfigBlock, backendConfigBlock.Type())
 	if err != nil {
@@ -1599,7 +1638,7 @@ func TestMetaBackend_planLocal(t *testing.T) {
 		if err != nil {
 			t.Fatalf("err: %s", err)
 		}
-		actual, err := statefile.Read(f)
+		actual, err := statefile.Read(f, encryption.StateEncryptionDisabled())
 		f.Close()
 		if err != nil {
 			t.Fatalf("err: %s", err)
@@ -1626,6 +1665,7 @@ func TestMetaBackend_planLocalStatePath(t *testing.T) {
 	backendConfigBlock := cty.ObjectVal(map[string]cty.Value{
 		"path":          cty.NullVal(cty.String),
 		"workspace_dir": cty.NullVal(cty.String),
+		"history_limit": cty.NullVal(cty.Number),
 	})
 	backendConfigRaw, err := plans.NewDynamicValue(backendConfigBlock, backendConfigBlock.Type())
 	if err != nil {
@@ -1641,7 +1681,7 @@ func TestMetaBackend_planLocalStatePath(t *testing.T) {
 	statePath := "foo.tfstate"
 
 	// put an initial state there that needs to be backed up
-	err = (statemgr.NewFilesystem(statePath)).WriteState(original)
+	err = (statemgr.NewFilesystem(statePath, encryption.StateEncryptionDisabled())).WriteState(original)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1700,7 +1740,7 @@ func TestMetaBackend_planLocalStatePath(t *testing.T) {
 		if err != nil {
 			t.Fatalf("err: %s", err)
 		}
-		actual, err := statefile.Read(f)
+		actual, err := statefile.Read(f, encryption.StateEncryptionDisabled())
 		f.Close()
 		if err != nil {
 			t.Fatalf("err: %s", err)
@@ -1725,6 +1765,7 @@ func TestMetaBackend_planLocalMatch(t *testing.T) {
 	backendConfigBlock := cty.ObjectVal(map[string]cty.Value{
 		"path":          cty.NullVal(cty.String),
 		"workspace_dir": cty.NullVal(cty.String),
+		"history_limit": cty.NullVal(cty.Number),
 	})
 	backendConfigRaw, err := plans.NewDynamicValue(backendConfigBlock, backendConfigBlock.Type())
 	if err != nil {
@@ -1787,7 +1828,7 @@ func TestMetaBackend_planLocalMatch(t *testing.T) {
 		if err != nil {
 			t.Fatalf("err: %s", err)
 		}
-		actual, err := statefile.Read(f)
+		actual, err := statefile.Read(f, encryption.StateEncryptionDisabled())
 		f.Close()
 		if err != nil {
 			t.Fatalf("err: %s", err)
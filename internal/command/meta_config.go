@@ -179,11 +179,15 @@ func (m *Meta) loadHCLFile(filename string) (hcl.Body, tfdiags.Diagnostics) {
 // installModules reads a root module from the given directory and attempts
 // recursively to install all of its descendent modules.
 //
+// Remote module packages are verified against the module locks in the
+// dependency lock file, which is then updated to lock any newly-installed
+// packages unless flagLockfile is "readonly".
+//
 // The given hooks object will be notified of installation progress, which
 // can then be relayed to the end-user. The uiModuleInstallHooks type in
 // this package has a reasonable implementation for displaying notifications
 // via a provided cli.Ui.
-func (m *Meta) installModules(ctx context.Context, rootDir, testsDir string, upgrade, installErrsOnly bool, hooks initwd.ModuleInstallHooks) (abort bool, diags tfdiags.Diagnostics) {
+func (m *Meta) installModules(ctx context.Context, rootDir, testsDir string, upgrade, installErrsOnly bool, flagLockfile string, hooks initwd.ModuleInstallHooks) (abort bool, diags tfdiags.Diagnostics) {
 	ctx, span := tracer.Start(ctx, "install modules")
 	defer span.End()
 
@@ -201,9 +205,20 @@ func (m *Meta) installModules(ctx context.Context, rootDir, testsDir string, upg
 		return true, diags
 	}
 
+	previousLocks, moreDiags := m.lockedDependencies()
+	diags = diags.Append(moreDiags)
+	if moreDiags.HasErrors() {
+		return true, diags
+	}
+	newLocks := previousLocks.DeepCopy()
+
 	inst := initwd.NewModuleInstaller(m.modulesDir(), loader, m.registryClient())
+	inst.SetDependencyLocks(newLocks)
+	if m.ModuleCacheDir != "" {
+		inst.SetGlobalCacheDir(m.ModuleCacheDir)
+	}
 
-	_, moreDiags := inst.InstallModules(ctx, rootDir, testsDir, upgrade, installErrsOnly, hooks)
+	_, moreDiags = inst.InstallModules(ctx, rootDir, testsDir, upgrade, installErrsOnly, hooks)
 	diags = diags.Append(moreDiags)
 
 	if ctx.Err() == context.Canceled {
@@ -212,6 +227,11 @@ func (m *Meta) installModules(ctx context.Context, rootDir, testsDir string, upg
 		return true, diags
 	}
 
+	if !diags.HasErrors() {
+		moreDiags = m.updateModuleLocks(previousLocks, newLocks, flagLockfile)
+		diags = diags.Append(moreDiags)
+	}
+
 	return false, diags
 }
 
@@ -360,6 +380,7 @@ func (m *Meta) initConfigLoader() (*configload.Loader, error) {
 			return nil, err
 		}
 		loader.AllowLanguageExperiments(m.AllowExperimentalFeatures)
+		loader.SetStaticInputs(m.staticInputs())
 		m.configLoader = loader
 		if m.View != nil {
 			m.View.SetConfigSources(loader.Sources)
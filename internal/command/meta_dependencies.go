@@ -4,8 +4,11 @@
 package command
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/opentofu/opentofu/internal/depsfile"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -66,6 +69,52 @@ func (m *Meta) replaceLockedDependencies(new *depsfile.Locks) tfdiags.Diagnostic
 	return depsfile.SaveLocksToFile(new, dependencyLockFilename)
 }
 
+// updateModuleLocks saves the given new locks to the lock file in the current
+// working directory if their module locks differ from those in the given
+// previous locks, which must be the locks that were loaded from the lock file
+// before installing modules.
+//
+// If flagLockfile is "readonly" then the lock file is never updated. Instead,
+// it's an error if any module that was already locked now has a different
+// checksum, and just a warning if there are any other changes, such as locks
+// for newly-added modules.
+func (m *Meta) updateModuleLocks(previousLocks, newLocks *depsfile.Locks, flagLockfile string) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	if newLocks.Equal(previousLocks) {
+		return diags
+	}
+
+	if flagLockfile == "readonly" {
+		var changed []string
+		for key, newLock := range newLocks.AllModules() {
+			if previousLock := previousLocks.Module(key); previousLock != nil && previousLock.Hash() != newLock.Hash() {
+				changed = append(changed, "module."+strings.ReplaceAll(key, ".", ".module."))
+			}
+		}
+		if len(changed) != 0 {
+			sort.Strings(changed)
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				`Module dependency changes detected`,
+				fmt.Sprintf(
+					"The checksums of the packages installed for the following modules don't match the dependency lock file, but the lock file is read-only:\n  - %s\n\nTo use and record these packages, run \"tofu init\" without the \"-lockfile=readonly\" flag.",
+					strings.Join(changed, "\n  - "),
+				),
+			))
+			return diags
+		}
+
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Warning,
+			`Module lock file not updated`,
+			`Changes to the module packages were detected, but not saved in the .terraform.lock.hcl file. To record these packages, run "tofu init" without the "-lockfile=readonly" flag.`,
+		))
+		return diags
+	}
+
+	return m.replaceLockedDependencies(newLocks)
+}
+
 // annotateDependencyLocksWithOverrides modifies the given Locks object in-place
 // to track as overridden any provider address that's subject to testing
 // overrides, development overrides, or "unmanaged provider" status.
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// Encryption returns the encryption to use for state and plan files in the
+// current working directory.
+//
+// The result combines the "encryption" block in the root module, if any,
+// with any configuration given in the environment variable named by
+// encryption.ConfigEnvName, which takes precedence. It is initialized on
+// first use and then reused for the remainder of the command.
+func (m *Meta) Encryption() (encryption.Encryption, tfdiags.Diagnostics) {
+	if m.encryption != nil {
+		return m.encryption, nil
+	}
+
+	var diags tfdiags.Diagnostics
+
+	var cfg *encryption.Config
+	if m.dirIsConfigPath(".") {
+		mod, moreDiags := m.loadSingleModule(".")
+		// Only return error diagnostics at this point. Any warnings will be
+		// caught again later and duplicated in the output.
+		if moreDiags.HasErrors() {
+			diags = diags.Append(moreDiags)
+			return nil, diags
+		}
+		cfg = mod.Encryption
+	}
+
+	envCfg, hclDiags := encryption.ConfigFromEnv()
+	diags = diags.Append(hclDiags)
+	if hclDiags.HasErrors() {
+		return nil, diags
+	}
+
+	enc, hclDiags := encryption.New(encryption.MergeConfigs(cfg, envCfg))
+	diags = diags.Append(hclDiags)
+	if hclDiags.HasErrors() {
+		return nil, diags
+	}
+
+	m.encryption = enc
+	return enc, diags
+}
@@ -46,5 +46,10 @@ func (m *Meta) PlanFile(path string) (*planfile.WrappedPlanFile, error) {
 		return nil, nil
 	}
 
-	return planfile.OpenWrapped(path)
+	enc, diags := m.Encryption()
+	if diags.HasErrors() {
+		return nil, diags.Err()
+	}
+
+	return planfile.OpenWrapped(path, enc.Plan())
 }
@@ -16,6 +16,7 @@ import (
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	terraformProvider "github.com/opentofu/opentofu/internal/builtin/providers/tf"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/getproviders"
 	"github.com/opentofu/opentofu/internal/logging"
 	tfplugin "github.com/opentofu/opentofu/internal/plugin"
@@ -336,9 +337,20 @@ func (m *Meta) providerFactories() (map[addrs.Provider]providers.Factory, error)
 }
 
 func (m *Meta) internalProviders() map[string]providers.Factory {
+	// The terraform_remote_state data source can read state encrypted with
+	// the methods configured for this configuration's own state.
+	enc, encDiags := m.Encryption()
+	if encDiags.HasErrors() {
+		// Any problems with the encryption configuration are reported when
+		// the backend is initialized, so we don't report them again here.
+		log.Printf("[WARN] Failed to load encryption configuration for the terraform provider (ignored): %s", encDiags.Err().Error())
+		enc = encryption.Disabled()
+	}
+	stateEnc := enc.RemoteState()
+
 	return map[string]providers.Factory{
 		"terraform": func() (providers.Interface, error) {
-			return terraformProvider.NewProvider(), nil
+			return terraformProvider.NewProviderWithStateEncryption(stateEnc), nil
 		},
 	}
 }
@@ -7,12 +7,15 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	hcljson "github.com/hashicorp/hcl/v2/json"
+	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/backend"
+	"github.com/opentofu/opentofu/internal/command/arguments"
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/opentofu/opentofu/internal/tofu"
@@ -133,6 +136,51 @@ func (m *Meta) collectVariableValues() (map[string]backend.UnparsedVariableValue
 	return ret, diags
 }
 
+// setVariableArgs records the -var and -var-file options parsed by the
+// arguments package, for use by collectVariableValues.
+func (m *Meta) setVariableArgs(args *arguments.Vars) {
+	varArgs := args.All()
+	items := make([]rawFlag, len(varArgs))
+	for i := range varArgs {
+		items[i].Name = varArgs[i].Name
+		items[i].Value = varArgs[i].Value
+	}
+	m.variableArgs = rawFlags{items: &items}
+}
+
+// staticInputs returns the values given for the root module's input
+// variables on the command line, in variable definitions files and in the
+// environment, for static evaluation of the parts of the configuration that
+// must be known before planning, such as the backend configuration.
+func (m *Meta) staticInputs() configs.StaticInputs {
+	var once sync.Once
+	var values map[string]backend.UnparsedVariableValue
+	var diags tfdiags.Diagnostics
+
+	return func(v *configs.Variable) (cty.Value, hcl.Diagnostics) {
+		var collectDiags hcl.Diagnostics
+		once.Do(func() {
+			values, diags = m.collectVariableValues()
+			// Problems collecting the values are reported only once, rather
+			// than for every variable.
+			collectDiags = diags.ToHCL()
+		})
+		if diags.HasErrors() {
+			return cty.NilVal, collectDiags
+		}
+
+		raw, exists := values[v.Name]
+		if !exists {
+			return cty.NilVal, nil
+		}
+		val, valDiags := raw.ParseVariableValue(v.ParsingMode)
+		if valDiags.HasErrors() {
+			return cty.NilVal, valDiags.ToHCL()
+		}
+		return val.Value, nil
+	}
+}
+
 func (m *Meta) addVarsFromFile(filename string, sourceType tofu.ValueSourceType, to map[string]backend.UnparsedVariableValue) tfdiags.Diagnostics {
 	var diags tfdiags.Diagnostics
 
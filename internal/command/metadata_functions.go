@@ -10,6 +10,9 @@ import (
 
 	"github.com/opentofu/opentofu/internal/command/jsonfunction"
 	"github.com/opentofu/opentofu/internal/lang"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/opentofu/opentofu/internal/tofu"
 )
 
 var (
@@ -59,6 +62,22 @@ func (c *MetadataFunctionsCommand) Run(args []string) int {
 		filteredFuncs[k] = v
 	}
 
+	// If we're running in a configuration directory then we'll also include
+	// the functions contributed by the providers it requires.
+	if c.dirIsConfigPath(".") {
+		providerFuncs, diags := c.providerFunctions(".")
+		if diags.HasErrors() {
+			c.showDiagnostics(tfdiags.Diagnostics(nil).Append(tfdiags.Sourceless(
+				tfdiags.Warning,
+				"Provider functions are unavailable",
+				fmt.Sprintf("The functions contributed by providers could not be loaded, so only the built-in functions are included: %s. Run \"tofu init\" to install the providers this configuration requires.", diags.Err()),
+			)))
+		}
+		for k, v := range providerFuncs {
+			filteredFuncs[k] = v
+		}
+	}
+
 	jsonFunctions, marshalDiags := jsonfunction.Marshal(filteredFuncs)
 	if marshalDiags.HasErrors() {
 		c.showDiagnostics(marshalDiags)
@@ -69,10 +88,54 @@ func (c *MetadataFunctionsCommand) Run(args []string) int {
 	return 0
 }
 
+// providerFunctions returns the functions contributed by the providers
+// required by the root module in the given directory, using the names by
+// which they can be called from that module.
+func (c *MetadataFunctionsCommand) providerFunctions(dir string) (map[string]function.Function, tfdiags.Diagnostics) {
+	config, diags := c.loadConfig(dir)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	reqs := config.Module.ProviderRequirements
+	if reqs == nil || len(reqs.RequiredProviders) == 0 {
+		return nil, diags
+	}
+
+	opts, err := c.contextOpts()
+	if err != nil {
+		return nil, diags.Append(err)
+	}
+	tfCtx, ctxDiags := tofu.NewContext(opts)
+	diags = diags.Append(ctxDiags)
+	if ctxDiags.HasErrors() {
+		return nil, diags
+	}
+
+	schemas, schemaDiags := tfCtx.Schemas(config, nil)
+	diags = diags.Append(schemaDiags)
+	if schemaDiags.HasErrors() {
+		return nil, diags
+	}
+
+	ret := make(map[string]function.Function)
+	for localName, req := range reqs.RequiredProviders {
+		for name, spec := range schemas.ProviderSchema(req.Type).Functions {
+			ret[lang.ProviderFunctionName(localName, name)] = spec.BuildFunction(name, func() (providers.Interface, error) {
+				return nil, fmt.Errorf("provider functions cannot be called while listing function signatures")
+			})
+		}
+	}
+	return ret, diags
+}
+
 const metadataFunctionsCommandHelp = `
 Usage: tofu [global options] metadata functions -json
 
   Prints out a json representation of the available function signatures.
+
+  When run in a directory containing an initialized configuration, the
+  functions contributed by the providers that configuration requires are
+  included too, named as provider::<provider local name>::<function name>.
 `
 
 func isIgnoredFunction(name string) bool {
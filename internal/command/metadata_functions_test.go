@@ -8,6 +8,9 @@ import (
 	"testing"
 
 	"github.com/mitchellh/cli"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/providers"
 )
 
 func TestMetadataFunctions_error(t *testing.T) {
@@ -68,6 +71,59 @@ func TestMetadataFunctions_output(t *testing.T) {
 	}
 }
 
+func TestMetadataFunctions_providerFunctions(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("metadata-functions-provider"), td)
+	defer testChdir(t, td)()
+
+	p := testProvider()
+	p.GetProviderSchemaResponse = &providers.GetProviderSchemaResponse{
+		Functions: map[string]providers.FunctionSpec{
+			"echo": {
+				Parameters: []providers.FunctionParameterSpec{
+					{Name: "input", Type: cty.String},
+				},
+				Return:  cty.String,
+				Summary: "Returns its argument.",
+			},
+		},
+	}
+
+	ui := new(cli.MockUi)
+	c := &MetadataFunctionsCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			Ui:               ui,
+		},
+	}
+
+	if code := c.Run([]string{"-json"}); code != 0 {
+		t.Fatalf("wrong exit status %d; want 0\nstderr: %s", code, ui.ErrorWriter.String())
+	}
+	if stderr := ui.ErrorWriter.String(); stderr != "" {
+		t.Fatalf("expected empty stderr, got:\n%s", stderr)
+	}
+
+	var got functions
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	gotEcho, ok := got.Signatures["provider::test::echo"]
+	if !ok {
+		t.Fatal(`missing function signature for "provider::test::echo"`)
+	}
+	wantEcho := `{"description":"Returns its argument.","return_type":"string","parameters":[{"name":"input","type":"string"}]}`
+	if string(gotEcho) != wantEcho {
+		t.Fatalf("wrong function signature for \"provider::test::echo\":\ngot: %q\nwant: %q", gotEcho, wantEcho)
+	}
+
+	// the built-in functions must still be present
+	if _, ok := got.Signatures["max"]; !ok {
+		t.Fatal(`missing function signature for "max"`)
+	}
+}
+
 type functions struct {
 	FormatVersion string                     `json:"format_version"`
 	Signatures    map[string]json.RawMessage `json:"function_signatures,omitempty"`
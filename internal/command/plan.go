@@ -66,6 +66,10 @@ func (c *PlanCommand) Run(rawArgs []string) int {
 
 	diags = diags.Append(c.providerDevOverrideRuntimeWarnings())
 
+	// The backend configuration may refer to input variables, so their
+	// values must be available before the backend is prepared.
+	c.Meta.setVariableArgs(args.Vars)
+
 	// Prepare the backend with the backend-specific arguments
 	be, beDiags := c.PrepareBackend(args.State, args.ViewType)
 	diags = diags.Append(beDiags)
@@ -157,7 +161,9 @@ func (c *PlanCommand) OperationRequest(
 	opReq.PlanOutPath = planOutPath
 	opReq.GenerateConfigOut = generateConfigOut
 	opReq.Targets = args.Targets
+	opReq.Excludes = args.Excludes
 	opReq.ForceReplace = args.ForceReplace
+	opReq.AllowDeferral = args.AllowDeferral
 	opReq.Type = backend.OperationTypePlan
 	opReq.View = view.Operation()
 
@@ -181,13 +187,7 @@ func (c *PlanCommand) GatherVariables(opReq *backend.Operation, args *arguments.
 	// structure, we could move the variable gathering code to the arguments
 	// package directly, removing this shim layer.
 
-	varArgs := args.All()
-	items := make([]rawFlag, len(varArgs))
-	for i := range varArgs {
-		items[i].Name = varArgs[i].Name
-		items[i].Value = varArgs[i].Value
-	}
-	c.Meta.variableArgs = rawFlags{items: &items}
+	c.Meta.setVariableArgs(args)
 	opReq.Variables, diags = c.collectVariableValues()
 
 	return diags
@@ -210,10 +210,23 @@ Plan Customization Options:
   can also use these options when you run "tofu apply" without passing
   it a saved plan, in order to plan and apply in a single command.
 
+  -allow-deferral     Defer the changes for resources whose count or for_each
+                      value isn't known yet to a later plan, along with the
+                      changes for everything that depends on them, instead
+                      of failing. Run the plan again after applying to
+                      converge the deferred resources.
+
   -destroy            Select the "destroy" planning mode, which creates a plan
                       to destroy all objects currently managed by this
                       OpenTofu configuration instead of the usual behavior.
 
+  -exclude=resource   Limit the planning operation to not operate on the given
+                      module, resource, or resource instance and all of the
+                      resources and modules that depend on it. You can use
+                      this option multiple times to exclude more than one
+                      object. This is for exceptional use only, and can't be
+                      used together with -target.
+
   -refresh-only       Select the "refresh only" planning mode, which checks
                       whether remote objects still match the outcome of the
                       most recent OpenTofu apply but does not propose any
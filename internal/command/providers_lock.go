@@ -237,7 +237,11 @@ func (c *ProvidersLockCommand) Run(args []string) int {
 				if keyID != "" {
 					keyID = c.Colorize().Color(fmt.Sprintf(", key ID [reset][bold]%s[reset]", keyID))
 				}
-				c.Ui.Output(fmt.Sprintf("- Retrieved %s %s for %s (%s%s)", provider.ForDisplay(), version, platform, auth, keyID))
+				var identity string
+				if auth != nil && auth.Identity != "" {
+					identity = c.Colorize().Color(fmt.Sprintf(", identity [reset][bold]%s[reset]", auth.Identity))
+				}
+				c.Ui.Output(fmt.Sprintf("- Retrieved %s %s for %s (%s%s%s)", provider.ForDisplay(), version, platform, auth, keyID, identity))
 			},
 		}
 		ctx := evts.OnContext(ctx)
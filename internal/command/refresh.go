@@ -66,6 +66,10 @@ func (c *RefreshCommand) Run(rawArgs []string) int {
 	// object state for now.
 	c.Meta.parallelism = args.Operation.Parallelism
 
+	// The backend configuration may refer to input variables, so their
+	// values must be available before the backend is prepared.
+	c.Meta.setVariableArgs(args.Vars)
+
 	// Prepare the backend with the backend-specific arguments
 	be, beDiags := c.PrepareBackend(args.State, args.ViewType)
 	diags = diags.Append(beDiags)
@@ -144,6 +148,7 @@ func (c *RefreshCommand) OperationRequest(be backend.Enhanced, view views.Refres
 	opReq.ConfigDir = "."
 	opReq.Hooks = view.Hooks()
 	opReq.Targets = args.Targets
+	opReq.Excludes = args.Excludes
 	opReq.Type = backend.OperationTypeRefresh
 	opReq.View = view.Operation()
 
@@ -167,13 +172,7 @@ func (c *RefreshCommand) GatherVariables(opReq *backend.Operation, args *argumen
 	// structure, we could move the variable gathering code to the arguments
 	// package directly, removing this shim layer.
 
-	varArgs := args.All()
-	items := make([]rawFlag, len(varArgs))
-	for i := range varArgs {
-		items[i].Name = varArgs[i].Name
-		items[i].Value = varArgs[i].Value
-	}
-	c.Meta.variableArgs = rawFlags{items: &items}
+	c.Meta.setVariableArgs(args)
 	opReq.Variables, diags = c.collectVariableValues()
 
 	return diags
@@ -196,6 +195,11 @@ Options:
                       accompanied by errors, show them in a more compact form
                       that includes only the summary messages.
 
+  -exclude=resource   Resource to exclude. Operation will be limited to all
+                      resources that are not excluded and do not depend on
+                      an excluded resource. This flag can be used multiple
+                      times.
+
   -input=true         Ask for input for variables if not directly set.
 
   -lock=false         Don't hold a state lock during the operation. This is
@@ -16,6 +16,7 @@ import (
 	"github.com/opentofu/opentofu/internal/command/arguments"
 	"github.com/opentofu/opentofu/internal/command/views"
 	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/plans/planfile"
 	"github.com/opentofu/opentofu/internal/states/statefile"
@@ -182,13 +183,19 @@ func (c *ShowCommand) showFromPath(path string) (*plans.Plan, *cloudplan.RemoteP
 	var stateFile *statefile.File
 	var config *configs.Config
 
+	enc, encDiags := c.Encryption()
+	diags = diags.Append(encDiags)
+	if encDiags.HasErrors() {
+		return nil, nil, nil, nil, diags
+	}
+
 	// Path might be a local plan file, a bookmark to a saved cloud plan, or a
 	// state file. First, try to get a plan and associated data from a local
 	// plan file. If that fails, try to get a json plan from the path argument.
 	// If that fails, try to get the statefile from the path argument.
-	plan, jsonPlan, stateFile, config, planErr = c.getPlanFromPath(path)
+	plan, jsonPlan, stateFile, config, planErr = c.getPlanFromPath(path, enc.Plan())
 	if planErr != nil {
-		stateFile, stateErr = getStateFromPath(path)
+		stateFile, stateErr = getStateFromPath(path, enc.State())
 		if stateErr != nil {
 			// To avoid spamming the user with irrelevant errors, first check to
 			// see if one of our errors happens to know for a fact what file
@@ -254,14 +261,14 @@ func (c *ShowCommand) showFromPath(path string) (*plans.Plan, *cloudplan.RemoteP
 // yield a json plan, and cloud plans do not yield real plan/state/config
 // structs. An error generally suggests that the given path is either a
 // directory or a statefile.
-func (c *ShowCommand) getPlanFromPath(path string) (*plans.Plan, *cloudplan.RemotePlanJSON, *statefile.File, *configs.Config, error) {
+func (c *ShowCommand) getPlanFromPath(path string, enc encryption.PlanEncryption) (*plans.Plan, *cloudplan.RemotePlanJSON, *statefile.File, *configs.Config, error) {
 	var err error
 	var plan *plans.Plan
 	var jsonPlan *cloudplan.RemotePlanJSON
 	var stateFile *statefile.File
 	var config *configs.Config
 
-	pf, err := planfile.OpenWrapped(path)
+	pf, err := planfile.OpenWrapped(path, enc)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
@@ -319,7 +326,7 @@ func getDataFromPlanfileReader(planReader *planfile.Reader) (*plans.Plan, *state
 }
 
 // getStateFromPath returns a statefile if the user-supplied path points to a statefile.
-func getStateFromPath(path string) (*statefile.File, error) {
+func getStateFromPath(path string, enc encryption.StateEncryption) (*statefile.File, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("Error loading statefile: %w", err)
@@ -327,7 +334,7 @@ func getStateFromPath(path string) (*statefile.File, error) {
 	defer file.Close()
 
 	var stateFile *statefile.File
-	stateFile, err = statefile.Read(file)
+	stateFile, err = statefile.Read(file, enc)
 	if err != nil {
 		return nil, fmt.Errorf("Error reading %s as a statefile: %w", path, err)
 	}
@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+// StateHistoryCommand is a Command implementation that lists the prior
+// state snapshots kept by the local backend.
+type StateHistoryCommand struct {
+	StateMeta
+}
+
+func (c *StateHistoryCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	cmdFlags := c.Meta.defaultFlagSet("state history")
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+	if len(cmdFlags.Args()) != 0 {
+		c.Ui.Error("The state history command expects no arguments.")
+		return 1
+	}
+
+	stateMgr, err := c.State()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateLoadingState, err))
+		return 1
+	}
+	fs, ok := stateMgr.(*statemgr.Filesystem)
+	if !ok || fs.HistoryDir() == "" {
+		c.Ui.Error(errStateHistoryUnavailable)
+		return 1
+	}
+
+	if err := stateMgr.RefreshState(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to load state: %s", err))
+		return 1
+	}
+
+	snapshots, err := fs.History()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to read state history: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("%-8s  %-20s  %-9s  %s", "Serial", "Replaced", "Resources", "Lineage"))
+	if state := stateMgr.State(); state != nil {
+		meta := fs.StateSnapshotMeta()
+		c.Ui.Output(fmt.Sprintf("%-8d  %-20s  %-9d  %s", meta.Serial, "(current)", stateResourceInstanceCount(state), meta.Lineage))
+	}
+	for _, snap := range snapshots {
+		c.Ui.Output(fmt.Sprintf("%-8d  %-20s  %-9d  %s", snap.Serial, snap.Time.Format(time.RFC3339), stateResourceInstanceCount(snap.File.State), snap.File.Lineage))
+	}
+
+	return 0
+}
+
+// stateResourceInstanceCount returns the number of resource instances with
+// a current object in the given state.
+func stateResourceInstanceCount(state *states.State) int {
+	if state == nil {
+		return 0
+	}
+	count := 0
+	for _, ms := range state.Modules {
+		for _, rs := range ms.Resources {
+			for _, is := range rs.Instances {
+				if is.HasCurrent() {
+					count++
+				}
+			}
+		}
+	}
+	return count
+}
+
+func (c *StateHistoryCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] state history [options]
+
+  List the prior versions of the state kept by the local backend.
+
+  The local backend keeps prior state snapshots only when its
+  "history_limit" argument is set. Each time an operation changes the
+  state, the snapshot it replaces is added to the history, and the oldest
+  snapshots are removed once there are more than "history_limit" of them.
+
+  Use "tofu state rollback" to restore one of the listed snapshots.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateHistoryCommand) Synopsis() string {
+	return "List prior versions of the local state"
+}
+
+const errStateHistoryUnavailable = `No state history is available.
+
+State history is only kept by the local backend, when its "history_limit"
+argument is set to the number of prior state snapshots to keep.`
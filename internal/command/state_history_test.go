@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/states/statefile"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+func TestStateHistory(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("state-history"), td)
+	defer testChdir(t, td)()
+
+	testStateHistoryWrite(t, 1, 2, 3)
+
+	ui := cli.NewMockUi()
+	c := &StateHistoryCommand{
+		StateMeta{
+			Meta: Meta{
+				testingOverrides: metaOverridesForProvider(testProvider()),
+				Ui:               ui,
+			},
+		},
+	}
+	if code := c.Run(nil); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(ui.OutputWriter.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected a header, the current state and two snapshots, got:\n%s", ui.OutputWriter.String())
+	}
+	for i, want := range []string{"3         (current)", "2 ", "1 "} {
+		if !strings.HasPrefix(lines[i+1], want) {
+			t.Errorf("line %d is %q, expected it to start with %q", i+1, lines[i+1], want)
+		}
+	}
+	if !strings.Contains(lines[1], " 3          fake-for-testing") {
+		t.Errorf("current state line should report 3 resources: %q", lines[1])
+	}
+}
+
+func TestStateHistory_unavailable(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("backend-unchanged"), td)
+	defer testChdir(t, td)()
+
+	ui := cli.NewMockUi()
+	c := &StateHistoryCommand{
+		StateMeta{
+			Meta: Meta{
+				testingOverrides: metaOverridesForProvider(testProvider()),
+				Ui:               ui,
+			},
+		},
+	}
+	if code := c.Run(nil); code != 1 {
+		t.Fatalf("expected error, got %d\n\n%s", code, ui.OutputWriter.String())
+	}
+	if got, want := ui.ErrorWriter.String(), "No state history is available."; !strings.Contains(got, want) {
+		t.Fatalf("expected error containing %q, got:\n%s", want, got)
+	}
+}
+
+// testStateHistoryWrite writes a sequence of state snapshots to the state
+// of the "state-history" fixture, one per session, so that each snapshot
+// other than the last is kept in the history. The snapshot for each count
+// has that many test_instance.foo instances.
+func testStateHistoryWrite(t *testing.T, counts ...int) {
+	t.Helper()
+
+	for i, count := range counts {
+		mgr := statemgr.NewFilesystem("local-state.tfstate", encryption.StateEncryptionDisabled())
+		mgr.SetHistory("local-state.tfstate.history", 2)
+		if err := mgr.RefreshState(); err != nil {
+			t.Fatal(err)
+		}
+
+		state := states.BuildState(func(s *states.SyncState) {
+			for j := 0; j < count; j++ {
+				s.SetResourceInstanceCurrent(
+					addrs.Resource{
+						Mode: addrs.ManagedResourceMode,
+						Type: "test_instance",
+						Name: "foo",
+					}.Instance(addrs.IntKey(j)).Absolute(addrs.RootModuleInstance),
+					&states.ResourceInstanceObjectSrc{
+						AttrsJSON: []byte(`{"id":"bar"}`),
+						Status:    states.ObjectReady,
+					},
+					addrs.AbsProviderConfig{
+						Provider: addrs.NewDefaultProvider("test"),
+						Module:   addrs.RootModule,
+					},
+				)
+			}
+		})
+		if i == 0 {
+			if err := mgr.WriteStateForMigration(&statefile.File{
+				Lineage: "fake-for-testing",
+				Serial:  1,
+				State:   state,
+			}, true); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		if err := mgr.WriteState(state); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
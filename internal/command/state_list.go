@@ -4,12 +4,14 @@
 package command
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/mitchellh/cli"
 
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/command/jsonstate"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
@@ -24,8 +26,10 @@ type StateListCommand struct {
 func (c *StateListCommand) Run(args []string) int {
 	args = c.Meta.process(args)
 	var statePath string
+	var jsonOutput bool
 	cmdFlags := c.Meta.defaultFlagSet("state list")
 	cmdFlags.StringVar(&statePath, "state", "", "path")
+	cmdFlags.BoolVar(&jsonOutput, "json", false, "json")
 	lookupId := cmdFlags.String("id", "", "Restrict output to paths with a resource having the specified ID.")
 	if err := cmdFlags.Parse(args); err != nil {
 		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
@@ -81,14 +85,37 @@ func (c *StateListCommand) Run(args []string) int {
 		return 1
 	}
 
+	output := stateListJSON{
+		FormatVersion: jsonstate.FormatVersion,
+		Resources:     []jsonstate.Resource{},
+	}
 	for _, addr := range addrs {
 		if is := state.ResourceInstance(addr); is != nil {
 			if *lookupId == "" || *lookupId == states.LegacyInstanceObjectID(is.Current) {
-				c.Ui.Output(addr.String())
+				if !jsonOutput {
+					c.Ui.Output(addr.String())
+					continue
+				}
+
+				r, err := jsonstate.MarshalResourceInstanceSummary(state, addr)
+				if err != nil {
+					c.Ui.Error(fmt.Sprintf("Failed to marshal state to json: %s", err))
+					return 1
+				}
+				output.Resources = append(output.Resources, r)
 			}
 		}
 	}
 
+	if jsonOutput {
+		jsonOut, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to marshal state to json: %s", err))
+			return 1
+		}
+		c.Ui.Output(string(jsonOut))
+	}
+
 	c.showDiagnostics(diags)
 
 	return 0
@@ -125,6 +152,11 @@ Options:
                       resource types have an attribute named "id" whose value
                       equals the given id string.
 
+  -json               Produce output in a machine-readable JSON format,
+                      describing the address, mode, type, name, index and
+                      provider of each resource instance. The filters apply
+                      in the same way as for the human-readable output.
+
 `
 	return strings.TrimSpace(helpText)
 }
@@ -133,6 +165,12 @@ func (c *StateListCommand) Synopsis() string {
 	return "List resources in the state"
 }
 
+// stateListJSON is the JSON output of "tofu state list -json".
+type stateListJSON struct {
+	FormatVersion string               `json:"format_version"`
+	Resources     []jsonstate.Resource `json:"resources"`
+}
+
 const errStateLoadingState = `Error loading the state: %[1]s
 
 Please ensure that your OpenTofu state exists and that you've
@@ -4,10 +4,15 @@
 package command
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/states"
 )
 
 func TestStateList(t *testing.T) {
@@ -268,6 +273,157 @@ func TestStateList_modules(t *testing.T) {
 
 }
 
+func TestStateList_json(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("state-list-nested-modules"), td)
+	defer testChdir(t, td)()
+
+	p := testProvider()
+	ui := cli.NewMockUi()
+	c := &StateListCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			Ui:               ui,
+		},
+	}
+
+	args := []string{"-json", "module.count"}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &got); err != nil {
+		t.Fatalf("invalid JSON output: %s\n%s", err, ui.OutputWriter.String())
+	}
+	want := map[string]interface{}{
+		"format_version": "1.0",
+		"resources": []interface{}{
+			map[string]interface{}{
+				"address":        "module.count[0].test_instance.count",
+				"mode":           "managed",
+				"type":           "test_instance",
+				"name":           "count",
+				"provider_name":  "registry.opentofu.org/hashicorp/test",
+				"schema_version": float64(0),
+			},
+			map[string]interface{}{
+				"address":        "module.count[1].test_instance.count",
+				"mode":           "managed",
+				"type":           "test_instance",
+				"name":           "count",
+				"provider_name":  "registry.opentofu.org/hashicorp/test",
+				"schema_version": float64(0),
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("wrong output\n%s", diff)
+	}
+}
+
+func TestStateList_jsonDeposedOnly(t *testing.T) {
+	state := states.BuildState(func(s *states.SyncState) {
+		s.SetResourceInstanceDeposed(
+			addrs.Resource{
+				Mode: addrs.ManagedResourceMode,
+				Type: "test_instance",
+				Name: "foo",
+			}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance),
+			states.NewDeposedKey(),
+			&states.ResourceInstanceObjectSrc{
+				AttrsJSON:     []byte(`{"id":"bar"}`),
+				Status:        states.ObjectReady,
+				SchemaVersion: 1,
+			},
+			addrs.AbsProviderConfig{
+				Provider: addrs.NewDefaultProvider("test"),
+				Module:   addrs.RootModule,
+			},
+		)
+	})
+	statePath := testStateFile(t, state)
+
+	p := testProvider()
+	ui := cli.NewMockUi()
+	c := &StateListCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			Ui:               ui,
+		},
+	}
+
+	args := []string{"-state", statePath}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if got, want := ui.OutputWriter.String(), "test_instance.foo\n"; got != want {
+		t.Fatalf("wrong output\ngot:  %q\nwant: %q", got, want)
+	}
+
+	ui = cli.NewMockUi()
+	c = &StateListCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			Ui:               ui,
+		},
+	}
+	args = []string{"-state", statePath, "-json"}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(ui.OutputWriter.String()), &got); err != nil {
+		t.Fatalf("invalid JSON output: %s\n%s", err, ui.OutputWriter.String())
+	}
+	want := map[string]interface{}{
+		"format_version": "1.0",
+		"resources": []interface{}{
+			map[string]interface{}{
+				"address":        "test_instance.foo",
+				"mode":           "managed",
+				"type":           "test_instance",
+				"name":           "foo",
+				"provider_name":  "registry.opentofu.org/hashicorp/test",
+				"schema_version": float64(0),
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("wrong output\n%s", diff)
+	}
+}
+
+func TestStateList_jsonWithNonExistentID(t *testing.T) {
+	state := testState()
+	statePath := testStateFile(t, state)
+
+	p := testProvider()
+	ui := cli.NewMockUi()
+	c := &StateListCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			Ui:               ui,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-id", "baz",
+		"-json",
+	}
+	if code := c.Run(args); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+
+	expected := "{\n  \"format_version\": \"1.0\",\n  \"resources\": []\n}\n"
+	actual := ui.OutputWriter.String()
+	if actual != expected {
+		t.Fatalf("Expected:\n%q\n\nTo equal: %q", actual, expected)
+	}
+}
+
 const testStateListOutput = `
 test_instance.foo
 `
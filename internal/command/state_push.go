@@ -14,6 +14,7 @@ import (
 	"github.com/opentofu/opentofu/internal/command/arguments"
 	"github.com/opentofu/opentofu/internal/command/clistate"
 	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/states/statefile"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -65,8 +66,9 @@ func (c *StatePushCommand) Run(args []string) int {
 		r = f
 	}
 
-	// Read the state
-	srcStateFile, err := statefile.Read(r)
+	// Read the state, which is expected to be unencrypted as produced by
+	// "tofu state pull".
+	srcStateFile, err := statefile.Read(r, encryption.StateEncryptionDisabled())
 	if c, ok := r.(io.Closer); ok {
 		// Close the reader if possible right now since we're done with it.
 		c.Close()
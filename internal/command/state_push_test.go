@@ -11,6 +11,7 @@ import (
 	"github.com/mitchellh/cli"
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/backend/remote-state/inmem"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/states"
 )
 
@@ -262,7 +263,7 @@ func TestStatePush_forceRemoteState(t *testing.T) {
 	}
 
 	// put a dummy state in place, so we have something to force
-	b := backend.TestBackendConfig(t, inmem.New(), nil)
+	b := backend.TestBackendConfig(t, inmem.New(encryption.StateEncryptionDisabled()), nil)
 	sMgr, err := b.StateMgr("test")
 	if err != nil {
 		t.Fatal(err)
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/clistate"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+// StateRollbackCommand is a Command implementation that restores a prior
+// state snapshot kept by the local backend.
+type StateRollbackCommand struct {
+	StateMeta
+}
+
+func (c *StateRollbackCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+	var serial int64
+	cmdFlags := c.Meta.defaultFlagSet("state rollback")
+	cmdFlags.Int64Var(&serial, "serial", -1, "serial")
+	cmdFlags.BoolVar(&c.Meta.stateLock, "lock", true, "lock state")
+	cmdFlags.DurationVar(&c.Meta.stateLockTimeout, "lock-timeout", 0, "lock timeout")
+	if err := cmdFlags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing command-line flags: %s\n", err.Error()))
+		return 1
+	}
+	if len(cmdFlags.Args()) != 0 || serial < 0 {
+		c.Ui.Error("The state rollback command requires the -serial option and no arguments.\n")
+		return cli.RunResultHelp
+	}
+
+	if diags := c.Meta.checkRequiredVersion(); diags != nil {
+		c.showDiagnostics(diags)
+		return 1
+	}
+
+	stateMgr, err := c.State()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateLoadingState, err))
+		return 1
+	}
+	fs, ok := stateMgr.(*statemgr.Filesystem)
+	if !ok || fs.HistoryDir() == "" {
+		c.Ui.Error(errStateHistoryUnavailable)
+		return 1
+	}
+
+	if c.stateLock {
+		stateLocker := clistate.NewLocker(c.stateLockTimeout, views.NewStateLocker(arguments.ViewHuman, c.View))
+		if diags := stateLocker.Lock(stateMgr, "state-rollback"); diags.HasErrors() {
+			c.showDiagnostics(diags)
+			return 1
+		}
+		defer func() {
+			if diags := stateLocker.Unlock(); diags.HasErrors() {
+				c.showDiagnostics(diags)
+			}
+		}()
+	}
+
+	if err := stateMgr.RefreshState(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to refresh state: %s", err))
+		return 1
+	}
+	if stateMgr.State() == nil {
+		c.Ui.Error(errStateNotFound)
+		return 1
+	}
+	meta := fs.StateSnapshotMeta()
+
+	snapshots, err := fs.History()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to read state history: %s", err))
+		return 1
+	}
+	var snap *statemgr.HistorySnapshot
+	for _, s := range snapshots {
+		if s.Serial == uint64(serial) {
+			snap = s
+			break
+		}
+	}
+	if snap == nil {
+		c.Ui.Error(fmt.Sprintf(errStateRollbackNotFound, serial))
+		return 1
+	}
+	if snap.File.Lineage != meta.Lineage {
+		c.Ui.Error(fmt.Sprintf(errStateRollbackLineage, serial, snap.File.Lineage, meta.Lineage))
+		return 1
+	}
+
+	// The snapshot is written as a new version of the current state, so its
+	// serial continues from the current one and the version being replaced
+	// is itself kept in the history.
+	if err := stateMgr.WriteState(snap.File.State); err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateRollbackPersist, err))
+		return 1
+	}
+	if err := stateMgr.PersistState(nil); err != nil {
+		c.Ui.Error(fmt.Sprintf(errStateRollbackPersist, err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Restored the state snapshot with serial %d as serial %d.", serial, fs.StateSnapshotMeta().Serial))
+	return 0
+}
+
+func (c *StateRollbackCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] state rollback [options] -serial=N
+
+  Restore a prior version of the state kept by the local backend.
+
+  The snapshot with the given serial, as listed by "tofu state history",
+  replaces the current state. It is saved as a new version with a higher
+  serial, so the state being replaced is kept in the history and the
+  rollback can itself be undone.
+
+  This command creates a timestamped backup of the state on every
+  invocation.
+
+Options:
+
+  -serial=N           The serial of the state snapshot to restore. Required.
+
+  -lock=false         Don't hold a state lock during the operation. This is
+                      dangerous if others might concurrently run commands
+                      against the same workspace.
+
+  -lock-timeout=0s    Duration to retry a state lock.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateRollbackCommand) Synopsis() string {
+	return "Restore a prior version of the local state"
+}
+
+const errStateRollbackNotFound = `No state snapshot with serial %d in the history.
+
+Run "tofu state history" to list the available snapshots.`
+
+const errStateRollbackLineage = `Cannot restore the state snapshot with serial %d.
+
+The snapshot has lineage %q, which does not match the lineage %q of the
+current state, so it belongs to a different state.`
+
+const errStateRollbackPersist = `Error saving the state: %s
+
+The state was not saved. No items were restored. The state from before
+this command is still in place.`
@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/opentofu/opentofu/internal/encryption"
+	"github.com/opentofu/opentofu/internal/states/statemgr"
+)
+
+func TestStateRollback(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("state-history"), td)
+	defer testChdir(t, td)()
+
+	testStateHistoryWrite(t, 1, 2, 3)
+
+	ui := cli.NewMockUi()
+	c := &StateRollbackCommand{
+		StateMeta{
+			Meta: Meta{
+				testingOverrides: metaOverridesForProvider(testProvider()),
+				Ui:               ui,
+			},
+		},
+	}
+	if code := c.Run([]string{"-serial=1"}); code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, ui.ErrorWriter.String())
+	}
+	if got, want := ui.OutputWriter.String(), "Restored the state snapshot with serial 1 as serial 4."; !strings.Contains(got, want) {
+		t.Fatalf("expected output containing %q, got:\n%s", want, got)
+	}
+
+	mgr := statemgr.NewFilesystem("local-state.tfstate", encryption.StateEncryptionDisabled())
+	mgr.SetHistory("local-state.tfstate.history", 2)
+	if err := mgr.RefreshState(); err != nil {
+		t.Fatal(err)
+	}
+	if got := stateResourceInstanceCount(mgr.State()); got != 1 {
+		t.Errorf("expected the restored state to have 1 resource instance, got %d", got)
+	}
+	if got := mgr.StateSnapshotMeta().Lineage; got != "fake-for-testing" {
+		t.Errorf("wrong lineage %q", got)
+	}
+
+	// The replaced state must have been kept in the history, pushing out
+	// the snapshot that was restored.
+	snapshots, err := mgr.History()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var serials []uint64
+	for _, snap := range snapshots {
+		serials = append(serials, snap.Serial)
+	}
+	if len(serials) != 2 || serials[0] != 3 || serials[1] != 2 {
+		t.Errorf("wrong history serials %v", serials)
+	}
+}
+
+func TestStateRollback_notFound(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("state-history"), td)
+	defer testChdir(t, td)()
+
+	testStateHistoryWrite(t, 1, 2)
+
+	ui := cli.NewMockUi()
+	c := &StateRollbackCommand{
+		StateMeta{
+			Meta: Meta{
+				testingOverrides: metaOverridesForProvider(testProvider()),
+				Ui:               ui,
+			},
+		},
+	}
+	if code := c.Run([]string{"-serial=5"}); code != 1 {
+		t.Fatalf("expected error, got %d\n\n%s", code, ui.OutputWriter.String())
+	}
+	if got, want := ui.ErrorWriter.String(), "No state snapshot with serial 5 in the history."; !strings.Contains(got, want) {
+		t.Fatalf("expected error containing %q, got:\n%s", want, got)
+	}
+}
@@ -4,6 +4,7 @@
 package command
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -28,8 +29,10 @@ type StateShowCommand struct {
 
 func (c *StateShowCommand) Run(args []string) int {
 	args = c.Meta.process(args)
+	var jsonOutput bool
 	cmdFlags := c.Meta.defaultFlagSet("state show")
 	cmdFlags.StringVar(&c.Meta.statePath, "state", "", "path")
+	cmdFlags.BoolVar(&jsonOutput, "json", false, "json")
 	if err := cmdFlags.Parse(args); err != nil {
 		c.Streams.Eprintf("Error parsing command-line flags: %s\n", err.Error())
 		return 1
@@ -131,6 +134,24 @@ func (c *StateShowCommand) Run(args []string) int {
 		return 1
 	}
 
+	if jsonOutput {
+		r, err := jsonstate.MarshalResourceInstance(state, addr, schemas)
+		if err != nil {
+			c.Streams.Eprintf("Failed to marshal state to json: %s\n", err)
+			return 1
+		}
+		jsonOut, err := json.MarshalIndent(stateShowJSON{
+			FormatVersion: jsonstate.FormatVersion,
+			Resource:      r,
+		}, "", "  ")
+		if err != nil {
+			c.Streams.Eprintf("Failed to marshal state to json: %s\n", err)
+			return 1
+		}
+		c.Streams.Println(string(jsonOut))
+		return 0
+	}
+
 	// check if the resource has a configured provider, otherwise this will use the default provider
 	rs := state.Resource(addr.ContainingResource())
 	absPc := addrs.AbsProviderConfig{
@@ -184,6 +205,12 @@ Options:
                       up OpenTofu-managed resources. By default it will
                       use the state "terraform.tfstate" if it exists.
 
+  -json               Produce output in a machine-readable JSON format. The
+                      "values" property holds the attribute values of the
+                      resource instance, with sensitive values replaced by
+                      null, and "sensitive_values" marks those which are
+                      sensitive.
+
 `
 	return strings.TrimSpace(helpText)
 }
@@ -192,6 +219,12 @@ func (c *StateShowCommand) Synopsis() string {
 	return "Show a resource in the state"
 }
 
+// stateShowJSON is the JSON output of "tofu state show -json".
+type stateShowJSON struct {
+	FormatVersion string             `json:"format_version"`
+	Resource      jsonstate.Resource `json:"resource"`
+}
+
 const errNoInstanceFound = `No instance found for the given address!
 
 This command requires that the address references one specific instance.
@@ -4,9 +4,12 @@
 package command
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
 	"github.com/opentofu/opentofu/internal/providers"
@@ -76,6 +79,89 @@ func TestStateShow(t *testing.T) {
 	}
 }
 
+func TestStateShow_json(t *testing.T) {
+	state := states.BuildState(func(s *states.SyncState) {
+		s.SetResourceInstanceCurrent(
+			addrs.Resource{
+				Mode: addrs.ManagedResourceMode,
+				Type: "test_instance",
+				Name: "foo",
+			}.Instance(addrs.IntKey(1)).Absolute(addrs.RootModuleInstance),
+			&states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{"id":"bar","foo":"value","secret":"hunter2"}`),
+				Status:    states.ObjectReady,
+			},
+			addrs.AbsProviderConfig{
+				Provider: addrs.NewDefaultProvider("test"),
+				Module:   addrs.RootModule,
+			},
+		)
+	})
+	statePath := testStateFile(t, state)
+
+	p := testProvider()
+	p.GetProviderSchemaResponse = &providers.GetProviderSchemaResponse{
+		ResourceTypes: map[string]providers.Schema{
+			"test_instance": {
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"id":     {Type: cty.String, Optional: true, Computed: true},
+						"foo":    {Type: cty.String, Optional: true},
+						"secret": {Type: cty.String, Optional: true, Sensitive: true},
+					},
+				},
+			},
+		},
+	}
+
+	streams, done := terminal.StreamsForTesting(t)
+	c := &StateShowCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(p),
+			Streams:          streams,
+		},
+	}
+
+	args := []string{
+		"-state", statePath,
+		"-json",
+		"test_instance.foo[1]",
+	}
+	code := c.Run(args)
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("bad: %d\n\n%s", code, output.Stderr())
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(output.Stdout()), &got); err != nil {
+		t.Fatalf("invalid JSON output: %s\n%s", err, output.Stdout())
+	}
+	want := map[string]interface{}{
+		"format_version": "1.0",
+		"resource": map[string]interface{}{
+			"address":        "test_instance.foo[1]",
+			"mode":           "managed",
+			"type":           "test_instance",
+			"name":           "foo",
+			"index":          float64(1),
+			"provider_name":  "registry.opentofu.org/hashicorp/test",
+			"schema_version": float64(0),
+			"values": map[string]interface{}{
+				"id":     "bar",
+				"foo":    "value",
+				"secret": nil,
+			},
+			"sensitive_values": map[string]interface{}{
+				"secret": true,
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("wrong output\n%s", diff)
+	}
+}
+
 func TestStateShow_multi(t *testing.T) {
 	submod, _ := addrs.ParseModuleInstanceStr("module.sub")
 	state := states.BuildState(func(s *states.SyncState) {
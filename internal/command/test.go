@@ -7,6 +7,7 @@ import (
 	"path"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/hcl/v2"
@@ -20,6 +21,7 @@ import (
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/logging"
 	"github.com/opentofu/opentofu/internal/moduletest"
+	"github.com/opentofu/opentofu/internal/overrides"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -59,8 +61,15 @@ Options:
   -json                 If specified, machine readable output will be printed in
                         JSON format
 
+  -junit-xml=path       If specified, OpenTofu will also write a JUnit XML
+                        report of the test results to the given file.
+
   -no-color             If specified, output won't contain any color.
 
+  -parallelism=n        Limit the number of test files executed at the same
+                        time. Defaults to 1, which executes the test files one
+                        after another.
+
   -test-directory=path  Set the OpenTofu test directory, defaults to "tests". When set, the
                         test command will search for test files in the current directory and
                         in the one specified by the flag.
@@ -98,6 +107,16 @@ func (c *TestCommand) Run(rawArgs []string) int {
 	}
 
 	view := views.NewTest(args.ViewType, c.View)
+	if args.JUnitXMLFile != "" {
+		view = views.TestMulti{
+			view,
+			views.NewTestJUnitXMLFile(args.JUnitXMLFile, c.View),
+		}
+	}
+
+	// The variables given on the command line are recorded before loading
+	// the configuration, since module source addresses may refer to them.
+	c.setVariableArgs(args.Vars)
 
 	config, configDiags := c.loadConfigWithTests(".", args.TestDirectory)
 	diags = diags.Append(configDiags)
@@ -184,15 +203,6 @@ func (c *TestCommand) Run(rawArgs []string) int {
 
 	// Users can also specify variables via the command line, so we'll parse
 	// all that here.
-	var items []rawFlag
-	for _, variable := range args.Vars.All() {
-		items = append(items, rawFlag{
-			Name:  variable.Name,
-			Value: variable.Value,
-		})
-	}
-	c.variableArgs = rawFlags{items: &items}
-
 	variables, variableDiags := c.collectVariableValues()
 	diags = diags.Append(variableDiags)
 	if variableDiags.HasErrors() {
@@ -242,7 +252,8 @@ func (c *TestCommand) Run(rawArgs []string) int {
 		Cancelled: false,
 		Stopped:   false,
 
-		Verbose: args.Verbose,
+		Verbose:     args.Verbose,
+		Parallelism: args.Parallelism,
 	}
 
 	view.Abstract(&suite)
@@ -332,6 +343,10 @@ type TestSuiteRunner struct {
 
 	// Verbose tells the runner to print out plan files during each test run.
 	Verbose bool
+
+	// Parallelism is the maximum number of test files to execute at the same
+	// time.
+	Parallelism int
 }
 
 func (runner *TestSuiteRunner) Start(globals map[string]backend.UnparsedVariableValue) {
@@ -341,34 +356,141 @@ func (runner *TestSuiteRunner) Start(globals map[string]backend.UnparsedVariable
 	}
 	sort.Strings(files) // execute the files in alphabetical order
 
+	parallelism := runner.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	// The files start executing in alphabetical order, up to parallelism of
+	// them at a time. Each file buffers its output until it completes, and
+	// we render the buffered output in the same alphabetical order so that
+	// it doesn't depend on which files happen to finish first.
+	var viewLock sync.Mutex
+	fileViews := make([]*testFileView, len(files))
+	done := make([]chan struct{}, len(files))
+	for ix := range files {
+		fileViews[ix] = &testFileView{Test: runner.View, lock: &viewLock}
+		done[ix] = make(chan struct{})
+	}
+
+	queue := make(chan int)
+	go func() {
+		defer close(queue)
+		for ix := range files {
+			queue <- ix
+		}
+	}()
+	for i := 0; i < parallelism; i++ {
+		go func() {
+			defer logging.PanicHandler()
+			for ix := range queue {
+				runner.executeFile(runner.Suite.Files[files[ix]], fileViews[ix])
+				close(done[ix])
+			}
+		}()
+	}
+
 	runner.Suite.Status = moduletest.Pass
-	for _, name := range files {
+	for ix, name := range files {
+		<-done[ix]
+		fileViews[ix].flush()
+
 		if runner.Cancelled {
 			return
 		}
+		runner.Suite.Status = runner.Suite.Status.Merge(runner.Suite.Files[name].Status)
+	}
+}
 
-		file := runner.Suite.Files[name]
+func (runner *TestSuiteRunner) executeFile(file *moduletest.File, view views.Test) {
+	if runner.Cancelled {
+		return
+	}
 
-		fileRunner := &TestFileRunner{
-			Suite: runner,
-			States: map[string]*TestFileState{
-				MainStateIdentifier: {
-					Run:   nil,
-					State: states.NewState(),
-				},
+	fileRunner := &TestFileRunner{
+		Suite:  runner,
+		Config: runner.Config.CopyForTest(),
+		View:   view,
+		States: map[string]*TestFileState{
+			MainStateIdentifier: {
+				Run:   nil,
+				State: states.NewState(),
 			},
-		}
+		},
+	}
+
+	fileRunner.ExecuteTestFile(file)
+	fileRunner.Cleanup(file)
+}
+
+// testFileView buffers the output of a single test file, so that test files
+// executing at the same time are still rendered one after another.
+//
+// FatalInterruptSummary is rendered immediately, as the rendering of the
+// buffered output might never happen after a hard interrupt.
+type testFileView struct {
+	views.Test
+
+	// lock is shared between the views of all the test files, to protect the
+	// underlying view.
+	lock *sync.Mutex
+
+	pending []func()
+}
+
+func (v *testFileView) File(file *moduletest.File) {
+	v.record(func() { v.Test.File(file) })
+}
+
+func (v *testFileView) Run(run *moduletest.Run, file *moduletest.File) {
+	v.record(func() { v.Test.Run(run, file) })
+}
+
+func (v *testFileView) DestroySummary(diags tfdiags.Diagnostics, run *moduletest.Run, file *moduletest.File, state *states.State) {
+	v.record(func() { v.Test.DestroySummary(diags, run, file, state) })
+}
+
+func (v *testFileView) Diagnostics(run *moduletest.Run, file *moduletest.File, diags tfdiags.Diagnostics) {
+	v.record(func() { v.Test.Diagnostics(run, file, diags) })
+}
+
+func (v *testFileView) FatalInterruptSummary(run *moduletest.Run, file *moduletest.File, states map[*moduletest.Run]*states.State, created []*plans.ResourceInstanceChangeSrc) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.Test.FatalInterruptSummary(run, file, states, created)
+}
 
-		fileRunner.ExecuteTestFile(file)
-		fileRunner.Cleanup(file)
-		runner.Suite.Status = runner.Suite.Status.Merge(file.Status)
+func (v *testFileView) record(render func()) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.pending = append(v.pending, render)
+}
+
+// flush renders all the output buffered so far.
+func (v *testFileView) flush() {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	for _, render := range v.pending {
+		render()
 	}
+	v.pending = nil
 }
 
 type TestFileRunner struct {
 	Suite *TestSuiteRunner
 
-	States map[string]*TestFileState
+	// Config is the configuration under test. It is a copy of the suite's
+	// configuration, so it can be transformed for each run block without
+	// affecting other test files executing at the same time.
+	Config *configs.Config
+
+	// View renders the output for this test file.
+	View views.Test
+
+	// States maps the state keys of the run blocks to their current states.
+	// statesLock protects States while run blocks execute in parallel.
+	States     map[string]*TestFileState
+	statesLock sync.Mutex
 }
 
 type TestFileState struct {
@@ -380,7 +502,7 @@ func (runner *TestFileRunner) ExecuteTestFile(file *moduletest.File) {
 	log.Printf("[TRACE] TestFileRunner: executing test file %s", file.Name)
 
 	file.Status = file.Status.Merge(moduletest.Pass)
-	for _, run := range file.Runs {
+	for _, group := range testRunGroups(file.Runs) {
 		if runner.Suite.Cancelled {
 			// This means a hard stop has been requested, in this case we don't
 			// even stop to mark future tests as having been skipped. They'll
@@ -388,68 +510,94 @@ func (runner *TestFileRunner) ExecuteTestFile(file *moduletest.File) {
 			return
 		}
 
-		if runner.Suite.Stopped {
-			// Then the test was requested to be stopped, so we just mark each
-			// following test as skipped and move on.
-			run.Status = moduletest.Skip
+		if runner.Suite.Stopped || file.Status == moduletest.Error {
+			// Then the test was requested to be stopped, or the overall test
+			// file has errored, so we don't keep trying to execute tests.
+			// Instead, we mark all remaining run blocks as skipped.
+			for _, run := range group {
+				run.Status = moduletest.Skip
+			}
 			continue
 		}
 
-		if file.Status == moduletest.Error {
-			// If the overall test file has errored, we don't keep trying to
-			// execute tests. Instead, we mark all remaining run blocks as
-			// skipped.
-			run.Status = moduletest.Skip
-			continue
+		if len(group) == 1 {
+			runner.executeRun(group[0], file)
+		} else {
+			log.Printf("[TRACE] TestFileRunner: executing %d run blocks in parallel in %s", len(group), file.Name)
+
+			var wg sync.WaitGroup
+			for _, run := range group {
+				wg.Add(1)
+				go func(run *moduletest.Run) {
+					defer logging.PanicHandler()
+					defer wg.Done()
+					runner.executeRun(run, file)
+				}(run)
+			}
+			wg.Wait()
 		}
 
-		key := MainStateIdentifier
-		config := runner.Suite.Config
-		if run.Config.ConfigUnderTest != nil {
-			config = run.Config.ConfigUnderTest
-			// Then we need to load an alternate state and not the main one.
+		for _, run := range group {
+			file.Status = file.Status.Merge(run.Status)
+		}
+	}
 
-			key = run.Config.Module.Source.String()
-			if key == MainStateIdentifier {
-				// This is bad. It means somehow the module we're loading has
-				// the same key as main state and we're about to corrupt things.
-
-				run.Diagnostics = run.Diagnostics.Append(&hcl.Diagnostic{
-					Severity: hcl.DiagError,
-					Summary:  "Invalid module source",
-					Detail:   fmt.Sprintf("The source for the selected module evaluated to %s which should not be possible. This is a bug in OpenTofu - please report it!", key),
-					Subject:  run.Config.Module.DeclRange.Ptr(),
-				})
-
-				run.Status = moduletest.Error
-				file.Status = moduletest.Error
-				continue // Abort!
-			}
+	runner.View.File(file)
+	for _, run := range file.Runs {
+		runner.View.Run(run, file)
+	}
+}
 
-			if _, exists := runner.States[key]; !exists {
-				runner.States[key] = &TestFileState{
-					Run:   nil,
-					State: states.NewState(),
-				}
-			}
-		}
+// executeRun executes a single run block against the state selected by its
+// state key, and records the updated state.
+func (runner *TestFileRunner) executeRun(run *moduletest.Run, file *moduletest.File) {
+	key := testStateKey(run)
+	config := runner.Config
+	if run.Config.ConfigUnderTest != nil {
+		config = run.Config.ConfigUnderTest
+		// Then we need to load an alternate state and not the main one.
 
-		state, updatedState := runner.ExecuteTestRun(run, file, runner.States[key].State, config)
-		if updatedState {
-			// Only update the most recent run and state if the state was
-			// actually updated by this change. We want to use the run that
-			// most recently updated the tracked state as the cleanup
-			// configuration.
-			runner.States[key].State = state
-			runner.States[key].Run = run
-		}
+		if key == MainStateIdentifier {
+			// This is bad. It means somehow the module we're loading has
+			// the same key as main state and we're about to corrupt things.
+
+			run.Diagnostics = run.Diagnostics.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid module source",
+				Detail:   fmt.Sprintf("The source for the selected module evaluated to %s which should not be possible. This is a bug in OpenTofu - please report it!", key),
+				Subject:  run.Config.Module.DeclRange.Ptr(),
+			})
 
-		file.Status = file.Status.Merge(run.Status)
+			run.Status = moduletest.Error
+			return // Abort!
+		}
 	}
 
-	runner.Suite.View.File(file)
-	for _, run := range file.Runs {
-		runner.Suite.View.Run(run, file)
+	runner.statesLock.Lock()
+	if _, exists := runner.States[key]; !exists {
+		runner.States[key] = &TestFileState{
+			Run:   nil,
+			State: states.NewState(),
+		}
+	}
+	current := runner.States[key].State
+	runner.statesLock.Unlock()
+
+	start := time.Now()
+	state, updatedState := runner.ExecuteTestRun(run, file, current, config)
+	run.ExecutionMeta = &moduletest.RunExecutionMeta{
+		Start:    start,
+		Duration: time.Since(start),
+	}
+	if updatedState {
+		// Only update the most recent run and state if the state was
+		// actually updated by this change. We want to use the run that
+		// most recently updated the tracked state as the cleanup
+		// configuration.
+		runner.statesLock.Lock()
+		runner.States[key].State = state
+		runner.States[key].Run = run
+		runner.statesLock.Unlock()
 	}
 }
 
@@ -667,6 +815,7 @@ func (runner *TestFileRunner) destroy(config *configs.Config, state *states.Stat
 	planOpts := &tofu.PlanOpts{
 		Mode:         plans.DestroyMode,
 		SetVariables: variables,
+		Overrides:    overrides.PackageOverrides(run.Config, file.Config),
 	}
 
 	tfCtx, ctxDiags := tofu.NewContext(runner.Suite.Opts)
@@ -740,6 +889,7 @@ func (runner *TestFileRunner) plan(config *configs.Config, state *states.State,
 		SkipRefresh:        !run.Config.Options.Refresh,
 		SetVariables:       variables,
 		ExternalReferences: references,
+		Overrides:          overrides.PackageOverrides(run.Config, file.Config),
 	}
 
 	tfCtx, ctxDiags := tofu.NewContext(runner.Suite.Opts)
@@ -845,6 +995,7 @@ func (runner *TestFileRunner) wait(ctx *tofu.Context, runningCtx context.Context
 	handleCancelled := func() {
 		log.Printf("[DEBUG] TestFileRunner: test execution cancelled during %s", identifier)
 
+		runner.statesLock.Lock()
 		states := make(map[*moduletest.Run]*states.State)
 		states[nil] = runner.States[MainStateIdentifier].State
 		for key, module := range runner.States {
@@ -853,7 +1004,8 @@ func (runner *TestFileRunner) wait(ctx *tofu.Context, runningCtx context.Context
 			}
 			states[module.Run] = module.State
 		}
-		runner.Suite.View.FatalInterruptSummary(run, file, states, created)
+		runner.statesLock.Unlock()
+		runner.View.FatalInterruptSummary(run, file, states, created)
 
 		cancelled = true
 		go ctx.Stop()
@@ -914,18 +1066,18 @@ func (runner *TestFileRunner) Cleanup(file *moduletest.File) {
 			diags = diags.Append(tfdiags.Sourceless(tfdiags.Error, "Inconsistent state", fmt.Sprintf("Found inconsistent state while cleaning up %s. This is a bug in OpenTofu - please report it", file.Name)))
 		}
 	} else {
-		reset, configDiags := runner.Suite.Config.TransformForTest(main.Run.Config, file.Config)
+		reset, configDiags := runner.Config.TransformForTest(main.Run.Config, file.Config)
 		diags = diags.Append(configDiags)
 
 		if !configDiags.HasErrors() {
 			var destroyDiags tfdiags.Diagnostics
-			updated, destroyDiags = runner.destroy(runner.Suite.Config, main.State, main.Run, file)
+			updated, destroyDiags = runner.destroy(runner.Config, main.State, main.Run, file)
 			diags = diags.Append(destroyDiags)
 		}
 
 		reset()
 	}
-	runner.Suite.View.DestroySummary(diags, main.Run, file, updated)
+	runner.View.DestroySummary(diags, main.Run, file, updated)
 
 	if runner.Suite.Cancelled {
 		// In case things were cancelled during the last execution.
@@ -955,7 +1107,7 @@ func (runner *TestFileRunner) Cleanup(file *moduletest.File) {
 
 			var diags tfdiags.Diagnostics
 			diags = diags.Append(tfdiags.Sourceless(tfdiags.Error, "Inconsistent state", fmt.Sprintf("Found inconsistent state while cleaning up %s. This is a bug in OpenTofu - please report it", file.Name)))
-			runner.Suite.View.DestroySummary(diags, nil, file, state.State)
+			runner.View.DestroySummary(diags, nil, file, state.State)
 			continue
 		}
 
@@ -999,7 +1151,7 @@ func (runner *TestFileRunner) Cleanup(file *moduletest.File) {
 			updated, destroyDiags = runner.destroy(state.Run.Config.ConfigUnderTest, state.State, state.Run, file)
 			diags = diags.Append(destroyDiags)
 		}
-		runner.Suite.View.DestroySummary(diags, state.Run, file, updated)
+		runner.View.DestroySummary(diags, state.Run, file, updated)
 
 		reset()
 	}
@@ -1007,6 +1159,50 @@ func (runner *TestFileRunner) Cleanup(file *moduletest.File) {
 
 // helper functions
 
+// testStateKey returns the key of the state that the given run block executes
+// against: the main state for the configuration under test, or a separate
+// state for each alternate module loaded by run blocks.
+func testStateKey(run *moduletest.Run) string {
+	if run.Config.ConfigUnderTest == nil {
+		return MainStateIdentifier
+	}
+	return run.Config.Module.Source.String()
+}
+
+// testRunGroups splits the given run blocks into the groups that should
+// execute at the same time, in order.
+//
+// Consecutive run blocks marked as parallel are grouped together as long as
+// they don't share a state. Every other run block is in a group of its own, so
+// it executes only after all the run blocks before it have completed.
+func testRunGroups(runs []*moduletest.Run) [][]*moduletest.Run {
+	var groups [][]*moduletest.Run
+
+	var current []*moduletest.Run
+	keys := make(map[string]bool)
+	for _, run := range runs {
+		key := testStateKey(run)
+		if len(current) > 0 && (!run.Config.Parallel || keys[key]) {
+			groups = append(groups, current)
+			current = nil
+			keys = make(map[string]bool)
+		}
+
+		if !run.Config.Parallel {
+			groups = append(groups, []*moduletest.Run{run})
+			continue
+		}
+
+		current = append(current, run)
+		keys[key] = true
+	}
+	if len(current) > 0 {
+		groups = append(groups, current)
+	}
+
+	return groups
+}
+
 // buildInputVariablesForTest creates a tofu.InputValues mapping for
 // variable values that are relevant to the config being tested.
 //
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"os"
 	"path"
 	"strings"
 	"testing"
@@ -12,6 +13,8 @@ import (
 	"github.com/opentofu/opentofu/internal/addrs"
 	testing_command "github.com/opentofu/opentofu/internal/command/testing"
 	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/moduletest"
 	"github.com/opentofu/opentofu/internal/providers"
 	"github.com/opentofu/opentofu/internal/terminal"
 )
@@ -32,6 +35,12 @@ func TestTest(t *testing.T) {
 			expected: "1 passed, 0 failed.",
 			code:     0,
 		},
+		"multiple_files_parallel": {
+			override: "multiple_files",
+			args:     []string{"-parallelism=2"},
+			expected: "2 passed, 0 failed.",
+			code:     0,
+		},
 		"simple_pass_nested_alternate": {
 			args:     []string{"-test-directory", "other"},
 			expected: "1 passed, 0 failed.",
@@ -156,6 +165,10 @@ func TestTest(t *testing.T) {
 			expected: "1 passed, 0 failed.",
 			code:     0,
 		},
+		"mock_provider": {
+			expected: "1 passed, 0 failed.",
+			code:     0,
+		},
 	}
 	for name, tc := range tcs {
 		t.Run(name, func(t *testing.T) {
@@ -245,6 +258,12 @@ func TestTest_Full_Output(t *testing.T) {
 			code:     0,
 			args:     []string{"-no-color"},
 		},
+		"is_sorted_parallel": {
+			override: "is_sorted",
+			expected: "1.tftest.hcl... pass\n  run \"1\"... pass\n2.tftest.hcl... pass\n  run \"2\"... pass\n3.tftest.hcl... pass\n  run \"3\"... pass",
+			code:     0,
+			args:     []string{"-no-color", "-parallelism=3"},
+		},
 	}
 	for name, tc := range tcs {
 		t.Run(name, func(t *testing.T) {
@@ -835,6 +854,108 @@ func TestTest_NestedSetupModules(t *testing.T) {
 	}
 }
 
+func TestTest_Overrides(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath(path.Join("test", "override_resources")), td)
+	defer testChdir(t, td)()
+
+	provider := testing_command.NewProvider(nil)
+
+	providerSource, close := newMockProviderSource(t, map[string][]string{
+		"test": {"1.0.0"},
+	})
+	defer close()
+
+	streams, done := terminal.StreamsForTesting(t)
+	view := views.NewView(streams)
+	ui := new(cli.MockUi)
+
+	meta := Meta{
+		testingOverrides: metaOverridesForProvider(provider.Provider),
+		Ui:               ui,
+		View:             view,
+		Streams:          streams,
+		ProviderSource:   providerSource,
+	}
+
+	init := &InitCommand{
+		Meta: meta,
+	}
+
+	if code := init.Run(nil); code != 0 {
+		t.Fatalf("expected status code 0 but got %d: %s", code, ui.ErrorWriter)
+	}
+
+	command := &TestCommand{
+		Meta: meta,
+	}
+
+	code := command.Run(nil)
+	output := done(t)
+
+	if code != 0 {
+		t.Errorf("expected status code 0 but got %d: %s", code, output.All())
+	}
+
+	if !strings.Contains(output.Stdout(), "1 passed, 0 failed.") {
+		t.Errorf("output didn't contain expected string:\n\n%s", output.All())
+	}
+
+	// The overridden resources must never reach the real provider.
+	if provider.ResourceCount() > 0 {
+		t.Errorf("overridden resources were created by the provider: %s", provider.ResourceString())
+	}
+}
+
+func TestTest_JUnitXML(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath(path.Join("test", "simple_fail")), td)
+	defer testChdir(t, td)()
+
+	provider := testing_command.NewProvider(nil)
+	view, done := testView(t)
+
+	c := &TestCommand{
+		Meta: Meta{
+			testingOverrides: metaOverridesForProvider(provider.Provider),
+			View:             view,
+		},
+	}
+
+	code := c.Run([]string{"-junit-xml=report.xml", "-no-color"})
+	output := done(t)
+
+	if code != 1 {
+		t.Errorf("expected status code 1 but got %d", code)
+	}
+
+	// The human readable output is still produced alongside the report.
+	if !strings.Contains(output.Stdout(), "0 passed, 1 failed.") {
+		t.Errorf("output didn't contain expected string:\n\n%s", output.All())
+	}
+
+	report, err := os.ReadFile("report.xml")
+	if err != nil {
+		t.Fatalf("failed to read report: %s", err)
+	}
+
+	for _, expected := range []string{
+		`<testsuites tests="1" failures="1" errors="0" skipped="0">`,
+		`<testsuite name="main.tftest.hcl" tests="1" failures="1" errors="0" skipped="0"`,
+		`<testcase name="validate_test_resource" classname="main.tftest.hcl"`,
+		`<failure message="Test assertions failed">`,
+		`invalid value`,
+	} {
+		if !strings.Contains(string(report), expected) {
+			t.Errorf("report didn't contain %q:\n\n%s", expected, report)
+		}
+	}
+
+	if provider.ResourceCount() > 0 {
+		t.Errorf("should have deleted all resources on completion but left %v", provider.ResourceString())
+	}
+}
+
 func TestTest_StatePropagation(t *testing.T) {
 	td := t.TempDir()
 	testCopyDir(t, testFixturePath(path.Join("test", "state_propagation")), td)
@@ -951,6 +1072,119 @@ Success! 5 passed, 0 failed.
 	}
 }
 
+func TestTest_ParallelRuns(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath(path.Join("test", "parallel_runs")), td)
+	defer testChdir(t, td)()
+
+	provider := testing_command.NewProvider(nil)
+
+	providerSource, close := newMockProviderSource(t, map[string][]string{
+		"test": {"1.0.0"},
+	})
+	defer close()
+
+	streams, done := terminal.StreamsForTesting(t)
+	view := views.NewView(streams)
+	ui := new(cli.MockUi)
+
+	meta := Meta{
+		testingOverrides: metaOverridesForProvider(provider.Provider),
+		Ui:               ui,
+		View:             view,
+		Streams:          streams,
+		ProviderSource:   providerSource,
+	}
+
+	init := &InitCommand{
+		Meta: meta,
+	}
+
+	if code := init.Run(nil); code != 0 {
+		t.Fatalf("expected status code 0 but got %d: %s", code, ui.ErrorWriter)
+	}
+
+	c := &TestCommand{
+		Meta: meta,
+	}
+
+	code := c.Run([]string{"-no-color"})
+	output := done(t)
+
+	if code != 0 {
+		t.Errorf("expected status code 0 but got %d", code)
+	}
+
+	// The run blocks are reported in the order they are declared, regardless
+	// of the order they completed in.
+	expected := `main.tftest.hcl... pass
+  run "apply_main"... pass
+  run "apply_example"... pass
+  run "plan_main"... pass
+  run "apply_example_again"... pass
+
+Success! 4 passed, 0 failed.
+`
+
+	actual := output.All()
+
+	if diff := cmp.Diff(actual, expected); len(diff) > 0 {
+		t.Errorf("output didn't match expected:\nexpected:\n%s\nactual:\n%s\ndiff:\n%s", expected, actual, diff)
+	}
+
+	if provider.ResourceCount() > 0 {
+		t.Errorf("should have deleted all resources on completion but left %v", provider.ResourceString())
+	}
+}
+
+func TestTestRunGroups(t *testing.T) {
+	run := func(name string, parallel bool, module string) *moduletest.Run {
+		config := &configs.TestRun{
+			Name:     name,
+			Parallel: parallel,
+		}
+		if module != "" {
+			config.Module = &configs.TestRunModuleCall{
+				Source: addrs.ModuleSourceLocal(module),
+			}
+			config.ConfigUnderTest = &configs.Config{}
+		}
+		return &moduletest.Run{Name: name, Config: config}
+	}
+
+	runs := []*moduletest.Run{
+		run("a", true, ""),
+		run("b", true, "./example"),
+		run("c", true, ""),
+		run("d", false, ""),
+		run("e", true, "./example"),
+		run("f", true, "./other"),
+		run("g", false, "./example"),
+		run("h", true, ""),
+	}
+
+	var got [][]string
+	for _, group := range testRunGroups(runs) {
+		var names []string
+		for _, run := range group {
+			names = append(names, run.Name)
+		}
+		got = append(got, names)
+	}
+
+	want := [][]string{
+		{"a", "b"},
+		{"c"},
+		{"d"},
+		{"e", "f"},
+		{"g"},
+		{"h"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong groups\n%s", diff)
+	}
+}
+
 func TestTest_OnlyExternalModules(t *testing.T) {
 	td := t.TempDir()
 	testCopyDir(t, testFixturePath(path.Join("test", "only_modules")), td)
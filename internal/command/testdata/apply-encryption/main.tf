@@ -0,0 +1,18 @@
+terraform {
+  encryption {
+    key_provider "pbkdf2" "test" {
+      passphrase = "correct-horse-battery-staple"
+      iterations = 200000
+    }
+    method "aes_gcm" "test" {
+      keys = key_provider.pbkdf2.test
+    }
+    state {
+      method = method.aes_gcm.test
+    }
+  }
+}
+
+resource "test_instance" "foo" {
+  ami = "bar"
+}
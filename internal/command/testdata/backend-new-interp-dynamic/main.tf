@@ -0,0 +1,7 @@
+resource "test_instance" "foo" {}
+
+terraform {
+    backend "local" {
+        path = test_instance.foo.id
+    }
+}
@@ -0,0 +1,10 @@
+variable "foo" {
+  default = "bar"
+  static  = true
+}
+
+terraform {
+    backend "local" {
+        path = "${var.foo}"
+    }
+}
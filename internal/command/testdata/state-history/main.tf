@@ -0,0 +1,6 @@
+terraform {
+  backend "local" {
+    path          = "local-state.tfstate"
+    history_limit = 2
+  }
+}
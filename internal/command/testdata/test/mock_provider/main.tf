@@ -0,0 +1,7 @@
+resource "test_resource" "a" {
+  value = "a"
+}
+
+data "test_data_source" "b" {
+  id = "does-not-exist"
+}
@@ -0,0 +1,25 @@
+mock_provider "test" {
+  mock_resource "test_resource" {
+    defaults = {
+      id = "mocked"
+    }
+  }
+
+  mock_data "test_data_source" {
+    defaults = {
+      value = "mocked"
+    }
+  }
+}
+
+run "test" {
+  assert {
+    condition     = test_resource.a.id == "mocked"
+    error_message = "invalid id"
+  }
+
+  assert {
+    condition     = data.test_data_source.b.value == "mocked"
+    error_message = "invalid data value"
+  }
+}
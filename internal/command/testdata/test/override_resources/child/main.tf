@@ -0,0 +1,7 @@
+resource "test_resource" "c" {
+  value = "c"
+}
+
+output "id" {
+  value = test_resource.c.id
+}
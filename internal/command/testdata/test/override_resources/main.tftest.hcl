@@ -0,0 +1,37 @@
+override_data {
+  target = data.test_data_source.b
+  values = {
+    value = "overridden"
+  }
+}
+
+override_module {
+  target = module.child
+  outputs = {
+    id = "child"
+  }
+}
+
+run "test" {
+  override_resource {
+    target = test_resource.a
+    values = {
+      id = "overridden"
+    }
+  }
+
+  assert {
+    condition     = test_resource.a.id == "overridden"
+    error_message = "invalid id"
+  }
+
+  assert {
+    condition     = data.test_data_source.b.value == "overridden"
+    error_message = "invalid data value"
+  }
+
+  assert {
+    condition     = module.child.id == "child"
+    error_message = "invalid module output"
+  }
+}
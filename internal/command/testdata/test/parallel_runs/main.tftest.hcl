@@ -0,0 +1,52 @@
+# The first two run blocks use different states, so they execute at the same
+# time. The third run block uses the same state as the first, so it must wait
+# for it and see the state it created.
+
+run "apply_main" {
+  parallel = true
+
+  variables {
+    input = "start"
+  }
+}
+
+run "apply_example" {
+  parallel = true
+
+  module {
+    source = "./example"
+  }
+
+  variables {
+    input = "start"
+  }
+}
+
+run "plan_main" {
+  command  = plan
+  parallel = true
+
+  variables {
+    input = "update"
+  }
+
+  assert {
+    condition     = test_resource.resource.value == "update"
+    error_message = "bad value"
+  }
+}
+
+run "apply_example_again" {
+  module {
+    source = "./example"
+  }
+
+  variables {
+    input = "again"
+  }
+
+  assert {
+    condition     = test_resource.module_resource.value == "again"
+    error_message = "bad value"
+  }
+}
@@ -18,6 +18,7 @@ type ChangeSummary struct {
 	Change    int       `json:"change"`
 	Import    int       `json:"import"`
 	Remove    int       `json:"remove"`
+	Forget    int       `json:"forget,omitempty"`
 	Operation Operation `json:"operation"`
 }
 
@@ -34,10 +35,14 @@ func (cs *ChangeSummary) String() string {
 	case OperationDestroyed:
 		return fmt.Sprintf("Destroy complete! Resources: %d destroyed.", cs.Remove)
 	case OperationPlanned:
+		var forget string
+		if cs.Forget > 0 {
+			forget = fmt.Sprintf(", %d to forget", cs.Forget)
+		}
 		if cs.Import > 0 {
-			return fmt.Sprintf("Plan: %d to import, %d to add, %d to change, %d to destroy.", cs.Import, cs.Add, cs.Change, cs.Remove)
+			return fmt.Sprintf("Plan: %d to import, %d to add, %d to change, %d to destroy%s.", cs.Import, cs.Add, cs.Change, cs.Remove, forget)
 		}
-		return fmt.Sprintf("Plan: %d to add, %d to change, %d to destroy.", cs.Add, cs.Change, cs.Remove)
+		return fmt.Sprintf("Plan: %d to add, %d to change, %d to destroy%s.", cs.Add, cs.Change, cs.Remove, forget)
 	default:
 		return fmt.Sprintf("%s: %d add, %d change, %d destroy", cs.Operation, cs.Add, cs.Change, cs.Remove)
 	}
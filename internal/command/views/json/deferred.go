@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package json
+
+import (
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/plans"
+)
+
+type DeferredResource struct {
+	Resource ResourceAddr `json:"resource"`
+	Reason   string       `json:"reason"`
+}
+
+func NewDeferredResource(dr *plans.DeferredResource) *DeferredResource {
+	ret := &DeferredResource{
+		// Deferred resources have no instances yet, so the address we report
+		// has no instance key.
+		Resource: newResourceAddr(dr.Addr.Instance(addrs.NoKey)),
+	}
+	switch dr.Reason {
+	case plans.DeferredReasonCountUnknown:
+		ret.Reason = "count_unknown"
+	case plans.DeferredReasonForEachUnknown:
+		ret.Reason = "for_each_unknown"
+	case plans.DeferredReasonDependency:
+		ret.Reason = "dependency"
+	}
+	return ret
+}
+
+func (d *DeferredResource) String() string {
+	return fmt.Sprintf("%s: Deferred (%s)", d.Resource.Addr, d.Reason)
+}
@@ -16,6 +16,8 @@ const (
 	MessagePlannedChange MessageType = "planned_change"
 	MessageChangeSummary MessageType = "change_summary"
 	MessageOutputs       MessageType = "outputs"
+	MessagePolicyResult  MessageType = "policy_result"
+	MessageDeferred      MessageType = "deferred_resource"
 
 	// Hook-driven messages
 	MessageApplyStart        MessageType = "apply_start"
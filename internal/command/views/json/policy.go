@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package json
+
+import (
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/checks"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+type PolicyResult struct {
+	Name            string   `json:"name"`
+	Severity        string   `json:"severity"`
+	Status          string   `json:"status"`
+	FailureMessages []string `json:"failure_messages,omitempty"`
+}
+
+func NewPolicyResult(result *plans.PolicyResult) *PolicyResult {
+	ret := &PolicyResult{
+		Name:            result.Name,
+		Severity:        "error",
+		FailureMessages: result.FailureMessages,
+	}
+	if result.Severity == tfdiags.Warning {
+		ret.Severity = "warning"
+	}
+	switch result.Status {
+	case checks.StatusPass:
+		ret.Status = "pass"
+	case checks.StatusFail:
+		ret.Status = "fail"
+	case checks.StatusError:
+		ret.Status = "error"
+	default:
+		ret.Status = "unknown"
+	}
+	return ret
+}
+
+func (r *PolicyResult) String() string {
+	return fmt.Sprintf("Policy %q: %s", r.Name, r.Status)
+}
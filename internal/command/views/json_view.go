@@ -111,6 +111,22 @@ func (v *JSONView) ChangeSummary(cs *json.ChangeSummary) {
 	)
 }
 
+func (v *JSONView) PolicyResult(r *json.PolicyResult) {
+	v.log.Info(
+		r.String(),
+		"type", json.MessagePolicyResult,
+		"policy", r,
+	)
+}
+
+func (v *JSONView) DeferredResource(d *json.DeferredResource) {
+	v.log.Info(
+		d.String(),
+		"type", json.MessageDeferred,
+		"deferred", d,
+	)
+}
+
 func (v *JSONView) Hook(h json.Hook) {
 	v.log.Info(
 		h.String(),
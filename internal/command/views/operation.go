@@ -9,12 +9,14 @@ import (
 	"strings"
 
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/checks"
 	"github.com/opentofu/opentofu/internal/command/arguments"
 	"github.com/opentofu/opentofu/internal/command/format"
 	"github.com/opentofu/opentofu/internal/command/jsonformat"
 	"github.com/opentofu/opentofu/internal/command/jsonplan"
 	"github.com/opentofu/opentofu/internal/command/jsonprovider"
 	"github.com/opentofu/opentofu/internal/command/views/json"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/states/statefile"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -27,7 +29,7 @@ type Operation interface {
 	Stopping()
 	Cancelled(planMode plans.Mode)
 
-	EmergencyDumpState(stateFile *statefile.File) error
+	EmergencyDumpState(stateFile *statefile.File, enc encryption.StateEncryption) error
 
 	PlannedChange(change *plans.ResourceInstanceChangeSrc)
 	Plan(plan *plans.Plan, schemas *tofu.Schemas)
@@ -81,9 +83,9 @@ func (v *OperationHuman) Cancelled(planMode plans.Mode) {
 	}
 }
 
-func (v *OperationHuman) EmergencyDumpState(stateFile *statefile.File) error {
+func (v *OperationHuman) EmergencyDumpState(stateFile *statefile.File, enc encryption.StateEncryption) error {
 	stateBuf := new(bytes.Buffer)
-	jsonErr := statefile.Write(stateFile, stateBuf)
+	jsonErr := statefile.Write(stateFile, stateBuf, enc)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -112,6 +114,7 @@ func (v *OperationHuman) Plan(plan *plans.Plan, schemas *tofu.Schemas) {
 		ResourceDrift:         drift,
 		ProviderSchemas:       jsonprovider.MarshalForRenderer(schemas),
 		RelevantAttributes:    attrs,
+		DeferredResources:     jsonplan.MarshalDeferredResources(plan.DeferredResources),
 	}
 
 	// Side load some data that we can't extract from the JSON plan.
@@ -124,6 +127,35 @@ func (v *OperationHuman) Plan(plan *plans.Plan, schemas *tofu.Schemas) {
 	}
 
 	renderer.RenderHumanPlan(jplan, plan.UIMode, opts...)
+
+	if len(plan.PolicyResults) > 0 {
+		v.policyResults(plan.PolicyResults)
+	}
+}
+
+// policyResults renders a summary of the results of the policy blocks in the
+// configuration. The details of any failures are reported separately as
+// diagnostics.
+func (v *OperationHuman) policyResults(results []*plans.PolicyResult) {
+	v.view.streams.Println(v.view.colorize.Color("\n[bold]Policy results:[reset]"))
+	for _, result := range results {
+		var status string
+		switch result.Status {
+		case checks.StatusPass:
+			status = "[green]passed"
+		case checks.StatusFail:
+			if result.Severity == tfdiags.Warning {
+				status = "[yellow]failed (warning)"
+			} else {
+				status = "[red]failed"
+			}
+		case checks.StatusError:
+			status = "[red]error"
+		default:
+			status = "unknown"
+		}
+		v.view.streams.Println(v.view.colorize.Color(fmt.Sprintf("  - %s: %s[reset]", result.Name, status)))
+	}
 }
 
 func (v *OperationHuman) PlannedChange(change *plans.ResourceInstanceChangeSrc) {
@@ -197,9 +229,9 @@ func (v *OperationJSON) Cancelled(planMode plans.Mode) {
 	}
 }
 
-func (v *OperationJSON) EmergencyDumpState(stateFile *statefile.File) error {
+func (v *OperationJSON) EmergencyDumpState(stateFile *statefile.File, enc encryption.StateEncryption) error {
 	stateBuf := new(bytes.Buffer)
-	jsonErr := statefile.Write(stateFile, stateBuf)
+	jsonErr := statefile.Write(stateFile, stateBuf, enc)
 	if jsonErr != nil {
 		return jsonErr
 	}
@@ -243,6 +275,8 @@ func (v *OperationJSON) Plan(plan *plans.Plan, schemas *tofu.Schemas) {
 		case plans.CreateThenDelete, plans.DeleteThenCreate:
 			cs.Add++
 			cs.Remove++
+		case plans.Forget:
+			cs.Forget++
 		}
 
 		if change.Action != plans.NoOp || !change.Addr.Equal(change.PrevRunAddr) || change.Importing != nil {
@@ -252,6 +286,14 @@ func (v *OperationJSON) Plan(plan *plans.Plan, schemas *tofu.Schemas) {
 
 	v.view.ChangeSummary(cs)
 
+	for _, dr := range plan.DeferredResources {
+		v.view.DeferredResource(json.NewDeferredResource(dr))
+	}
+
+	for _, result := range plan.PolicyResults {
+		v.view.PolicyResult(json.NewPolicyResult(result))
+	}
+
 	var rootModuleOutputs []*plans.OutputChangeSrc
 	for _, output := range plan.Changes.Outputs {
 		if !output.Addr.Module.IsRoot() {
@@ -10,12 +10,15 @@ import (
 	"testing"
 
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/checks"
 	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/lang/globalref"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/statefile"
 	"github.com/opentofu/opentofu/internal/terminal"
+	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/opentofu/opentofu/internal/tofu"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -65,7 +68,7 @@ func TestOperation_emergencyDumpState(t *testing.T) {
 
 	stateFile := statefile.New(nil, "foo", 1)
 
-	err := v.EmergencyDumpState(stateFile)
+	err := v.EmergencyDumpState(stateFile, encryption.StateEncryptionDisabled())
 	if err != nil {
 		t.Fatalf("unexpected error dumping state: %s", err)
 	}
@@ -359,6 +362,64 @@ Plan: 1 to add, 0 to change, 0 to destroy.
 	}
 }
 
+func TestOperation_planPolicyResults(t *testing.T) {
+	streams, done := terminal.StreamsForTesting(t)
+	v := NewOperation(arguments.ViewHuman, true, NewView(streams))
+
+	plan := &plans.Plan{
+		Changes: plans.NewChanges(),
+		PolicyResults: []*plans.PolicyResult{
+			{Name: "a", Severity: tfdiags.Error, Status: checks.StatusPass},
+			{Name: "b", Severity: tfdiags.Error, Status: checks.StatusFail, FailureMessages: []string{"Boom."}},
+			{Name: "c", Severity: tfdiags.Warning, Status: checks.StatusFail, FailureMessages: []string{"Hmm."}},
+		},
+	}
+	v.Plan(plan, testSchemas())
+
+	want := `
+Policy results:
+  - a: passed
+  - b: failed
+  - c: failed (warning)
+`
+	if got := done(t).Stdout(); !strings.HasSuffix(got, want) {
+		t.Errorf("unexpected output\ngot:\n%s\nwant suffix:\n%s", got, want)
+	}
+}
+
+func TestOperation_planDeferredResources(t *testing.T) {
+	streams, done := terminal.StreamsForTesting(t)
+	v := NewOperation(arguments.ViewHuman, true, NewView(streams))
+
+	plan := &plans.Plan{
+		Changes: plans.NewChanges(),
+		DeferredResources: []*plans.DeferredResource{
+			{
+				Addr:   addrs.Resource{Mode: addrs.ManagedResourceMode, Type: "test_resource", Name: "a"}.Absolute(addrs.RootModuleInstance),
+				Reason: plans.DeferredReasonCountUnknown,
+			},
+			{
+				Addr:   addrs.Resource{Mode: addrs.ManagedResourceMode, Type: "test_resource", Name: "b"}.Absolute(addrs.RootModuleInstance),
+				Reason: plans.DeferredReasonDependency,
+			},
+		},
+	}
+	v.Plan(plan, testSchemas())
+
+	want := `
+OpenTofu deferred the changes for the following resources, because it can't
+yet determine which instances they should have:
+  # test_resource.a (the count value isn't known yet)
+  # test_resource.b (it depends on a deferred resource)
+
+Create and apply another plan after applying this one to plan the deferred
+changes.
+`
+	if got := done(t).Stdout(); !strings.HasSuffix(got, want) {
+		t.Errorf("unexpected output\ngot:\n%s\nwant suffix:\n%s", got, want)
+	}
+}
+
 func TestOperation_planWithDatasource(t *testing.T) {
 	streams, done := terminal.StreamsForTesting(t)
 	v := NewOperation(arguments.ViewHuman, true, NewView(streams))
@@ -530,7 +591,7 @@ func TestOperationJSON_emergencyDumpState(t *testing.T) {
 
 	stateFile := statefile.New(nil, "foo", 1)
 	stateBuf := new(bytes.Buffer)
-	err := statefile.Write(stateFile, stateBuf)
+	err := statefile.Write(stateFile, stateBuf, encryption.StateEncryptionDisabled())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -540,7 +601,7 @@ func TestOperationJSON_emergencyDumpState(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	err = v.EmergencyDumpState(stateFile)
+	err = v.EmergencyDumpState(stateFile, encryption.StateEncryptionDisabled())
 	if err != nil {
 		t.Fatalf("unexpected error dumping state: %s", err)
 	}
@@ -586,6 +647,101 @@ func TestOperationJSON_planNoChanges(t *testing.T) {
 	testJSONViewOutputEquals(t, done(t).Stdout(), want)
 }
 
+func TestOperationJSON_planPolicyResults(t *testing.T) {
+	streams, done := terminal.StreamsForTesting(t)
+	v := &OperationJSON{view: NewJSONView(NewView(streams))}
+
+	plan := &plans.Plan{
+		Changes: plans.NewChanges(),
+		PolicyResults: []*plans.PolicyResult{
+			{Name: "a", Severity: tfdiags.Warning, Status: checks.StatusFail, FailureMessages: []string{"Boom."}},
+		},
+	}
+	v.Plan(plan, nil)
+
+	want := []map[string]interface{}{
+		{
+			"@level":   "info",
+			"@message": "Plan: 0 to add, 0 to change, 0 to destroy.",
+			"@module":  "tofu.ui",
+			"type":     "change_summary",
+			"changes": map[string]interface{}{
+				"operation": "plan",
+				"add":       float64(0),
+				"import":    float64(0),
+				"change":    float64(0),
+				"remove":    float64(0),
+			},
+		},
+		{
+			"@level":   "info",
+			"@message": `Policy "a": fail`,
+			"@module":  "tofu.ui",
+			"type":     "policy_result",
+			"policy": map[string]interface{}{
+				"name":             "a",
+				"severity":         "warning",
+				"status":           "fail",
+				"failure_messages": []interface{}{"Boom."},
+			},
+		},
+	}
+
+	testJSONViewOutputEquals(t, done(t).Stdout(), want)
+}
+
+func TestOperationJSON_planDeferredResources(t *testing.T) {
+	streams, done := terminal.StreamsForTesting(t)
+	v := &OperationJSON{view: NewJSONView(NewView(streams))}
+
+	plan := &plans.Plan{
+		Changes: plans.NewChanges(),
+		DeferredResources: []*plans.DeferredResource{
+			{
+				Addr:   addrs.Resource{Mode: addrs.ManagedResourceMode, Type: "test_resource", Name: "a"}.Absolute(addrs.RootModuleInstance),
+				Reason: plans.DeferredReasonForEachUnknown,
+			},
+		},
+	}
+	v.Plan(plan, nil)
+
+	want := []map[string]interface{}{
+		{
+			"@level":   "info",
+			"@message": "Plan: 0 to add, 0 to change, 0 to destroy.",
+			"@module":  "tofu.ui",
+			"type":     "change_summary",
+			"changes": map[string]interface{}{
+				"operation": "plan",
+				"add":       float64(0),
+				"import":    float64(0),
+				"change":    float64(0),
+				"remove":    float64(0),
+			},
+		},
+		{
+			"@level":   "info",
+			"@message": "test_resource.a: Deferred (for_each_unknown)",
+			"@module":  "tofu.ui",
+			"type":     "deferred_resource",
+			"deferred": map[string]interface{}{
+				"resource": map[string]interface{}{
+					"addr":             "test_resource.a",
+					"implied_provider": "test",
+					"module":           "",
+					"resource":         "test_resource.a",
+					"resource_key":     nil,
+					"resource_name":    "a",
+					"resource_type":    "test_resource",
+				},
+				"reason": "for_each_unknown",
+			},
+		},
+	}
+
+	testJSONViewOutputEquals(t, done(t).Stdout(), want)
+}
+
 func TestOperationJSON_plan(t *testing.T) {
 	streams, done := terminal.StreamsForTesting(t)
 	v := &OperationJSON{view: NewJSONView(NewView(streams))}
@@ -85,6 +85,7 @@ func (v *ShowHuman) Display(config *configs.Config, plan *plans.Plan, planJSON *
 			ResourceDrift:         drift,
 			ProviderSchemas:       jsonprovider.MarshalForRenderer(schemas),
 			RelevantAttributes:    attrs,
+			DeferredResources:     jsonplan.MarshalDeferredResources(plan.DeferredResources),
 		}
 
 		var opts []plans.Quality
@@ -0,0 +1,293 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/command/format"
+	"github.com/opentofu/opentofu/internal/moduletest"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// TestJUnitXMLFile writes a JUnit XML report of the test results to a file
+// once all the tests have completed.
+//
+// It doesn't print anything itself, so it should be combined with one of the
+// other Test views using TestMulti.
+type TestJUnitXMLFile struct {
+	view     *View
+	filename string
+
+	// cleanupDiags records any diagnostics from destroying the state created
+	// by each test file, keyed by file name, so they can be included in the
+	// report.
+	cleanupDiags map[string]tfdiags.Diagnostics
+}
+
+var _ Test = (*TestJUnitXMLFile)(nil)
+
+// NewTestJUnitXMLFile returns a Test view that writes a JUnit XML report to
+// the given file. The given View is used to render diagnostics within the
+// report, and to report any errors writing the file.
+func NewTestJUnitXMLFile(filename string, view *View) *TestJUnitXMLFile {
+	return &TestJUnitXMLFile{
+		view:         view,
+		filename:     filename,
+		cleanupDiags: make(map[string]tfdiags.Diagnostics),
+	}
+}
+
+func (t *TestJUnitXMLFile) Abstract(_ *moduletest.Suite) {}
+
+func (t *TestJUnitXMLFile) Conclusion(suite *moduletest.Suite) {
+	src, err := t.report(suite)
+	if err == nil {
+		err = os.WriteFile(t.filename, src, 0644)
+	}
+	if err != nil {
+		var diags tfdiags.Diagnostics
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to write JUnit XML report",
+			fmt.Sprintf("Could not write the test results to %s: %s.", t.filename, err),
+		))
+		t.view.Diagnostics(diags)
+	}
+}
+
+func (t *TestJUnitXMLFile) File(_ *moduletest.File) {}
+
+func (t *TestJUnitXMLFile) Run(_ *moduletest.Run, _ *moduletest.File) {}
+
+func (t *TestJUnitXMLFile) DestroySummary(diags tfdiags.Diagnostics, _ *moduletest.Run, file *moduletest.File, _ *states.State) {
+	if len(diags) > 0 {
+		t.cleanupDiags[file.Name] = t.cleanupDiags[file.Name].Append(diags)
+	}
+}
+
+func (t *TestJUnitXMLFile) Diagnostics(_ *moduletest.Run, _ *moduletest.File, _ tfdiags.Diagnostics) {
+	// Diagnostics for runs and files are recorded within the suite, so we'll
+	// pick them up from there when writing the report.
+}
+
+func (t *TestJUnitXMLFile) Interrupted() {}
+
+func (t *TestJUnitXMLFile) FatalInterrupt() {}
+
+func (t *TestJUnitXMLFile) FatalInterruptSummary(_ *moduletest.Run, _ *moduletest.File, _ map[*moduletest.Run]*states.State, _ []*plans.ResourceInstanceChangeSrc) {
+	// We don't write a report at all after a fatal interrupt, since the
+	// Conclusion is never reached.
+}
+
+// report builds the JUnit XML document for the given suite.
+func (t *TestJUnitXMLFile) report(suite *moduletest.Suite) ([]byte, error) {
+	var names []string
+	for name := range suite.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names) // the files are executed in alphabetical order
+
+	var report junitTestSuites
+	for _, name := range names {
+		file := suite.Files[name]
+
+		testSuite := junitTestSuite{
+			Name:  file.Name,
+			Tests: len(file.Runs),
+		}
+
+		var duration time.Duration
+		for _, run := range file.Runs {
+			testCase := junitTestCase{
+				Name:      run.Name,
+				Classname: file.Name,
+			}
+			if meta := run.ExecutionMeta; meta != nil {
+				duration += meta.Duration
+				testCase.Time = junitDuration(meta.Duration)
+				testCase.Timestamp = junitTimestamp(meta.Start)
+				if testSuite.Timestamp == "" {
+					testSuite.Timestamp = testCase.Timestamp
+				}
+			}
+
+			switch run.Status {
+			case moduletest.Pass:
+				testCase.SystemErr = t.diagnostics(run.Diagnostics)
+			case moduletest.Fail:
+				testSuite.Failures++
+				testCase.Failure = &junitMessage{
+					Message: "Test assertions failed",
+					Body:    t.diagnostics(run.Diagnostics),
+				}
+			case moduletest.Error:
+				testSuite.Errors++
+				testCase.Error = &junitMessage{
+					Message: "Encountered an error",
+					Body:    t.diagnostics(run.Diagnostics),
+				}
+			default:
+				testSuite.Skipped++
+				testCase.Skipped = &junitMessage{
+					Message: junitSkippedMessage(file),
+				}
+			}
+
+			testSuite.Cases = append(testSuite.Cases, testCase)
+		}
+		testSuite.Time = junitDuration(duration)
+
+		var fileDiags tfdiags.Diagnostics
+		fileDiags = fileDiags.Append(file.Diagnostics, t.cleanupDiags[file.Name])
+		testSuite.SystemErr = t.diagnostics(fileDiags)
+
+		report.Tests += testSuite.Tests
+		report.Failures += testSuite.Failures
+		report.Errors += testSuite.Errors
+		report.Skipped += testSuite.Skipped
+		report.Suites = append(report.Suites, testSuite)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+// diagnostics renders the given diagnostics as plain text, suitable for
+// inclusion in the report.
+func (t *TestJUnitXMLFile) diagnostics(diags tfdiags.Diagnostics) string {
+	var parts []string
+	for _, diag := range diags {
+		parts = append(parts, strings.TrimSpace(format.DiagnosticPlain(diag, t.view.configSources(), 0)))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func junitSkippedMessage(file *moduletest.File) string {
+	if file.Status == moduletest.Error {
+		return "Skipped due to an earlier error in this file"
+	}
+	return "Skipped due to an interrupt"
+}
+
+func junitDuration(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
+
+func junitTimestamp(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05")
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+	SystemErr string          `xml:"system-err,omitempty"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr,omitempty"`
+	Timestamp string        `xml:"timestamp,attr,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	SystemErr string        `xml:"system-err,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Body    string `xml:",cdata"`
+}
+
+// TestMulti is a Test view that forwards every call to each of the views it
+// contains, in order.
+type TestMulti []Test
+
+var _ Test = TestMulti(nil)
+
+func (m TestMulti) Abstract(suite *moduletest.Suite) {
+	for _, view := range m {
+		view.Abstract(suite)
+	}
+}
+
+func (m TestMulti) Conclusion(suite *moduletest.Suite) {
+	for _, view := range m {
+		view.Conclusion(suite)
+	}
+}
+
+func (m TestMulti) File(file *moduletest.File) {
+	for _, view := range m {
+		view.File(file)
+	}
+}
+
+func (m TestMulti) Run(run *moduletest.Run, file *moduletest.File) {
+	for _, view := range m {
+		view.Run(run, file)
+	}
+}
+
+func (m TestMulti) DestroySummary(diags tfdiags.Diagnostics, run *moduletest.Run, file *moduletest.File, state *states.State) {
+	for _, view := range m {
+		view.DestroySummary(diags, run, file, state)
+	}
+}
+
+func (m TestMulti) Diagnostics(run *moduletest.Run, file *moduletest.File, diags tfdiags.Diagnostics) {
+	for _, view := range m {
+		view.Diagnostics(run, file, diags)
+	}
+}
+
+func (m TestMulti) Interrupted() {
+	for _, view := range m {
+		view.Interrupted()
+	}
+}
+
+func (m TestMulti) FatalInterrupt() {
+	for _, view := range m {
+		view.FatalInterrupt()
+	}
+}
+
+func (m TestMulti) FatalInterruptSummary(run *moduletest.Run, file *moduletest.File, states map[*moduletest.Run]*states.State, created []*plans.ResourceInstanceChangeSrc) {
+	for _, view := range m {
+		view.FatalInterruptSummary(run, file, states, created)
+	}
+}
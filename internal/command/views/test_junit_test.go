@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/moduletest"
+	"github.com/opentofu/opentofu/internal/terminal"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func TestTestJUnitXMLFile_Conclusion(t *testing.T) {
+	start := time.Date(2023, time.October, 1, 12, 30, 0, 0, time.UTC)
+
+	tcs := map[string]struct {
+		Suite    *moduletest.Suite
+		Cleanup  map[string]tfdiags.Diagnostics
+		Expected string
+	}{
+		"no tests": {
+			Suite: &moduletest.Suite{},
+			Expected: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="0" failures="0" errors="0" skipped="0"></testsuites>
+`,
+		},
+
+		"mixed results": {
+			Suite: &moduletest.Suite{
+				Status: moduletest.Error,
+				Files: map[string]*moduletest.File{
+					"main.tftest.hcl": {
+						Name:   "main.tftest.hcl",
+						Status: moduletest.Fail,
+						Runs: []*moduletest.Run{
+							{
+								Name:   "first",
+								Status: moduletest.Pass,
+								ExecutionMeta: &moduletest.RunExecutionMeta{
+									Start:    start,
+									Duration: 1500 * time.Millisecond,
+								},
+							},
+							{
+								Name:   "second",
+								Status: moduletest.Fail,
+								ExecutionMeta: &moduletest.RunExecutionMeta{
+									Start:    start.Add(2 * time.Second),
+									Duration: 250 * time.Millisecond,
+								},
+								Diagnostics: tfdiags.Diagnostics{
+									tfdiags.Sourceless(tfdiags.Error, "Test assertion failed", "The value was wrong."),
+								},
+							},
+						},
+					},
+					"errored.tftest.hcl": {
+						Name:   "errored.tftest.hcl",
+						Status: moduletest.Error,
+						Runs: []*moduletest.Run{
+							{
+								Name:   "broken",
+								Status: moduletest.Error,
+								ExecutionMeta: &moduletest.RunExecutionMeta{
+									Start:    start,
+									Duration: 10 * time.Millisecond,
+								},
+								Diagnostics: tfdiags.Diagnostics{
+									tfdiags.Sourceless(tfdiags.Error, "Invalid thing", "Something broke."),
+								},
+							},
+							{
+								Name:   "after",
+								Status: moduletest.Skip,
+							},
+						},
+					},
+				},
+			},
+			Cleanup: map[string]tfdiags.Diagnostics{
+				"errored.tftest.hcl": {
+					tfdiags.Sourceless(tfdiags.Warning, "Cleanup warning", "Something was left behind."),
+				},
+			},
+			Expected: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="4" failures="1" errors="1" skipped="1">
+  <testsuite name="errored.tftest.hcl" tests="2" failures="0" errors="1" skipped="1" time="0.010" timestamp="2023-10-01T12:30:00">
+    <testcase name="broken" classname="errored.tftest.hcl" time="0.010" timestamp="2023-10-01T12:30:00">
+      <error message="Encountered an error"><![CDATA[Error: Invalid thing
+
+Something broke.]]></error>
+    </testcase>
+    <testcase name="after" classname="errored.tftest.hcl">
+      <skipped message="Skipped due to an earlier error in this file"></skipped>
+    </testcase>
+    <system-err>Warning: Cleanup warning&#xA;&#xA;Something was left behind.</system-err>
+  </testsuite>
+  <testsuite name="main.tftest.hcl" tests="2" failures="1" errors="0" skipped="0" time="1.750" timestamp="2023-10-01T12:30:00">
+    <testcase name="first" classname="main.tftest.hcl" time="1.500" timestamp="2023-10-01T12:30:00"></testcase>
+    <testcase name="second" classname="main.tftest.hcl" time="0.250" timestamp="2023-10-01T12:30:02">
+      <failure message="Test assertions failed"><![CDATA[Error: Test assertion failed
+
+The value was wrong.]]></failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`,
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "report.xml")
+
+			streams, done := terminal.StreamsForTesting(t)
+			view := NewTestJUnitXMLFile(filename, NewView(streams))
+			for file, diags := range tc.Cleanup {
+				view.DestroySummary(diags, nil, &moduletest.File{Name: file}, nil)
+			}
+			view.Conclusion(tc.Suite)
+
+			output := done(t)
+			if stderr := output.Stderr(); len(stderr) > 0 {
+				t.Errorf("unexpected errors: %s", stderr)
+			}
+
+			actual, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.Expected, string(actual)); len(diff) > 0 {
+				t.Errorf("expected:\n%s\nactual:\n%s\ndiff:\n%s", tc.Expected, actual, diff)
+			}
+		})
+	}
+}
+
+func TestTestJUnitXMLFile_writeError(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "missing", "report.xml")
+
+	streams, done := terminal.StreamsForTesting(t)
+	view := NewTestJUnitXMLFile(filename, NewView(streams))
+	view.Conclusion(&moduletest.Suite{})
+
+	output := done(t)
+	if stderr := output.Stderr(); !strings.Contains(stderr, "Failed to write JUnit XML report") {
+		t.Errorf("expected write error, got: %s", stderr)
+	}
+}
@@ -148,7 +148,13 @@ func (c *WorkspaceNewCommand) Run(args []string) int {
 		return 1
 	}
 
-	stateFile, err := statefile.Read(f)
+	enc, encDiags := c.Encryption()
+	if encDiags.HasErrors() {
+		c.showDiagnostics(encDiags)
+		return 1
+	}
+
+	stateFile, err := statefile.Read(f, enc.State())
 	if err != nil {
 		c.Ui.Error(err.Error())
 		return 1
@@ -868,6 +868,44 @@ func (c *Config) CheckCoreVersionRequirements() hcl.Diagnostics {
 	return diags
 }
 
+// CopyForTest returns a copy of the receiving root configuration that can be
+// transformed for a test independently of the receiver, so that tests using
+// the same configuration can execute at the same time.
+//
+// Only the parts of the configuration tree that are modified while executing
+// a test are copied: the Config objects themselves, so that they link to the
+// copied root, and the root module's provider configurations and variables.
+// Everything else is shared with the receiver and must not be modified.
+func (c *Config) CopyForTest() *Config {
+	return c.copyForTest(nil, nil)
+}
+
+func (c *Config) copyForTest(root, parent *Config) *Config {
+	ret := *c
+	if root == nil {
+		root = &ret
+
+		module := *c.Module
+		module.ProviderConfigs = make(map[string]*Provider, len(c.Module.ProviderConfigs))
+		for key, provider := range c.Module.ProviderConfigs {
+			module.ProviderConfigs[key] = provider
+		}
+		module.Variables = make(map[string]*Variable, len(c.Module.Variables))
+		for name, variable := range c.Module.Variables {
+			module.Variables[name] = variable
+		}
+		ret.Module = &module
+	}
+	ret.Root = root
+	ret.Parent = parent
+
+	ret.Children = make(map[string]*Config, len(c.Children))
+	for name, child := range c.Children {
+		ret.Children[name] = child.copyForTest(root, &ret)
+	}
+	return &ret
+}
+
 // TransformForTest prepares the config to execute the given test.
 //
 // This function directly edits the config that is to be tested, and returns a
@@ -941,6 +979,8 @@ func (c *Config) TransformForTest(run *TestRun, file *TestFile) (func(), hcl.Dia
 				Version:    testProvider.Version,
 				Config:     testProvider.Config,
 				DeclRange:  testProvider.DeclRange,
+				Mock:       testProvider.Mock,
+				MockData:   testProvider.MockData,
 			}
 
 		}
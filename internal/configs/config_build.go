@@ -82,7 +82,7 @@ func buildTestModules(root *Config, walker ModuleWalker) hcl.Diagnostics {
 				CallRange:         run.Module.DeclRange,
 			}
 
-			cfg, modDiags := loadModule(root, &req, walker)
+			cfg, modDiags := loadModule(root, &req, walker, nil)
 			diags = append(diags, modDiags...)
 
 			if cfg != nil {
@@ -118,6 +118,7 @@ func buildChildModules(parent *Config, walker ModuleWalker) (map[string]*Config,
 	ret := map[string]*Config{}
 
 	calls := parent.Module.ModuleCalls
+	eval := parent.Module.staticEval()
 
 	// We'll sort the calls by their local names so that they'll appear in a
 	// predictable order in any logging that's produced during the walk.
@@ -133,6 +134,10 @@ func buildChildModules(parent *Config, walker ModuleWalker) (map[string]*Config,
 		copy(path, parent.Path)
 		path[len(path)-1] = call.Name
 
+		// The source address and version constraint may refer to input
+		// variables and local values, so they're resolved only now.
+		diags = append(diags, call.decodeStaticFields(eval)...)
+
 		req := ModuleRequest{
 			Name:              call.Name,
 			Path:              path,
@@ -142,7 +147,7 @@ func buildChildModules(parent *Config, walker ModuleWalker) (map[string]*Config,
 			Parent:            parent,
 			CallRange:         call.DeclRange,
 		}
-		child, modDiags := loadModule(parent.Root, &req, walker)
+		child, modDiags := loadModule(parent.Root, &req, walker, eval.callInputs(call))
 		diags = append(diags, modDiags...)
 		if child == nil {
 			// This means an error occurred, there should be diagnostics within
@@ -156,7 +161,9 @@ func buildChildModules(parent *Config, walker ModuleWalker) (map[string]*Config,
 	return ret, diags
 }
 
-func loadModule(root *Config, req *ModuleRequest, walker ModuleWalker) (*Config, hcl.Diagnostics) {
+// loadModule loads the module for the given request, statically evaluating
+// its expressions with the given input values.
+func loadModule(root *Config, req *ModuleRequest, walker ModuleWalker, inputs StaticInputs) (*Config, hcl.Diagnostics) {
 	var diags hcl.Diagnostics
 
 	mod, ver, modDiags := walker.LoadModule(req)
@@ -167,6 +174,7 @@ func loadModule(root *Config, req *ModuleRequest, walker ModuleWalker) (*Config,
 		// returned at least one error diagnostic in that case.
 		return nil, diags
 	}
+	mod.staticEvaluator = NewStaticEvaluator(mod, inputs)
 
 	cfg := &Config{
 		Parent:          req.Parent,
@@ -191,6 +199,15 @@ func loadModule(root *Config, req *ModuleRequest, walker ModuleWalker) (*Config,
 		})
 	}
 
+	for _, policy := range mod.Policies {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagWarning,
+			Summary:  "Policy configuration ignored",
+			Detail:   "Policies apply to the changes in the entire plan, so OpenTofu evaluates policy blocks only in the root module.\n\nThis is a warning rather than an error because it's sometimes convenient to temporarily call a root module as a child module for testing purposes, but this policy block will have no effect.",
+			Subject:  policy.DeclRange.Ptr(),
+		})
+	}
+
 	if len(mod.Import) > 0 {
 		diags = diags.Append(&hcl.Diagnostic{
 			Severity: hcl.DiagError,
@@ -603,6 +603,46 @@ Use the provider argument in the target resource block to configure the provider
 	})
 }
 
+func TestConfigCopyForTest(t *testing.T) {
+	cfg, diags := testNestedModuleConfigFromDir(t, "testdata/valid-modules/nested-providers-fqns")
+	assertNoDiagnostics(t, diags)
+
+	cp := cfg.CopyForTest()
+	if cp == cfg || cp.Module == cfg.Module {
+		t.Fatalf("root config was not copied")
+	}
+	if cp.Root != cp {
+		t.Errorf("copy's root is not the copy itself")
+	}
+	for name, child := range cp.Children {
+		if child == cfg.Children[name] {
+			t.Errorf("child %q was not copied", name)
+		}
+		if child.Root != cp || child.Parent != cp {
+			t.Errorf("child %q does not link to the copied root", name)
+		}
+		if child.Module != cfg.Children[name].Module {
+			t.Errorf("child %q module was copied, but should be shared", name)
+		}
+	}
+
+	// Transforming the copy must not affect the original.
+	cp.Module.Variables["new"] = &Variable{Name: "new"}
+	reset, diags := cp.TransformForTest(nil, &TestFile{
+		Providers: map[string]*Provider{
+			"test": {Name: "test"},
+		},
+	})
+	assertNoDiagnostics(t, diags)
+	defer reset()
+	if _, exists := cfg.Module.Variables["new"]; exists {
+		t.Errorf("variable added to the copy was added to the original")
+	}
+	if _, exists := cfg.Module.ProviderConfigs["test"]; exists {
+		t.Errorf("provider added to the copy was added to the original")
+	}
+}
+
 func TestTransformForTest(t *testing.T) {
 
 	str := func(providers map[string]string) string {
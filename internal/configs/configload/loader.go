@@ -164,3 +164,13 @@ func (l *Loader) ImportSourcesFromSnapshot(snap *Snapshot) {
 func (l *Loader) AllowLanguageExperiments(allowed bool) {
 	l.parser.AllowLanguageExperiments(allowed)
 }
+
+// SetStaticInputs specifies the values of the root module's input variables
+// that are used to evaluate the backend configuration and the source
+// addresses of module calls, which must be known before planning.
+//
+// This must be called before any configuration is loaded for it to have an
+// effect.
+func (l *Loader) SetStaticInputs(inputs configs.StaticInputs) {
+	l.parser.SetStaticInputs(inputs)
+}
@@ -5,6 +5,7 @@ package configs
 
 import (
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/opentofu/opentofu/internal/addrs"
 )
 
@@ -12,6 +13,17 @@ type Import struct {
 	ID hcl.Expression
 	To addrs.AbsResourceInstance
 
+	// ToKey is the expression giving the instance key of the "to" address
+	// when that key isn't a constant, such as in "aws_instance.a[each.key]".
+	// In that case To has no instance key of its own. ToKey may only be set
+	// when ForEach is also set.
+	ToKey hcl.Expression
+
+	// ForEach, if set, is the expression whose elements each describe a
+	// separate object to import, using each.key and each.value in ID and
+	// ToKey.
+	ForEach hcl.Expression
+
 	ProviderConfigRef *ProviderConfigRef
 	Provider          addrs.Provider
 
@@ -32,13 +44,33 @@ func decodeImportBlock(block *hcl.Block) (*Import, hcl.Diagnostics) {
 		imp.ID = attr.Expr
 	}
 
+	if attr, exists := content.Attributes["for_each"]; exists {
+		imp.ForEach = attr.Expr
+	}
+
 	if attr, exists := content.Attributes["to"]; exists {
-		traversal, traversalDiags := hcl.AbsTraversalForExpr(attr.Expr)
-		diags = append(diags, traversalDiags...)
-		if !traversalDiags.HasErrors() {
-			to, toDiags := addrs.ParseAbsResourceInstance(traversal)
-			diags = append(diags, toDiags.ToHCL()...)
-			imp.To = to
+		to, toKey, toDiags := decodeImportTo(attr.Expr)
+		diags = append(diags, toDiags...)
+		imp.To = to
+		imp.ToKey = toKey
+
+		switch {
+		case toDiags.HasErrors():
+			// Already reported.
+		case imp.ToKey != nil && imp.ForEach == nil:
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid import address",
+				Detail:   "The instance key of the import target address must be a constant value unless the import block uses for_each.",
+				Subject:  imp.ToKey.Range().Ptr(),
+			})
+		case imp.ToKey == nil && imp.ForEach != nil:
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid import address",
+				Detail:   "An import block with for_each must use each.key or each.value in the instance key of its target address, such as aws_instance.example[each.key], so that each element imports to a different resource instance.",
+				Subject:  attr.Expr.Range().Ptr(),
+			})
 		}
 	}
 
@@ -61,11 +93,53 @@ func decodeImportBlock(block *hcl.Block) (*Import, hcl.Diagnostics) {
 	return imp, diags
 }
 
+// decodeImportTo decodes the "to" argument of an import block. The instance
+// key of the resource address may be an arbitrary expression, in which case
+// it is returned separately and the returned address has no instance key.
+func decodeImportTo(expr hcl.Expression) (addrs.AbsResourceInstance, hcl.Expression, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	if indexExpr, ok := expr.(*hclsyntax.IndexExpr); ok {
+		// hcl.AbsTraversalForExpr only accepts constant keys, so we'll handle
+		// a non-constant key ourselves and decode the rest as usual.
+		if _, keyDiags := indexExpr.Key.Value(nil); keyDiags.HasErrors() {
+			traversal, traversalDiags := hcl.AbsTraversalForExpr(indexExpr.Collection)
+			diags = append(diags, traversalDiags...)
+			if traversalDiags.HasErrors() {
+				return addrs.AbsResourceInstance{}, nil, diags
+			}
+			to, toDiags := addrs.ParseAbsResourceInstance(traversal)
+			diags = append(diags, toDiags.ToHCL()...)
+			if !toDiags.HasErrors() && to.Resource.Key != addrs.NoKey {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid import address",
+					Detail:   "A resource instance address can have only one instance key.",
+					Subject:  expr.Range().Ptr(),
+				})
+			}
+			return to, indexExpr.Key, diags
+		}
+	}
+
+	traversal, traversalDiags := hcl.AbsTraversalForExpr(expr)
+	diags = append(diags, traversalDiags...)
+	if traversalDiags.HasErrors() {
+		return addrs.AbsResourceInstance{}, nil, diags
+	}
+	to, toDiags := addrs.ParseAbsResourceInstance(traversal)
+	diags = append(diags, toDiags.ToHCL()...)
+	return to, nil, diags
+}
+
 var importBlockSchema = &hcl.BodySchema{
 	Attributes: []hcl.AttributeSchema{
 		{
 			Name: "provider",
 		},
+		{
+			Name: "for_each",
+		},
 		{
 			Name:     "id",
 			Required: true,
@@ -9,6 +9,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hcltest"
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/zclconf/go-cty/cty"
@@ -33,6 +34,13 @@ func TestImportBlock_decode(t *testing.T) {
 
 	mod_bar_expr := hcltest.MockExprTraversalSrc("module.bar.test_instance.bar")
 
+	each_key_index_expr, hclDiags := hclsyntax.ParseExpression([]byte("test_instance.bar[each.key]"), "mock.tf", hcl.InitialPos)
+	if hclDiags.HasErrors() {
+		t.Fatal(hclDiags.Error())
+	}
+	each_key_expr := each_key_index_expr.(*hclsyntax.IndexExpr).Key
+	for_each_expr := hcltest.MockExprLiteral(cty.MapVal(map[string]cty.Value{"one": cty.StringVal("foo")}))
+
 	tests := map[string]struct {
 		input *hcl.Block
 		want  *Import
@@ -110,6 +118,90 @@ func TestImportBlock_decode(t *testing.T) {
 			},
 			``,
 		},
+		"for_each": {
+			&hcl.Block{
+				Type: "import",
+				Body: hcltest.MockBody(&hcl.BodyContent{
+					Attributes: hcl.Attributes{
+						"for_each": {
+							Name: "for_each",
+							Expr: for_each_expr,
+						},
+						"id": {
+							Name: "id",
+							Expr: foo_str_expr,
+						},
+						"to": {
+							Name: "to",
+							Expr: each_key_index_expr,
+						},
+					},
+				}),
+				DefRange: blockRange,
+			},
+			&Import{
+				To:        mustAbsResourceInstanceAddr("test_instance.bar"),
+				ToKey:     each_key_expr,
+				ForEach:   for_each_expr,
+				ID:        foo_str_expr,
+				DeclRange: blockRange,
+			},
+			``,
+		},
+		"error: dynamic key without for_each": {
+			&hcl.Block{
+				Type: "import",
+				Body: hcltest.MockBody(&hcl.BodyContent{
+					Attributes: hcl.Attributes{
+						"id": {
+							Name: "id",
+							Expr: foo_str_expr,
+						},
+						"to": {
+							Name: "to",
+							Expr: each_key_index_expr,
+						},
+					},
+				}),
+				DefRange: blockRange,
+			},
+			&Import{
+				To:        mustAbsResourceInstanceAddr("test_instance.bar"),
+				ToKey:     each_key_expr,
+				ID:        foo_str_expr,
+				DeclRange: blockRange,
+			},
+			"Invalid import address",
+		},
+		"error: for_each with constant key": {
+			&hcl.Block{
+				Type: "import",
+				Body: hcltest.MockBody(&hcl.BodyContent{
+					Attributes: hcl.Attributes{
+						"for_each": {
+							Name: "for_each",
+							Expr: for_each_expr,
+						},
+						"id": {
+							Name: "id",
+							Expr: foo_str_expr,
+						},
+						"to": {
+							Name: "to",
+							Expr: bar_index_expr,
+						},
+					},
+				}),
+				DefRange: blockRange,
+			},
+			&Import{
+				To:        mustAbsResourceInstanceAddr("test_instance.bar[\"one\"]"),
+				ForEach:   for_each_expr,
+				ID:        foo_str_expr,
+				DeclRange: blockRange,
+			},
+			"Invalid import address",
+		},
 		"error: missing id argument": {
 			&hcl.Block{
 				Type: "import",
@@ -165,7 +257,10 @@ func TestImportBlock_decode(t *testing.T) {
 				t.Fatal("expected error")
 			}
 
-			if !cmp.Equal(got, test.want, typeComparer, valueComparer) {
+			// hclsyntax expressions have unexported fields, so we compare
+			// expressions by identity instead.
+			exprComparer := cmp.Comparer(func(a, b hclsyntax.Expression) bool { return a == b })
+			if !cmp.Equal(got, test.want, typeComparer, valueComparer, exprComparer) {
 				t.Fatalf("wrong result: %s", cmp.Diff(got, test.want))
 			}
 		})
@@ -0,0 +1,336 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
+// MockData describes the values that a mock provider returns in place of
+// calling the real provider.
+//
+// Any computed attributes of a resource or data source that aren't given a
+// default value here are filled with generated values instead.
+type MockData struct {
+	// MockResources and MockDataResources hold the default values for each
+	// managed resource type and data source type respectively, keyed by type
+	// name.
+	MockResources     map[string]*MockResource
+	MockDataResources map[string]*MockResource
+}
+
+// MockResource represents a "mock_resource" or "mock_data" block within a
+// "mock_provider" block, giving default values for all resources or data
+// sources of a particular type.
+type MockResource struct {
+	Mode addrs.ResourceMode
+	Type string
+
+	// Defaults is an object value whose attributes are used in place of
+	// generated values for computed attributes. It is never null, but might
+	// be an empty object.
+	Defaults cty.Value
+
+	DeclRange hcl.Range
+	TypeRange hcl.Range
+}
+
+// OverrideKind describes what sort of object an Override applies to.
+type OverrideKind rune
+
+const (
+	// OverrideResource is the kind of an "override_resource" block.
+	OverrideResource OverrideKind = 'R'
+
+	// OverrideDataResource is the kind of an "override_data" block.
+	OverrideDataResource OverrideKind = 'D'
+
+	// OverrideModule is the kind of an "override_module" block.
+	OverrideModule OverrideKind = 'M'
+)
+
+// Override represents an "override_resource", "override_data" or
+// "override_module" block within a test file or run block.
+//
+// An overridden resource or data source never calls its provider, and
+// instead behaves as if its provider were mocked with Values as the defaults.
+// An overridden module returns Values as its output values, and every
+// resource and data source inside it is mocked with generated values.
+type Override struct {
+	Kind OverrideKind
+
+	// Target is the address of the overridden object. For resources and data
+	// sources this is either an addrs.AbsResource or an
+	// addrs.AbsResourceInstance, and for modules it is an
+	// addrs.ModuleInstance.
+	Target addrs.Targetable
+
+	// Values is an object value containing either the attribute values of the
+	// overridden resource or data source, or the output values of the
+	// overridden module. It is never null, but might be an empty object.
+	Values cty.Value
+
+	DeclRange   hcl.Range
+	TargetRange hcl.Range
+}
+
+func decodeMockProviderBlock(block *hcl.Block) (*Provider, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	content, moreDiags := block.Body.Content(mockProviderBlockSchema)
+	diags = append(diags, moreDiags...)
+
+	name := block.Labels[0]
+	nameDiags := checkProviderNameNormalized(name, block.DefRange)
+	diags = append(diags, nameDiags...)
+	if nameDiags.HasErrors() {
+		// If the name is invalid then we mustn't produce a result because
+		// downstreams could try to use it as a provider type and then crash.
+		return nil, diags
+	}
+
+	provider := &Provider{
+		Name:      name,
+		NameRange: block.LabelRanges[0],
+		DeclRange: block.DefRange,
+
+		// Mock providers are never configured, so they have no configuration
+		// of their own.
+		Config: hcl.EmptyBody(),
+
+		Mock: true,
+		MockData: &MockData{
+			MockResources:     make(map[string]*MockResource),
+			MockDataResources: make(map[string]*MockResource),
+		},
+	}
+
+	if attr, exists := content.Attributes["alias"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &provider.Alias)
+		diags = append(diags, valDiags...)
+		provider.AliasRange = attr.Expr.Range().Ptr()
+
+		if !hclsyntax.ValidIdentifier(provider.Alias) {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid provider configuration alias",
+				Detail:   fmt.Sprintf("An alias must be a valid name. %s", badIdentifierDetail),
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+		}
+	}
+
+	for _, block := range content.Blocks {
+		res, resDiags := decodeMockResourceBlock(block)
+		diags = append(diags, resDiags...)
+		if resDiags.HasErrors() {
+			continue
+		}
+
+		resources := provider.MockData.MockResources
+		if res.Mode == addrs.DataResourceMode {
+			resources = provider.MockData.MockDataResources
+		}
+		if existing, exists := resources[res.Type]; exists {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("Duplicate %q block", block.Type),
+				Detail:   fmt.Sprintf("This mock provider already has a %q block for %q, defined at %s.", block.Type, res.Type, existing.DeclRange),
+				Subject:  block.DefRange.Ptr(),
+			})
+			continue
+		}
+		resources[res.Type] = res
+	}
+
+	return provider, diags
+}
+
+func decodeMockResourceBlock(block *hcl.Block) (*MockResource, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	content, moreDiags := block.Body.Content(mockResourceBlockSchema)
+	diags = append(diags, moreDiags...)
+
+	res := &MockResource{
+		Mode:      addrs.ManagedResourceMode,
+		Type:      block.Labels[0],
+		Defaults:  cty.EmptyObjectVal,
+		DeclRange: block.DefRange,
+		TypeRange: block.LabelRanges[0],
+	}
+	if block.Type == "mock_data" {
+		res.Mode = addrs.DataResourceMode
+	}
+
+	if attr, exists := content.Attributes["defaults"]; exists {
+		val, valDiags := decodeStaticObject(attr)
+		diags = append(diags, valDiags...)
+		res.Defaults = val
+	}
+
+	return res, diags
+}
+
+func decodeOverrideBlock(block *hcl.Block) (*Override, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	override := &Override{
+		Values:    cty.EmptyObjectVal,
+		DeclRange: block.DefRange,
+	}
+
+	valuesAttrName := "values"
+	switch block.Type {
+	case "override_resource":
+		override.Kind = OverrideResource
+	case "override_data":
+		override.Kind = OverrideDataResource
+	case "override_module":
+		override.Kind = OverrideModule
+		valuesAttrName = "outputs"
+	}
+
+	content, moreDiags := block.Body.Content(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{
+			{Name: "target", Required: true},
+			{Name: valuesAttrName},
+		},
+	})
+	diags = append(diags, moreDiags...)
+
+	if attr, exists := content.Attributes["target"]; exists {
+		override.TargetRange = attr.Expr.Range()
+
+		traversal, traversalDiags := hcl.AbsTraversalForExpr(attr.Expr)
+		diags = append(diags, traversalDiags...)
+		if !traversalDiags.HasErrors() {
+			target, targetDiags := addrs.ParseTarget(traversal)
+			diags = append(diags, targetDiags.ToHCL()...)
+			if !targetDiags.HasErrors() {
+				override.Target = target.Subject
+				diags = append(diags, override.validateTarget()...)
+			}
+		}
+	}
+
+	if attr, exists := content.Attributes[valuesAttrName]; exists {
+		val, valDiags := decodeStaticObject(attr)
+		diags = append(diags, valDiags...)
+		override.Values = val
+	}
+
+	return override, diags
+}
+
+// validateTarget checks that the target of the override is the right kind of
+// object for the kind of override.
+func (o *Override) validateTarget() hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	var mode addrs.ResourceMode
+	switch target := o.Target.(type) {
+	case addrs.AbsResource:
+		mode = target.Resource.Mode
+	case addrs.AbsResourceInstance:
+		mode = target.Resource.Resource.Mode
+	case addrs.ModuleInstance:
+		if o.Kind != OverrideModule {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid override target",
+				Detail:   fmt.Sprintf("The target of an override block for a resource or data source must be a resource or data source address, not %s. Use an override_module block to override a module.", target),
+				Subject:  o.TargetRange.Ptr(),
+			})
+		}
+		return diags
+	}
+
+	switch {
+	case o.Kind == OverrideModule:
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid override target",
+			Detail:   fmt.Sprintf("The target of an override_module block must be a module call, not %s.", o.Target),
+			Subject:  o.TargetRange.Ptr(),
+		})
+	case o.Kind == OverrideResource && mode != addrs.ManagedResourceMode:
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid override target",
+			Detail:   fmt.Sprintf("The target of an override_resource block must be a managed resource, not %s. Use an override_data block to override a data source.", o.Target),
+			Subject:  o.TargetRange.Ptr(),
+		})
+	case o.Kind == OverrideDataResource && mode != addrs.DataResourceMode:
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid override target",
+			Detail:   fmt.Sprintf("The target of an override_data block must be a data source, not %s. Use an override_resource block to override a managed resource.", o.Target),
+			Subject:  o.TargetRange.Ptr(),
+		})
+	}
+	return diags
+}
+
+// decodeStaticObject decodes the given attribute as an object value that must
+// not depend on any references or function calls, since the values in mock
+// and override blocks are needed before anything else is evaluated.
+func decodeStaticObject(attr *hcl.Attribute) (cty.Value, hcl.Diagnostics) {
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() {
+		return cty.EmptyObjectVal, diags
+	}
+
+	switch {
+	case val.IsNull():
+		return cty.EmptyObjectVal, diags
+	case !val.Type().IsObjectType() && !val.Type().IsMapType():
+		return cty.EmptyObjectVal, append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid mock values",
+			Detail:   fmt.Sprintf("The %q argument requires an object, mapping attribute names to their values.", attr.Name),
+			Subject:  attr.Expr.Range().Ptr(),
+		})
+	case !val.IsWhollyKnown():
+		return cty.EmptyObjectVal, append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid mock values",
+			Detail:   fmt.Sprintf("The %q argument must not contain unknown values.", attr.Name),
+			Subject:  attr.Expr.Range().Ptr(),
+		})
+	}
+
+	// We always work with objects, so that the attributes can have different
+	// types.
+	return cty.ObjectVal(val.AsValueMap()), diags
+}
+
+var mockProviderBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "alias"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type:       "mock_resource",
+			LabelNames: []string{"type"},
+		},
+		{
+			Type:       "mock_data",
+			LabelNames: []string{"type"},
+		},
+	},
+}
+
+var mockResourceBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "defaults"},
+	},
+}
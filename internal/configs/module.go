@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/hcl/v2"
 
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/experiments"
 
 	tfversion "github.com/opentofu/opentofu/version"
@@ -35,6 +36,7 @@ type Module struct {
 
 	Backend              *Backend
 	CloudConfig          *CloudConfig
+	Encryption           *encryption.Config
 	ProviderConfigs      map[string]*Provider
 	ProviderRequirements *RequiredProviders
 	ProviderLocalNames   map[addrs.Provider]string
@@ -49,12 +51,19 @@ type Module struct {
 	ManagedResources map[string]*Resource
 	DataResources    map[string]*Resource
 
-	Moved  []*Moved
-	Import []*Import
+	Moved   []*Moved
+	Removed []*Removed
+	Import  []*Import
 
 	Checks map[string]*Check
 
+	Policies map[string]*Policy
+
 	Tests map[string]*TestFile
+
+	// staticEvaluator evaluates the parts of the module that must be known
+	// before planning. See Module.staticEval.
+	staticEvaluator *StaticEvaluator
 }
 
 // File describes the contents of a single configuration file.
@@ -75,6 +84,7 @@ type File struct {
 
 	Backends          []*Backend
 	CloudConfigs      []*CloudConfig
+	Encryptions       []*encryption.Config
 	ProviderConfigs   []*Provider
 	ProviderMetas     []*ProviderMeta
 	RequiredProviders []*RequiredProviders
@@ -88,10 +98,13 @@ type File struct {
 	ManagedResources []*Resource
 	DataResources    []*Resource
 
-	Moved  []*Moved
-	Import []*Import
+	Moved   []*Moved
+	Removed []*Removed
+	Import  []*Import
 
 	Checks []*Check
+
+	Policies []*Policy
 }
 
 // NewModuleWithTests matches NewModule except it will also load in the provided
@@ -124,6 +137,7 @@ func NewModule(primaryFiles, overrideFiles []*File) (*Module, hcl.Diagnostics) {
 		ManagedResources:   map[string]*Resource{},
 		DataResources:      map[string]*Resource{},
 		Checks:             map[string]*Check{},
+		Policies:           map[string]*Policy{},
 		ProviderMetas:      map[addrs.Provider]*ProviderMeta{},
 		Tests:              map[string]*TestFile{},
 	}
@@ -178,9 +192,43 @@ func NewModule(primaryFiles, overrideFiles []*File) (*Module, hcl.Diagnostics) {
 	// Generate the FQN -> LocalProviderName map
 	mod.gatherProviderLocalNames()
 
+	// The backend configuration must be known before planning, so it is
+	// evaluated statically and may refer to input variables and local values.
+	if mod.Backend != nil {
+		backend := *mod.Backend
+		backend.Config = staticBody{
+			body: backend.Config,
+			eval: mod.staticEval,
+			what: "the backend configuration",
+		}
+		mod.Backend = &backend
+	}
+
+	// Module source addresses and version constraints are evaluated only
+	// once the module's inputs are known, but we can already report any
+	// references that could never be evaluated statically.
+	for _, mc := range mod.ModuleCalls {
+		diags = append(diags, mc.checkStaticFields(mod.staticEval())...)
+	}
+
 	return mod, diags
 }
 
+// staticEval returns the evaluator for the expressions in the module that
+// must be known before planning, such as the backend configuration and the
+// source addresses of module calls.
+//
+// Modules loaded by a Parser use the input values given to
+// Parser.SetStaticInputs, and child modules are given the values of the
+// arguments in their calls by BuildConfig. Otherwise, all input variables
+// take their default values.
+func (m *Module) staticEval() *StaticEvaluator {
+	if m.staticEvaluator == nil {
+		m.staticEvaluator = NewStaticEvaluator(m, nil)
+	}
+	return m.staticEvaluator
+}
+
 // ResourceByAddr returns the configuration for the resource with the given
 // address, or nil if there is no such resource.
 func (m *Module) ResourceByAddr(addr addrs.Resource) *Resource {
@@ -231,6 +279,19 @@ func (m *Module) appendFile(file *File) hcl.Diagnostics {
 		m.CloudConfig = c
 	}
 
+	for _, e := range file.Encryptions {
+		if m.Encryption != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate encryption configuration",
+				Detail:   fmt.Sprintf("A module may have only one encryption configuration. Encryption was previously configured at %s.", m.Encryption.DeclRange),
+				Subject:  &e.DeclRange,
+			})
+			continue
+		}
+		m.Encryption = e
+	}
+
 	if m.Backend != nil && m.CloudConfig != nil {
 		diags = append(diags, &hcl.Diagnostic{
 			Severity: hcl.DiagError,
@@ -397,6 +458,19 @@ func (m *Module) appendFile(file *File) hcl.Diagnostics {
 		m.Checks[c.Name] = c
 	}
 
+	for _, p := range file.Policies {
+		if existing, exists := m.Policies[p.Name]; exists {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("Duplicate policy %q configuration", existing.Name),
+				Detail:   fmt.Sprintf("A policy block named %q was already declared at %s. Policy blocks must be unique within each module.", existing.Name, existing.DeclRange),
+				Subject:  &p.DeclRange,
+			})
+			continue
+		}
+		m.Policies[p.Name] = p
+	}
+
 	// Handle the provider associations for all data resources together.
 	for _, r := range m.DataResources {
 		// set the provider FQN for the resource
@@ -420,6 +494,20 @@ func (m *Module) appendFile(file *File) hcl.Diagnostics {
 	// runtime.)
 	m.Moved = append(m.Moved, file.Moved...)
 
+	for _, r := range file.Removed {
+		for _, mr := range m.Removed {
+			if r.From != nil && mr.From != nil && r.From.String() == mr.From.String() {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Duplicate \"removed\" block",
+					Detail:   fmt.Sprintf("A removed block for %s was already declared at %s. An object can have only one removed block.", r.From, mr.DeclRange),
+					Subject:  &r.DeclRange,
+				})
+			}
+		}
+		m.Removed = append(m.Removed, r)
+	}
+
 	for _, i := range file.Import {
 		for _, mi := range m.Import {
 			if i.To.Equal(mi.To) {
@@ -514,6 +602,20 @@ func (m *Module) mergeFile(file *File) hcl.Diagnostics {
 		}
 	}
 
+	if len(file.Encryptions) != 0 {
+		switch len(file.Encryptions) {
+		case 1:
+			m.Encryption = file.Encryptions[0]
+		default:
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate encryption configuration",
+				Detail:   fmt.Sprintf("Each override file may have only one encryption configuration. Encryption was previously configured at %s.", file.Encryptions[0].DeclRange),
+				Subject:  &file.Encryptions[1].DeclRange,
+			})
+		}
+	}
+
 	for _, pc := range file.ProviderConfigs {
 		key := pc.moduleUniqueKey()
 		existing, exists := m.ProviderConfigs[key]
@@ -647,6 +749,15 @@ func (m *Module) mergeFile(file *File) hcl.Diagnostics {
 		})
 	}
 
+	for _, m := range file.Removed {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Cannot override 'removed' blocks",
+			Detail:   "Records of removed objects can appear only in normal files, not in override files.",
+			Subject:  m.DeclRange.Ptr(),
+		})
+	}
+
 	for _, m := range file.Import {
 		diags = append(diags, &hcl.Diagnostic{
 			Severity: hcl.DiagError,
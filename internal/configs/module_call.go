@@ -35,6 +35,13 @@ type ModuleCall struct {
 	DependsOn []hcl.Traversal
 
 	DeclRange hcl.Range
+
+	// sourceExpr and versionAttr are the source and version arguments, if
+	// they refer to input variables or local values. In that case they are
+	// decoded into the fields above by decodeStaticFields, once the inputs
+	// of the module containing the call are known.
+	sourceExpr  hcl.Expression
+	versionAttr *hcl.Attribute
 }
 
 func decodeModuleBlock(block *hcl.Block, override bool) (*ModuleCall, hcl.Diagnostics) {
@@ -65,72 +72,28 @@ func decodeModuleBlock(block *hcl.Block, override bool) (*ModuleCall, hcl.Diagno
 
 	haveVersionArg := false
 	if attr, exists := content.Attributes["version"]; exists {
-		var versionDiags hcl.Diagnostics
-		mc.Version, versionDiags = decodeVersionConstraint(attr)
-		diags = append(diags, versionDiags...)
 		haveVersionArg = true
+		if len(attr.Expr.Variables()) != 0 {
+			// The version constraint refers to input variables or local
+			// values, so we can decode it only once the module's inputs
+			// are known. See decodeStaticFields.
+			mc.versionAttr = attr
+		} else {
+			var versionDiags hcl.Diagnostics
+			mc.Version, versionDiags = decodeVersionConstraint(attr)
+			diags = append(diags, versionDiags...)
+		}
 	}
 
 	if attr, exists := content.Attributes["source"]; exists {
 		mc.SourceSet = true
 		mc.SourceAddrRange = attr.Expr.Range()
-		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &mc.SourceAddrRaw)
-		diags = append(diags, valDiags...)
-		if !valDiags.HasErrors() {
-			var addr addrs.ModuleSource
-			var err error
-			if haveVersionArg {
-				addr, err = addrs.ParseModuleSourceRegistry(mc.SourceAddrRaw)
-			} else {
-				addr, err = addrs.ParseModuleSource(mc.SourceAddrRaw)
-			}
-			mc.SourceAddr = addr
-			if err != nil {
-				// NOTE: We leave mc.SourceAddr as nil for any situation where the
-				// source attribute is invalid, so any code which tries to carefully
-				// use the partial result of a failed config decode must be
-				// resilient to that.
-				mc.SourceAddr = nil
-
-				// NOTE: In practice it's actually very unlikely to end up here,
-				// because our source address parser can turn just about any string
-				// into some sort of remote package address, and so for most errors
-				// we'll detect them only during module installation. There are
-				// still a _few_ purely-syntax errors we can catch at parsing time,
-				// though, mostly related to remote package sub-paths and local
-				// paths.
-				switch err := err.(type) {
-				case *getmodules.MaybeRelativePathErr:
-					diags = append(diags, &hcl.Diagnostic{
-						Severity: hcl.DiagError,
-						Summary:  "Invalid module source address",
-						Detail: fmt.Sprintf(
-							"OpenTofu failed to determine your intended installation method for remote module package %q.\n\nIf you intended this as a path relative to the current module, use \"./%s\" instead. The \"./\" prefix indicates that the address is a relative filesystem path.",
-							err.Addr, err.Addr,
-						),
-						Subject: mc.SourceAddrRange.Ptr(),
-					})
-				default:
-					if haveVersionArg {
-						// In this case we'll include some extra context that
-						// we assumed a registry source address due to the
-						// version argument.
-						diags = append(diags, &hcl.Diagnostic{
-							Severity: hcl.DiagError,
-							Summary:  "Invalid registry module source address",
-							Detail:   fmt.Sprintf("Failed to parse module registry address: %s.\n\nOpenTofu assumed that you intended a module registry source address because you also set the argument \"version\", which applies only to registry modules.", err),
-							Subject:  mc.SourceAddrRange.Ptr(),
-						})
-					} else {
-						diags = append(diags, &hcl.Diagnostic{
-							Severity: hcl.DiagError,
-							Summary:  "Invalid module source address",
-							Detail:   fmt.Sprintf("Failed to parse module source address: %s.", err),
-							Subject:  mc.SourceAddrRange.Ptr(),
-						})
-					}
-				}
-			}
+		if len(attr.Expr.Variables()) != 0 {
+			// As with the version constraint above, this is decoded later
+			// by decodeStaticFields.
+			mc.sourceExpr = attr.Expr
+		} else {
+			diags = append(diags, mc.decodeSource(attr.Expr, haveVersionArg)...)
 		}
 	}
 
@@ -200,6 +163,134 @@ func decodeModuleBlock(block *hcl.Block, override bool) (*ModuleCall, hcl.Diagno
 	return mc, diags
 }
 
+// decodeSource decodes the given source address expression into the
+// SourceAddr and SourceAddrRaw fields.
+func (mc *ModuleCall) decodeSource(expr hcl.Expression, haveVersionArg bool) hcl.Diagnostics {
+	mc.SourceAddr = nil
+	diags := gohcl.DecodeExpression(expr, nil, &mc.SourceAddrRaw)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	var addr addrs.ModuleSource
+	var err error
+	if haveVersionArg {
+		addr, err = addrs.ParseModuleSourceRegistry(mc.SourceAddrRaw)
+	} else {
+		addr, err = addrs.ParseModuleSource(mc.SourceAddrRaw)
+	}
+	mc.SourceAddr = addr
+	if err != nil {
+		// NOTE: We leave mc.SourceAddr as nil for any situation where the
+		// source attribute is invalid, so any code which tries to carefully
+		// use the partial result of a failed config decode must be
+		// resilient to that.
+		mc.SourceAddr = nil
+
+		// NOTE: In practice it's actually very unlikely to end up here,
+		// because our source address parser can turn just about any string
+		// into some sort of remote package address, and so for most errors
+		// we'll detect them only during module installation. There are
+		// still a _few_ purely-syntax errors we can catch at parsing time,
+		// though, mostly related to remote package sub-paths and local
+		// paths.
+		switch err := err.(type) {
+		case *getmodules.MaybeRelativePathErr:
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid module source address",
+				Detail: fmt.Sprintf(
+					"OpenTofu failed to determine your intended installation method for remote module package %q.\n\nIf you intended this as a path relative to the current module, use \"./%s\" instead. The \"./\" prefix indicates that the address is a relative filesystem path.",
+					err.Addr, err.Addr,
+				),
+				Subject: mc.SourceAddrRange.Ptr(),
+			})
+		default:
+			if haveVersionArg {
+				// In this case we'll include some extra context that
+				// we assumed a registry source address due to the
+				// version argument.
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid registry module source address",
+					Detail:   fmt.Sprintf("Failed to parse module registry address: %s.\n\nOpenTofu assumed that you intended a module registry source address because you also set the argument \"version\", which applies only to registry modules.", err),
+					Subject:  mc.SourceAddrRange.Ptr(),
+				})
+			} else {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid module source address",
+					Detail:   fmt.Sprintf("Failed to parse module source address: %s.", err),
+					Subject:  mc.SourceAddrRange.Ptr(),
+				})
+			}
+		}
+	}
+	return diags
+}
+
+// checkStaticFields returns error diagnostics for any references in the
+// source address and version constraint of the call that cannot be evaluated
+// statically, using the given evaluator for the module that contains the
+// call. If there are any, the call is treated as having an invalid source
+// address or version constraint from then on.
+func (mc *ModuleCall) checkStaticFields(eval *StaticEvaluator) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	if mc.versionAttr != nil {
+		versionDiags := eval.CheckReferences(mc.versionAttr.Expr, fmt.Sprintf("the version constraint of module %q", mc.Name))
+		diags = append(diags, versionDiags...)
+		if versionDiags.HasErrors() {
+			mc.Version = VersionConstraint{DeclRange: mc.versionAttr.Range}
+			mc.versionAttr = nil
+		}
+	}
+
+	if mc.sourceExpr != nil {
+		sourceDiags := eval.CheckReferences(mc.sourceExpr, fmt.Sprintf("the source address of module %q", mc.Name))
+		diags = append(diags, sourceDiags...)
+		if sourceDiags.HasErrors() {
+			mc.sourceExpr = nil
+		}
+	}
+
+	return diags
+}
+
+// decodeStaticFields decodes the source address and version constraint of
+// the call if they refer to input variables or local values, using the given
+// evaluator for the module that contains the call. It does nothing for calls
+// whose source address and version constraint were already decoded.
+func (mc *ModuleCall) decodeStaticFields(eval *StaticEvaluator) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	if mc.versionAttr != nil {
+		mc.Version = VersionConstraint{DeclRange: mc.versionAttr.Range}
+		val, valDiags := eval.Evaluate(mc.versionAttr.Expr, fmt.Sprintf("the version constraint of module %q", mc.Name))
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() {
+			attr := *mc.versionAttr
+			attr.Expr = hcl.StaticExpr(val, attr.Expr.Range())
+			var versionDiags hcl.Diagnostics
+			mc.Version, versionDiags = decodeVersionConstraint(&attr)
+			diags = append(diags, versionDiags...)
+		}
+	}
+
+	if mc.sourceExpr != nil {
+		mc.SourceAddr = nil
+		mc.SourceAddrRaw = ""
+		val, valDiags := eval.Evaluate(mc.sourceExpr, fmt.Sprintf("the source address of module %q", mc.Name))
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() {
+			haveVersionArg := mc.Version.DeclRange != hcl.Range{}
+			diags = append(diags, mc.decodeSource(hcl.StaticExpr(val, mc.SourceAddrRange), haveVersionArg)...)
+		}
+	}
+
+	return diags
+}
+
 // EntersNewPackage returns true if this call is to an external module, either
 // directly via a remote source address or indirectly via a registry source
 // address.
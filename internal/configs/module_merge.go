@@ -49,6 +49,10 @@ func (v *Variable) merge(ov *Variable) hcl.Diagnostics {
 		v.Sensitive = ov.Sensitive
 		v.SensitiveSet = ov.SensitiveSet
 	}
+	if ov.StaticSet {
+		v.Static = ov.Static
+		v.StaticSet = ov.StaticSet
+	}
 	if ov.Default != cty.NilVal {
 		v.Default = ov.Default
 	}
@@ -171,6 +175,7 @@ func (mc *ModuleCall) merge(omc *ModuleCall) hcl.Diagnostics {
 		mc.SourceAddrRaw = omc.SourceAddrRaw
 		mc.SourceAddrRange = omc.SourceAddrRange
 		mc.SourceSet = omc.SourceSet
+		mc.sourceExpr = omc.sourceExpr
 	}
 
 	if omc.Count != nil {
@@ -181,8 +186,9 @@ func (mc *ModuleCall) merge(omc *ModuleCall) hcl.Diagnostics {
 		mc.ForEach = omc.ForEach
 	}
 
-	if len(omc.Version.Required) != 0 {
+	if len(omc.Version.Required) != 0 || omc.versionAttr != nil {
 		mc.Version = omc.Version
+		mc.versionAttr = omc.versionAttr
 	}
 
 	mc.Config = MergeBodies(mc.Config, omc.Config)
@@ -39,6 +39,14 @@ type Variable struct {
 	DescriptionSet bool
 	SensitiveSet   bool
 
+	// Static indicates that the variable may be used in the parts of the
+	// configuration that must be known before planning, such as the backend
+	// configuration and the source addresses of module calls. The value of
+	// a static variable must be given whenever the configuration is loaded,
+	// rather than only when planning.
+	Static    bool
+	StaticSet bool
+
 	// Nullable indicates that null is a valid value for this variable. Setting
 	// Nullable to false means that the module can expect this variable to
 	// never be null.
@@ -120,6 +128,12 @@ func decodeVariableBlock(block *hcl.Block, override bool) (*Variable, hcl.Diagno
 		v.SensitiveSet = true
 	}
 
+	if attr, exists := content.Attributes["static"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &v.Static)
+		diags = append(diags, valDiags...)
+		v.StaticSet = true
+	}
+
 	if attr, exists := content.Attributes["nullable"]; exists {
 		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &v.Nullable)
 		diags = append(diags, valDiags...)
@@ -538,6 +552,9 @@ var variableBlockSchema = &hcl.BodySchema{
 		{
 			Name: "sensitive",
 		},
+		{
+			Name: "static",
+		},
 		{
 			Name: "nullable",
 		},
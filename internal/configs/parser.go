@@ -27,6 +27,10 @@ type Parser struct {
 	// for itself whether to enable it so that tests can cover both the
 	// allowed and not-allowed situations.
 	allowExperiments bool
+
+	// staticInputs provides the values of the input variables of the modules
+	// loaded by this parser, for use in static evaluation.
+	staticInputs StaticInputs
 }
 
 // NewParser creates and returns a new Parser that reads files from the given
@@ -121,3 +125,17 @@ func (p *Parser) ForceFileSource(filename string, src []byte) {
 func (p *Parser) AllowLanguageExperiments(allowed bool) {
 	p.allowExperiments = allowed
 }
+
+// SetStaticInputs specifies the values of input variables that subsequent
+// LoadConfigDir (and similar) calls will use for static evaluation of the
+// loaded modules, such as of their backend configuration and the source
+// addresses of their module calls.
+//
+// These are the values for the root module. Child modules loaded by
+// BuildConfig instead take their values from the arguments of their calls.
+//
+// If this method is never called for a particular parser, all input
+// variables take their default values during static evaluation.
+func (p *Parser) SetStaticInputs(inputs StaticInputs) {
+	p.staticInputs = inputs
+}
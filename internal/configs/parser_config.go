@@ -5,6 +5,8 @@ package configs
 
 import (
 	"github.com/hashicorp/hcl/v2"
+
+	"github.com/opentofu/opentofu/internal/encryption"
 )
 
 // LoadConfigFile reads the file at the given path and parses it as a config
@@ -97,6 +99,13 @@ func (p *Parser) loadConfigFile(path string, override bool) (*File, hcl.Diagnost
 						file.CloudConfigs = append(file.CloudConfigs, cloudCfg)
 					}
 
+				case "encryption":
+					encryptionCfg, cfgDiags := encryption.DecodeConfig(innerBlock.Body, innerBlock.DefRange)
+					diags = append(diags, cfgDiags...)
+					if encryptionCfg != nil {
+						file.Encryptions = append(file.Encryptions, encryptionCfg)
+					}
+
 				case "required_providers":
 					reqs, reqsDiags := decodeRequiredProvidersBlock(innerBlock)
 					diags = append(diags, reqsDiags...)
@@ -180,6 +189,13 @@ func (p *Parser) loadConfigFile(path string, override bool) (*File, hcl.Diagnost
 				file.Moved = append(file.Moved, cfg)
 			}
 
+		case "removed":
+			cfg, cfgDiags := decodeRemovedBlock(block)
+			diags = append(diags, cfgDiags...)
+			if cfg != nil {
+				file.Removed = append(file.Removed, cfg)
+			}
+
 		case "import":
 			cfg, cfgDiags := decodeImportBlock(block)
 			diags = append(diags, cfgDiags...)
@@ -194,6 +210,13 @@ func (p *Parser) loadConfigFile(path string, override bool) (*File, hcl.Diagnost
 				file.Checks = append(file.Checks, cfg)
 			}
 
+		case "policy":
+			cfg, cfgDiags := decodePolicyBlock(block, override)
+			diags = append(diags, cfgDiags...)
+			if cfg != nil {
+				file.Policies = append(file.Policies, cfg)
+			}
+
 		default:
 			// Should never happen because the above cases should be exhaustive
 			// for all block type names in our schema.
@@ -284,6 +307,9 @@ var configFileSchema = &hcl.BodySchema{
 		{
 			Type: "moved",
 		},
+		{
+			Type: "removed",
+		},
 		{
 			Type: "import",
 		},
@@ -291,6 +317,10 @@ var configFileSchema = &hcl.BodySchema{
 			Type:       "check",
 			LabelNames: []string{"name"},
 		},
+		{
+			Type:       "policy",
+			LabelNames: []string{"name"},
+		},
 	},
 }
 
@@ -310,6 +340,9 @@ var terraformBlockSchema = &hcl.BodySchema{
 		{
 			Type: "cloud",
 		},
+		{
+			Type: "encryption",
+		},
 		{
 			Type: "required_providers",
 		},
@@ -51,6 +51,7 @@ func (p *Parser) LoadConfigDir(path string) (*Module, hcl.Diagnostics) {
 	diags = append(diags, modDiags...)
 
 	mod.SourceDir = path
+	mod.staticEvaluator = NewStaticEvaluator(mod, p.staticInputs)
 
 	return mod, diags
 }
@@ -74,6 +75,7 @@ func (p *Parser) LoadConfigDirWithTests(path string, testDirectory string) (*Mod
 	diags = append(diags, modDiags...)
 
 	mod.SourceDir = path
+	mod.staticEvaluator = NewStaticEvaluator(mod, p.staticInputs)
 
 	return mod, diags
 }
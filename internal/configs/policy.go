@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// PolicyPlanObjectName is the name of the only object policy conditions can
+// refer to, which describes the changes in the plan.
+const PolicyPlanObjectName = "plan"
+
+// Policy represents a configuration defined policy block.
+//
+// A policy block contains 1-n assert blocks, whose conditions are evaluated
+// against the plan object after OpenTofu has created a plan. Policy blocks
+// are allowed only in the root module.
+type Policy struct {
+	Name string
+
+	// Severity is hcl.DiagError if a failed assertion makes the plan fail,
+	// or hcl.DiagWarning if it only produces a warning.
+	Severity hcl.DiagnosticSeverity
+
+	Asserts []*CheckRule
+
+	DeclRange hcl.Range
+}
+
+func decodePolicyBlock(block *hcl.Block, override bool) (*Policy, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	policy := &Policy{
+		Name:      block.Labels[0],
+		Severity:  hcl.DiagError,
+		DeclRange: block.DefRange,
+	}
+
+	if override {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Can't override policy blocks",
+			Detail:   "Override files cannot override policy blocks.",
+			Subject:  policy.DeclRange.Ptr(),
+		})
+		return policy, diags
+	}
+
+	content, moreDiags := block.Body.Content(policyBlockSchema)
+	diags = append(diags, moreDiags...)
+
+	if !hclsyntax.ValidIdentifier(policy.Name) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid policy block name",
+			Detail:   badIdentifierDetail,
+			Subject:  &block.LabelRanges[0],
+		})
+	}
+
+	if attr, exists := content.Attributes["severity"]; exists {
+		var severity string
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &severity)
+		diags = append(diags, valDiags...)
+		switch {
+		case valDiags.HasErrors():
+		case severity == "error":
+			policy.Severity = hcl.DiagError
+		case severity == "warning":
+			policy.Severity = hcl.DiagWarning
+		default:
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid policy severity",
+				Detail:   `The severity of a policy must be either "error" or "warning".`,
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+		}
+	}
+
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case "assert":
+			assert, moreDiags := decodeCheckRuleBlock(block, override)
+			diags = append(diags, moreDiags...)
+			diags = append(diags, assert.validatePolicyReferences()...)
+			if !diags.HasErrors() {
+				policy.Asserts = append(policy.Asserts, assert)
+			}
+		default:
+			panic(fmt.Sprintf("unhandled policy nested block %q", block.Type))
+		}
+	}
+
+	if len(policy.Asserts) == 0 && !diags.HasErrors() {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Zero assert blocks",
+			Detail:   "Policy blocks must have at least one assert block.",
+			Subject:  policy.DeclRange.Ptr(),
+		})
+	}
+
+	return policy, diags
+}
+
+// validatePolicyReferences returns error diagnostics if the check rule refers
+// to anything other than the plan object, which is the only object available
+// to policy conditions.
+func (cr *CheckRule) validatePolicyReferences() hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	for _, expr := range []hcl.Expression{cr.Condition, cr.ErrorMessage} {
+		if expr == nil {
+			continue
+		}
+		for _, traversal := range expr.Variables() {
+			if name := traversal.RootName(); name != PolicyPlanObjectName {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid reference in policy",
+					Detail:   fmt.Sprintf("Policy conditions and error messages can refer only to the %q object, which describes the planned changes, and not to %q.", PolicyPlanObjectName, name),
+					Subject:  traversal.SourceRange().Ptr(),
+				})
+			}
+		}
+	}
+	return diags
+}
+
+var policyBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "severity"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "assert"},
+	},
+}
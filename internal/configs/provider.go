@@ -29,6 +29,12 @@ type Provider struct {
 
 	DeclRange hcl.Range
 
+	// Mock is true if this provider configuration was declared by a
+	// "mock_provider" block in a test file, in which case the provider is
+	// never configured and MockData describes the values it returns instead.
+	Mock     bool
+	MockData *MockData
+
 	// TODO: this may not be set in some cases, so it is not yet suitable for
 	// use outside of this package. We currently only use it for internal
 	// validation, but once we verify that this can be set in all cases, we can
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
+// Removed represents a "removed" block in the configuration, which declares
+// that a resource or module is no longer managed by OpenTofu and should be
+// removed from the state without destroying the corresponding remote
+// objects.
+type Removed struct {
+	From *addrs.RemoveEndpoint
+
+	DeclRange hcl.Range
+}
+
+func decodeRemovedBlock(block *hcl.Block) (*Removed, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	removed := &Removed{
+		DeclRange: block.DefRange,
+	}
+
+	content, moreDiags := block.Body.Content(removedBlockSchema)
+	diags = append(diags, moreDiags...)
+
+	if attr, exists := content.Attributes["from"]; exists {
+		from, traversalDiags := hcl.AbsTraversalForExpr(attr.Expr)
+		diags = append(diags, traversalDiags...)
+		if !traversalDiags.HasErrors() {
+			from, fromDiags := addrs.ParseRemoveEndpoint(from)
+			diags = append(diags, fromDiags.ToHCL()...)
+			removed.From = from
+		}
+	}
+
+	return removed, diags
+}
+
+var removedBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name:     "from",
+			Required: true,
+		},
+	},
+}
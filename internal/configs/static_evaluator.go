@@ -0,0 +1,382 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/lang"
+)
+
+// StaticInputs returns the value given for an input variable of a module
+// from outside of the module, for use in static evaluation.
+//
+// It returns cty.NilVal if no value was given for the variable, in which case
+// the variable's default value is used instead.
+type StaticInputs func(v *Variable) (cty.Value, hcl.Diagnostics)
+
+// StaticEvaluator evaluates the expressions in a module which must be known
+// before OpenTofu can plan any changes, such as the backend configuration and
+// the source addresses of module calls.
+//
+// Such expressions may refer only to input variables that are declared as
+// static and to local values that are themselves derived only from such
+// variables. All of the built-in pure functions are available, too.
+type StaticEvaluator struct {
+	module *Module
+	inputs StaticInputs
+
+	mu        sync.Mutex
+	vars      map[string]cty.Value
+	locals    map[string]cty.Value
+	inFlight  map[string]bool
+	functions *lang.Scope
+}
+
+// NewStaticEvaluator returns a StaticEvaluator for the given module, taking
+// the values of its input variables from the given inputs. If inputs is nil
+// then all variables take their default values.
+func NewStaticEvaluator(mod *Module, inputs StaticInputs) *StaticEvaluator {
+	return &StaticEvaluator{
+		module:   mod,
+		inputs:   inputs,
+		vars:     make(map[string]cty.Value),
+		locals:   make(map[string]cty.Value),
+		inFlight: make(map[string]bool),
+	}
+}
+
+// Evaluate returns the value of the given expression. The what argument
+// describes where the expression appears, such as "the backend
+// configuration", for use in error messages.
+func (e *StaticEvaluator) Evaluate(expr hcl.Expression, what string) (cty.Value, hcl.Diagnostics) {
+	ctx, diags := e.evalContext(expr.Variables(), what)
+	if diags.HasErrors() {
+		return cty.DynamicVal, diags
+	}
+	val, moreDiags := expr.Value(ctx)
+	diags = append(diags, moreDiags...)
+	return val, diags
+}
+
+func (e *StaticEvaluator) evalContext(refs []hcl.Traversal, what string) (*hcl.EvalContext, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	vars := make(map[string]cty.Value)
+	locals := make(map[string]cty.Value)
+
+	for _, traversal := range refs {
+		ref, refDiags := addrs.ParseRef(traversal)
+		diags = append(diags, refDiags.ToHCL()...)
+		if refDiags.HasErrors() {
+			continue
+		}
+
+		switch addr := ref.Subject.(type) {
+		case addrs.InputVariable:
+			val, valDiags := e.variable(addr.Name, ref.SourceRange.ToHCL(), what)
+			diags = append(diags, valDiags...)
+			vars[addr.Name] = val
+		case addrs.LocalValue:
+			val, valDiags := e.local(addr.Name, ref.SourceRange.ToHCL(), what)
+			diags = append(diags, valDiags...)
+			locals[addr.Name] = val
+		default:
+			diags = append(diags, dynamicValueDiagnostic(ref, what))
+		}
+	}
+
+	if e.functions == nil {
+		e.functions = &lang.Scope{
+			BaseDir:  e.module.SourceDir,
+			PureOnly: true,
+		}
+	}
+
+	return &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"var":   cty.ObjectVal(vars),
+			"local": cty.ObjectVal(locals),
+		},
+		Functions: e.functions.Functions(),
+	}, diags
+}
+
+// CheckReferences returns error diagnostics for any references in the given
+// expression, or in the local values it refers to, that cannot be evaluated
+// statically. Unlike Evaluate, it doesn't need the values of any input
+// variables, so it can report such problems while a module is being loaded.
+func (e *StaticEvaluator) CheckReferences(expr hcl.Expression, what string) hcl.Diagnostics {
+	return e.checkReferences(expr.Variables(), what, make(map[string]bool))
+}
+
+func (e *StaticEvaluator) checkReferences(refs []hcl.Traversal, what string, seenLocals map[string]bool) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	for _, traversal := range refs {
+		ref, refDiags := addrs.ParseRef(traversal)
+		diags = append(diags, refDiags.ToHCL()...)
+		if refDiags.HasErrors() {
+			continue
+		}
+
+		switch addr := ref.Subject.(type) {
+		case addrs.InputVariable:
+			_, varDiags := e.staticVariable(addr.Name, ref.SourceRange.ToHCL(), what)
+			diags = append(diags, varDiags...)
+		case addrs.LocalValue:
+			l, localDiags := e.declaredLocal(addr.Name, ref.SourceRange.ToHCL())
+			diags = append(diags, localDiags...)
+			if l == nil || seenLocals[addr.Name] {
+				// We report cycles only when evaluating, since this might
+				// be the same local value referred to twice.
+				continue
+			}
+			seenLocals[addr.Name] = true
+			diags = append(diags, e.checkReferences(l.Expr.Variables(), what, seenLocals)...)
+		default:
+			diags = append(diags, dynamicValueDiagnostic(ref, what))
+		}
+	}
+	return diags
+}
+
+// staticVariable returns the declaration of the given input variable, or
+// error diagnostics if it isn't declared or isn't declared as static.
+func (e *StaticEvaluator) staticVariable(name string, rng hcl.Range, what string) (*Variable, hcl.Diagnostics) {
+	v, exists := e.module.Variables[name]
+	if !exists {
+		return nil, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Reference to undeclared input variable",
+			Detail:   fmt.Sprintf("An input variable with the name %q has not been declared.", name),
+			Subject:  rng.Ptr(),
+		}}
+	}
+	if !v.Static {
+		return nil, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Non-static variable in static context",
+			Detail:   fmt.Sprintf("The input variable %q cannot be used in %s, because its value must be known before OpenTofu plans any changes. To use it here, set static = true in its declaration at %s.", name, what, v.DeclRange),
+			Subject:  rng.Ptr(),
+		}}
+	}
+	return v, nil
+}
+
+// declaredLocal returns the declaration of the given local value, or an error
+// diagnostic if it isn't declared.
+func (e *StaticEvaluator) declaredLocal(name string, rng hcl.Range) (*Local, hcl.Diagnostics) {
+	l, exists := e.module.Locals[name]
+	if !exists {
+		return nil, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Reference to undeclared local value",
+			Detail:   fmt.Sprintf("A local value with the name %q has not been declared.", name),
+			Subject:  rng.Ptr(),
+		}}
+	}
+	return l, nil
+}
+
+func dynamicValueDiagnostic(ref *addrs.Reference, what string) *hcl.Diagnostic {
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Dynamic value in static context",
+		Detail:   fmt.Sprintf("%s cannot be used in %s, because its value must be known before OpenTofu plans any changes. Only static input variables and local values derived from them are available here.", ref.Subject, what),
+		Subject:  ref.SourceRange.ToHCL().Ptr(),
+	}
+}
+
+func (e *StaticEvaluator) variable(name string, rng hcl.Range, what string) (cty.Value, hcl.Diagnostics) {
+	v, diags := e.staticVariable(name, rng, what)
+	if diags.HasErrors() {
+		return cty.DynamicVal, diags
+	}
+
+	e.mu.Lock()
+	val, cached := e.vars[name]
+	e.mu.Unlock()
+	if cached {
+		return val, nil
+	}
+
+	val = cty.NilVal
+	if e.inputs != nil {
+		val, diags = e.inputs(v)
+		if diags.HasErrors() {
+			return cty.DynamicVal, diags
+		}
+	}
+	if val == cty.NilVal || (val.IsNull() && !v.Nullable) {
+		if v.Default == cty.NilVal {
+			return cty.DynamicVal, append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "No value for required variable",
+				Detail:   fmt.Sprintf("The input variable %q is used in %s, so its value must be known before OpenTofu plans any changes. Set it using -var, -var-file or an environment variable, or give it a default value.", name, what),
+				Subject:  rng.Ptr(),
+			})
+		}
+		val = v.Default
+	}
+
+	if v.TypeDefaults != nil && !val.IsNull() {
+		val = v.TypeDefaults.Apply(val)
+	}
+	val, err := convert.Convert(val, v.ConstraintType)
+	if err != nil {
+		return cty.DynamicVal, append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid value for input variable",
+			Detail:   fmt.Sprintf("The value for input variable %q is not suitable: %s.", name, err),
+			Subject:  rng.Ptr(),
+		})
+	}
+
+	e.mu.Lock()
+	e.vars[name] = val
+	e.mu.Unlock()
+	return val, diags
+}
+
+func (e *StaticEvaluator) local(name string, rng hcl.Range, what string) (cty.Value, hcl.Diagnostics) {
+	l, diags := e.declaredLocal(name, rng)
+	if diags.HasErrors() {
+		return cty.DynamicVal, diags
+	}
+
+	e.mu.Lock()
+	val, cached := e.locals[name]
+	cycle := e.inFlight[name]
+	if !cached && !cycle {
+		e.inFlight[name] = true
+	}
+	e.mu.Unlock()
+	if cached {
+		return val, nil
+	}
+	if cycle {
+		return cty.DynamicVal, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Cycle in local values",
+			Detail:   fmt.Sprintf("The local value %q refers to itself, directly or indirectly.", name),
+			Subject:  rng.Ptr(),
+		}}
+	}
+
+	val, diags = e.Evaluate(l.Expr, what)
+
+	e.mu.Lock()
+	delete(e.inFlight, name)
+	if !diags.HasErrors() {
+		e.locals[name] = val
+	}
+	e.mu.Unlock()
+	return val, diags
+}
+
+// callInputs returns the inputs for the child module of the given call,
+// taking their values from the call's arguments as evaluated by the
+// receiver.
+func (e *StaticEvaluator) callInputs(call *ModuleCall) StaticInputs {
+	var once sync.Once
+	var attrs hcl.Attributes
+	var attrDiags hcl.Diagnostics
+
+	return func(v *Variable) (cty.Value, hcl.Diagnostics) {
+		once.Do(func() {
+			attrs, attrDiags = call.Config.JustAttributes()
+		})
+		attr, exists := attrs[v.Name]
+		if !exists {
+			if attrDiags.HasErrors() {
+				return cty.NilVal, attrDiags
+			}
+			return cty.NilVal, nil
+		}
+		return e.Evaluate(attr.Expr, fmt.Sprintf("the argument for input variable %q of module %q", v.Name, call.Name))
+	}
+}
+
+// staticBody is an hcl.Body whose expressions are evaluated by a
+// StaticEvaluator, regardless of the evaluation context that the caller
+// provides.
+//
+// The evaluator is looked up only when an expression is evaluated, so that
+// the inputs of a module can be decided after the body was wrapped.
+type staticBody struct {
+	body hcl.Body
+	eval func() *StaticEvaluator
+	what string
+}
+
+var _ hcl.Body = staticBody{}
+
+func (b staticBody) Content(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Diagnostics) {
+	content, diags := b.body.Content(schema)
+	return b.wrapContent(content), diags
+}
+
+func (b staticBody) PartialContent(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Body, hcl.Diagnostics) {
+	content, remain, diags := b.body.PartialContent(schema)
+	if remain != nil {
+		remain = staticBody{body: remain, eval: b.eval, what: b.what}
+	}
+	return b.wrapContent(content), remain, diags
+}
+
+func (b staticBody) JustAttributes() (hcl.Attributes, hcl.Diagnostics) {
+	attrs, diags := b.body.JustAttributes()
+	return b.wrapAttributes(attrs), diags
+}
+
+func (b staticBody) MissingItemRange() hcl.Range {
+	return b.body.MissingItemRange()
+}
+
+func (b staticBody) wrapContent(content *hcl.BodyContent) *hcl.BodyContent {
+	if content == nil {
+		return nil
+	}
+
+	ret := *content
+	ret.Attributes = b.wrapAttributes(content.Attributes)
+	ret.Blocks = make(hcl.Blocks, len(content.Blocks))
+	for i, block := range content.Blocks {
+		wrapped := *block
+		wrapped.Body = staticBody{body: block.Body, eval: b.eval, what: b.what}
+		ret.Blocks[i] = &wrapped
+	}
+	return &ret
+}
+
+func (b staticBody) wrapAttributes(attrs hcl.Attributes) hcl.Attributes {
+	if attrs == nil {
+		return nil
+	}
+
+	ret := make(hcl.Attributes, len(attrs))
+	for name, attr := range attrs {
+		wrapped := *attr
+		wrapped.Expr = staticExpr{Expression: attr.Expr, eval: b.eval, what: b.what}
+		ret[name] = &wrapped
+	}
+	return ret
+}
+
+// staticExpr is an hcl.Expression that is evaluated by a StaticEvaluator,
+// ignoring the evaluation context given to Value.
+type staticExpr struct {
+	hcl.Expression
+	eval func() *StaticEvaluator
+	what string
+}
+
+func (x staticExpr) Value(*hcl.EvalContext) (cty.Value, hcl.Diagnostics) {
+	return x.eval().Evaluate(x.Expression, x.what)
+}
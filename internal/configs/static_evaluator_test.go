@@ -0,0 +1,207 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"path/filepath"
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestStaticEvaluator_Evaluate(t *testing.T) {
+	parser := testParser(map[string]string{
+		"main.tf": `
+variable "env" {
+  type   = string
+  static = true
+}
+
+variable "region" {
+  type    = string
+  default = "eu-west-1"
+  static  = true
+}
+
+variable "instance_type" {
+  type    = string
+  default = "t3.micro"
+}
+
+locals {
+  prefix = "${var.env}-${var.region}"
+  key    = upper(local.prefix)
+  loop   = local.loop
+  size   = var.instance_type
+}
+
+resource "test_thing" "a" {}
+`,
+	})
+	mod, diags := parser.LoadConfigDir(".")
+	assertNoDiagnostics(t, diags)
+
+	inputs := func(v *Variable) (cty.Value, hcl.Diagnostics) {
+		if v.Name == "env" {
+			return cty.StringVal("prod"), nil
+		}
+		return cty.NilVal, nil
+	}
+
+	tests := map[string]struct {
+		inputs StaticInputs
+		expr   string
+		want   cty.Value
+		diag   string
+	}{
+		"variable": {
+			inputs: inputs,
+			expr:   `var.env`,
+			want:   cty.StringVal("prod"),
+		},
+		"default": {
+			inputs: inputs,
+			expr:   `var.region`,
+			want:   cty.StringVal("eu-west-1"),
+		},
+		"locals and functions": {
+			inputs: inputs,
+			expr:   `local.key`,
+			want:   cty.StringVal("PROD-EU-WEST-1"),
+		},
+		"missing value": {
+			expr: `var.env`,
+			diag: "No value for required variable",
+		},
+		"undeclared variable": {
+			inputs: inputs,
+			expr:   `var.nope`,
+			diag:   "Reference to undeclared input variable",
+		},
+		"non-static variable": {
+			inputs: inputs,
+			expr:   `var.instance_type`,
+			diag:   "Non-static variable in static context",
+		},
+		"local from non-static variable": {
+			inputs: inputs,
+			expr:   `local.size`,
+			diag:   "Non-static variable in static context",
+		},
+		"resource": {
+			inputs: inputs,
+			expr:   `test_thing.a.id`,
+			diag:   "Dynamic value in static context",
+		},
+		"cycle": {
+			inputs: inputs,
+			expr:   `local.loop`,
+			diag:   "Cycle in local values",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, diags := hclsyntax.ParseExpression([]byte(test.expr), "", hcl.InitialPos)
+			assertNoDiagnostics(t, diags)
+
+			got, diags := NewStaticEvaluator(mod, test.inputs).Evaluate(expr, "the test")
+			if test.diag != "" {
+				assertDiagnosticSummary(t, diags, test.diag)
+				return
+			}
+			assertNoDiagnostics(t, diags)
+			if !got.RawEquals(test.want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestBuildConfig_staticModuleSource(t *testing.T) {
+	parser := NewParser(nil)
+	parser.SetStaticInputs(func(v *Variable) (cty.Value, hcl.Diagnostics) {
+		if v.Name == "which" {
+			return cty.StringVal("a"), nil
+		}
+		return cty.NilVal, nil
+	})
+	mod, diags := parser.LoadConfigDir("testdata/config-build-static")
+	assertNoDiagnostics(t, diags)
+
+	var got []string
+	cfg, diags := BuildConfig(mod, ModuleWalkerFunc(
+		func(req *ModuleRequest) (*Module, *version.Version, hcl.Diagnostics) {
+			got = append(got, req.SourceAddr.String())
+			sourcePath := filepath.Join(req.Parent.Module.SourceDir, req.SourceAddr.String())
+			mod, diags := parser.LoadConfigDir(sourcePath)
+			return mod, nil, diags
+		},
+	))
+	assertNoDiagnostics(t, diags)
+
+	want := []string{"./child_a", "../child_c"}
+	assertResultDeepEqual(t, got, want)
+
+	if got := cfg.Children["child"].Children["leaf"].Module.Outputs["hello"]; got == nil {
+		t.Fatalf("missing output 'hello' in child.leaf")
+	}
+}
+
+func TestParserLoadConfigDir_staticModuleSourceInvalid(t *testing.T) {
+	tests := map[string]struct {
+		src  string
+		diag string
+	}{
+		"resource": {
+			src: `
+resource "test_thing" "a" {}
+
+module "child" {
+  source = "./${test_thing.a.id}"
+}
+`,
+			diag: "Dynamic value in static context",
+		},
+		"non-static variable": {
+			src: `
+variable "dir" {
+  type = string
+}
+
+locals {
+  child_dir = "./${var.dir}"
+}
+
+module "child" {
+  source = local.child_dir
+}
+`,
+			diag: "Non-static variable in static context",
+		},
+		"undeclared variable": {
+			src: `
+module "child" {
+  source  = "example.com/foo/bar/baz"
+  version = var.nope
+}
+`,
+			diag: "Reference to undeclared input variable",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			parser := testParser(map[string]string{"main.tf": test.src})
+
+			// The problem is reported as soon as the module is loaded,
+			// before the values of its input variables are known.
+			_, diags := parser.LoadConfigDir(".")
+			assertDiagnosticSummary(t, diags, test.diag)
+		})
+	}
+}
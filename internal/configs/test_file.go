@@ -52,6 +52,11 @@ type TestFile struct {
 	// test.
 	Providers map[string]*Provider
 
+	// Overrides defines a set of resources, data sources and modules whose
+	// results should be replaced with fixed values for every run block within
+	// the test file.
+	Overrides []*Override
+
 	// Runs defines the sequential list of run blocks that should be executed in
 	// order.
 	Runs []*TestRun
@@ -119,6 +124,18 @@ type TestRun struct {
 	// run.
 	ExpectFailures []hcl.Traversal
 
+	// Parallel is true if this run block may execute at the same time as the
+	// run blocks around it that are also marked as parallel, as long as they
+	// don't share a state.
+	Parallel bool
+
+	// Overrides defines a set of resources, data sources and modules whose
+	// results should be replaced with fixed values for this run block.
+	//
+	// Any overrides specified here take precedence over overrides for the
+	// same object within the test file.
+	Overrides []*Override
+
 	NameDeclRange      hcl.Range
 	VariablesDeclRange hcl.Range
 	DeclRange          hcl.Range
@@ -230,6 +247,18 @@ func loadTestFile(body hcl.Body) (*TestFile, hcl.Diagnostics) {
 			if provider != nil {
 				tf.Providers[provider.moduleUniqueKey()] = provider
 			}
+		case "mock_provider":
+			provider, providerDiags := decodeMockProviderBlock(block)
+			diags = append(diags, providerDiags...)
+			if provider != nil {
+				tf.Providers[provider.moduleUniqueKey()] = provider
+			}
+		case "override_resource", "override_data", "override_module":
+			override, overrideDiags := decodeOverrideBlock(block)
+			diags = append(diags, overrideDiags...)
+			if !overrideDiags.HasErrors() {
+				tf.Overrides = append(tf.Overrides, override)
+			}
 		}
 	}
 
@@ -305,6 +334,12 @@ func decodeTestRunBlock(block *hcl.Block) (*TestRun, hcl.Diagnostics) {
 			if !moduleDiags.HasErrors() {
 				r.Module = module
 			}
+		case "override_resource", "override_data", "override_module":
+			override, overrideDiags := decodeOverrideBlock(block)
+			diags = append(diags, overrideDiags...)
+			if !overrideDiags.HasErrors() {
+				r.Overrides = append(r.Overrides, override)
+			}
 		}
 	}
 
@@ -354,6 +389,11 @@ func decodeTestRunBlock(block *hcl.Block) (*TestRun, hcl.Diagnostics) {
 		r.ExpectFailures = failures
 	}
 
+	if attr, exists := content.Attributes["parallel"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &r.Parallel)
+		diags = append(diags, valDiags...)
+	}
+
 	return &r, diags
 }
 
@@ -531,9 +571,22 @@ var testFileSchema = &hcl.BodySchema{
 			Type:       "provider",
 			LabelNames: []string{"name"},
 		},
+		{
+			Type:       "mock_provider",
+			LabelNames: []string{"name"},
+		},
 		{
 			Type: "variables",
 		},
+		{
+			Type: "override_resource",
+		},
+		{
+			Type: "override_data",
+		},
+		{
+			Type: "override_module",
+		},
 	},
 }
 
@@ -542,6 +595,7 @@ var testRunBlockSchema = &hcl.BodySchema{
 		{Name: "command"},
 		{Name: "providers"},
 		{Name: "expect_failures"},
+		{Name: "parallel"},
 	},
 	Blocks: []hcl.BlockHeaderSchema{
 		{
@@ -556,6 +610,15 @@ var testRunBlockSchema = &hcl.BodySchema{
 		{
 			Type: "module",
 		},
+		{
+			Type: "override_resource",
+		},
+		{
+			Type: "override_data",
+		},
+		{
+			Type: "override_module",
+		},
 	},
 }
 
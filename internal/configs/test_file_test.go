@@ -1,11 +1,13 @@
 package configs
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestTestRun_Validate(t *testing.T) {
@@ -92,3 +94,187 @@ func parseTraversal(t *testing.T, addr string) hcl.Traversal {
 	}
 	return traversal
 }
+
+func TestLoadTestFile_mocks(t *testing.T) {
+	src := `
+mock_provider "aws" {
+  alias = "mocked"
+
+  mock_resource "aws_s3_bucket" {
+    defaults = {
+      arn = "arn:aws:s3:::bucket"
+    }
+  }
+
+  mock_data "aws_caller_identity" {}
+}
+
+override_resource {
+  target = aws_s3_bucket.a
+  values = {
+    id = "a"
+  }
+}
+
+run "test" {
+  override_data {
+    target = data.aws_caller_identity.current
+  }
+
+  override_module {
+    target = module.child[0]
+    outputs = {
+      name = "child"
+    }
+  }
+}
+`
+	file, diags := hclsyntax.ParseConfig([]byte(src), "main.tftest.hcl", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	tf, diags := loadTestFile(file.Body)
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+
+	provider, ok := tf.Providers["aws.mocked"]
+	if !ok {
+		t.Fatalf("missing mock provider; got %v", tf.Providers)
+	}
+	if !provider.Mock {
+		t.Errorf("provider is not a mock provider")
+	}
+	res, ok := provider.MockData.MockResources["aws_s3_bucket"]
+	if !ok {
+		t.Fatalf("missing mock_resource block")
+	}
+	if got, want := res.Defaults, cty.ObjectVal(map[string]cty.Value{"arn": cty.StringVal("arn:aws:s3:::bucket")}); !got.RawEquals(want) {
+		t.Errorf("wrong defaults\ngot:  %#v\nwant: %#v", got, want)
+	}
+	if _, ok := provider.MockData.MockDataResources["aws_caller_identity"]; !ok {
+		t.Errorf("missing mock_data block")
+	}
+
+	if len(tf.Overrides) != 1 {
+		t.Fatalf("wrong number of file overrides %d; want 1", len(tf.Overrides))
+	}
+	if got, want := tf.Overrides[0].Target.String(), "aws_s3_bucket.a"; got != want {
+		t.Errorf("wrong override target %q; want %q", got, want)
+	}
+
+	run := tf.Runs[0]
+	if len(run.Overrides) != 2 {
+		t.Fatalf("wrong number of run overrides %d; want 2", len(run.Overrides))
+	}
+	if got, want := run.Overrides[0].Kind, OverrideDataResource; got != want {
+		t.Errorf("wrong override kind %q; want %q", got, want)
+	}
+	if got := run.Overrides[0].Values; !got.RawEquals(cty.EmptyObjectVal) {
+		t.Errorf("wrong values for override without values: %#v", got)
+	}
+	if got, want := run.Overrides[1].Target.String(), "module.child[0]"; got != want {
+		t.Errorf("wrong override target %q; want %q", got, want)
+	}
+}
+
+func TestLoadTestFile_parallel(t *testing.T) {
+	src := `
+run "first" {
+  parallel = true
+}
+
+run "second" {
+}
+`
+	file, diags := hclsyntax.ParseConfig([]byte(src), "main.tftest.hcl", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	tf, diags := loadTestFile(file.Body)
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+
+	if !tf.Runs[0].Parallel {
+		t.Errorf("first run block is not parallel")
+	}
+	if tf.Runs[1].Parallel {
+		t.Errorf("second run block is parallel")
+	}
+}
+
+func TestLoadTestFile_invalidOverrides(t *testing.T) {
+	tcs := map[string]struct {
+		src  string
+		want string
+	}{
+		"resource targets data source": {
+			`
+override_resource {
+  target = data.aws_caller_identity.current
+}
+`,
+			"The target of an override_resource block must be a managed resource",
+		},
+		"data targets resource": {
+			`
+override_data {
+  target = aws_s3_bucket.a
+}
+`,
+			"The target of an override_data block must be a data source",
+		},
+		"module targets resource": {
+			`
+override_module {
+  target = aws_s3_bucket.a
+}
+`,
+			"The target of an override_module block must be a module call",
+		},
+		"resource targets module": {
+			`
+override_resource {
+  target = module.child
+}
+`,
+			"Use an override_module block to override a module.",
+		},
+		"dynamic values": {
+			`
+override_resource {
+  target = aws_s3_bucket.a
+  values = {
+    id = var.id
+  }
+}
+`,
+			"Variables not allowed",
+		},
+		"values not an object": {
+			`
+override_resource {
+  target = aws_s3_bucket.a
+  values = "id"
+}
+`,
+			"The \"values\" argument requires an object",
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			file, diags := hclsyntax.ParseConfig([]byte(tc.src), "main.tftest.hcl", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatal(diags.Error())
+			}
+			_, diags = loadTestFile(file.Body)
+			if !diags.HasErrors() {
+				t.Fatalf("unexpected success")
+			}
+			if got := diags.Error(); !strings.Contains(got, tc.want) {
+				t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, tc.want)
+			}
+		})
+	}
+}
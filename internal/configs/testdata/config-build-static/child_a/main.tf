@@ -0,0 +1,8 @@
+variable "leaf" {
+  type   = string
+  static = true
+}
+
+module "leaf" {
+  source = "../${var.leaf}"
+}
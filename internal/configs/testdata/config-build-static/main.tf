@@ -0,0 +1,14 @@
+variable "which" {
+  type   = string
+  static = true
+}
+
+locals {
+  child_dir = "./child_${var.which}"
+}
+
+module "child" {
+  source = local.child_dir
+
+  leaf = "child_c"
+}
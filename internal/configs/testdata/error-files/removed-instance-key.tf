@@ -0,0 +1,11 @@
+removed {
+  from = test_instance.foo[0] # ERROR: Resource instance keys not allowed
+}
+
+removed {
+  from = module.child["a"] # ERROR: Module instance keys not allowed
+}
+
+removed {
+  from = data.test_data_source.foo # ERROR: Data source address is not allowed
+}
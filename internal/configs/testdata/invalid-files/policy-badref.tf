@@ -0,0 +1,19 @@
+variable "max" {
+  type = number
+}
+
+policy "limit_deletions" {
+  assert {
+    condition     = plan.counts.delete <= var.max # ERROR: Invalid reference in policy
+    error_message = "Too many deletions."
+  }
+}
+
+policy "bad_severity" {
+  severity = "fatal" # ERROR: Invalid policy severity
+
+  assert {
+    condition     = plan.counts.delete == 0
+    error_message = "No deletions."
+  }
+}
@@ -0,0 +1,6 @@
+policy "ignored" {
+  assert {
+    condition     = plan.counts.delete == 0
+    error_message = "No deletions."
+  }
+}
@@ -0,0 +1,23 @@
+
+terraform {
+  encryption {
+    key_provider "pbkdf2" "example" {
+      passphrase = "correct-horse-battery-staple"
+    }
+    method "aes_gcm" "example" {
+      keys = key_provider.pbkdf2.example
+    }
+    method "unencrypted" "migration" {}
+
+    state {
+      method = method.aes_gcm.example
+      fallback {
+        method = method.unencrypted.migration
+      }
+    }
+    plan {
+      method   = method.aes_gcm.example
+      enforced = true
+    }
+  }
+}
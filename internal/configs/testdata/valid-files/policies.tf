@@ -0,0 +1,15 @@
+policy "limit_deletions" {
+  assert {
+    condition     = plan.counts.delete <= 5
+    error_message = "This plan deletes ${plan.counts.delete} objects, but at most 5 are allowed."
+  }
+}
+
+policy "no_database_replacement" {
+  severity = "warning"
+
+  assert {
+    condition     = length([for c in plan.changes : c if c.type == "aws_db_instance" && c.action == "replace"]) == 0
+    error_message = "This plan replaces a database instance."
+  }
+}
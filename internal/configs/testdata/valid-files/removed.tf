@@ -0,0 +1,11 @@
+removed {
+  from = test_instance.foo
+}
+
+removed {
+  from = module.child
+}
+
+removed {
+  from = module.child.test_instance.bar
+}
@@ -0,0 +1,6 @@
+
+resource "bar_resource" "c" {}
+
+output "id" {
+  value = bar_resource.c.id
+}
@@ -0,0 +1,16 @@
+
+variable "input" {
+  type = string
+}
+
+resource "foo_resource" "a" {
+  value = var.input
+}
+
+data "foo_data_source" "b" {
+  value = foo_resource.a.value
+}
+
+module "child" {
+  source = "./child"
+}
@@ -0,0 +1,28 @@
+mock_provider "foo" {
+  mock_resource "foo_resource" {
+    defaults = {
+      id = "fake"
+    }
+  }
+
+  mock_data "foo_data_source" {
+    defaults = {
+      computed = "fake"
+    }
+  }
+}
+
+mock_provider "bar" {
+  alias = "mocked"
+}
+
+run "test_run_one" {
+  variables {
+    input = "one"
+  }
+
+  assert {
+    condition     = foo_resource.a.id == "fake"
+    error_message = "invalid value"
+  }
+}
@@ -0,0 +1,31 @@
+override_resource {
+  target = foo_resource.a
+  values = {
+    id = "overridden"
+  }
+}
+
+override_module {
+  target = module.child
+  outputs = {
+    id = "child"
+  }
+}
+
+run "test_run_one" {
+  variables {
+    input = "one"
+  }
+
+  override_data {
+    target = data.foo_data_source.b
+    values = {
+      computed = "overridden"
+    }
+  }
+
+  assert {
+    condition     = module.child.id == "child"
+    error_message = "invalid value"
+  }
+}
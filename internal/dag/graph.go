@@ -357,6 +357,16 @@ func (g *Graph) Dot(opts *DotOpts) []byte {
 	return newMarshalGraph("", g).Dot(opts)
 }
 
+// JSON returns the JSON representation of this Graph.
+func (g *Graph) JSON(opts *MarshalOpts) ([]byte, error) {
+	return newMarshalGraph("", g).JSON(opts)
+}
+
+// Mermaid returns the Mermaid flowchart representation of this Graph.
+func (g *Graph) Mermaid(opts *MarshalOpts) []byte {
+	return newMarshalGraph("", g).Mermaid(opts)
+}
+
 // VertexName returns the name of a vertex.
 func VertexName(raw Vertex) string {
 	switch v := raw.(type) {
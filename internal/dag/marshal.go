@@ -4,6 +4,7 @@
 package dag
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"sort"
@@ -61,6 +62,9 @@ type marshalVertex struct {
 	// This is to help transition from the old Dot interfaces. We record if the
 	// node was a GraphNodeDotter here, so we can call it to get attributes.
 	graphNodeDotter GraphNodeDotter
+
+	// vertex is the vertex this structure was built from.
+	vertex Vertex
 }
 
 func newMarshalVertex(v Vertex) *marshalVertex {
@@ -79,6 +83,7 @@ func newMarshalVertex(v Vertex) *marshalVertex {
 		Name:            name,
 		Attrs:           make(map[string]string),
 		graphNodeDotter: dn,
+		vertex:          v,
 	}
 }
 
@@ -198,3 +203,89 @@ func marshalSubgrapher(v Vertex) (*Graph, bool) {
 
 	return nil, false
 }
+
+// MarshalOpts are the options for generating the JSON and Mermaid
+// representations of a Graph.
+type MarshalOpts struct {
+	// NodeKind, if set, returns a short description of the kind of the given
+	// vertex, such as "resource", or an empty string if it has no particular
+	// kind. The JSON representation includes the kind of each vertex, and the
+	// Mermaid representation uses it as the class of each node.
+	NodeKind func(Vertex) string
+}
+
+// jsonGraph is the JSON representation of a graph. Unlike the marshal*
+// structs, it identifies vertices by name so that the output for the same
+// graph is always the same.
+type jsonGraph struct {
+	Nodes []jsonNode `json:"nodes"`
+	Edges []jsonEdge `json:"edges"`
+}
+
+type jsonNode struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+	Kind  string `json:"kind,omitempty"`
+}
+
+// jsonEdge describes an edge from Source to Target, which means that Source
+// depends on Target.
+type jsonEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// JSON returns the JSON representation of the top level of the graph, which
+// lists the vertices sorted by name, and the edges between them sorted by
+// source and target name.
+func (g *marshalGraph) JSON(opts *MarshalOpts) ([]byte, error) {
+	if opts == nil {
+		opts = &MarshalOpts{}
+	}
+
+	byID := make(map[string]*marshalVertex, len(g.Vertices))
+	ret := jsonGraph{
+		Nodes: make([]jsonNode, 0, len(g.Vertices)),
+		Edges: make([]jsonEdge, 0, len(g.Edges)),
+	}
+	for _, v := range g.Vertices {
+		byID[v.ID] = v
+		node := jsonNode{
+			ID:    VertexName(v.vertex),
+			Label: v.label(),
+		}
+		if opts.NodeKind != nil {
+			node.Kind = opts.NodeKind(v.vertex)
+		}
+		ret.Nodes = append(ret.Nodes, node)
+	}
+	for _, e := range g.Edges {
+		ret.Edges = append(ret.Edges, jsonEdge{
+			Source: VertexName(byID[e.Source].vertex),
+			Target: VertexName(byID[e.Target].vertex),
+		})
+	}
+	sort.Slice(ret.Edges, func(i, j int) bool {
+		if ret.Edges[i].Source != ret.Edges[j].Source {
+			return ret.Edges[i].Source < ret.Edges[j].Source
+		}
+		return ret.Edges[i].Target < ret.Edges[j].Target
+	})
+
+	return json.MarshalIndent(ret, "", "  ")
+}
+
+// label returns the human-readable label of the vertex, which is the label
+// the vertex chooses for the verbose DOT output, if any, or otherwise its name.
+func (v *marshalVertex) label() string {
+	if v.graphNodeDotter != nil {
+		node := v.graphNodeDotter.DotNode(VertexName(v.vertex), &DotOpts{Verbose: true})
+		if node != nil {
+			if label, ok := node.Attrs["label"]; ok {
+				return label
+			}
+			return node.Name
+		}
+	}
+	return VertexName(v.vertex)
+}
@@ -50,6 +50,55 @@ func TestGraphDot_quoted(t *testing.T) {
 	}
 }
 
+func TestGraphJSON(t *testing.T) {
+	var g Graph
+	quoted := `name["with-quotes"]`
+	g.Add(quoted)
+	g.Add("b")
+	g.Add("a")
+	g.Connect(BasicEdge(quoted, "b"))
+	g.Connect(BasicEdge("a", "b"))
+
+	kind := func(v Vertex) string {
+		if v == quoted {
+			return "quoted"
+		}
+		return ""
+	}
+	got, err := g.JSON(&MarshalOpts{NodeKind: kind})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.TrimSpace(testGraphJSONStr)
+	if string(got) != want {
+		t.Fatalf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestGraphMermaid(t *testing.T) {
+	var g Graph
+	quoted := `name["with-quotes"]`
+	g.Add(quoted)
+	g.Add("b")
+	g.Add("a")
+	g.Connect(BasicEdge(quoted, "b"))
+	g.Connect(BasicEdge("a", "b"))
+
+	kind := func(v Vertex) string {
+		if v == quoted {
+			return "quoted"
+		}
+		return ""
+	}
+	got := strings.TrimSpace(string(g.Mermaid(&MarshalOpts{NodeKind: kind})))
+
+	want := strings.TrimSpace(testGraphMermaidStr)
+	if got != want {
+		t.Fatalf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
 func TestGraphDot_attrs(t *testing.T) {
 	var g Graph
 	g.Add(&testGraphNodeDotter{
@@ -102,3 +151,42 @@ const testGraphDotAttrsStr = `digraph {
 		"[root] foo" [foo = "bar"]
 	}
 }`
+
+const testGraphJSONStr = `
+{
+  "nodes": [
+    {
+      "id": "a",
+      "label": "a"
+    },
+    {
+      "id": "b",
+      "label": "b"
+    },
+    {
+      "id": "name[\"with-quotes\"]",
+      "label": "name[\"with-quotes\"]",
+      "kind": "quoted"
+    }
+  ],
+  "edges": [
+    {
+      "source": "a",
+      "target": "b"
+    },
+    {
+      "source": "name[\"with-quotes\"]",
+      "target": "b"
+    }
+  ]
+}
+`
+
+const testGraphMermaidStr = `
+flowchart LR
+	n0["a"]
+	n1["b"]
+	n2["name[#quot;with-quotes#quot;]"]:::quoted
+	n0 --> n1
+	n2 --> n1
+`
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package dag
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Mermaid returns the Mermaid flowchart representation of the top level of
+// the graph, with an arrow from each vertex to each of the vertices it depends
+// on.
+//
+// Mermaid node IDs can't contain most of the characters in vertex names, so
+// the nodes are numbered in the order of the vertex names and labelled with
+// the vertex labels instead.
+func (g *marshalGraph) Mermaid(opts *MarshalOpts) []byte {
+	if opts == nil {
+		opts = &MarshalOpts{}
+	}
+
+	ids := make(map[string]string, len(g.Vertices))
+	names := make(map[string]string, len(g.Vertices))
+	for i, v := range g.Vertices {
+		ids[v.ID] = fmt.Sprintf("n%d", i)
+		names[v.ID] = VertexName(v.vertex)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("flowchart LR\n")
+	for _, v := range g.Vertices {
+		fmt.Fprintf(&buf, "\t%s[\"%s\"]", ids[v.ID], mermaidEscape(v.label()))
+		if opts.NodeKind != nil {
+			if kind := opts.NodeKind(v.vertex); kind != "" {
+				buf.WriteString(":::" + kind)
+			}
+		}
+		buf.WriteByte('\n')
+	}
+
+	edges := make([]*marshalEdge, len(g.Edges))
+	copy(edges, g.Edges)
+	sort.Slice(edges, func(i, j int) bool {
+		if names[edges[i].Source] != names[edges[j].Source] {
+			return names[edges[i].Source] < names[edges[j].Source]
+		}
+		return names[edges[i].Target] < names[edges[j].Target]
+	})
+	for _, e := range edges {
+		fmt.Fprintf(&buf, "\t%s --> %s\n", ids[e.Source], ids[e.Target])
+	}
+
+	return buf.Bytes()
+}
+
+// mermaidEscape escapes the characters in a Mermaid label that would
+// otherwise end the label or be interpreted as markup.
+var mermaidEscape = strings.NewReplacer(
+	`"`, "#quot;",
+	"<", "#lt;",
+	">", "#gt;",
+).Replace
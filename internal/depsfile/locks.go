@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"sort"
 
+	version "github.com/hashicorp/go-version"
+
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/getproviders"
 )
@@ -35,11 +37,12 @@ type Locks struct {
 	// settings, environment variables, or whatever similar sources.
 	overriddenProviders map[addrs.Provider]struct{}
 
-	// TODO: In future we'll also have module locks, but the design of that
-	// still needs some more work and we're deferring that to get the
-	// provider locking capability out sooner, because it's more common to
-	// directly depend on providers maintained outside your organization than
-	// modules maintained outside your organization.
+	// modules are the locks for the remote packages that module calls were
+	// installed from, keyed by the module's path in the same dot-separated
+	// form used for the keys in the module manifest, like "network.subnets".
+	// Module calls with local source addresses have no lock of their own,
+	// because they are part of the same package as their caller.
+	modules map[string]*ModuleLock
 
 	// sources is a copy of the map of source buffers produced by the HCL
 	// parser during loading, which we retain only so that the caller can
@@ -52,6 +55,7 @@ type Locks struct {
 func NewLocks() *Locks {
 	return &Locks{
 		providers: make(map[addrs.Provider]*ProviderLock),
+		modules:   make(map[string]*ModuleLock),
 
 		// no "sources" here, because that's only for locks objects loaded
 		// from files.
@@ -118,6 +122,45 @@ func (l *Locks) RemoveProvider(addr addrs.Provider) {
 	delete(l.providers, addr)
 }
 
+// Module returns the stored lock for the module with the given key, or nil
+// if that module currently has no lock.
+func (l *Locks) Module(key string) *ModuleLock {
+	return l.modules[key]
+}
+
+// AllModules returns a map describing all of the module locks in the
+// receiver, keyed by module key.
+func (l *Locks) AllModules() map[string]*ModuleLock {
+	// We return a copy of our internal map so that future calls to
+	// SetModule won't modify the map we're returning, or vice-versa.
+	ret := make(map[string]*ModuleLock, len(l.modules))
+	for k, v := range l.modules {
+		ret[k] = v
+	}
+	return ret
+}
+
+// SetModule creates a new lock or replaces the existing lock for the module
+// with the given key.
+//
+// SetModule returns the newly-created module lock object, which invalidates
+// any ModuleLock object previously returned from Module or SetModule for the
+// given key.
+func (l *Locks) SetModule(key string, source string, version *version.Version, commit string, digest string, hash getproviders.Hash) *ModuleLock {
+	new := NewModuleLock(key, source, version, commit, digest, hash)
+	l.modules[new.key] = new
+	return new
+}
+
+// RemoveModule removes any existing lock file entry for the module with the
+// given key.
+//
+// If the given module did not already have a lock entry, RemoveModule is
+// a no-op.
+func (l *Locks) RemoveModule(key string) {
+	delete(l.modules, key)
+}
+
 // SetProviderOverridden records that this particular OpenTofu process will
 // not pay attention to the recorded lock entry for the given provider, and
 // will instead access that provider's functionality in some other special
@@ -276,6 +319,16 @@ func (l *Locks) Equal(other *Locks) bool {
 	// We don't need to worry about providers that are in "other" but not
 	// in the receiver, because we tested the lengths being equal above.
 
+	if len(l.modules) != len(other.modules) {
+		return false
+	}
+	for key, thisLock := range l.modules {
+		otherLock, ok := other.modules[key]
+		if !ok || !thisLock.Equal(otherLock) {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -301,7 +354,7 @@ func (l *Locks) EqualProviderAddress(other *Locks) bool {
 // UI code might wish to use this to distinguish a lock file being
 // written for the first time from subsequent updates to that lock file.
 func (l *Locks) Empty() bool {
-	return len(l.providers) == 0
+	return len(l.providers) == 0 && len(l.modules) == 0
 }
 
 // DeepCopy creates a new Locks that represents the same information as the
@@ -322,6 +375,9 @@ func (l *Locks) DeepCopy() *Locks {
 		}
 		ret.SetProvider(addr, lock.version, lock.versionConstraints, hashes)
 	}
+	for key, lock := range l.modules {
+		ret.SetModule(key, lock.source, lock.version, lock.commit, lock.digest, lock.hash)
+	}
 	return ret
 }
 
@@ -440,3 +496,108 @@ func (l *ProviderLock) ContainsAll(target *ProviderLock) bool {
 func (l *ProviderLock) PreferredHashes() []getproviders.Hash {
 	return getproviders.PreferredHashes(l.hashes)
 }
+
+// NewModuleLock creates a new ModuleLock object that isn't associated with
+// any Locks object.
+//
+// This is here primarily for testing. Most callers should use Locks.SetModule
+// to construct a new module lock and insert it into a Locks object at the
+// same time.
+func NewModuleLock(key string, source string, version *version.Version, commit string, digest string, hash getproviders.Hash) *ModuleLock {
+	return &ModuleLock{
+		key:     key,
+		source:  source,
+		version: version,
+		commit:  commit,
+		digest:  digest,
+		hash:    hash,
+	}
+}
+
+// ModuleLock represents lock information for the remote package that a
+// particular module call was installed from.
+type ModuleLock struct {
+	// key identifies the module call this lock applies to, using the same
+	// dot-separated syntax as the keys in the module manifest.
+	key string
+
+	// source is the source address given for the module in configuration,
+	// which must be a remote source address. A lock applies only as long as
+	// the source address in configuration is unchanged.
+	source string
+
+	// version is the version that was selected from a module registry, or
+	// nil if the module was not installed from a module registry.
+	//
+	// commit is the commit that the package was checked out at if it was
+	// retrieved from a git repository, or an empty string otherwise. A
+	// module installed from a registry can have both.
+	//
+	// digest is the digest of the manifest that the package was installed
+	// from if it was retrieved from an OCI registry, or an empty string
+	// otherwise.
+	version *version.Version
+	commit  string
+	digest  string
+
+	// hash is a checksum of the content of the module package, using the
+	// same "h1:" scheme as for provider packages but ignoring any version
+	// control metadata in the package directory.
+	hash getproviders.Hash
+}
+
+// Key returns the key of the module this lock applies to.
+func (l *ModuleLock) Key() string {
+	return l.key
+}
+
+// Source returns the source address the locked package was installed from.
+func (l *ModuleLock) Source() string {
+	return l.source
+}
+
+// Version returns the version that was selected from the module registry,
+// or nil if the module didn't come from a module registry.
+func (l *ModuleLock) Version() *version.Version {
+	return l.version
+}
+
+// Commit returns the git commit that the package was checked out at, or an
+// empty string if the package didn't come from a git repository.
+func (l *ModuleLock) Commit() string {
+	return l.commit
+}
+
+// Digest returns the digest of the OCI manifest that the package was
+// installed from, or an empty string if the package didn't come from an OCI
+// registry.
+func (l *ModuleLock) Digest() string {
+	return l.digest
+}
+
+// Hash returns the checksum of the content of the locked module package.
+func (l *ModuleLock) Hash() getproviders.Hash {
+	return l.hash
+}
+
+// Equal returns true if the given ModuleLock represents the same information
+// as the receiver.
+func (l *ModuleLock) Equal(other *ModuleLock) bool {
+	if l == nil || other == nil {
+		return l == other
+	}
+	if (l.version == nil) != (other.version == nil) {
+		return false
+	}
+	if l.version != nil && l.version.String() != other.version.String() {
+		// We compare the string representations rather than using
+		// Version.Equal because changes to the build metadata are
+		// significant here.
+		return false
+	}
+	return l.key == other.key &&
+		l.source == other.source &&
+		l.commit == other.commit &&
+		l.digest == other.digest &&
+		l.hash == other.hash
+}
@@ -5,8 +5,11 @@ package depsfile
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
+	"strings"
 
+	goversion "github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclparse"
@@ -16,9 +19,9 @@ import (
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/oci"
 	"github.com/opentofu/opentofu/internal/replacefile"
 	"github.com/opentofu/opentofu/internal/tfdiags"
-	"github.com/opentofu/opentofu/version"
 )
 
 // LoadLocksFromFile reads locks from the given file, expecting it to be a
@@ -161,6 +164,30 @@ func SaveLocksToBytes(locks *Locks) ([]byte, tfdiags.Diagnostics) {
 		}
 	}
 
+	moduleKeys := make([]string, 0, len(locks.modules))
+	for key := range locks.modules {
+		moduleKeys = append(moduleKeys, key)
+	}
+	sort.Strings(moduleKeys)
+
+	for _, key := range moduleKeys {
+		lock := locks.modules[key]
+		rootBody.AppendNewline()
+		block := rootBody.AppendNewBlock("module", []string{lock.key})
+		body := block.Body()
+		body.SetAttributeValue("source", cty.StringVal(lock.source))
+		if lock.version != nil {
+			body.SetAttributeValue("version", cty.StringVal(lock.version.String()))
+		}
+		if lock.commit != "" {
+			body.SetAttributeValue("commit", cty.StringVal(lock.commit))
+		}
+		if lock.digest != "" {
+			body.SetAttributeValue("digest", cty.StringVal(lock.digest))
+		}
+		body.SetAttributeValue("hash", cty.StringVal(lock.hash.String()))
+	}
+
 	return f.Bytes(), diags
 }
 
@@ -173,11 +200,6 @@ func decodeLocksFromHCL(locks *Locks, body hcl.Body) tfdiags.Diagnostics {
 				Type:       "provider",
 				LabelNames: []string{"source_addr"},
 			},
-
-			// "module" is just a placeholder for future enhancement, so we
-			// can mostly-ignore the this block type we intend to add in
-			// future, but warn in case someone tries to use one e.g. if they
-			// downgraded to an earlier version of OpenTofu.
 			{
 				Type:       "module",
 				LabelNames: []string{"path"},
@@ -187,7 +209,7 @@ func decodeLocksFromHCL(locks *Locks, body hcl.Body) tfdiags.Diagnostics {
 	diags = diags.Append(hclDiags)
 
 	seenProviders := make(map[addrs.Provider]hcl.Range)
-	seenModule := false
+	seenModules := make(map[string]hcl.Range)
 	for _, block := range content.Blocks {
 
 		switch block.Type {
@@ -210,19 +232,22 @@ func decodeLocksFromHCL(locks *Locks, body hcl.Body) tfdiags.Diagnostics {
 			seenProviders[lock.addr] = block.DefRange
 
 		case "module":
-			// We'll just take the first module block to use for a single warning,
-			// because that's sufficient to get the point across without swamping
-			// the output with warning noise.
-			if !seenModule {
-				currentVersion := version.SemVer.String()
+			lock, moreDiags := decodeModuleLockFromHCL(block)
+			diags = diags.Append(moreDiags)
+			if lock == nil {
+				continue
+			}
+			if previousRng, exists := seenModules[lock.key]; exists {
 				diags = diags.Append(&hcl.Diagnostic{
-					Severity: hcl.DiagWarning,
-					Summary:  "Dependency locks for modules are not yet supported",
-					Detail:   fmt.Sprintf("OpenTofu v%s only supports dependency locks for providers, not for modules. This configuration may be intended for a later version of OpenTofu that also supports dependency locks for modules.", currentVersion),
+					Severity: hcl.DiagError,
+					Summary:  "Duplicate module lock",
+					Detail:   fmt.Sprintf("This lockfile already declared a lock for module %q at %s.", lock.key, previousRng.String()),
 					Subject:  block.TypeRange.Ptr(),
 				})
-				seenModule = true
+				continue
 			}
+			locks.modules[lock.key] = lock
+			seenModules[lock.key] = block.DefRange
 
 		default:
 			// Shouldn't get here because this should be exhaustive for
@@ -454,6 +479,181 @@ func decodeProviderHashesArgument(provider addrs.Provider, attr *hcl.Attribute)
 	return ret, diags
 }
 
+func decodeModuleLockFromHCL(block *hcl.Block) (*ModuleLock, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	key := block.Labels[0]
+	for _, name := range strings.Split(key, ".") {
+		if !hclsyntax.ValidIdentifier(name) {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid module path",
+				Detail:   "The module path for a module lock must be a sequence of module call names separated by periods, like \"network.subnets\".",
+				Subject:  block.LabelRanges[0].Ptr(),
+			})
+			return nil, diags
+		}
+	}
+
+	content, hclDiags := block.Body.Content(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{
+			{Name: "source", Required: true},
+			{Name: "version"},
+			{Name: "commit"},
+			{Name: "digest"},
+			{Name: "hash", Required: true},
+		},
+	})
+	diags = diags.Append(hclDiags)
+	if hclDiags.HasErrors() {
+		return nil, diags
+	}
+
+	ret := &ModuleLock{key: key}
+
+	var rawSource string
+	hclDiags = gohcl.DecodeExpression(content.Attributes["source"].Expr, nil, &rawSource)
+	diags = diags.Append(hclDiags)
+	if !hclDiags.HasErrors() {
+		source, err := addrs.ParseModuleSource(rawSource)
+		switch {
+		case err != nil:
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid module source address",
+				Detail:   fmt.Sprintf("The recorded source address for module %q is invalid: %s.", key, err),
+				Subject:  content.Attributes["source"].Expr.Range().Ptr(),
+			})
+		case isLocalModuleSource(source):
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid module source address",
+				Detail:   fmt.Sprintf("Module %q has a local source address, which is not eligible for dependency locking.", key),
+				Subject:  content.Attributes["source"].Expr.Range().Ptr(),
+			})
+		case source.String() != rawSource:
+			// Canonical forms are required in the lock file, to reduce the
+			// risk that a file diff will show changes that are entirely
+			// cosmetic.
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Non-normalized module source address",
+				Detail:   fmt.Sprintf("The source address for module %q must be written as %q, the normalized form.", key, source.String()),
+				Subject:  content.Attributes["source"].Expr.Range().Ptr(),
+			})
+		}
+		ret.source = rawSource
+	}
+
+	if attr, ok := content.Attributes["version"]; ok {
+		var raw string
+		hclDiags := gohcl.DecodeExpression(attr.Expr, nil, &raw)
+		diags = diags.Append(hclDiags)
+		if !hclDiags.HasErrors() {
+			v, err := goversion.NewVersion(raw)
+			switch {
+			case err != nil:
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid module version number",
+					Detail:   fmt.Sprintf("The selected version number for module %q is invalid: %s.", key, err),
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			case v.String() != raw:
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid module version number",
+					Detail:   fmt.Sprintf("The selected version number for module %q must be written in normalized form: %q.", key, v.String()),
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			default:
+				ret.version = v
+			}
+		}
+	}
+
+	if attr, ok := content.Attributes["commit"]; ok {
+		var raw string
+		hclDiags := gohcl.DecodeExpression(attr.Expr, nil, &raw)
+		diags = diags.Append(hclDiags)
+		if !hclDiags.HasErrors() {
+			if !gitCommitPattern.MatchString(raw) {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid module commit",
+					Detail:   fmt.Sprintf("The recorded commit for module %q must be a full commit id in lowercase hexadecimal.", key),
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			}
+			ret.commit = raw
+		}
+	}
+
+	if attr, ok := content.Attributes["digest"]; ok {
+		var raw string
+		hclDiags := gohcl.DecodeExpression(attr.Expr, nil, &raw)
+		diags = diags.Append(hclDiags)
+		if !hclDiags.HasErrors() {
+			switch _, err := oci.ParseDigest(raw); {
+			case err != nil:
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid module digest",
+					Detail:   fmt.Sprintf("The recorded manifest digest for module %q is invalid: %s.", key, err),
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			case !strings.HasPrefix(ret.source, "oci://"):
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid module digest",
+					Detail:   fmt.Sprintf("Module %q has a manifest digest, but only packages from an OCI registry can have one.", key),
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			}
+			ret.digest = raw
+		}
+	}
+
+	var rawHash string
+	hashExpr := content.Attributes["hash"].Expr
+	hclDiags = gohcl.DecodeExpression(hashExpr, nil, &rawHash)
+	diags = diags.Append(hclDiags)
+	if !hclDiags.HasErrors() {
+		hash, err := getproviders.ParseHash(rawHash)
+		switch {
+		case err != nil:
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid module hash string",
+				Detail:   fmt.Sprintf("Cannot interpret %q as a module hash: %s.", rawHash, err),
+				Subject:  hashExpr.Range().Ptr(),
+			})
+		case hash.Scheme() != getproviders.HashScheme1:
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid module hash string",
+				Detail:   fmt.Sprintf("Module hashes must use the %q scheme.", getproviders.HashScheme1),
+				Subject:  hashExpr.Range().Ptr(),
+			})
+		}
+		ret.hash = hash
+	}
+
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return ret, diags
+}
+
+// gitCommitPattern matches the full SHA-1 or SHA-256 object names that git
+// uses to identify commits.
+var gitCommitPattern = regexp.MustCompile(`^(?:[0-9a-f]{40}|[0-9a-f]{64})$`)
+
+func isLocalModuleSource(source addrs.ModuleSource) bool {
+	_, ok := source.(addrs.ModuleSourceLocal)
+	return ok
+}
+
 func encodeHashSetTokens(hashes []getproviders.Hash) hclwrite.Tokens {
 	// We'll generate the source code in a low-level way here (direct
 	// token manipulation) because it's desirable to maintain exactly
@@ -11,6 +11,8 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	version "github.com/hashicorp/go-version"
+
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/getproviders"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -105,6 +107,56 @@ func TestLoadLocksFromFile(t *testing.T) {
 					t.Errorf("wrong number of providers %d; want %d", got, want)
 				}
 
+			case "valid-module-locks.hcl":
+				if got, want := len(locks.modules), 3; got != want {
+					t.Errorf("wrong number of modules %d; want %d", got, want)
+				}
+
+				t.Run("registry", func(t *testing.T) {
+					lock := locks.Module("registry")
+					if lock == nil {
+						t.Fatal("no lock for module.registry")
+					}
+					if got, want := lock.Source(), "registry.opentofu.org/hashicorp/consul/aws"; got != want {
+						t.Errorf("wrong source\ngot:  %s\nwant: %s", got, want)
+					}
+					if got, want := lock.Version().String(), "0.11.0"; got != want {
+						t.Errorf("wrong version\ngot:  %s\nwant: %s", got, want)
+					}
+					if got, want := lock.Commit(), ""; got != want {
+						t.Errorf("wrong commit\ngot:  %s\nwant: %s", got, want)
+					}
+				})
+
+				t.Run("git.nested", func(t *testing.T) {
+					lock := locks.Module("git.nested")
+					if lock == nil {
+						t.Fatal("no lock for module.git.module.nested")
+					}
+					if lock.Version() != nil {
+						t.Errorf("unexpected version %s", lock.Version())
+					}
+					if got, want := lock.Commit(), "0123456789abcdef0123456789abcdef01234567"; got != want {
+						t.Errorf("wrong commit\ngot:  %s\nwant: %s", got, want)
+					}
+					if got, want := lock.Hash(), getproviders.HashScheme1.New("Sp4UHnIZjzMc8iKU1zaEMDyMyqXhW8x3wt6dpH4lk3w="); got != want {
+						t.Errorf("wrong hash\ngot:  %s\nwant: %s", got, want)
+					}
+				})
+
+				t.Run("oci", func(t *testing.T) {
+					lock := locks.Module("oci")
+					if lock == nil {
+						t.Fatal("no lock for module.oci")
+					}
+					if got, want := lock.Commit(), ""; got != want {
+						t.Errorf("wrong commit\ngot:  %s\nwant: %s", got, want)
+					}
+					if got, want := lock.Digest(), "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"; got != want {
+						t.Errorf("wrong digest\ngot:  %s\nwant: %s", got, want)
+					}
+				})
+
 			case "valid-provider-locks.hcl":
 				if got, want := len(locks.providers), 3; got != want {
 					t.Errorf("wrong number of providers %d; want %d", got, want)
@@ -221,6 +273,9 @@ func TestSaveLocksToFile(t *testing.T) {
 	locks.SetProvider(barProvider, oneDotTwo, pessimisticOneDotOh, nil)
 	locks.SetProvider(bazProvider, oneDotTwo, nil, nil)
 	locks.SetProvider(booProvider, oneDotTwo, abbreviatedOneDotTwo, nil)
+	locks.SetModule("network", "git::https://example.com/network.git?ref=v1.0.0", nil, "0123456789abcdef0123456789abcdef01234567", "", getproviders.HashScheme1.New("aaaa"))
+	locks.SetModule("consul", "registry.opentofu.org/hashicorp/consul/aws", version.Must(version.NewVersion("0.11.0")), "", "", getproviders.HashScheme1.New("bbbb"))
+	locks.SetModule("subnets", "oci://example.com/modules/subnets?tag=1.0.0", nil, "", "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", getproviders.HashScheme1.New("cccc"))
 
 	dir := t.TempDir()
 
@@ -269,6 +324,24 @@ provider "registry.opentofu.org/test/foo" {
     "test:cccccccccccccccccccccccccccccccccccccccccccccccc",
   ]
 }
+
+module "consul" {
+  source  = "registry.opentofu.org/hashicorp/consul/aws"
+  version = "0.11.0"
+  hash    = "h1:bbbb"
+}
+
+module "network" {
+  source = "git::https://example.com/network.git?ref=v1.0.0"
+  commit = "0123456789abcdef0123456789abcdef01234567"
+  hash   = "h1:aaaa"
+}
+
+module "subnets" {
+  source = "oci://example.com/modules/subnets?tag=1.0.0"
+  digest = "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+  hash   = "h1:cccc"
+}
 `
 	if diff := cmp.Diff(wantContent, gotContent); diff != "" {
 		t.Errorf("wrong result\n%s", diff)
@@ -83,6 +83,26 @@ func TestLocksEqual(t *testing.T) {
 		b.SetProvider(boopProvider, v2, v2EqConstraints, hashesB)
 		nonEqualBothWays(t, a, b)
 	})
+	t.Run("an extra module lock", func(t *testing.T) {
+		a := NewLocks()
+		b := NewLocks()
+		b.SetModule("network", "git::https://example.com/network.git", nil, "", "", getproviders.HashScheme1.New("1"))
+		nonEqualBothWays(t, a, b)
+	})
+	t.Run("both have network module with same hash", func(t *testing.T) {
+		a := NewLocks()
+		b := NewLocks()
+		a.SetModule("network", "git::https://example.com/network.git", nil, "", "", getproviders.HashScheme1.New("1"))
+		b.SetModule("network", "git::https://example.com/network.git", nil, "", "", getproviders.HashScheme1.New("1"))
+		equalBothWays(t, a, b)
+	})
+	t.Run("both have network module with different hashes", func(t *testing.T) {
+		a := NewLocks()
+		b := NewLocks()
+		a.SetModule("network", "git::https://example.com/network.git", nil, "", "", getproviders.HashScheme1.New("1"))
+		b.SetModule("network", "git::https://example.com/network.git", nil, "", "", getproviders.HashScheme1.New("2"))
+		nonEqualBothWays(t, a, b)
+	})
 }
 
 func TestLocksEqualProviderAddress(t *testing.T) {
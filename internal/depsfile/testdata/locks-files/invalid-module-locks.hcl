@@ -0,0 +1,57 @@
+module "invalid path" { # ERROR: Invalid module path
+  source = "git::https://example.com/network.git"
+  hash   = "h1:Sp4UHnIZjzMc8iKU1zaEMDyMyqXhW8x3wt6dpH4lk3w="
+}
+
+module "local" {
+  source = "./network" # ERROR: Invalid module source address
+  hash   = "h1:Sp4UHnIZjzMc8iKU1zaEMDyMyqXhW8x3wt6dpH4lk3w="
+}
+
+module "non_normalized" {
+  source = "hashicorp/consul/aws" # ERROR: Non-normalized module source address
+  hash   = "h1:Sp4UHnIZjzMc8iKU1zaEMDyMyqXhW8x3wt6dpH4lk3w="
+}
+
+module "version" {
+  source  = "registry.opentofu.org/hashicorp/consul/aws"
+  version = "v1.0" # ERROR: Invalid module version number
+  hash    = "h1:Sp4UHnIZjzMc8iKU1zaEMDyMyqXhW8x3wt6dpH4lk3w="
+}
+
+module "commit" {
+  source = "git::https://example.com/network.git"
+  commit = "main" # ERROR: Invalid module commit
+  hash   = "h1:Sp4UHnIZjzMc8iKU1zaEMDyMyqXhW8x3wt6dpH4lk3w="
+}
+
+module "digest" {
+  source = "oci://example.com/modules/network?tag=1.0.0"
+  digest = "sha256:abc" # ERROR: Invalid module digest
+  hash   = "h1:Sp4UHnIZjzMc8iKU1zaEMDyMyqXhW8x3wt6dpH4lk3w="
+}
+
+module "digest_not_oci" {
+  source = "git::https://example.com/network.git"
+  digest = "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08" # ERROR: Invalid module digest
+  hash   = "h1:Sp4UHnIZjzMc8iKU1zaEMDyMyqXhW8x3wt6dpH4lk3w="
+}
+
+module "hash" {
+  source = "git::https://example.com/network.git"
+  hash   = "zh:0123456789abcdef" # ERROR: Invalid module hash string
+}
+
+module "missing_hash" { # ERROR: Missing required argument
+  source = "git::https://example.com/network.git"
+}
+
+module "duplicate" {
+  source = "git::https://example.com/network.git"
+  hash   = "h1:Sp4UHnIZjzMc8iKU1zaEMDyMyqXhW8x3wt6dpH4lk3w="
+}
+
+module "duplicate" { # ERROR: Duplicate module lock
+  source = "git::https://example.com/network.git"
+  hash   = "h1:Sp4UHnIZjzMc8iKU1zaEMDyMyqXhW8x3wt6dpH4lk3w="
+}
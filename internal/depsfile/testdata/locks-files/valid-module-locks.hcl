@@ -0,0 +1,18 @@
+
+module "registry" {
+  source  = "registry.opentofu.org/hashicorp/consul/aws"
+  version = "0.11.0"
+  hash    = "h1:Sp4UHnIZjzMc8iKU1zaEMDyMyqXhW8x3wt6dpH4lk3w="
+}
+
+module "git.nested" {
+  source = "git::https://example.com/network.git?ref=v1.0.0"
+  commit = "0123456789abcdef0123456789abcdef01234567"
+  hash   = "h1:Sp4UHnIZjzMc8iKU1zaEMDyMyqXhW8x3wt6dpH4lk3w="
+}
+
+module "oci" {
+  source = "oci://example.com/modules/network?tag=1.0.0"
+  digest = "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+  hash   = "h1:Sp4UHnIZjzMc8iKU1zaEMDyMyqXhW8x3wt6dpH4lk3w="
+}
@@ -0,0 +1,311 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	hcljson "github.com/hashicorp/hcl/v2/json"
+)
+
+// ConfigEnvName is the name of the environment variable that can contain
+// encryption configuration, using the same syntax as the content of an
+// "encryption" block.
+//
+// Any key providers, methods, or targets declared in the environment
+// variable take precedence over those with the same address declared in
+// the configuration.
+const ConfigEnvName = "TF_ENCRYPTION"
+
+// Config represents the content of an "encryption" block.
+type Config struct {
+	KeyProviders []*KeyProviderConfig
+	Methods      []*MethodConfig
+
+	State *TargetConfig
+	Plan  *TargetConfig
+
+	DeclRange hcl.Range
+}
+
+// KeyProviderConfig represents a "key_provider" block.
+type KeyProviderConfig struct {
+	Type string
+	Name string
+	Body hcl.Body
+
+	TypeRange hcl.Range
+	DeclRange hcl.Range
+}
+
+// Addr returns the string used to refer to the key provider from elsewhere
+// in the encryption configuration.
+func (c *KeyProviderConfig) Addr() string {
+	return "key_provider." + c.Type + "." + c.Name
+}
+
+// MethodConfig represents a "method" block.
+type MethodConfig struct {
+	Type string
+	Name string
+	Body hcl.Body
+
+	TypeRange hcl.Range
+	DeclRange hcl.Range
+}
+
+// Addr returns the string used to refer to the method from elsewhere in the
+// encryption configuration.
+func (c *MethodConfig) Addr() string {
+	return "method." + c.Type + "." + c.Name
+}
+
+// TargetConfig represents a "state" or "plan" block, which selects the
+// method used for that kind of artifact.
+type TargetConfig struct {
+	// Method is a reference to the method used for both encryption and
+	// decryption.
+	Method hcl.Expression
+
+	// Enforced, if set, forbids Method from referring to an "unencrypted"
+	// method, so that a misconfiguration cannot cause plaintext data to
+	// be written.
+	Enforced bool
+
+	// Fallback optionally declares a method that is only used to decrypt
+	// data that Method cannot decrypt, such as data encrypted with a key
+	// that is being rotated out or data that is not encrypted yet.
+	Fallback *FallbackConfig
+
+	DeclRange hcl.Range
+}
+
+// FallbackConfig represents a "fallback" block inside a "state" or "plan"
+// block.
+type FallbackConfig struct {
+	Method hcl.Expression
+
+	DeclRange hcl.Range
+}
+
+// DecodeConfig decodes the body of an "encryption" block.
+func DecodeConfig(body hcl.Body, rng hcl.Range) (*Config, hcl.Diagnostics) {
+	cfg := &Config{
+		DeclRange: rng,
+	}
+
+	content, diags := body.Content(configSchema)
+
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case "key_provider":
+			cfg.KeyProviders = append(cfg.KeyProviders, &KeyProviderConfig{
+				Type:      block.Labels[0],
+				Name:      block.Labels[1],
+				Body:      block.Body,
+				TypeRange: block.LabelRanges[0],
+				DeclRange: block.DefRange,
+			})
+
+		case "method":
+			cfg.Methods = append(cfg.Methods, &MethodConfig{
+				Type:      block.Labels[0],
+				Name:      block.Labels[1],
+				Body:      block.Body,
+				TypeRange: block.LabelRanges[0],
+				DeclRange: block.DefRange,
+			})
+
+		case "state", "plan":
+			target, targetDiags := decodeTargetConfig(block)
+			diags = append(diags, targetDiags...)
+			if target == nil {
+				continue
+			}
+			existing := &cfg.State
+			if block.Type == "plan" {
+				existing = &cfg.Plan
+			}
+			if *existing != nil {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  fmt.Sprintf("Duplicate %s block", block.Type),
+					Detail:   fmt.Sprintf("The %s encryption was already configured at %s.", block.Type, (*existing).DeclRange),
+					Subject:  block.DefRange.Ptr(),
+				})
+				continue
+			}
+			*existing = target
+
+		default:
+			// Should never happen because the above cases should be exhaustive
+			// for all block type names in our schema.
+			continue
+		}
+	}
+
+	return cfg, diags
+}
+
+func decodeTargetConfig(block *hcl.Block) (*TargetConfig, hcl.Diagnostics) {
+	content, diags := block.Body.Content(targetSchema)
+
+	target := &TargetConfig{
+		DeclRange: block.DefRange,
+	}
+
+	if attr, exists := content.Attributes["method"]; exists {
+		target.Method = attr.Expr
+	}
+
+	if attr, exists := content.Attributes["enforced"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &target.Enforced)
+		diags = append(diags, valDiags...)
+	}
+
+	for _, fallbackBlock := range content.Blocks {
+		if target.Fallback != nil {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate fallback block",
+				Detail:   fmt.Sprintf("Only one fallback method can be declared for %s encryption.", block.Type),
+				Subject:  fallbackBlock.DefRange.Ptr(),
+			})
+			continue
+		}
+		fallbackContent, fallbackDiags := fallbackBlock.Body.Content(fallbackSchema)
+		diags = append(diags, fallbackDiags...)
+		if fallbackDiags.HasErrors() {
+			continue
+		}
+		target.Fallback = &FallbackConfig{
+			Method:    fallbackContent.Attributes["method"].Expr,
+			DeclRange: fallbackBlock.DefRange,
+		}
+	}
+
+	return target, diags
+}
+
+// ConfigFromEnv decodes the encryption configuration given in the
+// environment variable named by ConfigEnvName, returning nil if that
+// environment variable is not set.
+//
+// The environment variable can contain either native HCL syntax or JSON.
+func ConfigFromEnv() (*Config, hcl.Diagnostics) {
+	src := os.Getenv(ConfigEnvName)
+	if strings.TrimSpace(src) == "" {
+		return nil, nil
+	}
+	return parseConfigSource([]byte(src), ConfigEnvName)
+}
+
+func parseConfigSource(src []byte, filename string) (*Config, hcl.Diagnostics) {
+	var file *hcl.File
+	var diags hcl.Diagnostics
+	if strings.HasPrefix(strings.TrimSpace(string(src)), "{") {
+		file, diags = hcljson.Parse(src, filename)
+	} else {
+		file, diags = hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	cfg, cfgDiags := DecodeConfig(file.Body, hcl.Range{Filename: filename})
+	return cfg, append(diags, cfgDiags...)
+}
+
+// MergeConfigs combines the given base configuration with an override
+// configuration, such as one returned by ConfigFromEnv. Either argument can
+// be nil.
+//
+// Key providers and methods in the override replace those in the base that
+// have the same address, and a "state" or "plan" block in the override
+// replaces the corresponding block in the base entirely.
+func MergeConfigs(base, override *Config) *Config {
+	if base == nil {
+		return override
+	}
+	if override == nil {
+		return base
+	}
+
+	ret := &Config{
+		State:     base.State,
+		Plan:      base.Plan,
+		DeclRange: base.DeclRange,
+	}
+
+	overriddenKeyProviders := make(map[string]bool, len(override.KeyProviders))
+	for _, kpc := range override.KeyProviders {
+		overriddenKeyProviders[kpc.Addr()] = true
+	}
+	for _, kpc := range base.KeyProviders {
+		if !overriddenKeyProviders[kpc.Addr()] {
+			ret.KeyProviders = append(ret.KeyProviders, kpc)
+		}
+	}
+	ret.KeyProviders = append(ret.KeyProviders, override.KeyProviders...)
+
+	overriddenMethods := make(map[string]bool, len(override.Methods))
+	for _, mc := range override.Methods {
+		overriddenMethods[mc.Addr()] = true
+	}
+	for _, mc := range base.Methods {
+		if !overriddenMethods[mc.Addr()] {
+			ret.Methods = append(ret.Methods, mc)
+		}
+	}
+	ret.Methods = append(ret.Methods, override.Methods...)
+
+	if override.State != nil {
+		ret.State = override.State
+	}
+	if override.Plan != nil {
+		ret.Plan = override.Plan
+	}
+
+	return ret
+}
+
+var configSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type:       "key_provider",
+			LabelNames: []string{"type", "name"},
+		},
+		{
+			Type:       "method",
+			LabelNames: []string{"type", "name"},
+		},
+		{
+			Type: "state",
+		},
+		{
+			Type: "plan",
+		},
+	},
+}
+
+var targetSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "method", Required: true},
+		{Name: "enforced"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "fallback"},
+	},
+}
+
+var fallbackSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "method", Required: true},
+	},
+}
@@ -0,0 +1,238 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package encryption implements client-side encryption of the state and plan
+// files that OpenTofu writes, regardless of which backend eventually stores
+// them.
+//
+// Encryption is configured using an "encryption" block nested inside the
+// "terraform" block of the root module, and/or using the TF_ENCRYPTION
+// environment variable. The configuration declares a number of key providers,
+// which produce key material, and methods, which use that key material to
+// encrypt and decrypt data. The "state" and "plan" blocks then select which
+// method to use for each kind of artifact, along with an optional fallback
+// method that is only used for decryption, to support key rotation and
+// migration from unencrypted data.
+package encryption
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// Encryption is the entry point for all encryption-related behavior, giving
+// access to the encryption of each kind of artifact.
+type Encryption interface {
+	// State returns the encryption to use for state snapshots.
+	State() StateEncryption
+
+	// Plan returns the encryption to use for saved plan files.
+	Plan() PlanEncryption
+
+	// RemoteState returns the encryption to use for reading the state
+	// snapshots of other configurations, such as with the
+	// terraform_remote_state data source. It decrypts state using the same
+	// methods as State, but always accepts unencrypted state, because other
+	// configurations might not encrypt their state at all. It can't encrypt
+	// state.
+	RemoteState() StateEncryption
+}
+
+// StateEncryption encrypts and decrypts serialized state snapshots.
+type StateEncryption interface {
+	// EncryptState encrypts the given serialized state snapshot, returning
+	// the data unchanged if state encryption isn't configured.
+	EncryptState([]byte) ([]byte, error)
+
+	// DecryptState decrypts the given data previously returned by
+	// EncryptState. Unencrypted data is returned unchanged if the
+	// configuration allows reading unencrypted state, and is an error
+	// otherwise.
+	DecryptState([]byte) ([]byte, error)
+
+	// Enabled returns true if EncryptState actually encrypts state
+	// snapshots, rather than returning them unchanged.
+	Enabled() bool
+}
+
+// PlanEncryption encrypts and decrypts saved plan files.
+type PlanEncryption interface {
+	// EncryptPlan encrypts the given plan file contents, returning the data
+	// unchanged if plan encryption isn't configured.
+	EncryptPlan([]byte) ([]byte, error)
+
+	// DecryptPlan decrypts the given data previously returned by
+	// EncryptPlan. Unencrypted data is returned unchanged if the
+	// configuration allows reading unencrypted plans, and is an error
+	// otherwise.
+	DecryptPlan([]byte) ([]byte, error)
+}
+
+// New builds an Encryption from the given configuration, which may be nil
+// to represent that encryption is not configured at all.
+//
+// The result is safe to use even if error diagnostics are returned, but it
+// will then behave as if encryption were disabled.
+func New(cfg *Config) (Encryption, hcl.Diagnostics) {
+	if cfg == nil {
+		return Disabled(), nil
+	}
+
+	var diags hcl.Diagnostics
+
+	keyProviders := make(map[string]*keyProviderInstance, len(cfg.KeyProviders))
+	for _, kpc := range cfg.KeyProviders {
+		addr := kpc.Addr()
+		if existing, exists := keyProviders[addr]; exists {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate key provider",
+				Detail:   fmt.Sprintf("A key provider named %s was already declared at %s.", addr, existing.declRange),
+				Subject:  kpc.DeclRange.Ptr(),
+			})
+			continue
+		}
+		factory, ok := keyProviderTypes[kpc.Type]
+		if !ok {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unsupported key provider type",
+				Detail:   fmt.Sprintf("There is no key provider of type %q.", kpc.Type),
+				Subject:  kpc.TypeRange.Ptr(),
+			})
+			continue
+		}
+		kp, kpDiags := factory(kpc.Body)
+		diags = append(diags, kpDiags...)
+		if kpDiags.HasErrors() {
+			continue
+		}
+		keyProviders[addr] = &keyProviderInstance{
+			addr:      addr,
+			impl:      kp,
+			declRange: kpc.DeclRange,
+		}
+	}
+
+	methods := make(map[string]*methodInstance, len(cfg.Methods))
+	for _, mc := range cfg.Methods {
+		addr := mc.Addr()
+		if existing, exists := methods[addr]; exists {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate encryption method",
+				Detail:   fmt.Sprintf("An encryption method named %s was already declared at %s.", addr, existing.declRange),
+				Subject:  mc.DeclRange.Ptr(),
+			})
+			continue
+		}
+		m, mDiags := decodeMethod(mc, keyProviders)
+		diags = append(diags, mDiags...)
+		if mDiags.HasErrors() {
+			continue
+		}
+		methods[addr] = m
+	}
+
+	state, stateDiags := newTarget("state", cfg.State, methods)
+	diags = append(diags, stateDiags...)
+	plan, planDiags := newTarget("plan", cfg.Plan, methods)
+	diags = append(diags, planDiags...)
+
+	if diags.HasErrors() {
+		return Disabled(), diags
+	}
+
+	return &encryption{
+		state: &stateEncryption{target: state},
+		plan:  &planEncryption{target: plan},
+	}, diags
+}
+
+// Disabled returns an Encryption that leaves all data unencrypted, for
+// situations where encryption isn't configured.
+func Disabled() Encryption {
+	return &encryption{
+		state: &stateEncryption{},
+		plan:  &planEncryption{},
+	}
+}
+
+// StateEncryptionDisabled returns a StateEncryption that leaves state
+// snapshots unencrypted.
+func StateEncryptionDisabled() StateEncryption {
+	return Disabled().State()
+}
+
+// PlanEncryptionDisabled returns a PlanEncryption that leaves plan files
+// unencrypted.
+func PlanEncryptionDisabled() PlanEncryption {
+	return Disabled().Plan()
+}
+
+type encryption struct {
+	state *stateEncryption
+	plan  *planEncryption
+}
+
+func (e *encryption) State() StateEncryption {
+	return e.state
+}
+
+func (e *encryption) Plan() PlanEncryption {
+	return e.plan
+}
+
+func (e *encryption) RemoteState() StateEncryption {
+	return &remoteStateEncryption{target: e.state.target}
+}
+
+type stateEncryption struct {
+	target *target
+}
+
+func (s *stateEncryption) EncryptState(data []byte) ([]byte, error) {
+	return s.target.encrypt(data)
+}
+
+func (s *stateEncryption) DecryptState(data []byte) ([]byte, error) {
+	return s.target.decrypt("state", data)
+}
+
+func (s *stateEncryption) Enabled() bool {
+	return s.target.enabled()
+}
+
+// remoteStateEncryption is the read-only StateEncryption returned by
+// Encryption.RemoteState.
+type remoteStateEncryption struct {
+	target *target
+}
+
+func (s *remoteStateEncryption) EncryptState([]byte) ([]byte, error) {
+	return nil, fmt.Errorf("the state of another configuration can only be read")
+}
+
+func (s *remoteStateEncryption) DecryptState(data []byte) ([]byte, error) {
+	if parseEnvelope(data) == nil {
+		return data, nil
+	}
+	return s.target.decrypt("state", data)
+}
+
+func (s *remoteStateEncryption) Enabled() bool {
+	return false
+}
+
+type planEncryption struct {
+	target *target
+}
+
+func (p *planEncryption) EncryptPlan(data []byte) ([]byte, error) {
+	return p.target.encrypt(data)
+}
+
+func (p *planEncryption) DecryptPlan(data []byte) ([]byte, error) {
+	return p.target.decrypt("plan", data)
+}
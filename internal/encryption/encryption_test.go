@@ -0,0 +1,479 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testEncryption(t *testing.T, src string) Encryption {
+	t.Helper()
+
+	cfg, diags := parseConfigSource([]byte(src), "test.tf")
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors decoding config: %s", diags.Error())
+	}
+	enc, diags := New(cfg)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors building encryption: %s", diags.Error())
+	}
+	return enc
+}
+
+const testPBKDF2Config = `
+key_provider "pbkdf2" "current" {
+  passphrase = "correct-horse-battery-staple"
+  iterations = 200000
+}
+method "aes_gcm" "current" {
+  keys = key_provider.pbkdf2.current
+}
+`
+
+func TestEncryption_roundTrip(t *testing.T) {
+	enc := testEncryption(t, testPBKDF2Config+`
+state {
+  method = method.aes_gcm.current
+}
+plan {
+  method = method.aes_gcm.current
+}
+`)
+
+	plaintext := []byte(`{"version":4,"serial":1}`)
+
+	if !enc.State().Enabled() {
+		t.Fatalf("state encryption is not enabled")
+	}
+	encrypted, err := enc.State().EncryptState(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(encrypted, plaintext) {
+		t.Fatalf("encrypted state contains the plaintext")
+	}
+	decrypted, err := enc.State().DecryptState(encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Fatalf("wrong result\ngot:  %s\nwant: %s", decrypted, plaintext)
+	}
+
+	encrypted, err = enc.Plan().EncryptPlan(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err = enc.Plan().DecryptPlan(encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Fatalf("wrong result\ngot:  %s\nwant: %s", decrypted, plaintext)
+	}
+
+	// Unencrypted data is rejected unless an unencrypted method is
+	// explicitly allowed.
+	if _, err := enc.State().DecryptState(plaintext); err == nil {
+		t.Fatalf("expected error decrypting unencrypted state")
+	}
+}
+
+func TestEncryption_disabled(t *testing.T) {
+	enc := Disabled()
+	plaintext := []byte(`{"version":4}`)
+
+	if enc.State().Enabled() {
+		t.Fatalf("disabled state encryption reports that it is enabled")
+	}
+
+	got, err := enc.State().EncryptState(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Fatalf("disabled encryption changed the data: %s", got)
+	}
+
+	encrypted, err := testEncryption(t, testPBKDF2Config+`
+state {
+  method = method.aes_gcm.current
+}
+`).State().EncryptState(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = enc.State().DecryptState(encrypted)
+	if err == nil || !strings.Contains(err.Error(), "no state encryption is configured") {
+		t.Fatalf("wrong error: %v", err)
+	}
+}
+
+func TestEncryption_remoteState(t *testing.T) {
+	enc := testEncryption(t, testPBKDF2Config+`
+state {
+  method   = method.aes_gcm.current
+  enforced = true
+}
+`)
+	plaintext := []byte(`{"version":4,"serial":1}`)
+	remote := enc.RemoteState()
+
+	// The state of another configuration is decrypted using the methods
+	// configured for this one, but can also be unencrypted.
+	encrypted, err := enc.State().EncryptState(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, data := range [][]byte{encrypted, plaintext} {
+		got, err := remote.DecryptState(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, plaintext) {
+			t.Fatalf("wrong result\ngot:  %s\nwant: %s", got, plaintext)
+		}
+	}
+
+	if remote.Enabled() {
+		t.Fatalf("remote state encryption reports that it is enabled")
+	}
+	if _, err := remote.EncryptState(plaintext); err == nil {
+		t.Fatalf("remote state encryption encrypted state")
+	}
+
+	_, err = Disabled().RemoteState().DecryptState(encrypted)
+	if err == nil || !strings.Contains(err.Error(), "no state encryption is configured") {
+		t.Fatalf("wrong error: %v", err)
+	}
+}
+
+func TestEncryption_migrateFromUnencrypted(t *testing.T) {
+	enc := testEncryption(t, testPBKDF2Config+`
+method "unencrypted" "migrate" {}
+state {
+  method = method.aes_gcm.current
+  fallback {
+    method = method.unencrypted.migrate
+  }
+}
+`)
+	plaintext := []byte(`{"version":4}`)
+
+	got, err := enc.State().DecryptState(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Fatalf("wrong result: %s", got)
+	}
+
+	encrypted, err := enc.State().EncryptState(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parseEnvelope(encrypted) == nil {
+		t.Fatalf("state was not encrypted after migration")
+	}
+}
+
+func TestEncryption_keyRotation(t *testing.T) {
+	dir := t.TempDir()
+	oldKeyFile := filepath.Join(dir, "old.key")
+	newKeyFile := filepath.Join(dir, "new.key")
+	if err := os.WriteFile(oldKeyFile, []byte("000102030405060708090a0b0c0d0e0f"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(newKeyFile, []byte("101112131415161718191a1b1c1d1e1f\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	oldEnc := testEncryption(t, `
+key_provider "file" "old" {
+  path     = "`+filepath.ToSlash(oldKeyFile)+`"
+  encoding = "hex"
+}
+method "aes_gcm" "old" {
+  keys = key_provider.file.old
+}
+state {
+  method = method.aes_gcm.old
+}
+`)
+	newEnc := testEncryption(t, `
+key_provider "file" "old" {
+  path     = "`+filepath.ToSlash(oldKeyFile)+`"
+  encoding = "hex"
+}
+key_provider "file" "new" {
+  path     = "`+filepath.ToSlash(newKeyFile)+`"
+  encoding = "hex"
+}
+method "aes_gcm" "old" {
+  keys = key_provider.file.old
+}
+method "aes_gcm" "new" {
+  keys = key_provider.file.new
+}
+state {
+  method = method.aes_gcm.new
+  enforced = true
+  fallback {
+    method = method.aes_gcm.old
+  }
+}
+`)
+	plaintext := []byte(`{"version":4}`)
+
+	oldEncrypted, err := oldEnc.State().EncryptState(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := newEnc.State().DecryptState(oldEncrypted)
+	if err != nil {
+		t.Fatalf("failed to decrypt with fallback: %s", err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Fatalf("wrong result: %s", got)
+	}
+
+	newEncrypted, err := newEnc.State().EncryptState(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := oldEnc.State().DecryptState(newEncrypted); err == nil {
+		t.Fatalf("old key unexpectedly decrypted state encrypted with the new key")
+	}
+}
+
+func TestEncryption_externalKeyProvider(t *testing.T) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("requires /bin/sh")
+	}
+	// The key is the base64 encoding of 32 "k" characters.
+	enc := testEncryption(t, `
+key_provider "external" "test" {
+  command = ["/bin/sh", "-c", "cat >/dev/null; echo '{\"keys\":{\"encryption_key\":\"a2tra2tra2tra2tra2tra2tra2tra2tra2tra2tra2s=\",\"decryption_key\":\"a2tra2tra2tra2tra2tra2tra2tra2tra2tra2tra2s=\"}}'"]
+}
+method "aes_gcm" "test" {
+  keys = key_provider.external.test
+}
+state {
+  method = method.aes_gcm.test
+}
+`)
+	plaintext := []byte(`{"version":4}`)
+
+	encrypted, err := enc.State().EncryptState(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := enc.State().DecryptState(encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Fatalf("wrong result: %s", got)
+	}
+}
+
+func TestNew_errors(t *testing.T) {
+	tests := map[string]struct {
+		src  string
+		want string
+	}{
+		"enforced unencrypted": {
+			`
+method "unencrypted" "plain" {}
+state {
+  method   = method.unencrypted.plain
+  enforced = true
+}
+`,
+			"Unencrypted method is forbidden",
+		},
+		"undeclared method": {
+			`
+state {
+  method = method.aes_gcm.nope
+}
+`,
+			"Reference to undeclared encryption method",
+		},
+		"undeclared key provider": {
+			`
+method "aes_gcm" "example" {
+  keys = key_provider.pbkdf2.nope
+}
+`,
+			"Reference to undeclared key provider",
+		},
+		"short passphrase": {
+			`
+key_provider "pbkdf2" "example" {
+  passphrase = "short"
+}
+`,
+			"Passphrase is too short",
+		},
+		"invalid key length": {
+			`
+key_provider "pbkdf2" "example" {
+  passphrase = "correct-horse-battery-staple"
+  key_length = 20
+}
+`,
+			"Invalid key length",
+		},
+		"unsupported key provider": {
+			`
+key_provider "magic" "example" {
+}
+`,
+			"Unsupported key provider type",
+		},
+		"invalid reference": {
+			`
+state {
+  method = "aes_gcm"
+}
+`,
+			"Invalid reference",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg, diags := parseConfigSource([]byte(test.src), "test.tf")
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors decoding config: %s", diags.Error())
+			}
+			_, diags = New(cfg)
+			if !diags.HasErrors() {
+				t.Fatalf("unexpected success")
+			}
+			if got := diags.Error(); !strings.Contains(got, test.want) {
+				t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPBKDF2KeyProvider_invalidMeta(t *testing.T) {
+	p := &pbkdf2KeyProvider{passphrase: "correct-horse-battery-staple"}
+
+	tests := map[string]struct {
+		meta string
+		want string
+	}{
+		"too few iterations": {
+			`{"salt":"AAAA","iterations":1,"hash_function":"sha512","key_length":32}`,
+			"invalid number of iterations",
+		},
+		"too many iterations": {
+			`{"salt":"AAAA","iterations":2000000000,"hash_function":"sha512","key_length":32}`,
+			"invalid number of iterations",
+		},
+		"invalid key length": {
+			`{"salt":"AAAA","iterations":200000,"hash_function":"sha512","key_length":1000000000}`,
+			"invalid key length",
+		},
+		"unsupported hash function": {
+			`{"salt":"AAAA","iterations":200000,"hash_function":"md5","key_length":32}`,
+			"unsupported hash function",
+		},
+		"missing salt": {
+			`{"iterations":200000,"hash_function":"sha512","key_length":32}`,
+			"missing salt",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := p.Provide([]byte(test.meta))
+			if err == nil {
+				t.Fatalf("unexpected success")
+			}
+			if got := err.Error(); !strings.Contains(got, test.want) {
+				t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMergeConfigs(t *testing.T) {
+	base, diags := parseConfigSource([]byte(testPBKDF2Config+`
+state {
+  method = method.aes_gcm.current
+}
+`), "test.tf")
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+
+	t.Setenv(ConfigEnvName, `{
+  "key_provider": {
+    "pbkdf2": {
+      "current": {
+        "passphrase": "a-completely-different-passphrase",
+        "iterations": 200000
+      }
+    }
+  }
+}`)
+	override, diags := ConfigFromEnv()
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+
+	merged := MergeConfigs(base, override)
+	if got, want := len(merged.KeyProviders), 1; got != want {
+		t.Fatalf("wrong number of key providers %d; want %d", got, want)
+	}
+	if got, want := merged.KeyProviders[0].DeclRange.Filename, ConfigEnvName; got != want {
+		t.Fatalf("key provider was not overridden: declared in %q", got)
+	}
+	if merged.State == nil {
+		t.Fatalf("state configuration was lost")
+	}
+
+	enc, diags := New(merged)
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	encrypted, err := enc.State().EncryptState([]byte("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The base configuration alone uses a different passphrase, so must not
+	// be able to decrypt.
+	baseEnc, diags := New(base)
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	if _, err := baseEnc.State().DecryptState(encrypted); err == nil {
+		t.Fatalf("expected the overridden passphrase to be used")
+	}
+}
+
+func TestDecodeConfig_duplicateTargets(t *testing.T) {
+	_, diags := parseConfigSource([]byte(`
+state {
+  method = method.aes_gcm.a
+}
+state {
+  method = method.aes_gcm.b
+}
+`), "test.tf")
+	if !diags.HasErrors() {
+		t.Fatalf("unexpected success")
+	}
+	if got, want := diags[0].Summary, "Duplicate state block"; got != want {
+		t.Fatalf("wrong error %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// Keys is the key material produced by a key provider.
+type Keys struct {
+	// EncryptionKey is the key to use when encrypting new data.
+	EncryptionKey []byte
+
+	// DecryptionKey is the key to use when decrypting existing data. It is
+	// often the same as EncryptionKey, but can differ for key providers
+	// that derive keys from metadata stored alongside the encrypted data.
+	DecryptionKey []byte
+}
+
+// KeyProvider produces the key material used by an encryption method.
+type KeyProvider interface {
+	// Provide returns the keys to use.
+	//
+	// When decrypting, storedMeta is the metadata that Provide returned
+	// when the data was originally encrypted, and the returned
+	// DecryptionKey must be the key that was the EncryptionKey at that
+	// time. When encrypting, storedMeta is nil.
+	//
+	// The returned metadata is stored, unencrypted, alongside the encrypted
+	// data and so must not contain anything secret.
+	Provide(storedMeta json.RawMessage) (keys Keys, meta json.RawMessage, err error)
+}
+
+// keyProviderTypes is the table of all available key provider types,
+// mapping from the type name used in configuration to a function that
+// decodes a key provider of that type from its configuration body.
+var keyProviderTypes = map[string]func(body hcl.Body) (KeyProvider, hcl.Diagnostics){
+	"pbkdf2":   decodePBKDF2KeyProvider,
+	"file":     decodeFileKeyProvider,
+	"external": decodeExternalKeyProvider,
+}
+
+// keyProviderInstance is a key provider that has been fully configured.
+type keyProviderInstance struct {
+	addr      string
+	impl      KeyProvider
+	declRange hcl.Range
+}
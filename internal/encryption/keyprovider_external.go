@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+)
+
+// externalKeyProvider obtains keys by running an external program, which
+// allows integrating with key management systems that OpenTofu has no
+// built-in support for.
+//
+// The program receives a JSON object on its stdin with a single property
+// "meta", which is either null when encrypting or the metadata the program
+// previously returned when decrypting. It must write a JSON object to its
+// stdout with the following structure, where the keys are base64-encoded:
+//
+//	{
+//	  "keys": {
+//	    "encryption_key": "...",
+//	    "decryption_key": "..."
+//	  },
+//	  "meta": {}
+//	}
+//
+// "decryption_key" is required only when the input metadata was not null,
+// and "meta" is optional. Anything the program writes to stderr is passed
+// through to OpenTofu's own stderr.
+type externalKeyProvider struct {
+	command []string
+}
+
+var _ KeyProvider = (*externalKeyProvider)(nil)
+
+type externalKeyProviderInput struct {
+	Meta json.RawMessage `json:"meta"`
+}
+
+type externalKeyProviderOutput struct {
+	Keys struct {
+		EncryptionKey []byte `json:"encryption_key"`
+		DecryptionKey []byte `json:"decryption_key"`
+	} `json:"keys"`
+	Meta json.RawMessage `json:"meta,omitempty"`
+}
+
+func decodeExternalKeyProvider(body hcl.Body) (KeyProvider, hcl.Diagnostics) {
+	var cfg struct {
+		Command []string `hcl:"command"`
+	}
+	diags := gohcl.DecodeBody(body, nil, &cfg)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	if len(cfg.Command) == 0 || cfg.Command[0] == "" {
+		var subject *hcl.Range
+		if attrs, _ := body.JustAttributes(); attrs["command"] != nil {
+			subject = attrs["command"].Expr.Range().Ptr()
+		}
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid key provider command",
+			Detail:   "The command must be a list containing at least the program to run.",
+			Subject:  subject,
+		})
+		return nil, diags
+	}
+
+	return &externalKeyProvider{
+		command: cfg.Command,
+	}, diags
+}
+
+func (p *externalKeyProvider) Provide(storedMeta json.RawMessage) (Keys, json.RawMessage, error) {
+	input, err := json.Marshal(externalKeyProviderInput{Meta: storedMeta})
+	if err != nil {
+		return Keys{}, nil, err
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.Command(p.command[0], p.command[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return Keys{}, nil, fmt.Errorf("failed to run %s: %w", strings.Join(p.command, " "), err)
+	}
+
+	var output externalKeyProviderOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return Keys{}, nil, fmt.Errorf("invalid output from %s: %w", p.command[0], err)
+	}
+
+	meta := output.Meta
+	if len(meta) == 0 || string(meta) == "null" {
+		meta = nil
+	}
+	return Keys{
+		EncryptionKey: output.Keys.EncryptionKey,
+		DecryptionKey: output.Keys.DecryptionKey,
+	}, meta, nil
+}
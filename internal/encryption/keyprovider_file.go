@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+)
+
+// fileKeyProvider reads a key from a local file, which is typically
+// provisioned by some external secret management system.
+//
+// The file is read each time a key is needed, so that the key can be
+// rotated without restarting OpenTofu.
+type fileKeyProvider struct {
+	path string
+
+	// encoding is one of "raw", "base64" or "hex".
+	encoding string
+}
+
+var _ KeyProvider = (*fileKeyProvider)(nil)
+
+func decodeFileKeyProvider(body hcl.Body) (KeyProvider, hcl.Diagnostics) {
+	var cfg struct {
+		Path     string  `hcl:"path"`
+		Encoding *string `hcl:"encoding"`
+	}
+	diags := gohcl.DecodeBody(body, nil, &cfg)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	ret := &fileKeyProvider{
+		path:     cfg.Path,
+		encoding: "raw",
+	}
+	if cfg.Encoding != nil {
+		ret.encoding = *cfg.Encoding
+	}
+
+	switch ret.encoding {
+	case "raw", "base64", "hex":
+	default:
+		var subject *hcl.Range
+		if attrs, _ := body.JustAttributes(); attrs["encoding"] != nil {
+			subject = attrs["encoding"].Expr.Range().Ptr()
+		}
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Unsupported key file encoding",
+			Detail:   `The key file encoding must be "raw", "base64" or "hex".`,
+			Subject:  subject,
+		})
+		return nil, diags
+	}
+
+	return ret, diags
+}
+
+func (p *fileKeyProvider) Provide(_ json.RawMessage) (Keys, json.RawMessage, error) {
+	src, err := os.ReadFile(p.path)
+	if err != nil {
+		return Keys{}, nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	var key []byte
+	switch p.encoding {
+	case "base64":
+		key, err = base64.StdEncoding.DecodeString(string(bytes.TrimSpace(src)))
+	case "hex":
+		key, err = hex.DecodeString(string(bytes.TrimSpace(src)))
+	default:
+		key = src
+	}
+	if err != nil {
+		return Keys{}, nil, fmt.Errorf("invalid %s encoding in key file %s: %w", p.encoding, p.path, err)
+	}
+	if len(key) == 0 {
+		return Keys{}, nil, fmt.Errorf("key file %s is empty", p.path)
+	}
+
+	return Keys{
+		EncryptionKey: key,
+		DecryptionKey: key,
+	}, nil, nil
+}
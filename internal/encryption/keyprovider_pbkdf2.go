@@ -0,0 +1,208 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/json"
+	"fmt"
+	"hash"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	pbkdf2MinPassphraseLength = 16
+	pbkdf2DefaultKeyLength    = 32
+	pbkdf2DefaultIterations   = 600000
+	pbkdf2MinIterations       = 200000
+	pbkdf2MaxIterations       = 10000000
+	pbkdf2DefaultSaltLength   = 32
+	pbkdf2DefaultHashFunction = "sha512"
+)
+
+var pbkdf2HashFunctions = map[string]func() hash.Hash{
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// pbkdf2ValidKeyLength returns true if n is the length in bytes of a key
+// that the aes_gcm method can use.
+func pbkdf2ValidKeyLength(n int) bool {
+	return n == 16 || n == 24 || n == 32
+}
+
+// pbkdf2KeyProvider derives keys from a passphrase using PBKDF2.
+//
+// A new random salt is generated each time data is encrypted, and recorded
+// in the key provider metadata along with the other derivation parameters
+// so that the same key can be derived again for decryption even if the
+// parameters in the configuration change later.
+type pbkdf2KeyProvider struct {
+	passphrase   string
+	keyLength    int
+	iterations   int
+	saltLength   int
+	hashFunction string
+}
+
+var _ KeyProvider = (*pbkdf2KeyProvider)(nil)
+
+// pbkdf2Meta is the metadata recorded alongside data encrypted with a key
+// from a pbkdf2KeyProvider.
+type pbkdf2Meta struct {
+	Salt         []byte `json:"salt"`
+	Iterations   int    `json:"iterations"`
+	HashFunction string `json:"hash_function"`
+	KeyLength    int    `json:"key_length"`
+}
+
+func decodePBKDF2KeyProvider(body hcl.Body) (KeyProvider, hcl.Diagnostics) {
+	var cfg struct {
+		Passphrase   string  `hcl:"passphrase"`
+		KeyLength    *int    `hcl:"key_length"`
+		Iterations   *int    `hcl:"iterations"`
+		SaltLength   *int    `hcl:"salt_length"`
+		HashFunction *string `hcl:"hash_function"`
+	}
+	diags := gohcl.DecodeBody(body, nil, &cfg)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	ret := &pbkdf2KeyProvider{
+		passphrase:   cfg.Passphrase,
+		keyLength:    pbkdf2DefaultKeyLength,
+		iterations:   pbkdf2DefaultIterations,
+		saltLength:   pbkdf2DefaultSaltLength,
+		hashFunction: pbkdf2DefaultHashFunction,
+	}
+	if cfg.KeyLength != nil {
+		ret.keyLength = *cfg.KeyLength
+	}
+	if cfg.Iterations != nil {
+		ret.iterations = *cfg.Iterations
+	}
+	if cfg.SaltLength != nil {
+		ret.saltLength = *cfg.SaltLength
+	}
+	if cfg.HashFunction != nil {
+		ret.hashFunction = *cfg.HashFunction
+	}
+
+	// We look up the attributes again only to report the ranges of
+	// invalid arguments.
+	attrs, _ := body.JustAttributes()
+	subject := func(name string) *hcl.Range {
+		if attr, ok := attrs[name]; ok {
+			return attr.Expr.Range().Ptr()
+		}
+		return nil
+	}
+
+	if len(ret.passphrase) < pbkdf2MinPassphraseLength {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Passphrase is too short",
+			Detail:   fmt.Sprintf("The passphrase for the pbkdf2 key provider must be at least %d characters long.", pbkdf2MinPassphraseLength),
+			Subject:  subject("passphrase"),
+		})
+	}
+	if !pbkdf2ValidKeyLength(ret.keyLength) {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid key length",
+			Detail:   "The key length must be 16, 24 or 32 bytes, which are the key lengths that AES supports.",
+			Subject:  subject("key_length"),
+		})
+	}
+	if ret.iterations < pbkdf2MinIterations {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Too few iterations",
+			Detail:   fmt.Sprintf("The pbkdf2 key provider requires at least %d iterations.", pbkdf2MinIterations),
+			Subject:  subject("iterations"),
+		})
+	}
+	if ret.iterations > pbkdf2MaxIterations {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Too many iterations",
+			Detail:   fmt.Sprintf("The pbkdf2 key provider allows at most %d iterations.", pbkdf2MaxIterations),
+			Subject:  subject("iterations"),
+		})
+	}
+	if ret.saltLength <= 0 {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid salt length",
+			Detail:   "The salt length must be a positive number of bytes.",
+			Subject:  subject("salt_length"),
+		})
+	}
+	if _, ok := pbkdf2HashFunctions[ret.hashFunction]; !ok {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Unsupported hash function",
+			Detail:   `The hash function must be either "sha256" or "sha512".`,
+			Subject:  subject("hash_function"),
+		})
+	}
+
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return ret, diags
+}
+
+func (p *pbkdf2KeyProvider) Provide(storedMeta json.RawMessage) (Keys, json.RawMessage, error) {
+	var keys Keys
+
+	// Key derivation is deliberately expensive, so when decrypting we
+	// derive only the decryption key.
+	if len(storedMeta) != 0 {
+		var meta pbkdf2Meta
+		if err := json.Unmarshal(storedMeta, &meta); err != nil {
+			return keys, nil, fmt.Errorf("invalid key provider metadata: %w", err)
+		}
+		// The metadata isn't authenticated, so we must not trust it with
+		// anything that the configuration wouldn't be allowed to specify.
+		hashFn, ok := pbkdf2HashFunctions[meta.HashFunction]
+		if !ok {
+			return keys, nil, fmt.Errorf("unsupported hash function %q in key provider metadata", meta.HashFunction)
+		}
+		if meta.Iterations < pbkdf2MinIterations || meta.Iterations > pbkdf2MaxIterations {
+			return keys, nil, fmt.Errorf("invalid number of iterations %d in key provider metadata, which must be between %d and %d", meta.Iterations, pbkdf2MinIterations, pbkdf2MaxIterations)
+		}
+		if !pbkdf2ValidKeyLength(meta.KeyLength) {
+			return keys, nil, fmt.Errorf("invalid key length %d in key provider metadata, which must be 16, 24 or 32", meta.KeyLength)
+		}
+		if len(meta.Salt) == 0 {
+			return keys, nil, fmt.Errorf("missing salt in key provider metadata")
+		}
+		keys.DecryptionKey = pbkdf2.Key([]byte(p.passphrase), meta.Salt, meta.Iterations, meta.KeyLength, hashFn)
+		return keys, storedMeta, nil
+	}
+
+	meta := pbkdf2Meta{
+		Salt:         make([]byte, p.saltLength),
+		Iterations:   p.iterations,
+		HashFunction: p.hashFunction,
+		KeyLength:    p.keyLength,
+	}
+	if _, err := rand.Read(meta.Salt); err != nil {
+		return keys, nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	keys.EncryptionKey = pbkdf2.Key([]byte(p.passphrase), meta.Salt, meta.Iterations, meta.KeyLength, pbkdf2HashFunctions[meta.HashFunction])
+
+	metaRaw, err := json.Marshal(meta)
+	if err != nil {
+		return keys, nil, err
+	}
+	return keys, metaRaw, nil
+}
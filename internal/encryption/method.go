@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+)
+
+// Method is an encryption algorithm that uses key material obtained from a
+// key provider.
+type Method interface {
+	// Encrypt encrypts the given data using the given key.
+	Encrypt(data, key []byte) ([]byte, error)
+
+	// Decrypt decrypts data previously returned from Encrypt, using the
+	// given key.
+	Decrypt(data, key []byte) ([]byte, error)
+}
+
+// methodTypes is the table of all available method types, mapping from the
+// type name used in configuration to a function that decodes a method of
+// that type from its configuration body.
+//
+// The "unencrypted" type is handled as a special case and so is not
+// included here.
+var methodTypes = map[string]func(body hcl.Body) (Method, hcl.Diagnostics){
+	"aes_gcm": decodeAESGCMMethod,
+}
+
+// methodInstance is a method that has been fully configured and associated
+// with a key provider.
+type methodInstance struct {
+	addr string
+
+	// impl is nil for an "unencrypted" method.
+	impl        Method
+	keyProvider *keyProviderInstance
+
+	declRange hcl.Range
+}
+
+// unencrypted returns true if the receiver is an "unencrypted" method,
+// which is used to explicitly allow reading or writing unencrypted data.
+func (m *methodInstance) unencrypted() bool {
+	return m != nil && m.impl == nil
+}
+
+func (m *methodInstance) decrypt(env *envelope) ([]byte, error) {
+	var meta json.RawMessage
+	if env.Meta != nil {
+		meta = env.Meta[m.keyProvider.addr]
+	}
+	keys, _, err := m.keyProvider.impl.Provide(meta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain decryption key from %s: %w", m.keyProvider.addr, err)
+	}
+	if len(keys.DecryptionKey) == 0 {
+		return nil, fmt.Errorf("%s did not provide a decryption key", m.keyProvider.addr)
+	}
+	return m.impl.Decrypt(env.EncryptedData, keys.DecryptionKey)
+}
+
+// methodKeysSchema is the part of the schema shared by all method types
+// other than "unencrypted", which selects the key provider.
+var methodKeysSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "keys", Required: true},
+	},
+}
+
+func decodeMethod(cfg *MethodConfig, keyProviders map[string]*keyProviderInstance) (*methodInstance, hcl.Diagnostics) {
+	ret := &methodInstance{
+		addr:      cfg.Addr(),
+		declRange: cfg.DeclRange,
+	}
+
+	if cfg.Type == "unencrypted" {
+		diags := gohcl.DecodeBody(cfg.Body, nil, &struct{}{})
+		return ret, diags
+	}
+
+	factory, ok := methodTypes[cfg.Type]
+	if !ok {
+		return nil, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Unsupported encryption method type",
+			Detail:   fmt.Sprintf("There is no encryption method of type %q.", cfg.Type),
+			Subject:  cfg.TypeRange.Ptr(),
+		}}
+	}
+
+	content, remain, diags := cfg.Body.PartialContent(methodKeysSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	keysExpr := content.Attributes["keys"].Expr
+	kpAddr, addrDiags := traversalAddr(keysExpr, "key_provider")
+	diags = append(diags, addrDiags...)
+	if !addrDiags.HasErrors() {
+		kp, ok := keyProviders[kpAddr]
+		if !ok {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Reference to undeclared key provider",
+				Detail:   fmt.Sprintf("There is no key provider %s declared in the encryption configuration.", kpAddr),
+				Subject:  keysExpr.Range().Ptr(),
+			})
+		}
+		ret.keyProvider = kp
+	}
+
+	impl, implDiags := factory(remain)
+	diags = append(diags, implDiags...)
+	ret.impl = impl
+
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return ret, diags
+}
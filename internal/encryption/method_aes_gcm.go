@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+)
+
+// aesGCMMethod encrypts data using AES in Galois/Counter Mode, which
+// provides both confidentiality and integrity.
+//
+// The key must be 16, 24 or 32 bytes long, selecting AES-128, AES-192 or
+// AES-256 respectively. A random nonce is generated for each encryption and
+// prepended to the ciphertext.
+type aesGCMMethod struct {
+	// aad is optional additional authenticated data, which must match
+	// between encryption and decryption but is not itself encrypted.
+	aad []byte
+}
+
+var _ Method = (*aesGCMMethod)(nil)
+
+func decodeAESGCMMethod(body hcl.Body) (Method, hcl.Diagnostics) {
+	var cfg struct {
+		AAD *string `hcl:"aad"`
+	}
+	diags := gohcl.DecodeBody(body, nil, &cfg)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	ret := &aesGCMMethod{}
+	if cfg.AAD != nil {
+		ret.aad = []byte(*cfg.AAD)
+	}
+	return ret, diags
+}
+
+func (m *aesGCMMethod) Encrypt(data, key []byte) ([]byte, error) {
+	gcm, err := m.cipher(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return gcm.Seal(nonce, nonce, data, m.aad), nil
+}
+
+func (m *aesGCMMethod) Decrypt(data, key []byte) ([]byte, error) {
+	gcm, err := m.cipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("encrypted data is too short")
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	decrypted, err := gcm.Open(nil, nonce, ciphertext, m.aad)
+	if err != nil {
+		// The error from crypto/cipher is intentionally vague, so we'll
+		// give a hint about the most likely cause.
+		return nil, fmt.Errorf("%w (the key is probably incorrect)", err)
+	}
+	return decrypted, nil
+}
+
+func (m *aesGCMMethod) cipher(key []byte) (cipher.AEAD, error) {
+	switch len(key) {
+	case 16, 24, 32:
+	default:
+		return nil, fmt.Errorf("AES-GCM requires a key of 16, 24 or 32 bytes, but the key provider returned %d bytes", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package encryption
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// envelopeVersion is the format version recorded in every encrypted
+// envelope, so that future versions of OpenTofu can change the format.
+const envelopeVersion = "v0"
+
+// envelope is the serialized form of an encrypted artifact.
+//
+// The key provider metadata is recorded in the clear so that key providers
+// which derive keys from some additional input, such as a salt, can
+// reproduce the same key when decrypting.
+type envelope struct {
+	Meta          map[string]json.RawMessage `json:"meta"`
+	EncryptedData []byte                     `json:"encrypted_data"`
+	Version       string                     `json:"encryption_version"`
+}
+
+// parseEnvelope attempts to interpret the given data as an encrypted
+// envelope, returning nil if it doesn't seem to be one.
+func parseEnvelope(data []byte) *envelope {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil
+	}
+	if env.Version == "" {
+		return nil
+	}
+	return &env
+}
+
+// target is the fully-resolved configuration for encrypting one kind of
+// artifact. A nil *target represents that encryption is not configured.
+type target struct {
+	primary  *methodInstance
+	fallback *methodInstance
+}
+
+func newTarget(name string, cfg *TargetConfig, methods map[string]*methodInstance) (*target, hcl.Diagnostics) {
+	if cfg == nil {
+		return nil, nil
+	}
+	var diags hcl.Diagnostics
+
+	primary, primaryDiags := resolveMethod(cfg.Method, methods)
+	diags = append(diags, primaryDiags...)
+	if primary != nil && primary.unencrypted() && cfg.Enforced {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Unencrypted method is forbidden",
+			Detail:   fmt.Sprintf("The %s encryption is enforced, so it cannot use %s, which does not encrypt data.", name, primary.addr),
+			Subject:  cfg.Method.Range().Ptr(),
+		})
+	}
+
+	var fallback *methodInstance
+	if cfg.Fallback != nil {
+		var fallbackDiags hcl.Diagnostics
+		fallback, fallbackDiags = resolveMethod(cfg.Fallback.Method, methods)
+		diags = append(diags, fallbackDiags...)
+	}
+
+	return &target{
+		primary:  primary,
+		fallback: fallback,
+	}, diags
+}
+
+func resolveMethod(expr hcl.Expression, methods map[string]*methodInstance) (*methodInstance, hcl.Diagnostics) {
+	if expr == nil {
+		// Missing required argument, already reported during decoding.
+		return nil, nil
+	}
+	addr, diags := traversalAddr(expr, "method")
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	m, ok := methods[addr]
+	if !ok {
+		return nil, diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Reference to undeclared encryption method",
+			Detail:   fmt.Sprintf("There is no encryption method %s declared in the encryption configuration.", addr),
+			Subject:  expr.Range().Ptr(),
+		})
+	}
+	return m, diags
+}
+
+// traversalAddr converts a static reference like method.aes_gcm.example
+// into its string form, checking that it refers to the given kind of object.
+func traversalAddr(expr hcl.Expression, kind string) (string, hcl.Diagnostics) {
+	invalid := hcl.Diagnostics{{
+		Severity: hcl.DiagError,
+		Summary:  "Invalid reference",
+		Detail:   fmt.Sprintf("Expected a reference of the form %s.TYPE.NAME.", kind),
+		Subject:  expr.Range().Ptr(),
+	}}
+
+	traversal, diags := hcl.AbsTraversalForExpr(expr)
+	if diags.HasErrors() {
+		return "", invalid
+	}
+	if len(traversal) != 3 || traversal.RootName() != kind {
+		return "", invalid
+	}
+	typeStep, typeOk := traversal[1].(hcl.TraverseAttr)
+	nameStep, nameOk := traversal[2].(hcl.TraverseAttr)
+	if !typeOk || !nameOk {
+		return "", invalid
+	}
+	return kind + "." + typeStep.Name + "." + nameStep.Name, nil
+}
+
+// enabled returns true if encrypt actually encrypts data.
+func (t *target) enabled() bool {
+	return t != nil && t.primary != nil && !t.primary.unencrypted()
+}
+
+func (t *target) encrypt(data []byte) ([]byte, error) {
+	if !t.enabled() {
+		return data, nil
+	}
+
+	m := t.primary
+	keys, meta, err := m.keyProvider.impl.Provide(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain encryption key from %s: %w", m.keyProvider.addr, err)
+	}
+	if len(keys.EncryptionKey) == 0 {
+		return nil, fmt.Errorf("%s did not provide an encryption key", m.keyProvider.addr)
+	}
+	encrypted, err := m.impl.Encrypt(data, keys.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt using %s: %w", m.addr, err)
+	}
+
+	env := envelope{
+		Meta:          map[string]json.RawMessage{},
+		EncryptedData: encrypted,
+		Version:       envelopeVersion,
+	}
+	if meta != nil {
+		env.Meta[m.keyProvider.addr] = meta
+	}
+	return json.Marshal(env)
+}
+
+func (t *target) decrypt(name string, data []byte) ([]byte, error) {
+	env := parseEnvelope(data)
+
+	if t == nil {
+		if env != nil {
+			return nil, fmt.Errorf("the %s is encrypted, but no %s encryption is configured", name, name)
+		}
+		return data, nil
+	}
+
+	if env == nil {
+		if t.primary.unencrypted() || t.fallback.unencrypted() {
+			return data, nil
+		}
+		return nil, fmt.Errorf("the %s is not encrypted; to migrate unencrypted %s, add an unencrypted method as the fallback method for %s encryption", name, name, name)
+	}
+	if env.Version != envelopeVersion {
+		return nil, fmt.Errorf("the %s uses unsupported encryption format version %q", name, env.Version)
+	}
+
+	var errs []error
+	for _, m := range []*methodInstance{t.primary, t.fallback} {
+		if m == nil || m.unencrypted() {
+			continue
+		}
+		decrypted, err := m.decrypt(env)
+		if err == nil {
+			return decrypted, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", m.addr, err))
+	}
+	return nil, fmt.Errorf("failed to decrypt %s: %w", name, errors.Join(errs...))
+}
@@ -0,0 +1,8 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package flock implements exclusive advisory locks on files, for
+// coordinating changes to directories that are shared between concurrent
+// OpenTofu processes, such as the global plugin and module cache
+// directories.
+package flock
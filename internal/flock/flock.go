@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package flock
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// processLocks serializes the locks taken by different goroutines in this
+// process, because the underlying operating system locks don't necessarily
+// exclude other holders in the same process. Each is a channel with a
+// buffer of one, so that waiting for it can be cancelled.
+var (
+	processLocksMu sync.Mutex
+	processLocks   = make(map[string]chan struct{})
+)
+
+// The interval between attempts to take a lock that another process holds
+// starts at minRetryInterval and doubles up to maxRetryInterval.
+const (
+	minRetryInterval = 10 * time.Millisecond
+	maxRetryInterval = 500 * time.Millisecond
+)
+
+// Lock blocks until it holds an exclusive lock on the file at the given path,
+// creating the file first if it doesn't already exist, and then returns a
+// function that releases the lock.
+//
+// If the lock is held elsewhere, Lock logs which lock it's waiting for and
+// then waits until it can take the lock or until the given context is
+// cancelled, in which case it returns an error wrapping the context's error.
+//
+// The lock is advisory: it excludes only other callers of Lock for the same
+// path, whether in this process or in another process, and doesn't prevent
+// anything from accessing the file or the directory it protects.
+func Lock(ctx context.Context, path string) (unlock func() error, err error) {
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	processLocksMu.Lock()
+	sem, ok := processLocks[path]
+	if !ok {
+		sem = make(chan struct{}, 1)
+		processLocks[path] = sem
+	}
+	processLocksMu.Unlock()
+	select {
+	case sem <- struct{}{}:
+	default:
+		log.Printf("[TRACE] flock: waiting for another goroutine to release %s", path)
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("gave up waiting for lock %s: %w", path, ctx.Err())
+		}
+	}
+	release := func() { <-sem }
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+	log.Printf("[TRACE] flock: locking %s", path)
+	if err := waitLockFile(ctx, f); err != nil {
+		f.Close()
+		release()
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+
+	return func() error {
+		log.Printf("[TRACE] flock: unlocking %s", path)
+		err := unlockFile(f)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		release()
+		return err
+	}, nil
+}
+
+// waitLockFile repeatedly tries to lock the given file until it succeeds or
+// the given context is cancelled. We poll rather than using the blocking
+// lock operations because those can't be interrupted.
+func waitLockFile(ctx context.Context, f *os.File) error {
+	interval := minRetryInterval
+	for {
+		locked, err := tryLockFile(f)
+		if err != nil {
+			return err
+		}
+		if locked {
+			return nil
+		}
+		if interval == minRetryInterval {
+			log.Printf("[INFO] flock: waiting for another process to release the lock on %s", f.Name())
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("gave up waiting for another process to release it: %w", ctx.Err())
+		}
+		interval = min(interval*2, maxRetryInterval)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package flock
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLock(t *testing.T) {
+	dir := t.TempDir()
+	lockPath := filepath.Join(dir, "counter.lock")
+	counterPath := filepath.Join(dir, "counter")
+	if err := os.WriteFile(counterPath, []byte("0"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each goroutine increments the counter by reading and then rewriting
+	// the file, which loses updates unless the lock excludes the others.
+	const goroutines, increments = 8, 25
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < increments; j++ {
+				unlock, err := Lock(context.Background(), lockPath)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				raw, err := os.ReadFile(counterPath)
+				if err == nil {
+					n, _ := strconv.Atoi(string(raw))
+					err = os.WriteFile(counterPath, []byte(strconv.Itoa(n+1)), 0644)
+				}
+				if err != nil {
+					t.Error(err)
+				}
+				if err := unlock(); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	raw, err := os.ReadFile(counterPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(raw), strconv.Itoa(goroutines*increments); got != want {
+		t.Errorf("wrong count %s; want %s", got, want)
+	}
+}
+
+func TestLock_cancel(t *testing.T) {
+	lockPath := filepath.Join(t.TempDir(), "held.lock")
+	unlock, err := Lock(context.Background(), lockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := Lock(ctx, lockPath); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("wrong error %v; want %s", err, context.DeadlineExceeded)
+	}
+
+	// The cancelled attempt must not have disturbed the lock, so it can
+	// still be released and then taken again.
+	if err := unlock(); err != nil {
+		t.Fatal(err)
+	}
+	unlock, err = Lock(context.Background(), lockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := unlock(); err != nil {
+		t.Fatal(err)
+	}
+}
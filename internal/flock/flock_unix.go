@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !windows
+// +build !windows
+
+package flock
+
+import (
+	"errors"
+	"io"
+	"os"
+	"syscall"
+)
+
+// We use fcntl POSIX locks, as statemgr.Filesystem does, for the most
+// consistent behavior across platforms and hopefully some compatibility
+// over NFS and CIFS.
+func tryLockFile(f *os.File) (bool, error) {
+	flock := &syscall.Flock_t{
+		Type:   syscall.F_WRLCK,
+		Whence: int16(io.SeekStart),
+		Start:  0,
+		Len:    0,
+	}
+	for {
+		err := syscall.FcntlFlock(f.Fd(), syscall.F_SETLK, flock)
+		switch {
+		case err == nil:
+			return true, nil
+		case errors.Is(err, syscall.EAGAIN) || errors.Is(err, syscall.EACCES):
+			// Another process holds the lock.
+			return false, nil
+		case !errors.Is(err, syscall.EINTR):
+			return false, err
+		}
+	}
+}
+
+func unlockFile(f *os.File) error {
+	flock := &syscall.Flock_t{
+		Type:   syscall.F_UNLCK,
+		Whence: int16(io.SeekStart),
+		Start:  0,
+		Len:    0,
+	}
+	return syscall.FcntlFlock(f.Fd(), syscall.F_SETLK, flock)
+}
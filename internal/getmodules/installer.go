@@ -5,6 +5,11 @@ package getmodules
 
 import (
 	"context"
+
+	getter "github.com/hashicorp/go-getter"
+	svcauth "github.com/hashicorp/terraform-svchost/auth"
+
+	"github.com/opentofu/opentofu/internal/oci"
 )
 
 // PackageFetcher is a low-level utility for fetching remote module packages
@@ -21,12 +26,31 @@ import (
 // no way to reset this cache, so a particular PackageFetcher instance should
 // live only for the duration of a single initialization process.
 type PackageFetcher struct {
-	getter reusingGetter
+	getter  reusingGetter
+	getters map[string]getter.Getter
+	oci     *ociGetter
+}
+
+// NewPackageFetcher returns a new PackageFetcher.
+//
+// If ociCreds is not nil then any credentials it has for the hostname of an
+// OCI registry are used to authenticate requests for module packages from
+// that registry.
+func NewPackageFetcher(ociCreds svcauth.CredentialsSource) *PackageFetcher {
+	return newPackageFetcherWithOCIClient(oci.NewClient(nil, ociCreds))
 }
 
-func NewPackageFetcher() *PackageFetcher {
+func newPackageFetcherWithOCIClient(ociClient *oci.Client) *PackageFetcher {
+	getters := make(map[string]getter.Getter, len(goGetterGetters)+1)
+	for scheme, g := range goGetterGetters {
+		getters[scheme] = g
+	}
+	g := &ociGetter{client: ociClient}
+	getters["oci"] = g
 	return &PackageFetcher{
-		getter: reusingGetter{},
+		getter:  reusingGetter{},
+		getters: getters,
+		oci:     g,
 	}
 }
 
@@ -43,5 +67,21 @@ func NewPackageFetcher() *PackageFetcher {
 // caller must resolve that itself, possibly with the help of the
 // getmodules.SplitPackageSubdir and getmodules.ExpandSubdirGlobs functions.
 func (f *PackageFetcher) FetchPackage(ctx context.Context, instDir string, packageAddr string) error {
-	return f.getter.getWithGoGetter(ctx, instDir, packageAddr)
+	return f.getter.getWithGoGetter(ctx, instDir, packageAddr, f.getters)
+}
+
+// OCIManifestDigest returns the digest of the manifest that the package at
+// the given oci: address was installed from by an earlier call to
+// FetchPackage, or an empty string if no package was fetched from that
+// address or if it isn't an oci: address.
+//
+// A package address that selects a manifest by tag can refer to different
+// manifests over time, so the module installer records this digest in the
+// dependency lock file.
+func (f *PackageFetcher) OCIManifestDigest(packageAddr string) string {
+	dir, ok := f.getter[packageAddr]
+	if !ok {
+		return ""
+	}
+	return string(f.oci.manifestDigest(dir))
 }
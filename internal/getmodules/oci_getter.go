@@ -0,0 +1,179 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package getmodules
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	getter "github.com/hashicorp/go-getter"
+
+	"github.com/opentofu/opentofu/internal/oci"
+)
+
+// These are the artifact and media types used for module packages stored in
+// an OCI registry.
+//
+// Each module package is an OCI image manifest of artifact type
+// OCIModuleArtifactType, which has an archive of the package as its only
+// layer, of media type OCIModuleArchiveMediaType.
+const (
+	OCIModuleArtifactType     = "application/vnd.opentofu.modulepkg"
+	OCIModuleArchiveMediaType = "archive/zip"
+)
+
+// ociGetter is a go-getter getter for module packages stored in an OCI
+// registry, using source addresses like these:
+//
+//	oci://example.com/modules/network?tag=1.0.0
+//	oci://example.com/modules/network?digest=sha256:...
+//
+// If neither a tag nor a digest is given then the "latest" tag is used.
+type ociGetter struct {
+	client *oci.Client
+
+	getterClient *getter.Client
+
+	// digests records the digest of the manifest of each package that was
+	// fetched, by the directory it was fetched into, so that packages
+	// selected by tag can be locked to the manifest that was installed.
+	digestsMu sync.Mutex
+	digests   map[string]oci.Digest
+}
+
+var _ getter.Getter = (*ociGetter)(nil)
+
+func (g *ociGetter) ClientMode(u *url.URL) (getter.ClientMode, error) {
+	return getter.ClientModeDir, nil
+}
+
+func (g *ociGetter) Get(dst string, u *url.URL) error {
+	ctx := context.Background()
+	if g.getterClient != nil && g.getterClient.Ctx != nil {
+		ctx = g.getterClient.Ctx
+	}
+
+	repo, reference, err := parseOCIModuleSource(u)
+	if err != nil {
+		return err
+	}
+
+	manifest, digest, err := g.client.Manifest(ctx, repo, reference)
+	if err != nil {
+		return err
+	}
+	if manifest.ArtifactType != OCIModuleArtifactType {
+		return fmt.Errorf("%s in %s has artifact type %q, but a module package must have artifact type %q", reference, repo, manifest.ArtifactType, OCIModuleArtifactType)
+	}
+	layers := manifest.LayersOfType(OCIModuleArchiveMediaType)
+	if len(layers) != 1 {
+		return fmt.Errorf("%s in %s must have exactly one layer of type %q", reference, repo, OCIModuleArchiveMediaType)
+	}
+	log.Printf("[TRACE] getmodules: fetching %s %s from %s, with manifest digest %s", OCIModuleArtifactType, reference, repo, digest)
+
+	f, err := os.CreateTemp("", "tofu-modulepkg")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	// The client verifies the blob's content against the digest in the
+	// manifest, and the manifest itself against its own digest, so the
+	// package is authenticated by its manifest digest.
+	if err := g.client.Blob(ctx, repo, layers[0], f); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if err := new(getter.ZipDecompressor).Decompress(dst, f.Name(), true, 0); err != nil {
+		return err
+	}
+
+	g.digestsMu.Lock()
+	defer g.digestsMu.Unlock()
+	if g.digests == nil {
+		g.digests = make(map[string]oci.Digest)
+	}
+	g.digests[dst] = digest
+	return nil
+}
+
+func (g *ociGetter) GetFile(dst string, u *url.URL) error {
+	return fmt.Errorf("OCI module sources can only be used to install whole module packages")
+}
+
+func (g *ociGetter) SetClient(c *getter.Client) {
+	g.getterClient = c
+}
+
+// manifestDigest returns the digest of the manifest of the package that was
+// fetched into the given directory, or an empty string if no package was
+// fetched into it.
+func (g *ociGetter) manifestDigest(dir string) oci.Digest {
+	g.digestsMu.Lock()
+	defer g.digestsMu.Unlock()
+	return g.digests[dir]
+}
+
+// OCIPackageAtDigest returns the address of the manifest with the given
+// digest in the repository of the given oci: package address, replacing
+// any tag or digest that the address has.
+//
+// The module installer uses this to install exactly the package that was
+// recorded in the dependency lock file, even if the source address selects
+// it by a tag that has since moved.
+func OCIPackageAtDigest(packageAddr string, digest string) (string, error) {
+	u, err := url.Parse(packageAddr)
+	if err != nil || u.Scheme != "oci" {
+		return "", fmt.Errorf("%q is not an OCI module source", packageAddr)
+	}
+	if _, err := oci.ParseDigest(digest); err != nil {
+		return "", err
+	}
+	// A valid digest has no characters that need escaping, and we leave
+	// its colon unescaped to match how digests are written in sources.
+	u.RawQuery = "digest=" + digest
+	return u.String(), nil
+}
+
+// parseOCIModuleSource returns the repository and the tag or digest of the
+// manifest that the given oci: source address refers to.
+func parseOCIModuleSource(u *url.URL) (oci.Repository, string, error) {
+	repo, err := oci.ParseRepository(u.Host + u.Path)
+	if err != nil {
+		return oci.Repository{}, "", fmt.Errorf("invalid OCI module source %q: %w", u, err)
+	}
+
+	query := u.Query()
+	for name := range query {
+		if name != "tag" && name != "digest" {
+			return oci.Repository{}, "", fmt.Errorf("invalid OCI module source %q: unsupported argument %q", u, name)
+		}
+	}
+	tag, digest := query.Get("tag"), query.Get("digest")
+	switch {
+	case tag != "" && digest != "":
+		return oci.Repository{}, "", fmt.Errorf("invalid OCI module source %q: must not have both a tag and a digest", u)
+	case digest != "":
+		if _, err := oci.ParseDigest(digest); err != nil {
+			return oci.Repository{}, "", fmt.Errorf("invalid OCI module source %q: %w", u, err)
+		}
+		return repo, digest, nil
+	case tag != "":
+		if !oci.ValidTag(tag) || strings.Contains(tag, ":") {
+			return oci.Repository{}, "", fmt.Errorf("invalid OCI module source %q: invalid tag %q", u, tag)
+		}
+		return repo, tag, nil
+	default:
+		return repo, "latest", nil
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package getmodules
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/oci/ocitest"
+)
+
+func TestPackageFetcher_oci(t *testing.T) {
+	registry := ocitest.NewRegistry(t)
+	pkg := testZip(t, map[string]string{
+		"main.tf":        `output "hello" { value = "world" }`,
+		"child/child.tf": `# child module`,
+	})
+	desc := registry.PushArtifact("modules/hello", OCIModuleArtifactType, OCIModuleArchiveMediaType, pkg, "1.0.0", "latest")
+	registry.PushArtifact("modules/other", "application/vnd.example", OCIModuleArchiveMediaType, pkg, "1.0.0")
+
+	tests := map[string]string{
+		"tag":     "oci://" + registry.Host() + "/modules/hello?tag=1.0.0",
+		"digest":  "oci://" + registry.Host() + "/modules/hello?digest=" + string(desc.Digest),
+		"default": "oci://" + registry.Host() + "/modules/hello",
+	}
+	for name, addr := range tests {
+		t.Run(name, func(t *testing.T) {
+			fetcher := newPackageFetcherWithOCIClient(registry.Client())
+			instDir := filepath.Join(t.TempDir(), "pkg")
+			if err := fetcher.FetchPackage(context.Background(), instDir, addr); err != nil {
+				t.Fatal(err)
+			}
+			for _, name := range []string{"main.tf", "child/child.tf"} {
+				if _, err := os.Stat(filepath.Join(instDir, name)); err != nil {
+					t.Errorf("missing %s: %s", name, err)
+				}
+			}
+			if got, want := fetcher.OCIManifestDigest(addr), string(desc.Digest); got != want {
+				t.Errorf("wrong manifest digest %q; want %q", got, want)
+			}
+		})
+	}
+
+	errorTests := map[string]string{
+		"oci://" + registry.Host() + "/modules/hello?tag=2.0.0":               "Not Found",
+		"oci://" + registry.Host() + "/modules/other?tag=1.0.0":               `but a module package must have artifact type "application/vnd.opentofu.modulepkg"`,
+		"oci://" + registry.Host() + "/modules/hello?tag=1.0.0&digest=sha256": "must not have both a tag and a digest",
+		"oci://" + registry.Host() + "/modules/hello?version=1.0.0":           `unsupported argument "version"`,
+	}
+	for addr, wantErr := range errorTests {
+		t.Run(addr, func(t *testing.T) {
+			fetcher := newPackageFetcherWithOCIClient(registry.Client())
+			err := fetcher.FetchPackage(context.Background(), filepath.Join(t.TempDir(), "pkg"), addr)
+			if err == nil || !strings.Contains(err.Error(), wantErr) {
+				t.Fatalf("wrong error: %v; want %q", err, wantErr)
+			}
+		})
+	}
+}
+
+func TestOCIPackageAtDigest(t *testing.T) {
+	const digest = "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	tests := map[string]string{
+		"oci://example.com/modules/network":                        "oci://example.com/modules/network?digest=" + digest,
+		"oci://example.com/modules/network?tag=1.0.0":              "oci://example.com/modules/network?digest=" + digest,
+		"oci://example.com/modules/network?digest=sha256:00000000": "oci://example.com/modules/network?digest=" + digest,
+	}
+	for addr, want := range tests {
+		got, err := OCIPackageAtDigest(addr, digest)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", addr, err)
+		} else if got != want {
+			t.Errorf("%s: wrong result %q; want %q", addr, got, want)
+		}
+	}
+
+	if _, err := OCIPackageAtDigest("git::https://example.com/network.git", digest); err == nil {
+		t.Error("unexpected success for a git source")
+	}
+	if _, err := OCIPackageAtDigest("oci://example.com/modules/network", "sha256:nope"); err == nil {
+		t.Error("unexpected success for an invalid digest")
+	}
+}
+
+func testZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
@@ -153,6 +153,30 @@ func (err ErrPlatformNotSupported) Error() string {
 	)
 }
 
+// ErrSigstoreUnsupported is an error type used to indicate that a provider
+// package must be verified using a sigstore bundle, but the source that was
+// asked for it has no way to obtain one.
+//
+// MultiSource treats this like ErrPlatformNotSupported, so that another
+// source can still offer the package, but reports it if none of them do.
+type ErrSigstoreUnsupported struct {
+	Provider addrs.Provider
+	Version  Version
+
+	// Source describes the source that can't verify the package, as
+	// returned by its ForDisplay method.
+	Source string
+}
+
+func (err ErrSigstoreUnsupported) Error() string {
+	return fmt.Sprintf(
+		"provider %s %s must be verified using a sigstore bundle, which %s does not support; use the \"direct\" installation method for this provider instead",
+		err.Provider,
+		err.Version,
+		err.Source,
+	)
+}
+
 // ErrProtocolNotSupported is an error type used to indicate that a particular
 // version of a provider is not supported by the current version of OpenTofu.
 //
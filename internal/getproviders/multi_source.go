@@ -89,6 +89,7 @@ func (s MultiSource) PackageMeta(ctx context.Context, provider addrs.Provider, v
 		return PackageMeta{}, ErrProviderNotFound{provider, s.sourcesForProvider(provider)}
 	}
 
+	var sigstoreErr error
 	for _, selector := range s {
 		if !selector.CanHandleProvider(provider) {
 			continue // doesn't match the given patterns
@@ -99,11 +100,22 @@ func (s MultiSource) PackageMeta(ctx context.Context, provider addrs.Provider, v
 			return meta, nil
 		case ErrProviderNotFound, ErrRegistryProviderNotKnown, ErrPlatformNotSupported:
 			continue // ignore, then
+		case ErrSigstoreUnsupported:
+			// A later source might be able to verify the package, but if
+			// none of them have it then this is the more useful error.
+			if sigstoreErr == nil {
+				sigstoreErr = err
+			}
+			continue
 		default:
 			return PackageMeta{}, err
 		}
 	}
 
+	if sigstoreErr != nil {
+		return PackageMeta{}, sigstoreErr
+	}
+
 	// If we fall out here then none of the sources have the requested
 	// package.
 	return PackageMeta{}, ErrPlatformNotSupported{
@@ -0,0 +1,217 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package getproviders
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	svchost "github.com/hashicorp/terraform-svchost"
+	svcauth "github.com/hashicorp/terraform-svchost/auth"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/oci"
+)
+
+// These are the artifact and media types used for provider packages stored
+// in an OCI registry.
+//
+// Each provider version is an OCI image index of artifact type
+// OCIProviderArtifactType, tagged with the version number. The index refers
+// to one image manifest of artifact type OCIProviderTargetArtifactType per
+// supported platform, each of which has the provider's distribution archive
+// as its only layer, of media type OCIProviderArchiveMediaType.
+const (
+	OCIProviderArtifactType       = "application/vnd.opentofu.provider"
+	OCIProviderTargetArtifactType = "application/vnd.opentofu.provider-target"
+	OCIProviderArchiveMediaType   = "archive/zip"
+)
+
+// OCIMirrorSource is a source that reads provider packages from repositories
+// in an OCI registry, such as a container registry.
+type OCIMirrorSource struct {
+	repositoryTemplate string
+	client             *oci.Client
+}
+
+var _ Source = (*OCIMirrorSource)(nil)
+
+// NewOCIMirrorSource constructs and returns a new OCI mirror source which
+// finds the repository for each provider using the given template, which
+// must refer to both of the placeholders ${namespace} and ${type}, and may
+// also refer to ${hostname}. For example:
+//
+//	example.com/opentofu-providers/${namespace}/${type}
+//
+// If creds is not nil then any credentials it has for the registry's
+// hostname are used to authenticate requests to the registry.
+func NewOCIMirrorSource(repositoryTemplate string, creds svcauth.CredentialsSource) *OCIMirrorSource {
+	return newOCIMirrorSourceWithClient(repositoryTemplate, oci.NewClient(nil, creds))
+}
+
+func newOCIMirrorSourceWithClient(repositoryTemplate string, client *oci.Client) *OCIMirrorSource {
+	return &OCIMirrorSource{
+		repositoryTemplate: repositoryTemplate,
+		client:             client,
+	}
+}
+
+// ValidateOCIRepositoryTemplate returns an error if the given string isn't
+// a valid repository template for NewOCIMirrorSource.
+func ValidateOCIRepositoryTemplate(template string) error {
+	for _, placeholder := range []string{"${namespace}", "${type}"} {
+		if !strings.Contains(template, placeholder) {
+			return fmt.Errorf("the repository template must include %s, so that each provider has its own repository", placeholder)
+		}
+	}
+	_, err := oci.ParseRepository(expandOCIRepositoryTemplate(template, addrs.NewDefaultProvider("placeholder")))
+	return err
+}
+
+// AvailableVersions returns the versions of the given provider that have a
+// tag in the provider's repository.
+func (s *OCIMirrorSource) AvailableVersions(ctx context.Context, provider addrs.Provider) (VersionList, Warnings, error) {
+	repo, err := s.repository(provider)
+	if err != nil {
+		return nil, nil, ErrQueryFailed{Provider: provider, Wrapped: err}
+	}
+	log.Printf("[DEBUG] Querying available versions of provider %s at OCI repository %s", provider, repo)
+
+	tags, err := s.client.Tags(ctx, repo)
+	if err != nil {
+		switch {
+		case oci.IsNotFound(err):
+			return nil, nil, ErrProviderNotFound{Provider: provider}
+		case oci.IsUnauthorized(err):
+			// The registry hostname was already validated when we parsed
+			// the repository address, so this should not fail.
+			hostname, _ := svchost.ForComparison(repo.Registry)
+			return nil, nil, ErrUnauthorized{Hostname: hostname, HaveCredentials: true}
+		}
+		return nil, nil, s.errQueryFailed(provider, repo, err)
+	}
+
+	var ret VersionList
+	for _, tag := range tags {
+		// Tags can't contain "+", so build metadata is written with "_"
+		// instead. Tags that aren't versions at all, like "latest", are
+		// ignored.
+		version, err := ParseVersion(strings.ReplaceAll(tag, "_", "+"))
+		if err != nil {
+			log.Printf("[TRACE] Ignoring tag %q in OCI repository %s because it isn't a version number", tag, repo)
+			continue
+		}
+		ret = append(ret, version)
+	}
+	ret.Sort()
+	return ret, nil, nil
+}
+
+// PackageMeta finds the package for the given provider version and platform
+// in the index tagged with the version number.
+func (s *OCIMirrorSource) PackageMeta(ctx context.Context, provider addrs.Provider, version Version, target Platform) (PackageMeta, error) {
+	repo, err := s.repository(provider)
+	if err != nil {
+		return PackageMeta{}, ErrQueryFailed{Provider: provider, Wrapped: err}
+	}
+	log.Printf("[DEBUG] Finding package for %s v%s on %s in OCI repository %s", provider, version, target, repo)
+
+	tag := strings.ReplaceAll(version.String(), "+", "_")
+	index, _, err := s.client.Index(ctx, repo, tag)
+	if err != nil {
+		if oci.IsNotFound(err) {
+			return PackageMeta{}, s.errQueryFailed(provider, repo, fmt.Errorf("repository has no index for version %s", version))
+		}
+		return PackageMeta{}, s.errQueryFailed(provider, repo, err)
+	}
+	if index.ArtifactType != "" && index.ArtifactType != OCIProviderArtifactType {
+		return PackageMeta{}, s.errQueryFailed(provider, repo, fmt.Errorf("version %s has artifact type %q, but expected %q", version, index.ArtifactType, OCIProviderArtifactType))
+	}
+
+	// We fetch the manifests for all of the platforms, not just the one we
+	// need, so that the digests of all of the packages can be recorded in
+	// the dependency lock file.
+	var archive *oci.Descriptor
+	var allHashes []Hash
+	for _, desc := range index.Manifests {
+		if desc.Platform == nil || desc.ArtifactType != OCIProviderTargetArtifactType {
+			continue
+		}
+		manifest, _, err := s.client.Manifest(ctx, repo, string(desc.Digest))
+		if err != nil {
+			return PackageMeta{}, s.errQueryFailed(provider, repo, err)
+		}
+		layers := manifest.LayersOfType(OCIProviderArchiveMediaType)
+		if len(layers) != 1 {
+			return PackageMeta{}, s.errQueryFailed(provider, repo, fmt.Errorf("manifest %s must have exactly one layer of type %q", desc.Digest, OCIProviderArchiveMediaType))
+		}
+		if _, err := oci.ParseDigest(string(layers[0].Digest)); err != nil {
+			return PackageMeta{}, s.errQueryFailed(provider, repo, fmt.Errorf("manifest %s has an invalid layer: %w", desc.Digest, err))
+		}
+		allHashes = append(allHashes, HashLegacyZipSHAFromSHA(layers[0].Digest.SHA256()))
+
+		platform := Platform{OS: desc.Platform.OS, Arch: desc.Platform.Architecture}
+		if platform == target {
+			archive = &layers[0]
+		}
+	}
+	if archive == nil {
+		return PackageMeta{}, ErrPlatformNotSupported{
+			Provider:  provider,
+			Version:   version,
+			Platform:  target,
+			MirrorURL: repo.URL(),
+		}
+	}
+
+	return PackageMeta{
+		Provider:       provider,
+		Version:        version,
+		TargetPlatform: target,
+
+		Location: PackageOCIBlob{
+			Client:     s.client,
+			Repository: repo,
+			Blob:       *archive,
+		},
+		Filename:       fmt.Sprintf("terraform-provider-%s_%s_%s.zip", provider.Type, version, target),
+		Authentication: NewOCIDigestAuthentication(target, archive.Digest.SHA256(), allHashes),
+	}, nil
+}
+
+// ForDisplay returns a string description of the source for user-facing output.
+func (s *OCIMirrorSource) ForDisplay(provider addrs.Provider) string {
+	repo, err := s.repository(provider)
+	if err != nil {
+		return "OCI mirror " + s.repositoryTemplate
+	}
+	return "OCI mirror at " + repo.URL().String()
+}
+
+func (s *OCIMirrorSource) repository(provider addrs.Provider) (oci.Repository, error) {
+	return oci.ParseRepository(expandOCIRepositoryTemplate(s.repositoryTemplate, provider))
+}
+
+func (s *OCIMirrorSource) errQueryFailed(provider addrs.Provider, repo oci.Repository, err error) error {
+	if err == context.Canceled {
+		// This one has a special error type so that callers can
+		// handle it in a different way.
+		return ErrRequestCanceled{}
+	}
+	return ErrQueryFailed{
+		Provider:  provider,
+		Wrapped:   err,
+		MirrorURL: repo.URL(),
+	}
+}
+
+func expandOCIRepositoryTemplate(template string, provider addrs.Provider) string {
+	return strings.NewReplacer(
+		"${hostname}", provider.Hostname.ForDisplay(),
+		"${namespace}", strings.ToLower(provider.Namespace),
+		"${type}", strings.ToLower(provider.Type),
+	).Replace(template)
+}
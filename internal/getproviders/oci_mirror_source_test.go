@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package getproviders
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/oci"
+	"github.com/opentofu/opentofu/internal/oci/ocitest"
+)
+
+func TestOCIMirrorSource(t *testing.T) {
+	registry := ocitest.NewRegistry(t)
+	source := newOCIMirrorSourceWithClient(registry.Host()+"/providers/${namespace}/${type}", registry.Client())
+
+	provider := addrs.MustParseProviderSourceString("example.com/test/exists")
+	missingProvider := addrs.MustParseProviderSourceString("example.com/test/missing")
+	linux := Platform{OS: "linux", Arch: "amd64"}
+	darwin := Platform{OS: "darwin", Arch: "arm64"}
+	tos := Platform{OS: "tos", Arch: "m68k"}
+
+	packages := map[Platform][]byte{
+		linux:  []byte("linux package"),
+		darwin: []byte("darwin package"),
+	}
+	pushOCIProvider(registry, "providers/test/exists", "1.0.0", packages)
+	pushOCIProvider(registry, "providers/test/exists", "1.1.0+abc", packages)
+	registry.PushArtifact("providers/test/exists", "application/vnd.example", "archive/zip", nil, "latest")
+
+	t.Run("AvailableVersions for provider that exists", func(t *testing.T) {
+		got, _, err := source.AvailableVersions(context.Background(), provider)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		want := VersionList{
+			MustParseVersion("1.0.0"),
+			MustParseVersion("1.1.0+abc"),
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong result\n%s", diff)
+		}
+	})
+	t.Run("AvailableVersions for provider that doesn't exist", func(t *testing.T) {
+		_, _, err := source.AvailableVersions(context.Background(), missingProvider)
+		if _, ok := err.(ErrProviderNotFound); !ok {
+			t.Fatalf("wrong error type %T; want ErrProviderNotFound", err)
+		}
+	})
+	t.Run("PackageMeta for a version that exists", func(t *testing.T) {
+		got, err := source.PackageMeta(context.Background(), provider, MustParseVersion("1.1.0+abc"), linux)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		loc, ok := got.Location.(PackageOCIBlob)
+		if !ok {
+			t.Fatalf("wrong location type %T", got.Location)
+		}
+		if got, want := loc.Blob.Digest, oci.DigestBytes(packages[linux]); got != want {
+			t.Errorf("wrong blob digest %s; want %s", got, want)
+		}
+		if got, want := got.Filename, "terraform-provider-exists_1.1.0+abc_linux_amd64.zip"; got != want {
+			t.Errorf("wrong filename %q; want %q", got, want)
+		}
+
+		gotHashes := got.AcceptableHashes()
+		wantHashes := []Hash{
+			HashLegacyZipSHAFromSHA(oci.DigestBytes(packages[linux]).SHA256()),
+			HashLegacyZipSHAFromSHA(oci.DigestBytes(packages[linux]).SHA256()),
+			HashLegacyZipSHAFromSHA(oci.DigestBytes(packages[darwin]).SHA256()),
+		}
+		if diff := cmp.Diff(wantHashes, gotHashes); diff != "" {
+			t.Errorf("wrong hashes\n%s", diff)
+		}
+	})
+	t.Run("PackageMeta for a version that doesn't exist", func(t *testing.T) {
+		_, err := source.PackageMeta(context.Background(), provider, MustParseVersion("2.0.0"), linux)
+		if err == nil || !strings.Contains(err.Error(), "repository has no index for version 2.0.0") {
+			t.Fatalf("wrong error: %v", err)
+		}
+	})
+	t.Run("PackageMeta for an unsupported platform", func(t *testing.T) {
+		_, err := source.PackageMeta(context.Background(), provider, MustParseVersion("1.0.0"), tos)
+		if _, ok := err.(ErrPlatformNotSupported); !ok {
+			t.Fatalf("wrong error type %T; want ErrPlatformNotSupported", err)
+		}
+	})
+}
+
+func TestValidateOCIRepositoryTemplate(t *testing.T) {
+	tests := map[string]string{
+		"example.com/${namespace}/${type}":             "",
+		"example.com/${hostname}/${namespace}/${type}": "",
+		"example.com/${namespace}/provider":            "must include ${type}",
+		"example.com/${type}":                          "must include ${namespace}",
+		"example.com/${namespace}/${type}:latest":      "repository names may contain only",
+	}
+	for template, wantErr := range tests {
+		t.Run(template, func(t *testing.T) {
+			err := ValidateOCIRepositoryTemplate(template)
+			switch {
+			case wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case wantErr != "" && (err == nil || !strings.Contains(err.Error(), wantErr)):
+				t.Fatalf("wrong error: %v; want %q", err, wantErr)
+			}
+		})
+	}
+}
+
+// pushOCIProvider pushes an index for the given provider version to the
+// given repository, with the given package content for each platform.
+func pushOCIProvider(registry *ocitest.Registry, repo string, version string, packages map[Platform][]byte) {
+	index := &oci.Index{
+		SchemaVersion: 2,
+		MediaType:     oci.MediaTypeImageIndex,
+		ArtifactType:  OCIProviderArtifactType,
+	}
+	// We push the platforms in a consistent order so that the order of
+	// the hashes is predictable.
+	for _, platform := range []Platform{{"linux", "amd64"}, {"darwin", "arm64"}} {
+		content, ok := packages[platform]
+		if !ok {
+			continue
+		}
+		desc := registry.PushArtifact(repo, OCIProviderTargetArtifactType, OCIProviderArchiveMediaType, content)
+		desc.Platform = &oci.Platform{OS: platform.OS, Architecture: platform.Arch}
+		index.Manifests = append(index.Manifests, desc)
+	}
+	registry.PushManifest(repo, index, strings.ReplaceAll(version, "+", "_"))
+}
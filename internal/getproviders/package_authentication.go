@@ -25,6 +25,8 @@ const (
 	verifiedChecksum packageAuthenticationResult = iota
 	signed
 	signingSkipped
+	verifiedDigest
+	sigstoreSigned
 )
 
 const (
@@ -41,6 +43,10 @@ const (
 type PackageAuthenticationResult struct {
 	result packageAuthenticationResult
 	KeyID  string
+
+	// Identity is the identity recorded in the certificate that signed the
+	// package, for packages signed with a certificate in a sigstore bundle.
+	Identity string
 }
 
 func (t *PackageAuthenticationResult) String() string {
@@ -51,6 +57,8 @@ func (t *PackageAuthenticationResult) String() string {
 		"verified checksum",
 		"signed",
 		"signing skipped",
+		"verified digest",
+		"signed with sigstore bundle",
 	}[t.result]
 }
 
@@ -59,7 +67,16 @@ func (t *PackageAuthenticationResult) Signed() bool {
 	if t == nil {
 		return false
 	}
-	return t.result == signed
+	return t.result == signed || t.result == sigstoreSigned
+}
+
+// SigstoreSigned returns whether the package was authenticated as signed by
+// a signature in a sigstore bundle.
+func (t *PackageAuthenticationResult) SigstoreSigned() bool {
+	if t == nil {
+		return false
+	}
+	return t.result == sigstoreSigned
 }
 
 // SigningSkipped returns whether the package was authenticated but the key
@@ -71,6 +88,15 @@ func (t *PackageAuthenticationResult) SigningSkipped() bool {
 	return t.result == signingSkipped
 }
 
+// VerifiedDigest returns whether the package was authenticated against the
+// content digests in the manifests of an OCI registry.
+func (t *PackageAuthenticationResult) VerifiedDigest() bool {
+	if t == nil {
+		return false
+	}
+	return t.result == verifiedDigest
+}
+
 // SigningKey represents a key used to sign packages from a registry. These are
 // both in ASCII armored OpenPGP format.
 //
@@ -293,6 +319,41 @@ func (a archiveHashAuthentication) AcceptableHashes() []Hash {
 	return []Hash{HashLegacyZipSHAFromSHA(a.WantSHA256Sum)}
 }
 
+type ociDigestAuthentication struct {
+	archiveHashAuthentication
+	AllHashes []Hash
+}
+
+// NewOCIDigestAuthentication returns a PackageAuthentication implementation
+// that checks that the original distribution archive matches the digest of
+// the blob it was fetched from in an OCI registry.
+//
+// allHashes should contain the digests of the packages for all of the
+// platforms listed in the same OCI index, as hashes, so that they can all be
+// recorded in the dependency lock file. Because the index refers to the
+// package manifests by digest, the whole set is authenticated by the index
+// itself.
+//
+// Like NewArchiveChecksumAuthentication, this authentication is suitable
+// only for locations that refer to the original distribution archive.
+func NewOCIDigestAuthentication(platform Platform, wantSHA256Sum [sha256.Size]byte, allHashes []Hash) PackageAuthentication {
+	return ociDigestAuthentication{
+		archiveHashAuthentication: archiveHashAuthentication{platform, wantSHA256Sum},
+		AllHashes:                 allHashes,
+	}
+}
+
+func (a ociDigestAuthentication) AuthenticatePackage(localLocation PackageLocation) (*PackageAuthenticationResult, error) {
+	if _, err := a.archiveHashAuthentication.AuthenticatePackage(localLocation); err != nil {
+		return nil, err
+	}
+	return &PackageAuthenticationResult{result: verifiedDigest}, nil
+}
+
+func (a ociDigestAuthentication) AcceptableHashes() []Hash {
+	return append(a.archiveHashAuthentication.AcceptableHashes(), a.AllHashes...)
+}
+
 type matchingChecksumAuthentication struct {
 	Document      []byte
 	Filename      string
@@ -436,12 +497,18 @@ func (s signatureAuthentication) AuthenticatePackage(location PackageLocation) (
 }
 
 func (s signatureAuthentication) AcceptableHashes() []Hash {
-	// This is a bit of an abstraction leak because signatureAuthentication
-	// otherwise just treats the document as an opaque blob that's been
-	// signed, but here we're making assumptions about its format because
-	// we only want to trust that _all_ of the checksums are valid (rather
-	// than just the current platform's one) if we've also verified that the
-	// bag of checksums is signed.
+	return checksumsDocumentHashes(s.Document)
+}
+
+// checksumsDocumentHashes returns all of the hashes in the given checksums
+// document, or nothing at all if it doesn't look like a checksums document.
+func checksumsDocumentHashes(document []byte) []Hash {
+	// This is a bit of an abstraction leak because the signature
+	// authenticators otherwise just treat the document as an opaque blob
+	// that's been signed, but here we're making assumptions about its format
+	// because we only want to trust that _all_ of the checksums are valid
+	// (rather than just the current platform's one) if we've also verified
+	// that the bag of checksums is signed.
 	//
 	// In recognition of that layering quirk this implementation is intended to
 	// be somewhat resilient to potentially using this authenticator with
@@ -452,7 +519,7 @@ func (s signatureAuthentication) AcceptableHashes() []Hash {
 	// checksums files.
 
 	var ret []Hash
-	sc := bufio.NewScanner(bytes.NewReader(s.Document))
+	sc := bufio.NewScanner(bytes.NewReader(document))
 	for sc.Scan() {
 		parts := bytes.Fields(sc.Bytes())
 		if len(parts) != 0 && len(parts) < 2 {
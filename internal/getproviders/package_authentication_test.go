@@ -32,6 +32,10 @@ func TestPackageAuthenticationResult(t *testing.T) {
 			&PackageAuthenticationResult{result: signed},
 			"signed",
 		},
+		{
+			&PackageAuthenticationResult{result: sigstoreSigned},
+			"signed with sigstore bundle",
+		},
 	}
 	for _, test := range tests {
 		if got := test.result.String(); got != test.want {
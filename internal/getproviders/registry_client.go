@@ -170,7 +170,7 @@ func (c *registryClient) ProviderVersions(ctx context.Context, addr addrs.Provid
 //     supported by this version of tofu.
 //   - ErrUnauthorized if the registry responds with 401 or 403 status codes
 //   - ErrQueryFailed for any other operational problem.
-func (c *registryClient) PackageMeta(ctx context.Context, provider addrs.Provider, version Version, target Platform) (PackageMeta, error) {
+func (c *registryClient) PackageMeta(ctx context.Context, provider addrs.Provider, version Version, target Platform, sigstoreVerifier *SigstoreVerifier) (PackageMeta, error) {
 	endpointPath, err := url.Parse(path.Join(
 		provider.Namespace,
 		provider.Type,
@@ -225,8 +225,9 @@ func (c *registryClient) PackageMeta(ctx context.Context, provider addrs.Provide
 		DownloadURL string   `json:"download_url"`
 		SHA256Sum   string   `json:"shasum"`
 
-		SHA256SumsURL          string `json:"shasums_url"`
-		SHA256SumsSignatureURL string `json:"shasums_signature_url"`
+		SHA256SumsURL               string `json:"shasums_url"`
+		SHA256SumsSignatureURL      string `json:"shasums_signature_url"`
+		SHA256SumsSigstoreBundleURL string `json:"shasums_sigstore_bundle_url"`
 
 		SigningKeys SigningKeyList `json:"signing_keys"`
 	}
@@ -354,11 +355,39 @@ func (c *registryClient) PackageMeta(ctx context.Context, provider addrs.Provide
 		keys[i] = *key
 	}
 
-	ret.Authentication = PackageAuthenticationAll(
+	auths := []PackageAuthentication{
 		NewMatchingChecksumAuthentication(document, body.Filename, checksum),
 		NewArchiveChecksumAuthentication(ret.TargetPlatform, checksum),
 		NewSignatureAuthentication(ret, document, signature, keys, &provider),
-	)
+	}
+
+	if sigstoreVerifier != nil {
+		// Registries that don't say where the sigstore bundle is are assumed
+		// to publish it alongside the checksums, following the naming
+		// convention of "cosign sign-blob --bundle".
+		bundleURLStr := body.SHA256SumsSigstoreBundleURL
+		if bundleURLStr == "" {
+			bundleURLStr = shasumsURL.String() + ".sigstore.json"
+		}
+		bundleURL, err := url.Parse(bundleURLStr)
+		if err != nil {
+			return PackageMeta{}, fmt.Errorf("registry response includes invalid SHASUMS sigstore bundle URL: %w", err)
+		}
+		bundleURL = resp.Request.URL.ResolveReference(bundleURL)
+		if bundleURL.Scheme != "http" && bundleURL.Scheme != "https" {
+			return PackageMeta{}, fmt.Errorf("registry response includes invalid SHASUMS sigstore bundle URL: must use http or https scheme")
+		}
+		bundle, err := c.getFile(bundleURL)
+		if err != nil {
+			return PackageMeta{}, c.errQueryFailed(
+				provider,
+				fmt.Errorf("failed to retrieve sigstore bundle for provider: %w", err),
+			)
+		}
+		auths = append(auths, NewSigstoreBundleAuthentication(document, bundle, sigstoreVerifier))
+	}
+
+	ret.Authentication = PackageAuthenticationAll(auths...)
 
 	return ret, nil
 }
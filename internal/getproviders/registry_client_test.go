@@ -181,6 +181,8 @@ func fakeRegistryHandler(resp http.ResponseWriter, req *http.Request) {
 			resp.Write([]byte("000000000000000000000000000000000000000000000000000000000000f00d happycloud_1.2.0.zip\n000000000000000000000000000000000000000000000000000000000000face happycloud_1.2.0_face.zip\n"))
 		case "/pkg/awesomesauce/happycloud_1.2.0_SHA256SUMS.sig":
 			resp.Write([]byte("GPG signature"))
+		case "/pkg/awesomesauce/happycloud_1.2.0_SHA256SUMS.sigstore.json":
+			resp.Write([]byte("sigstore bundle"))
 		default:
 			resp.WriteHeader(404)
 			resp.Write([]byte("unknown package file download"))
@@ -17,6 +17,8 @@ import (
 // their originating provider registries.
 type RegistrySource struct {
 	services *disco.Disco
+
+	sigstoreVerifications []SigstoreVerification
 }
 
 var _ Source = (*RegistrySource)(nil)
@@ -29,6 +31,17 @@ func NewRegistrySource(services *disco.Disco) *RegistrySource {
 	}
 }
 
+// SetSigstoreVerifications requires the packages for the providers selected
+// by the given verifications to also be authenticated by a sigstore bundle
+// published alongside their checksums, using the verifier of the first
+// verification that selects each provider.
+//
+// Packages for other providers are authenticated as usual, and don't need a
+// sigstore bundle.
+func (s *RegistrySource) SetSigstoreVerifications(verifications []SigstoreVerification) {
+	s.sigstoreVerifications = verifications
+}
+
 // AvailableVersions returns all of the versions available for the provider
 // with the given address, or an error if that result cannot be determined.
 //
@@ -104,7 +117,7 @@ func (s *RegistrySource) PackageMeta(ctx context.Context, provider addrs.Provide
 		return PackageMeta{}, err
 	}
 
-	return client.PackageMeta(ctx, provider, version, target)
+	return client.PackageMeta(ctx, provider, version, target, sigstoreVerifierFor(s.sigstoreVerifications, provider))
 }
 
 func (s *RegistrySource) registryClient(hostname svchost.Hostname) (*registryClient, error) {
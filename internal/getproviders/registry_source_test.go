@@ -246,3 +246,54 @@ func TestSourcePackageMeta(t *testing.T) {
 	}
 
 }
+
+func TestSourcePackageMeta_sigstore(t *testing.T) {
+	source, _, close := testRegistrySource(t)
+	defer close()
+
+	verifier, err := NewSigstoreKeyVerifier(testSigstorePublicKeyPEM(t, testSigstoreKey(t).Public()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	source.SetSigstoreVerifications([]SigstoreVerification{
+		{
+			Include:  MultiSourceMatchingPatterns{{Hostname: "example.com", Namespace: "awesomesauce", Type: "*"}},
+			Verifier: verifier,
+		},
+	})
+
+	provider := addrs.NewProvider(svchost.Hostname("example.com"), "awesomesauce", "happycloud")
+	got, err := source.PackageMeta(context.Background(), provider, versions.MustParseVersion("1.2.0"), Platform{"linux", "amd64"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	checks, ok := got.Authentication.(packageAuthenticationAll)
+	if !ok || len(checks) != 4 {
+		t.Fatalf("wrong authentication %#v; want four checks", got.Authentication)
+	}
+	want := sigstoreBundleAuthentication{
+		Document: []byte("000000000000000000000000000000000000000000000000000000000000f00d happycloud_1.2.0.zip\n000000000000000000000000000000000000000000000000000000000000face happycloud_1.2.0_face.zip\n"),
+		Bundle:   []byte("sigstore bundle"),
+		Verifier: verifier,
+	}
+	if diff := cmp.Diff(want, checks[3], cmp.Comparer(func(a, b *SigstoreVerifier) bool { return a == b })); diff != "" {
+		t.Errorf("wrong sigstore authentication\n%s", diff)
+	}
+
+	// Providers that are excluded don't need a sigstore bundle.
+	source.SetSigstoreVerifications([]SigstoreVerification{
+		{
+			Include:  MultiSourceMatchingPatterns{{Hostname: "example.com", Namespace: "awesomesauce", Type: "*"}},
+			Exclude:  MultiSourceMatchingPatterns{provider},
+			Verifier: verifier,
+		},
+	})
+	got, err = source.PackageMeta(context.Background(), provider, versions.MustParseVersion("1.2.0"), Platform{"linux", "amd64"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if checks, ok := got.Authentication.(packageAuthenticationAll); !ok || len(checks) != 3 {
+		t.Errorf("wrong authentication %#v; want three checks", got.Authentication)
+	}
+}
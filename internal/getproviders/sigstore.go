@@ -0,0 +1,523 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package getproviders
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
+// SigstoreVerification requires the packages for a set of providers to be
+// authenticated by a sigstore bundle, in addition to any other authentication
+// that their source performs.
+type SigstoreVerification struct {
+	// Include and Exclude select the providers that this verification
+	// applies to, using the same rules as for MultiSourceSelector.
+	Include, Exclude MultiSourceMatchingPatterns
+
+	Verifier *SigstoreVerifier
+}
+
+// AppliesToProvider returns true if the given provider is both included by
+// the verification's include patterns and not excluded by its exclude
+// patterns.
+func (v SigstoreVerification) AppliesToProvider(addr addrs.Provider) bool {
+	return MultiSourceSelector{Include: v.Include, Exclude: v.Exclude}.CanHandleProvider(addr)
+}
+
+// sigstoreVerifierFor returns the verifier from the first of the given
+// verifications that applies to the given provider, or nil if none of them
+// apply.
+func sigstoreVerifierFor(verifications []SigstoreVerification, provider addrs.Provider) *SigstoreVerifier {
+	for _, v := range verifications {
+		if v.AppliesToProvider(provider) {
+			return v.Verifier
+		}
+	}
+	return nil
+}
+
+// NewSigstoreUnsupportedSource wraps a source that has no way to obtain
+// sigstore bundles, such as a provider mirror, so that it refuses to return
+// packages of the providers that the given verifications apply to. Otherwise
+// those providers could be installed from the source without the
+// verification that the configuration requires.
+func NewSigstoreUnsupportedSource(source Source, verifications []SigstoreVerification) Source {
+	if len(verifications) == 0 {
+		return source
+	}
+	return sigstoreUnsupportedSource{
+		source:        source,
+		verifications: verifications,
+	}
+}
+
+type sigstoreUnsupportedSource struct {
+	source        Source
+	verifications []SigstoreVerification
+}
+
+var _ Source = sigstoreUnsupportedSource{}
+
+func (s sigstoreUnsupportedSource) AvailableVersions(ctx context.Context, provider addrs.Provider) (VersionList, Warnings, error) {
+	return s.source.AvailableVersions(ctx, provider)
+}
+
+func (s sigstoreUnsupportedSource) PackageMeta(ctx context.Context, provider addrs.Provider, version Version, target Platform) (PackageMeta, error) {
+	if sigstoreVerifierFor(s.verifications, provider) != nil {
+		return PackageMeta{}, ErrSigstoreUnsupported{
+			Provider: provider,
+			Version:  version,
+			Source:   s.source.ForDisplay(provider),
+		}
+	}
+	return s.source.PackageMeta(ctx, provider, version, target)
+}
+
+func (s sigstoreUnsupportedSource) ForDisplay(provider addrs.Provider) string {
+	return s.source.ForDisplay(provider)
+}
+
+// SigstoreVerifier verifies signatures in the sigstore bundle format, such as
+// those created by "cosign sign-blob --bundle", without contacting any
+// sigstore services.
+//
+// A verifier either trusts signatures made with a particular public key, or
+// signatures made with any certificate issued by a set of trusted certificate
+// authorities, optionally restricted to a particular identity.
+type SigstoreVerifier struct {
+	publicKey crypto.PublicKey
+	keyID     string
+
+	roots           *x509.CertPool
+	identity        *regexp.Regexp
+	oidcIssuer      string
+	tlogPublicKey   crypto.PublicKey
+	tlogPublicKeyID []byte
+}
+
+// NewSigstoreKeyVerifier returns a SigstoreVerifier that accepts signatures
+// made with the private key corresponding to the given PEM-encoded public key.
+func NewSigstoreKeyVerifier(publicKeyPEM []byte) (*SigstoreVerifier, error) {
+	key, der, err := parsePEMPublicKey(publicKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(der)
+	return &SigstoreVerifier{
+		publicKey: key,
+		keyID:     strings.ToUpper(hex.EncodeToString(sum[:8])),
+	}, nil
+}
+
+// NewSigstoreCertificateVerifier returns a SigstoreVerifier that accepts
+// signatures made with a code signing certificate issued by one of the
+// certificate authorities in the given PEM-encoded certificates.
+//
+// If identity is not nil then the certificate must have a subject alternative
+// name matching it, and if oidcIssuer is not empty then the certificate must
+// record that its identity was issued by that OpenID Connect issuer, as in
+// the certificates issued by Fulcio.
+//
+// Certificates are normally checked as of the current time. If
+// tlogPublicKeyPEM is not empty then the bundle must instead include a
+// transparency log entry for the signature that's signed by that key, such as
+// the public key of the Rekor instance used to sign it, and certificates are
+// checked as of the time that the entry was made. That allows verifying
+// signatures made with short-lived certificates.
+func NewSigstoreCertificateVerifier(rootsPEM []byte, identity *regexp.Regexp, oidcIssuer string, tlogPublicKeyPEM []byte) (*SigstoreVerifier, error) {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(rootsPEM) {
+		return nil, fmt.Errorf("no PEM-encoded certificates found")
+	}
+	v := &SigstoreVerifier{
+		roots:      roots,
+		identity:   identity,
+		oidcIssuer: oidcIssuer,
+	}
+	if len(tlogPublicKeyPEM) != 0 {
+		key, der, err := parsePEMPublicKey(tlogPublicKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transparency log public key: %w", err)
+		}
+		// A transparency log's ID is the SHA-256 hash of its public key.
+		sum := sha256.Sum256(der)
+		v.tlogPublicKey = key
+		v.tlogPublicKeyID = sum[:]
+	}
+	return v, nil
+}
+
+func parsePEMPublicKey(data []byte) (crypto.PublicKey, []byte, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, nil, fmt.Errorf("no PEM-encoded public key found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, block.Bytes, nil
+}
+
+// sigstoreBundle is the subset of the sigstore bundle format that
+// SigstoreVerifier uses. It supports versions 0.1 through 0.3 of the format.
+type sigstoreBundle struct {
+	MediaType            string `json:"mediaType"`
+	VerificationMaterial struct {
+		X509CertificateChain *struct {
+			Certificates []struct {
+				RawBytes []byte `json:"rawBytes"`
+			} `json:"certificates"`
+		} `json:"x509CertificateChain"`
+		Certificate *struct {
+			RawBytes []byte `json:"rawBytes"`
+		} `json:"certificate"`
+		TlogEntries []sigstoreTlogEntry `json:"tlogEntries"`
+	} `json:"verificationMaterial"`
+	MessageSignature *struct {
+		MessageDigest *struct {
+			Algorithm string `json:"algorithm"`
+			Digest    []byte `json:"digest"`
+		} `json:"messageDigest"`
+		Signature []byte `json:"signature"`
+	} `json:"messageSignature"`
+	DSSEEnvelope json.RawMessage `json:"dsseEnvelope"`
+}
+
+type sigstoreTlogEntry struct {
+	LogIndex int64 `json:"logIndex,string"`
+	LogID    struct {
+		KeyID []byte `json:"keyId"`
+	} `json:"logId"`
+	IntegratedTime   int64 `json:"integratedTime,string"`
+	InclusionPromise *struct {
+		SignedEntryTimestamp []byte `json:"signedEntryTimestamp"`
+	} `json:"inclusionPromise"`
+	CanonicalizedBody []byte `json:"canonicalizedBody"`
+}
+
+// Verify checks that the given sigstore bundle contains a valid signature of
+// the given artifact, made with a key that the verifier trusts.
+//
+// If successful, it returns the ID of the verifier's public key, or the
+// identity of the signing certificate, as a description of the signer.
+func (v *SigstoreVerifier) Verify(artifact []byte, bundleJSON []byte) (string, error) {
+	var bundle sigstoreBundle
+	if err := json.Unmarshal(bundleJSON, &bundle); err != nil {
+		return "", fmt.Errorf("invalid sigstore bundle: %w", err)
+	}
+	if !strings.HasPrefix(bundle.MediaType, "application/vnd.dev.sigstore.bundle") {
+		return "", fmt.Errorf("invalid sigstore bundle: unsupported media type %q", bundle.MediaType)
+	}
+	sig := bundle.MessageSignature
+	if sig == nil {
+		if len(bundle.DSSEEnvelope) != 0 {
+			return "", fmt.Errorf("sigstore bundles with DSSE envelopes are not supported; the bundle must contain a signature of the checksums file itself")
+		}
+		return "", fmt.Errorf("invalid sigstore bundle: no message signature")
+	}
+
+	digest := sha256.Sum256(artifact)
+	if d := sig.MessageDigest; d != nil {
+		if d.Algorithm != "SHA2_256" {
+			return "", fmt.Errorf("sigstore bundle has unsupported digest algorithm %q", d.Algorithm)
+		}
+		if !bytes.Equal(d.Digest, digest[:]) {
+			return "", fmt.Errorf("sigstore bundle is for a different checksums file")
+		}
+	}
+
+	if v.publicKey != nil {
+		if err := verifySignature(v.publicKey, artifact, sig.Signature); err != nil {
+			return "", fmt.Errorf("sigstore bundle signature doesn't match the trusted public key: %w", err)
+		}
+		return v.keyID, nil
+	}
+
+	var certs []*x509.Certificate
+	switch {
+	case bundle.VerificationMaterial.Certificate != nil:
+		cert, err := x509.ParseCertificate(bundle.VerificationMaterial.Certificate.RawBytes)
+		if err != nil {
+			return "", fmt.Errorf("invalid certificate in sigstore bundle: %w", err)
+		}
+		certs = append(certs, cert)
+	case bundle.VerificationMaterial.X509CertificateChain != nil:
+		for _, raw := range bundle.VerificationMaterial.X509CertificateChain.Certificates {
+			cert, err := x509.ParseCertificate(raw.RawBytes)
+			if err != nil {
+				return "", fmt.Errorf("invalid certificate in sigstore bundle: %w", err)
+			}
+			certs = append(certs, cert)
+		}
+	}
+	if len(certs) == 0 {
+		return "", fmt.Errorf("sigstore bundle has no certificate, but a certificate is required")
+	}
+	leaf := certs[0]
+
+	verifyTime := time.Now()
+	if v.tlogPublicKey != nil {
+		t, err := v.verifyTlogEntries(bundle.VerificationMaterial.TlogEntries, leaf, digest[:], sig.Signature)
+		if err != nil {
+			return "", err
+		}
+		verifyTime = t
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         v.roots,
+		Intermediates: intermediates,
+		CurrentTime:   verifyTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	})
+	if err != nil {
+		return "", fmt.Errorf("sigstore bundle certificate is not trusted: %w", err)
+	}
+
+	identity, err := v.verifyIdentity(leaf)
+	if err != nil {
+		return "", err
+	}
+	if err := verifySignature(leaf.PublicKey, artifact, sig.Signature); err != nil {
+		return "", fmt.Errorf("sigstore bundle signature doesn't match its certificate: %w", err)
+	}
+	return identity, nil
+}
+
+// verifyIdentity checks the identity of the given certificate against the
+// verifier's requirements, and returns the identity to report.
+func (v *SigstoreVerifier) verifyIdentity(cert *x509.Certificate) (string, error) {
+	var identities []string
+	identities = append(identities, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		identities = append(identities, uri.String())
+	}
+	identities = append(identities, cert.DNSNames...)
+
+	identity := ""
+	for _, candidate := range identities {
+		if v.identity == nil || v.identity.MatchString(candidate) {
+			identity = candidate
+			break
+		}
+	}
+	if identity == "" && v.identity != nil {
+		return "", fmt.Errorf("sigstore bundle certificate identity %q doesn't match the required identity", strings.Join(identities, ", "))
+	}
+	if identity == "" {
+		identity = cert.Subject.String()
+	}
+
+	if v.oidcIssuer != "" {
+		issuer := fulcioOIDCIssuer(cert)
+		if issuer != v.oidcIssuer {
+			return "", fmt.Errorf("sigstore bundle certificate was issued for an identity from %q, but the required issuer is %q", issuer, v.oidcIssuer)
+		}
+	}
+	return identity, nil
+}
+
+// These are the object identifiers of the certificate extensions in which
+// Fulcio records the OpenID Connect issuer of a certificate's identity. The
+// first contains the raw issuer URL, while the second contains it as a
+// DER-encoded UTF8String.
+var (
+	fulcioIssuerV1OID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	fulcioIssuerV2OID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+func fulcioOIDCIssuer(cert *x509.Certificate) string {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(fulcioIssuerV2OID) {
+			var issuer string
+			if _, err := asn1.UnmarshalWithParams(ext.Value, &issuer, "utf8"); err == nil {
+				return issuer
+			}
+		}
+	}
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(fulcioIssuerV1OID) {
+			return string(ext.Value)
+		}
+	}
+	return ""
+}
+
+// verifyTlogEntries finds a transparency log entry for the given signature
+// that is promised by the verifier's trusted transparency log, and returns
+// the time it was added to the log.
+func (v *SigstoreVerifier) verifyTlogEntries(entries []sigstoreTlogEntry, cert *x509.Certificate, digest []byte, signature []byte) (time.Time, error) {
+	if len(entries) == 0 {
+		return time.Time{}, fmt.Errorf("sigstore bundle has no transparency log entries, but a transparency log entry is required")
+	}
+	var errs []error
+	for _, entry := range entries {
+		if err := v.verifyTlogEntry(entry, cert, digest, signature); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return time.Unix(entry.IntegratedTime, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("sigstore bundle has no valid transparency log entry: %w", errors.Join(errs...))
+}
+
+func (v *SigstoreVerifier) verifyTlogEntry(entry sigstoreTlogEntry, cert *x509.Certificate, digest []byte, signature []byte) error {
+	if !bytes.Equal(entry.LogID.KeyID, v.tlogPublicKeyID) {
+		return fmt.Errorf("entry %d is from a different transparency log", entry.LogIndex)
+	}
+	if entry.InclusionPromise == nil {
+		return fmt.Errorf("entry %d has no signed entry timestamp", entry.LogIndex)
+	}
+
+	// The signed entry timestamp is the transparency log's signature of the
+	// canonical JSON serialization of the following object, whose properties
+	// must therefore remain in lexical order.
+	payload, err := json.Marshal(struct {
+		Body           string `json:"body"`
+		IntegratedTime int64  `json:"integratedTime"`
+		LogID          string `json:"logID"`
+		LogIndex       int64  `json:"logIndex"`
+	}{
+		Body:           base64.StdEncoding.EncodeToString(entry.CanonicalizedBody),
+		IntegratedTime: entry.IntegratedTime,
+		LogID:          hex.EncodeToString(entry.LogID.KeyID),
+		LogIndex:       entry.LogIndex,
+	})
+	if err != nil {
+		return err
+	}
+	if err := verifySignature(v.tlogPublicKey, payload, entry.InclusionPromise.SignedEntryTimestamp); err != nil {
+		return fmt.Errorf("entry %d has an invalid signed entry timestamp: %w", entry.LogIndex, err)
+	}
+
+	// The entry must also be for the same signature, because otherwise it
+	// would say nothing about when this signature was made.
+	var body struct {
+		Kind string `json:"kind"`
+		Spec struct {
+			Data struct {
+				Hash struct {
+					Algorithm string `json:"algorithm"`
+					Value     string `json:"value"`
+				} `json:"hash"`
+			} `json:"data"`
+			Signature struct {
+				Content   []byte `json:"content"`
+				PublicKey struct {
+					Content []byte `json:"content"`
+				} `json:"publicKey"`
+			} `json:"signature"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(entry.CanonicalizedBody, &body); err != nil {
+		return fmt.Errorf("entry %d has an invalid body: %w", entry.LogIndex, err)
+	}
+	if body.Kind != "hashedrekord" {
+		return fmt.Errorf("entry %d has unsupported kind %q", entry.LogIndex, body.Kind)
+	}
+	if body.Spec.Data.Hash.Algorithm != "sha256" || body.Spec.Data.Hash.Value != hex.EncodeToString(digest) {
+		return fmt.Errorf("entry %d is for a different checksums file", entry.LogIndex)
+	}
+	if !bytes.Equal(body.Spec.Signature.Content, signature) {
+		return fmt.Errorf("entry %d is for a different signature", entry.LogIndex)
+	}
+	if block, _ := pem.Decode(body.Spec.Signature.PublicKey.Content); block == nil || !bytes.Equal(block.Bytes, cert.Raw) {
+		return fmt.Errorf("entry %d is for a different certificate", entry.LogIndex)
+	}
+
+	log.Printf("[TRACE] getproviders: sigstore bundle signature was added to the transparency log at %s", time.Unix(entry.IntegratedTime, 0))
+	return nil
+}
+
+// verifySignature verifies a signature of the given message made with the
+// private key corresponding to the given public key, using the signature
+// schemes that sigstore uses with each key type.
+func verifySignature(key crypto.PublicKey, message []byte, signature []byte) error {
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(message)
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, message, signature) {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(message)
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err == nil {
+			return nil
+		}
+		if err := rsa.VerifyPSS(key, crypto.SHA256, digest[:], signature, nil); err != nil {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+type sigstoreBundleAuthentication struct {
+	Document []byte
+	Bundle   []byte
+	Verifier *SigstoreVerifier
+}
+
+// NewSigstoreBundleAuthentication returns a PackageAuthentication
+// implementation that verifies that the given sigstore bundle contains a
+// signature of the given checksums document that the given verifier trusts.
+//
+// Like the result of NewSignatureAuthentication, it only verifies the
+// checksums document itself, so it must be combined with
+// NewMatchingChecksumAuthentication to authenticate a particular package.
+func NewSigstoreBundleAuthentication(document, bundle []byte, verifier *SigstoreVerifier) PackageAuthentication {
+	return sigstoreBundleAuthentication{
+		Document: document,
+		Bundle:   bundle,
+		Verifier: verifier,
+	}
+}
+
+func (s sigstoreBundleAuthentication) AuthenticatePackage(location PackageLocation) (*PackageAuthenticationResult, error) {
+	log.Printf("[DEBUG] Validating sigstore bundle signature of provider package %s", location)
+	signer, err := s.Verifier.Verify(s.Document, s.Bundle)
+	if err != nil {
+		return nil, err
+	}
+	if s.Verifier.publicKey != nil {
+		return &PackageAuthenticationResult{result: sigstoreSigned, KeyID: signer}, nil
+	}
+	return &PackageAuthenticationResult{result: sigstoreSigned, Identity: signer}, nil
+}
+
+func (s sigstoreBundleAuthentication) AcceptableHashes() []Hash {
+	return checksumsDocumentHashes(s.Document)
+}
@@ -0,0 +1,433 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package getproviders
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
+const testSigstoreDocument = "000000000000000000000000000000000000000000000000000000000000f00d happycloud_1.2.0.zip\n"
+
+func TestSigstoreBundleAuthentication_publicKey(t *testing.T) {
+	key := testSigstoreKey(t)
+	signature := testSigstoreSign(t, key, []byte(testSigstoreDocument))
+	bundle := testSigstoreBundle(t, []byte(testSigstoreDocument), signature, nil, nil)
+
+	verifier, err := NewSigstoreKeyVerifier(testSigstorePublicKeyPEM(t, key.Public()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := NewSigstoreBundleAuthentication([]byte(testSigstoreDocument), bundle, verifier)
+	result, err := auth.AuthenticatePackage(PackageLocalArchive("testdata/my-package.zip"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !result.SigstoreSigned() || !result.Signed() {
+		t.Errorf("wrong result: %s", result)
+	}
+	if result.KeyID != verifier.keyID || len(result.KeyID) != 16 {
+		t.Errorf("wrong key ID %q; want %q", result.KeyID, verifier.keyID)
+	}
+
+	wantHashes := []Hash{"zh:000000000000000000000000000000000000000000000000000000000000f00d"}
+	if got := auth.(PackageAuthenticationHashes).AcceptableHashes(); len(got) != 1 || got[0] != wantHashes[0] {
+		t.Errorf("wrong acceptable hashes %#v; want %#v", got, wantHashes)
+	}
+}
+
+func TestSigstoreBundleAuthentication_publicKeyFailure(t *testing.T) {
+	key := testSigstoreKey(t)
+	signature := testSigstoreSign(t, key, []byte(testSigstoreDocument))
+	verifier, err := NewSigstoreKeyVerifier(testSigstorePublicKeyPEM(t, key.Public()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherVerifier, err := NewSigstoreKeyVerifier(testSigstorePublicKeyPEM(t, testSigstoreKey(t).Public()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		document []byte
+		bundle   []byte
+		verifier *SigstoreVerifier
+		wantErr  string
+	}{
+		"wrong key": {
+			[]byte(testSigstoreDocument),
+			testSigstoreBundle(t, []byte(testSigstoreDocument), signature, nil, nil),
+			otherVerifier,
+			"sigstore bundle signature doesn't match the trusted public key: invalid signature",
+		},
+		"different document": {
+			[]byte(testSigstoreDocument + "extra\n"),
+			testSigstoreBundle(t, []byte(testSigstoreDocument), signature, nil, nil),
+			verifier,
+			"sigstore bundle is for a different checksums file",
+		},
+		"invalid JSON": {
+			[]byte(testSigstoreDocument),
+			[]byte("not a bundle"),
+			verifier,
+			"invalid sigstore bundle: invalid character 'o' in literal null (expecting 'u')",
+		},
+		"DSSE envelope": {
+			[]byte(testSigstoreDocument),
+			[]byte(`{"mediaType":"application/vnd.dev.sigstore.bundle.v0.3+json","dsseEnvelope":{}}`),
+			verifier,
+			"sigstore bundles with DSSE envelopes are not supported; the bundle must contain a signature of the checksums file itself",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			auth := NewSigstoreBundleAuthentication(test.document, test.bundle, test.verifier)
+			result, err := auth.AuthenticatePackage(PackageLocalArchive("testdata/my-package.zip"))
+			if result != nil {
+				t.Errorf("wrong result: got %#v, want nil", result)
+			}
+			if err == nil || err.Error() != test.wantErr {
+				t.Errorf("wrong error\ngot:  %v\nwant: %s", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestSigstoreBundleAuthentication_certificate(t *testing.T) {
+	const issuer = "https://token.actions.githubusercontent.com"
+	const identity = "https://github.com/awesomesauce/terraform-provider-happycloud/.github/workflows/release.yml@refs/tags/v1.2.0"
+
+	ca, caKey := testSigstoreCA(t)
+	rootsPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw})
+	tlogKey := testSigstoreKey(t)
+	tlogPEM := testSigstorePublicKeyPEM(t, tlogKey.Public())
+
+	// The signing certificate is short-lived, like those that Fulcio issues,
+	// and so it has already expired.
+	signedAt := time.Now().Add(-24 * time.Hour)
+	leafKey := testSigstoreKey(t)
+	leaf := testSigstoreLeaf(t, ca, caKey, leafKey, identity, issuer, signedAt)
+	signature := testSigstoreSign(t, leafKey, []byte(testSigstoreDocument))
+	entry := testSigstoreTlogEntry(t, tlogKey, []byte(testSigstoreDocument), signature, leaf, signedAt)
+	bundle := testSigstoreBundle(t, []byte(testSigstoreDocument), signature, leaf, []any{entry})
+
+	tests := map[string]struct {
+		bundle   []byte
+		identity string
+		issuer   string
+		tlog     []byte
+		want     string
+		wantErr  string
+	}{
+		"valid": {
+			bundle:   bundle,
+			identity: `^https://github\.com/awesomesauce/`,
+			issuer:   issuer,
+			tlog:     tlogPEM,
+			want:     identity,
+		},
+		"any identity": {
+			bundle: bundle,
+			tlog:   tlogPEM,
+			want:   identity,
+		},
+		"wrong identity": {
+			bundle:   bundle,
+			identity: `^https://github\.com/hashicorp/`,
+			tlog:     tlogPEM,
+			wantErr:  `sigstore bundle certificate identity "` + identity + `" doesn't match the required identity`,
+		},
+		"wrong issuer": {
+			bundle:  bundle,
+			issuer:  "https://accounts.google.com",
+			tlog:    tlogPEM,
+			wantErr: `sigstore bundle certificate was issued for an identity from "` + issuer + `", but the required issuer is "https://accounts.google.com"`,
+		},
+		"expired without transparency log": {
+			bundle:  bundle,
+			wantErr: "sigstore bundle certificate is not trusted: x509: certificate has expired or is not yet valid",
+		},
+		"no transparency log entry": {
+			bundle:  testSigstoreBundle(t, []byte(testSigstoreDocument), signature, leaf, nil),
+			tlog:    tlogPEM,
+			wantErr: "sigstore bundle has no transparency log entries, but a transparency log entry is required",
+		},
+		"untrusted transparency log": {
+			bundle:  bundle,
+			tlog:    testSigstorePublicKeyPEM(t, testSigstoreKey(t).Public()),
+			wantErr: "sigstore bundle has no valid transparency log entry: entry 42 is from a different transparency log",
+		},
+		"no certificate": {
+			bundle:  testSigstoreBundle(t, []byte(testSigstoreDocument), signature, nil, nil),
+			tlog:    tlogPEM,
+			wantErr: "sigstore bundle has no certificate, but a certificate is required",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var identityPattern *regexp.Regexp
+			if test.identity != "" {
+				identityPattern = regexp.MustCompile(test.identity)
+			}
+			verifier, err := NewSigstoreCertificateVerifier(rootsPEM, identityPattern, test.issuer, test.tlog)
+			if err != nil {
+				t.Fatal(err)
+			}
+			auth := NewSigstoreBundleAuthentication([]byte(testSigstoreDocument), test.bundle, verifier)
+			result, err := auth.AuthenticatePackage(PackageLocalArchive("testdata/my-package.zip"))
+			if test.wantErr != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), test.wantErr) {
+					t.Fatalf("wrong error\ngot:  %v\nwant: %s", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !result.SigstoreSigned() || result.Identity != test.want || result.KeyID != "" {
+				t.Errorf("wrong result %#v; want identity %q", result, test.want)
+			}
+		})
+	}
+}
+
+func TestSigstoreUnsupportedSource(t *testing.T) {
+	platform := Platform{OS: "amigaos", Arch: "m68k"}
+	verified := addrs.NewDefaultProvider("verified")
+	unverified := addrs.NewDefaultProvider("unverified")
+	version := MustParseVersion("1.0.0")
+
+	include, err := ParseMultiSourceMatchingPatterns([]string{verified.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifications := []SigstoreVerification{
+		{Include: include, Verifier: &SigstoreVerifier{}},
+	}
+
+	mirror := NewSigstoreUnsupportedSource(NewMockSource([]PackageMeta{
+		FakePackageMeta(verified, version, VersionList{MustParseVersion("5.0")}, platform),
+		FakePackageMeta(unverified, version, VersionList{MustParseVersion("5.0")}, platform),
+	}, nil), verifications)
+
+	t.Run("unverified provider", func(t *testing.T) {
+		if _, err := mirror.PackageMeta(context.Background(), unverified, version, platform); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
+	t.Run("verified provider", func(t *testing.T) {
+		versions, _, err := mirror.AvailableVersions(context.Background(), verified)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(versions) != 1 {
+			t.Fatalf("wrong versions %s; want only %s", versions, version)
+		}
+		_, err = mirror.PackageMeta(context.Background(), verified, version, platform)
+		if _, ok := err.(ErrSigstoreUnsupported); !ok {
+			t.Fatalf("wrong error type:\ngot:  %T\nwant: ErrSigstoreUnsupported", err)
+		}
+	})
+	t.Run("verified provider in later source", func(t *testing.T) {
+		want := FakePackageMeta(verified, version, VersionList{MustParseVersion("5.0")}, platform)
+		want.Filename = "direct"
+		multi := MultiSource{
+			{Source: mirror},
+			{Source: NewMockSource([]PackageMeta{want}, nil)},
+		}
+		got, err := multi.PackageMeta(context.Background(), verified, version, platform)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got.Filename != want.Filename {
+			t.Fatalf("package came from the wrong source: %s", got.Filename)
+		}
+	})
+	t.Run("verified provider only in unsupported source", func(t *testing.T) {
+		multi := MultiSource{
+			{Source: mirror},
+			{Source: NewMockSource(nil, nil)},
+		}
+		_, err := multi.PackageMeta(context.Background(), verified, version, platform)
+		if _, ok := err.(ErrSigstoreUnsupported); !ok {
+			t.Fatalf("wrong error type:\ngot:  %T\nwant: ErrSigstoreUnsupported", err)
+		}
+	})
+}
+
+func testSigstoreKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func testSigstorePublicKeyPEM(t *testing.T, key crypto.PublicKey) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func testSigstoreSign(t *testing.T, key *ecdsa.PrivateKey, message []byte) []byte {
+	t.Helper()
+	digest := sha256.Sum256(message)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signature
+}
+
+func testSigstoreCA(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key := testSigstoreKey(t)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test sigstore CA"},
+		NotBefore:             time.Now().Add(-365 * 24 * time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func testSigstoreLeaf(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey, key *ecdsa.PrivateKey, identity, issuer string, signedAt time.Time) *x509.Certificate {
+	t.Helper()
+	identityURL, err := url.Parse(identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuerExt, err := asn1.MarshalWithParams(issuer, "utf8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(2),
+		NotBefore:       signedAt.Add(-time.Minute),
+		NotAfter:        signedAt.Add(10 * time.Minute),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		URIs:            []*url.URL{identityURL},
+		ExtraExtensions: []pkix.Extension{{Id: fulcioIssuerV2OID, Value: issuerExt}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+// testSigstoreTlogEntry returns a transparency log entry for the given
+// signature, in the form that Rekor includes in a sigstore bundle.
+func testSigstoreTlogEntry(t *testing.T, tlogKey *ecdsa.PrivateKey, document, signature []byte, cert *x509.Certificate, integratedAt time.Time) map[string]any {
+	t.Helper()
+	tlogKeyDER, err := x509.MarshalPKIXPublicKey(tlogKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	logID := sha256.Sum256(tlogKeyDER)
+	digest := sha256.Sum256(document)
+
+	body, err := json.Marshal(map[string]any{
+		"apiVersion": "0.0.1",
+		"kind":       "hashedrekord",
+		"spec": map[string]any{
+			"data": map[string]any{
+				"hash": map[string]any{"algorithm": "sha256", "value": hex.EncodeToString(digest[:])},
+			},
+			"signature": map[string]any{
+				"content": signature,
+				"publicKey": map[string]any{
+					"content": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}),
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const logIndex = 42
+	payload := `{"body":"` + base64.StdEncoding.EncodeToString(body) + `","integratedTime":` + strconv.FormatInt(integratedAt.Unix(), 10) + `,"logID":"` + hex.EncodeToString(logID[:]) + `","logIndex":` + strconv.Itoa(logIndex) + `}`
+	return map[string]any{
+		"logIndex":          strconv.Itoa(logIndex),
+		"logId":             map[string]any{"keyId": logID[:]},
+		"kindVersion":       map[string]any{"kind": "hashedrekord", "version": "0.0.1"},
+		"integratedTime":    strconv.FormatInt(integratedAt.Unix(), 10),
+		"inclusionPromise":  map[string]any{"signedEntryTimestamp": testSigstoreSign(t, tlogKey, []byte(payload))},
+		"canonicalizedBody": body,
+	}
+}
+
+// testSigstoreBundle returns a sigstore bundle with the given signature of
+// the given document, with the given certificate if it isn't nil, and
+// otherwise with only a hint that the signature was made with a public key.
+func testSigstoreBundle(t *testing.T, document, signature []byte, cert *x509.Certificate, tlogEntries []any) []byte {
+	t.Helper()
+	digest := sha256.Sum256(document)
+	material := map[string]any{
+		"publicKey": map[string]any{"hint": "test"},
+	}
+	if cert != nil {
+		material = map[string]any{
+			"x509CertificateChain": map[string]any{
+				"certificates": []any{map[string]any{"rawBytes": cert.Raw}},
+			},
+		}
+	}
+	if tlogEntries != nil {
+		material["tlogEntries"] = tlogEntries
+	}
+	bundle, err := json.Marshal(map[string]any{
+		"mediaType":            "application/vnd.dev.sigstore.bundle+json;version=0.2",
+		"verificationMaterial": material,
+		"messageSignature": map[string]any{
+			"messageDigest": map[string]any{"algorithm": "SHA2_256", "digest": digest[:]},
+			"signature":     signature,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bundle
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/apparentlymart/go-versions/versions/constraints"
 
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/oci"
 )
 
 // Version represents a particular single version of a provider.
@@ -282,7 +283,8 @@ func (m PackageMeta) AcceptableHashes() []Hash {
 
 // PackageLocation represents a location where a provider distribution package
 // can be obtained. A value of this type contains one of the following
-// concrete types: PackageLocalArchive, PackageLocalDir, or PackageHTTPURL.
+// concrete types: PackageLocalArchive, PackageLocalDir, PackageHTTPURL, or
+// PackageOCIBlob.
 type PackageLocation interface {
 	packageLocation()
 	String() string
@@ -313,6 +315,22 @@ type PackageHTTPURL string
 func (p PackageHTTPURL) packageLocation() {}
 func (p PackageHTTPURL) String() string   { return string(p) }
 
+// PackageOCIBlob is a provider package location in an OCI registry, where
+// the package archive is stored as a blob in a particular repository.
+//
+// Fetching the blob may require authentication, so the location also
+// includes the client that must be used to fetch it.
+type PackageOCIBlob struct {
+	Client     *oci.Client
+	Repository oci.Repository
+	Blob       oci.Descriptor
+}
+
+func (p PackageOCIBlob) packageLocation() {}
+func (p PackageOCIBlob) String() string {
+	return p.Repository.URL().String() + "@" + p.Blob.Digest.String()
+}
+
 // PackageMetaList is a list of PackageMeta. It's just []PackageMeta with
 // some methods for convenient sorting and filtering.
 type PackageMetaList []PackageMeta
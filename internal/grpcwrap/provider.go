@@ -5,6 +5,7 @@ package grpcwrap
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/opentofu/opentofu/internal/plugin/convert"
 	"github.com/opentofu/opentofu/internal/providers"
@@ -63,9 +64,17 @@ func (p *provider) GetSchema(_ context.Context, req *tfplugin5.GetProviderSchema
 	}
 
 	resp.ServerCapabilities = &tfplugin5.GetProviderSchema_ServerCapabilities{
-		PlanDestroy: p.schema.ServerCapabilities.PlanDestroy,
+		PlanDestroy:       p.schema.ServerCapabilities.PlanDestroy,
+		MoveResourceState: p.schema.ServerCapabilities.MoveResourceState,
 	}
 
+	funcs, err := functionsToProto(p.schema.Functions)
+	if err != nil {
+		resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, err)
+		return resp, nil
+	}
+	resp.Functions = funcs
+
 	// include any diagnostics from the original GetSchema call
 	resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, p.schema.Diagnostics)
 
@@ -350,6 +359,34 @@ func (p *provider) ImportResourceState(_ context.Context, req *tfplugin5.ImportR
 	return resp, nil
 }
 
+func (p *provider) MoveResourceState(_ context.Context, req *tfplugin5.MoveResourceState_Request) (*tfplugin5.MoveResourceState_Response, error) {
+	resp := &tfplugin5.MoveResourceState_Response{}
+
+	moveResp := p.provider.MoveResourceState(providers.MoveResourceStateRequest{
+		SourceProviderAddress: req.SourceProviderAddress,
+		SourceTypeName:        req.SourceTypeName,
+		SourceSchemaVersion:   req.SourceSchemaVersion,
+		SourceStateJSON:       req.SourceState.GetJson(),
+		SourcePrivate:         req.SourcePrivate,
+		TargetTypeName:        req.TargetTypeName,
+	})
+	resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, moveResp.Diagnostics)
+	if moveResp.Diagnostics.HasErrors() {
+		return resp, nil
+	}
+
+	ty := p.schema.ResourceTypes[req.TargetTypeName].Block.ImpliedType()
+	targetState, err := encodeDynamicValue(moveResp.TargetState, ty)
+	if err != nil {
+		resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, err)
+		return resp, nil
+	}
+	resp.TargetState = targetState
+	resp.TargetPrivate = moveResp.TargetPrivate
+
+	return resp, nil
+}
+
 func (p *provider) ReadDataSource(_ context.Context, req *tfplugin5.ReadDataSource_Request) (*tfplugin5.ReadDataSource_Response, error) {
 	resp := &tfplugin5.ReadDataSource_Response{}
 	ty := p.schema.DataSources[req.TypeName].Block.ImpliedType()
@@ -386,6 +423,74 @@ func (p *provider) ReadDataSource(_ context.Context, req *tfplugin5.ReadDataSour
 	return resp, nil
 }
 
+func (p *provider) GetFunctions(context.Context, *tfplugin5.GetFunctions_Request) (*tfplugin5.GetFunctions_Response, error) {
+	resp := &tfplugin5.GetFunctions_Response{}
+
+	fnResp := p.provider.GetFunctions()
+	resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, fnResp.Diagnostics)
+	if fnResp.Diagnostics.HasErrors() {
+		return resp, nil
+	}
+
+	funcs, err := functionsToProto(fnResp.Functions)
+	if err != nil {
+		resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, err)
+		return resp, nil
+	}
+	resp.Functions = funcs
+
+	return resp, nil
+}
+
+func (p *provider) CallFunction(_ context.Context, req *tfplugin5.CallFunction_Request) (*tfplugin5.CallFunction_Response, error) {
+	resp := &tfplugin5.CallFunction_Response{}
+
+	spec, ok := p.schema.Functions[req.Name]
+	if !ok {
+		resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, fmt.Errorf("unknown function %q", req.Name))
+		return resp, nil
+	}
+
+	args := make([]cty.Value, len(req.Arguments))
+	for i, arg := range req.Arguments {
+		var param providers.FunctionParameterSpec
+		switch {
+		case i < len(spec.Parameters):
+			param = spec.Parameters[i]
+		case spec.VariadicParameter != nil:
+			param = *spec.VariadicParameter
+		default:
+			resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, fmt.Errorf("too many arguments for function %q", req.Name))
+			return resp, nil
+		}
+
+		var err error
+		args[i], err = decodeDynamicValue(arg, param.Type)
+		if err != nil {
+			resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, err)
+			return resp, nil
+		}
+	}
+
+	callResp := p.provider.CallFunction(providers.CallFunctionRequest{
+		Name:      req.Name,
+		Arguments: args,
+	})
+	resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, callResp.Diagnostics)
+	if callResp.Diagnostics.HasErrors() {
+		return resp, nil
+	}
+
+	var err error
+	resp.Result, err = encodeDynamicValue(callResp.Result, spec.Return)
+	if err != nil {
+		resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, err)
+		return resp, nil
+	}
+
+	return resp, nil
+}
+
 func (p *provider) Stop(context.Context, *tfplugin5.Stop_Request) (*tfplugin5.Stop_Response, error) {
 	resp := &tfplugin5.Stop_Response{}
 	err := p.provider.Stop()
@@ -420,3 +525,15 @@ func encodeDynamicValue(v cty.Value, ty cty.Type) (*tfplugin5.DynamicValue, erro
 		Msgpack: mp,
 	}, err
 }
+
+func functionsToProto(funcs map[string]providers.FunctionSpec) (map[string]*tfplugin5.Function, error) {
+	ret := make(map[string]*tfplugin5.Function, len(funcs))
+	for name, spec := range funcs {
+		fn, err := convert.FunctionSpecToProto(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid definition for function %q: %w", name, err)
+		}
+		ret[name] = fn
+	}
+	return ret, nil
+}
@@ -5,6 +5,7 @@ package grpcwrap
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/opentofu/opentofu/internal/plugin6/convert"
 	"github.com/opentofu/opentofu/internal/providers"
@@ -63,9 +64,17 @@ func (p *provider6) GetProviderSchema(_ context.Context, req *tfplugin6.GetProvi
 	}
 
 	resp.ServerCapabilities = &tfplugin6.GetProviderSchema_ServerCapabilities{
-		PlanDestroy: p.schema.ServerCapabilities.PlanDestroy,
+		PlanDestroy:       p.schema.ServerCapabilities.PlanDestroy,
+		MoveResourceState: p.schema.ServerCapabilities.MoveResourceState,
 	}
 
+	funcs, err := functionsToProto6(p.schema.Functions)
+	if err != nil {
+		resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, err)
+		return resp, nil
+	}
+	resp.Functions = funcs
+
 	// include any diagnostics from the original GetSchema call
 	resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, p.schema.Diagnostics)
 
@@ -350,6 +359,34 @@ func (p *provider6) ImportResourceState(_ context.Context, req *tfplugin6.Import
 	return resp, nil
 }
 
+func (p *provider6) MoveResourceState(_ context.Context, req *tfplugin6.MoveResourceState_Request) (*tfplugin6.MoveResourceState_Response, error) {
+	resp := &tfplugin6.MoveResourceState_Response{}
+
+	moveResp := p.provider.MoveResourceState(providers.MoveResourceStateRequest{
+		SourceProviderAddress: req.SourceProviderAddress,
+		SourceTypeName:        req.SourceTypeName,
+		SourceSchemaVersion:   req.SourceSchemaVersion,
+		SourceStateJSON:       req.SourceState.GetJson(),
+		SourcePrivate:         req.SourcePrivate,
+		TargetTypeName:        req.TargetTypeName,
+	})
+	resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, moveResp.Diagnostics)
+	if moveResp.Diagnostics.HasErrors() {
+		return resp, nil
+	}
+
+	ty := p.schema.ResourceTypes[req.TargetTypeName].Block.ImpliedType()
+	targetState, err := encodeDynamicValue6(moveResp.TargetState, ty)
+	if err != nil {
+		resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, err)
+		return resp, nil
+	}
+	resp.TargetState = targetState
+	resp.TargetPrivate = moveResp.TargetPrivate
+
+	return resp, nil
+}
+
 func (p *provider6) ReadDataSource(_ context.Context, req *tfplugin6.ReadDataSource_Request) (*tfplugin6.ReadDataSource_Response, error) {
 	resp := &tfplugin6.ReadDataSource_Response{}
 	ty := p.schema.DataSources[req.TypeName].Block.ImpliedType()
@@ -386,6 +423,74 @@ func (p *provider6) ReadDataSource(_ context.Context, req *tfplugin6.ReadDataSou
 	return resp, nil
 }
 
+func (p *provider6) GetFunctions(context.Context, *tfplugin6.GetFunctions_Request) (*tfplugin6.GetFunctions_Response, error) {
+	resp := &tfplugin6.GetFunctions_Response{}
+
+	fnResp := p.provider.GetFunctions()
+	resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, fnResp.Diagnostics)
+	if fnResp.Diagnostics.HasErrors() {
+		return resp, nil
+	}
+
+	funcs, err := functionsToProto6(fnResp.Functions)
+	if err != nil {
+		resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, err)
+		return resp, nil
+	}
+	resp.Functions = funcs
+
+	return resp, nil
+}
+
+func (p *provider6) CallFunction(_ context.Context, req *tfplugin6.CallFunction_Request) (*tfplugin6.CallFunction_Response, error) {
+	resp := &tfplugin6.CallFunction_Response{}
+
+	spec, ok := p.schema.Functions[req.Name]
+	if !ok {
+		resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, fmt.Errorf("unknown function %q", req.Name))
+		return resp, nil
+	}
+
+	args := make([]cty.Value, len(req.Arguments))
+	for i, arg := range req.Arguments {
+		var param providers.FunctionParameterSpec
+		switch {
+		case i < len(spec.Parameters):
+			param = spec.Parameters[i]
+		case spec.VariadicParameter != nil:
+			param = *spec.VariadicParameter
+		default:
+			resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, fmt.Errorf("too many arguments for function %q", req.Name))
+			return resp, nil
+		}
+
+		var err error
+		args[i], err = decodeDynamicValue6(arg, param.Type)
+		if err != nil {
+			resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, err)
+			return resp, nil
+		}
+	}
+
+	callResp := p.provider.CallFunction(providers.CallFunctionRequest{
+		Name:      req.Name,
+		Arguments: args,
+	})
+	resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, callResp.Diagnostics)
+	if callResp.Diagnostics.HasErrors() {
+		return resp, nil
+	}
+
+	var err error
+	resp.Result, err = encodeDynamicValue6(callResp.Result, spec.Return)
+	if err != nil {
+		resp.Diagnostics = convert.AppendProtoDiag(resp.Diagnostics, err)
+		return resp, nil
+	}
+
+	return resp, nil
+}
+
 func (p *provider6) StopProvider(context.Context, *tfplugin6.StopProvider_Request) (*tfplugin6.StopProvider_Response, error) {
 	resp := &tfplugin6.StopProvider_Response{}
 	err := p.provider.Stop()
@@ -420,3 +525,15 @@ func encodeDynamicValue6(v cty.Value, ty cty.Type) (*tfplugin6.DynamicValue, err
 		Msgpack: mp,
 	}, err
 }
+
+func functionsToProto6(funcs map[string]providers.FunctionSpec) (map[string]*tfplugin6.Function, error) {
+	ret := make(map[string]*tfplugin6.Function, len(funcs))
+	for name, spec := range funcs {
+		fn, err := convert.FunctionSpecToProto(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid definition for function %q: %w", name, err)
+		}
+		ret[name] = fn
+	}
+	return ret, nil
+}
@@ -163,7 +163,7 @@ func DirFromModule(ctx context.Context, loader *configload.Loader, rootDir, modu
 		Key: "",
 		Dir: rootDir,
 	}
-	fetcher := getmodules.NewPackageFetcher()
+	fetcher := getmodules.NewPackageFetcher(reg.CredentialsSource())
 
 	walker := inst.moduleInstallWalker(ctx, instManifest, true, wrapHooks, fetcher)
 	_, cDiags := inst.installDescendentModules(fakeRootModule, instManifest, walker, true)
@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package initwd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/opentofu/opentofu/internal/copy"
+	"github.com/opentofu/opentofu/internal/flock"
+)
+
+// moduleCache is a directory of module packages that is shared between
+// working directories, so that each package need only be downloaded once.
+//
+// Each package in the cache has a key that identifies exactly which content
+// it has, such as a registry module address with a version number, or a git
+// repository address with a commit. Packages whose content can't be
+// identified in that way are never cached.
+//
+// Each package is in a subdirectory named after a hash of its key, with a
+// lock file alongside it that serializes changes to it between concurrent
+// OpenTofu processes. Packages are installed into working directories by
+// hard-linking their files where possible, and otherwise by copying them,
+// so it's important not to modify the files of an installed module package.
+type moduleCache struct {
+	dir string
+}
+
+// moduleCacheKey returns the key for the package at the given package
+// address, with the content identified by the given version or commit.
+func moduleCacheKey(packageAddr string, versionOrCommit string) string {
+	return packageAddr + " " + versionOrCommit
+}
+
+// installPackage installs the package with the given key into instPath from
+// the cache if it's present there. Otherwise, it calls fetch to install the
+// package into instPath directly and then adds the result to the cache.
+//
+// The lock for the package is held throughout, so that concurrent processes
+// that need the same package wait for the first one to fetch it rather than
+// all fetching it at once. Errors from the cache itself are only logged, and
+// fetch is called instead, because the cache is only an optimization.
+func (c *moduleCache) installPackage(ctx context.Context, key string, instPath string, fetch func() error) error {
+	entry := c.entryPath(key)
+	unlock, err := flock.Lock(ctx, entry+".lock")
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		log.Printf("[WARN] ModuleInstaller: failed to lock module cache entry for %s: %s", key, err)
+		return fetch()
+	}
+	defer unlock()
+
+	if installed, err := c.installLocked(key, entry, instPath); err != nil {
+		log.Printf("[WARN] ModuleInstaller: failed to install %s from the module cache: %s", key, err)
+		if err := os.RemoveAll(instPath); err != nil {
+			return err
+		}
+	} else if installed {
+		return nil
+	}
+
+	if err := fetch(); err != nil {
+		return err
+	}
+	if err := c.storeLocked(key, entry, instPath); err != nil {
+		log.Printf("[WARN] ModuleInstaller: failed to add %s to the module cache: %s", key, err)
+	}
+	return nil
+}
+
+// install installs the package with the given key into instPath from the
+// cache, returning false if there is no such package in the cache or if it
+// can't be installed.
+func (c *moduleCache) install(ctx context.Context, key string, instPath string) bool {
+	entry := c.entryPath(key)
+	unlock, err := flock.Lock(ctx, entry+".lock")
+	if err != nil {
+		log.Printf("[WARN] ModuleInstaller: failed to lock module cache entry for %s: %s", key, err)
+		return false
+	}
+	defer unlock()
+
+	installed, err := c.installLocked(key, entry, instPath)
+	if err != nil {
+		log.Printf("[WARN] ModuleInstaller: failed to install %s from the module cache: %s", key, err)
+		os.RemoveAll(instPath)
+		return false
+	}
+	return installed
+}
+
+// store adds a copy of the package already installed at instPath to the
+// cache with the given key, unless the cache already has a package with
+// that key.
+func (c *moduleCache) store(ctx context.Context, key string, instPath string) {
+	entry := c.entryPath(key)
+	unlock, err := flock.Lock(ctx, entry+".lock")
+	if err != nil {
+		log.Printf("[WARN] ModuleInstaller: failed to lock module cache entry for %s: %s", key, err)
+		return
+	}
+	defer unlock()
+
+	if err := c.storeLocked(key, entry, instPath); err != nil {
+		log.Printf("[WARN] ModuleInstaller: failed to add %s to the module cache: %s", key, err)
+	}
+}
+
+func (c *moduleCache) installLocked(key string, entry string, instPath string) (bool, error) {
+	if _, err := os.Stat(entry); os.IsNotExist(err) {
+		log.Printf("[TRACE] ModuleInstaller: %s is not in the module cache", key)
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	log.Printf("[TRACE] ModuleInstaller: installing %s from the module cache at %s", key, entry)
+	if err := os.RemoveAll(instPath); err != nil {
+		return false, err
+	}
+	return true, copyModulePackage(instPath, entry, true)
+}
+
+func (c *moduleCache) storeLocked(key string, entry string, instPath string) error {
+	if _, err := os.Stat(entry); err == nil {
+		return nil
+	}
+
+	// We copy into a temporary directory first and then rename it into
+	// place, so that an entry is never left incomplete if we fail partway.
+	tmp, err := os.MkdirTemp(c.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	if err := copyModulePackage(tmp, instPath, false); err != nil {
+		return err
+	}
+
+	log.Printf("[TRACE] ModuleInstaller: adding %s to the module cache at %s", key, entry)
+	return os.Rename(tmp, entry)
+}
+
+func (c *moduleCache) entryPath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+// copyModulePackage copies all of the files in the package at src, including
+// any version control metadata, into dst. If link is set then it hard-links
+// the files where possible, instead of copying them.
+func copyModulePackage(dst string, src string, link bool) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case d.IsDir():
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		case d.Type()&fs.ModeSymlink != 0:
+			linkTarget, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(linkTarget, target)
+		case d.Type().IsRegular():
+			if link {
+				if err := os.Link(path, target); err == nil {
+					return nil
+				}
+				// Hard links aren't possible across filesystems, or on
+				// some filesystems at all, so we'll copy instead.
+			}
+			return copy.CopyFile(path, target)
+		default:
+			return fmt.Errorf("%s is not a regular file, directory, or symbolic link", rel)
+		}
+	})
+}
@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package initwd
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/configs/configload"
+	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/registry"
+)
+
+func TestModuleCache_installPackage(t *testing.T) {
+	cache := &moduleCache{dir: t.TempDir()}
+	const key = "example.com/foo/bar/baz 1.0.0"
+
+	var fetches atomic.Int32
+	fetch := func(instPath string) func() error {
+		return func() error {
+			fetches.Add(1)
+			writeFile(t, filepath.Join(instPath, "main.tf"), "# main")
+			writeFile(t, filepath.Join(instPath, ".git", "HEAD"), "ref: refs/heads/main\n")
+			return nil
+		}
+	}
+
+	// Concurrent installers of the same package must wait for whichever
+	// gets the lock first to fetch it, and then install it from the cache.
+	dir := t.TempDir()
+	var wg sync.WaitGroup
+	for n := 0; n < 8; n++ {
+		instPath := filepath.Join(dir, string(rune('a'+n)))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := cache.installPackage(context.Background(), key, instPath, fetch(instPath)); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("package was fetched %d times; want 1", got)
+	}
+	for n := 0; n < 8; n++ {
+		instPath := filepath.Join(dir, string(rune('a'+n)))
+		for _, name := range []string{"main.tf", ".git/HEAD"} {
+			if _, err := os.Stat(filepath.Join(instPath, filepath.FromSlash(name))); err != nil {
+				t.Errorf("missing %s: %s", name, err)
+			}
+		}
+	}
+
+	if !cache.install(context.Background(), key, filepath.Join(dir, "z")) {
+		t.Errorf("package was not installed from the cache")
+	}
+	if cache.install(context.Background(), "example.com/foo/bar/baz 2.0.0", filepath.Join(dir, "y")) {
+		t.Errorf("uncached package was installed from the cache")
+	}
+}
+
+func TestModuleInstaller_globalCache(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	repoDir := t.TempDir()
+	writeFile(t, filepath.Join(repoDir, "main.tf"), `output "greeting" { value = "hello" }`)
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "main.tf"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %s\n%s", args, err, out)
+		}
+	}
+
+	rootDir := t.TempDir()
+	writeFile(t, filepath.Join(rootDir, "main.tf"), `module "child" { source = "git::file://`+filepath.ToSlash(repoDir)+`" }`)
+
+	cacheDir := t.TempDir()
+	locks := depsfile.NewLocks()
+	install := func() string {
+		t.Helper()
+		loader, done := configload.NewLoaderForTests(t)
+		t.Cleanup(done)
+		inst := NewModuleInstaller(loader.ModulesDir(), loader, registry.NewClient(nil, nil))
+		inst.SetDependencyLocks(locks)
+		inst.SetGlobalCacheDir(cacheDir)
+		if _, diags := inst.InstallModules(context.Background(), rootDir, "tests", false, false, ModuleInstallHooksImpl{}); diags.HasErrors() {
+			t.Fatal(diags.Err())
+		}
+		return filepath.Join(loader.ModulesDir(), "child")
+	}
+
+	install()
+	lock := locks.Module("child")
+	if lock == nil || lock.Commit() == "" {
+		t.Fatalf("no commit was locked for module.child: %#v", lock)
+	}
+
+	// Once the package is cached, another working directory can install it
+	// without the original repository.
+	if err := os.RemoveAll(repoDir); err != nil {
+		t.Fatal(err)
+	}
+	instPath := install()
+	if got := gitHeadCommit(instPath); got != lock.Commit() {
+		t.Errorf("wrong commit %q installed from the cache; want %q", got, lock.Commit())
+	}
+	if got := locks.Module("child"); !got.Equal(lock) {
+		t.Errorf("lock changed from %#v to %#v", lock, got)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configload"
+	"github.com/opentofu/opentofu/internal/depsfile"
 	"github.com/opentofu/opentofu/internal/getmodules"
 	"github.com/opentofu/opentofu/internal/modsdir"
 	"github.com/opentofu/opentofu/internal/registry"
@@ -41,6 +42,19 @@ type ModuleInstaller struct {
 	// The keys in moduleVersionsUrl are the moduleVersion struct below and
 	// addresses and the values are underlying remote source addresses.
 	registryPackageSources map[moduleVersion]addrs.ModuleSourceRemote
+
+	// locks, if not nil, are the dependency locks that newly-installed
+	// remote module packages are verified against and then recorded in.
+	// lockedModules collects the keys of the remote modules that are part
+	// of the configuration during InstallModules, so that the locks for any
+	// other modules can be removed afterwards.
+	locks         *depsfile.Locks
+	lockedModules map[string]struct{}
+
+	// cache, if not nil, is a directory of module packages shared with
+	// other working directories, which the installer uses in preference to
+	// downloading a package whenever it can.
+	cache *moduleCache
 }
 
 type moduleVersion struct {
@@ -58,6 +72,40 @@ func NewModuleInstaller(modsDir string, loader *configload.Loader, reg *registry
 	}
 }
 
+// SetDependencyLocks makes the installer verify remote module packages
+// against the module locks in the given dependency locks as it installs
+// them, and update those locks in-place to describe the packages that were
+// installed.
+//
+// A module package that was already installed is not verified again, but a
+// lock is still recorded for it if there isn't one already. Locks for modules
+// that are no longer in the configuration are removed once all of the modules
+// have been installed successfully.
+//
+// If SetDependencyLocks is never called then the installer doesn't verify or
+// record any module locks.
+func (i *ModuleInstaller) SetDependencyLocks(locks *depsfile.Locks) {
+	i.locks = locks
+}
+
+// SetGlobalCacheDir makes the installer use the given directory, which must
+// already exist, as a cache of remote module packages that is shared between
+// working directories.
+//
+// Only packages whose content is identified exactly by their address can be
+// cached: registry modules, which are identified by their version, and
+// modules from git repositories, which are identified by their commit. A
+// cached module from a git repository is used only if the dependency lock
+// file already records its commit, because otherwise the installer can't
+// know which commit a branch or tag refers to without fetching it anyway.
+//
+// The cache is safe for concurrent use by multiple OpenTofu processes.
+// Packages are installed from it by hard-linking their files where possible,
+// so installed module packages must not be modified.
+func (i *ModuleInstaller) SetGlobalCacheDir(dir string) {
+	i.cache = &moduleCache{dir: dir}
+}
+
 // InstallModules analyses the root module in the given directory and installs
 // all of its direct and transitive dependencies into the given modules
 // directory, which must already exist.
@@ -121,7 +169,7 @@ func (i *ModuleInstaller) InstallModules(ctx context.Context, rootDir, testsDir
 		return nil, diags
 	}
 
-	fetcher := getmodules.NewPackageFetcher()
+	fetcher := getmodules.NewPackageFetcher(i.reg.CredentialsSource())
 
 	if hooks == nil {
 		// Use our no-op implementation as a placeholder
@@ -134,11 +182,18 @@ func (i *ModuleInstaller) InstallModules(ctx context.Context, rootDir, testsDir
 		Key: "",
 		Dir: rootDir,
 	}
+	i.lockedModules = make(map[string]struct{})
 	walker := i.moduleInstallWalker(ctx, manifest, upgrade, hooks, fetcher)
 
 	cfg, instDiags := i.installDescendentModules(rootMod, manifest, walker, installErrsOnly)
 	diags = append(diags, instDiags...)
 
+	// If anything failed then we may not have visited every module, so we
+	// can only tell which locks are no longer needed if we succeeded.
+	if !diags.HasErrors() {
+		i.pruneModuleLocks()
+	}
+
 	return cfg, diags
 }
 
@@ -172,6 +227,7 @@ func (i *ModuleInstaller) moduleInstallWalker(ctx context.Context, manifest mods
 
 			key := manifest.ModuleKey(req.Path)
 			instPath := i.packageInstallPath(req.Path)
+			locked := i.lockedModule(key, req.SourceAddr, upgrade)
 
 			log.Printf("[DEBUG] Module installer: begin %s", key)
 
@@ -190,6 +246,9 @@ func (i *ModuleInstaller) moduleInstallWalker(ctx context.Context, manifest mods
 				case record.Version != nil && !req.VersionConstraint.Required.Check(record.Version):
 					log.Printf("[TRACE] ModuleInstaller: %s version %s no longer compatible with constraints %s", key, record.Version, req.VersionConstraint.Required)
 					replace = true
+				case record.Version != nil && locked != nil && locked.Version() != nil && !sameModuleVersion(record.Version, locked.Version()):
+					log.Printf("[TRACE] ModuleInstaller: %s version %s differs from version %s in the dependency lock file", key, record.Version, locked.Version())
+					replace = true
 				}
 			}
 
@@ -253,6 +312,9 @@ func (i *ModuleInstaller) moduleInstallWalker(ctx context.Context, manifest mods
 					}
 
 					log.Printf("[TRACE] ModuleInstaller: Module installer: %s %s already installed in %s", key, record.Version, record.Dir)
+					if _, local := req.SourceAddr.(addrs.ModuleSourceLocal); !local {
+						diags = diags.Extend(i.lockInstalledModulePackage(req, key, instPath, record.Version))
+					}
 					return mod, record.Version, diags
 				}
 			}
@@ -272,14 +334,26 @@ func (i *ModuleInstaller) moduleInstallWalker(ctx context.Context, manifest mods
 
 			case addrs.ModuleSourceRegistry:
 				log.Printf("[TRACE] ModuleInstaller: %s is a registry module at %s", key, addr.String())
-				mod, v, mDiags := i.installRegistryModule(ctx, req, key, instPath, addr, manifest, hooks, fetcher)
+				mod, v, mDiags := i.installRegistryModule(ctx, req, key, instPath, addr, manifest, hooks, fetcher, locked)
 				diags = append(diags, mDiags...)
+				if mDiags.HasErrors() {
+					return mod, v, diags
+				}
+				if lDiags := i.lockModulePackage(req, key, instPath, v, "", locked); lDiags.HasErrors() {
+					return nil, nil, append(diags, i.discardModulePackage(key, instPath, manifest, lDiags)...)
+				}
 				return mod, v, diags
 
 			case addrs.ModuleSourceRemote:
 				log.Printf("[TRACE] ModuleInstaller: %s address %q will be handled by go-getter", key, addr.String())
-				mod, mDiags := i.installGoGetterModule(ctx, req, key, instPath, manifest, hooks, fetcher)
+				mod, digest, mDiags := i.installGoGetterModule(ctx, req, key, instPath, manifest, hooks, fetcher, locked)
 				diags = append(diags, mDiags...)
+				if mDiags.HasErrors() {
+					return mod, nil, diags
+				}
+				if lDiags := i.lockModulePackage(req, key, instPath, nil, digest, locked); lDiags.HasErrors() {
+					return nil, nil, append(diags, i.discardModulePackage(key, instPath, manifest, lDiags)...)
+				}
 				return mod, nil, diags
 
 			default:
@@ -291,6 +365,26 @@ func (i *ModuleInstaller) moduleInstallWalker(ctx context.Context, manifest mods
 	)
 }
 
+// discardModulePackage removes the package installed for the module with the
+// given key, along with its manifest record, so that a package that failed
+// verification won't be used by a later run. It returns the given
+// diagnostics along with any diagnostics about removing the package.
+func (i *ModuleInstaller) discardModulePackage(key string, instPath string, manifest modsdir.Manifest, diags hcl.Diagnostics) hcl.Diagnostics {
+	delete(manifest, key)
+	if err := os.RemoveAll(instPath); err != nil {
+		log.Printf("[TRACE] ModuleInstaller: failed to remove %s: %s", key, err)
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to remove local module cache",
+			Detail: fmt.Sprintf(
+				"OpenTofu tried to remove %s after it failed verification, but encountered an error: %s",
+				instPath, err,
+			),
+		})
+	}
+	return diags
+}
+
 func (i *ModuleInstaller) installDescendentModules(rootMod *configs.Module, manifest modsdir.Manifest, installWalker configs.ModuleWalker, installErrsOnly bool) (*configs.Config, tfdiags.Diagnostics) {
 	var diags tfdiags.Diagnostics
 
@@ -410,7 +504,7 @@ func (i *ModuleInstaller) installLocalModule(req *configs.ModuleRequest, key str
 	return mod, diags
 }
 
-func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *configs.ModuleRequest, key string, instPath string, addr addrs.ModuleSourceRegistry, manifest modsdir.Manifest, hooks ModuleInstallHooks, fetcher *getmodules.PackageFetcher) (*configs.Module, *version.Version, hcl.Diagnostics) {
+func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *configs.ModuleRequest, key string, instPath string, addr addrs.ModuleSourceRegistry, manifest modsdir.Manifest, hooks ModuleInstallHooks, fetcher *getmodules.PackageFetcher, locked *depsfile.ModuleLock) (*configs.Module, *version.Version, hcl.Diagnostics) {
 	var diags hcl.Diagnostics
 
 	hostname := addr.Package.Host
@@ -486,6 +580,7 @@ func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *config
 
 	var latestMatch *version.Version
 	var latestVersion *version.Version
+	var lockedMatch *version.Version
 	for _, mv := range modMeta.Versions {
 		v, err := version.NewVersion(mv.Version)
 		if err != nil {
@@ -552,6 +647,9 @@ func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *config
 			if latestMatch == nil || v.GreaterThan(latestMatch) {
 				latestMatch = v
 			}
+			if locked != nil && sameModuleVersion(v, locked.Version()) {
+				lockedMatch = v
+			}
 		}
 	}
 
@@ -575,6 +673,14 @@ func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *config
 		return nil, nil, diags
 	}
 
+	// If the version selected in the dependency lock file is still available
+	// and still meets the version constraints then we'll install that,
+	// rather than the latest version.
+	if lockedMatch != nil {
+		log.Printf("[TRACE] ModuleInstaller: %s selecting %s from the dependency lock file instead of the latest match %s", key, lockedMatch, latestMatch)
+		latestMatch = lockedMatch
+	}
+
 	// Report up to the caller that we're about to start downloading.
 	hooks.Download(key, packageAddr.String(), latestMatch)
 
@@ -625,7 +731,18 @@ func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *config
 
 	log.Printf("[TRACE] ModuleInstaller: %s %s %s is available at %q", key, packageAddr, latestMatch, dlAddr.Package)
 
-	err := fetcher.FetchPackage(ctx, instPath, dlAddr.Package.String())
+	// The address that the registry returns may not be stable, such as if
+	// it's a pre-signed URL, so we cache registry packages by their registry
+	// address and version instead.
+	fetch := func() error {
+		return fetcher.FetchPackage(ctx, instPath, dlAddr.Package.String())
+	}
+	var err error
+	if i.cache != nil {
+		err = i.cache.installPackage(ctx, moduleCacheKey(packageAddr.String(), latestMatch.String()), instPath, fetch)
+	} else {
+		err = fetch()
+	}
 	if errors.Is(err, context.Canceled) {
 		diags = diags.Append(&hcl.Diagnostic{
 			Severity: hcl.DiagError,
@@ -695,7 +812,7 @@ func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *config
 	return mod, latestMatch, diags
 }
 
-func (i *ModuleInstaller) installGoGetterModule(ctx context.Context, req *configs.ModuleRequest, key string, instPath string, manifest modsdir.Manifest, hooks ModuleInstallHooks, fetcher *getmodules.PackageFetcher) (*configs.Module, hcl.Diagnostics) {
+func (i *ModuleInstaller) installGoGetterModule(ctx context.Context, req *configs.ModuleRequest, key string, instPath string, manifest modsdir.Manifest, hooks ModuleInstallHooks, fetcher *getmodules.PackageFetcher, locked *depsfile.ModuleLock) (*configs.Module, string, hcl.Diagnostics) {
 	var diags hcl.Diagnostics
 
 	// Report up to the caller that we're about to start downloading.
@@ -710,11 +827,35 @@ func (i *ModuleInstaller) installGoGetterModule(ctx context.Context, req *config
 			Detail:   fmt.Sprintf("Cannot apply a version constraint to module %q (at %s:%d) because it doesn't come from a module registry.", req.Name, req.CallRange.Filename, req.CallRange.Start.Line),
 			Subject:  req.CallRange.Ptr(),
 		})
-		return nil, diags
+		return nil, "", diags
 	}
 
-	err := fetcher.FetchPackage(ctx, instPath, packageAddr.String())
-	if err != nil {
+	// A package from an OCI registry that was locked to a manifest digest is
+	// installed from that digest, even if the source address selects it by
+	// tag, so that moving the tag has no effect until the lock is upgraded.
+	fetchAddr := packageAddr.String()
+	if locked != nil && locked.Digest() != "" {
+		pinned, err := getmodules.OCIPackageAtDigest(fetchAddr, locked.Digest())
+		if err != nil {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid module lock",
+				Detail:   fmt.Sprintf("Cannot install module %q (%s:%d) from the manifest digest recorded in the dependency lock file: %s.", req.Name, req.CallRange.Filename, req.CallRange.Start.Line, err),
+				Subject:  req.CallRange.Ptr(),
+			})
+			return nil, "", diags
+		}
+		log.Printf("[TRACE] ModuleInstaller: %s is locked to %s", key, pinned)
+		fetchAddr = pinned
+	}
+
+	// A git repository can only be installed from the module cache if we
+	// already know which commit we need, but we can add any package that we
+	// check out from a git repository to the cache for future use.
+	cached := i.cache != nil && locked != nil && locked.Commit() != "" && i.cache.install(ctx, moduleCacheKey(packageAddr.String(), locked.Commit()), instPath)
+	if cached {
+		log.Printf("[TRACE] ModuleInstaller: %s %q was installed from the module cache", key, addr)
+	} else if err := fetcher.FetchPackage(ctx, instPath, fetchAddr); err != nil {
 		// go-getter generates a poor error for an invalid relative path, so
 		// we'll detect that case and generate a better one.
 		if _, ok := err.(*getmodules.MaybeRelativePathErr); ok {
@@ -746,7 +887,13 @@ func (i *ModuleInstaller) installGoGetterModule(ctx context.Context, req *config
 				Subject:  req.CallRange.Ptr(),
 			})
 		}
-		return nil, diags
+		return nil, "", diags
+	}
+	digest := fetcher.OCIManifestDigest(fetchAddr)
+	if i.cache != nil && !cached {
+		if commit := gitHeadCommit(instPath); commit != "" {
+			i.cache.store(ctx, moduleCacheKey(packageAddr.String(), commit), instPath)
+		}
 	}
 
 	modDir, err := getmodules.ExpandSubdirGlobs(instPath, addr.Subdir)
@@ -756,7 +903,7 @@ func (i *ModuleInstaller) installGoGetterModule(ctx context.Context, req *config
 			Summary:  "Failed to expand subdir globs",
 			Detail:   err.Error(),
 		})
-		return nil, diags
+		return nil, "", diags
 	}
 
 	log.Printf("[TRACE] ModuleInstaller: %s %q was downloaded to %s", key, addr, modDir)
@@ -792,7 +939,7 @@ func (i *ModuleInstaller) installGoGetterModule(ctx context.Context, req *config
 	log.Printf("[DEBUG] Module installer: %s installed at %s", key, modDir)
 	hooks.Install(key, nil, modDir)
 
-	return mod, diags
+	return mod, digest, diags
 }
 
 func (i *ModuleInstaller) packageInstallPath(modulePath addrs.Module) string {
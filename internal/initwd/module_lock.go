@@ -0,0 +1,254 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package initwd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"golang.org/x/mod/sumdb/dirhash"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/getproviders"
+)
+
+// lockedModule returns the existing dependency lock for the module with the
+// given key, but only if it was recorded for the given source address and the
+// caller didn't ask to upgrade modules. Otherwise, the existing lock (if any)
+// is not relevant to which package gets installed, and so the result is nil.
+func (i *ModuleInstaller) lockedModule(key string, source addrs.ModuleSource, upgrade bool) *depsfile.ModuleLock {
+	if i.locks == nil || upgrade {
+		return nil
+	}
+	lock := i.locks.Module(key)
+	if lock == nil || lock.Source() != source.String() {
+		return nil
+	}
+	return lock
+}
+
+// lockModulePackage verifies the remote package that was just installed at
+// instPath for the module with the given key against the given existing lock,
+// and then records the package in the installer's dependency locks.
+//
+// digest is the digest of the OCI manifest that the package was installed
+// from, or an empty string if it didn't come from an OCI registry.
+//
+// The given lock should be the result of lockedModule, so it is nil if any
+// newly-installed package is acceptable.
+func (i *ModuleInstaller) lockModulePackage(req *configs.ModuleRequest, key string, instPath string, v *version.Version, digest string, locked *depsfile.ModuleLock) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	if i.locks == nil {
+		return diags
+	}
+	i.lockedModules[key] = struct{}{}
+
+	hash, err := modulePackageHash(instPath)
+	if err != nil {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to compute module package checksum",
+			Detail:   fmt.Sprintf("Could not compute a checksum of the package installed for module %q (%s:%d): %s.", req.Name, req.CallRange.Filename, req.CallRange.Start.Line, err),
+			Subject:  req.CallRange.Ptr(),
+		})
+		return diags
+	}
+	commit := gitHeadCommit(instPath)
+
+	// If the locked version is no longer available, or no longer meets the
+	// version constraints, then we'll have selected a different version and
+	// so it's expected that the package will be different too.
+	if locked != nil && sameModuleVersion(locked.Version(), v) {
+		switch {
+		case locked.Commit() != "" && commit != locked.Commit():
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Module package doesn't match the dependency lock file",
+				Detail: fmt.Sprintf(
+					"The package for module %q (%s:%d) was checked out at commit %s, but the dependency lock file requires commit %s.\n\nIf this change is expected, run \"tofu init -upgrade\" to record the new commit in the dependency lock file.",
+					req.Name, req.CallRange.Filename, req.CallRange.Start.Line, commit, locked.Commit(),
+				),
+				Subject: req.CallRange.Ptr(),
+			})
+		case locked.Digest() != "" && digest != locked.Digest():
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Module package doesn't match the dependency lock file",
+				Detail: fmt.Sprintf(
+					"The package for module %q (%s:%d) was installed from manifest digest %s, but the dependency lock file requires digest %s.\n\nIf this change is expected, run \"tofu init -upgrade\" to record the new digest in the dependency lock file.",
+					req.Name, req.CallRange.Filename, req.CallRange.Start.Line, digest, locked.Digest(),
+				),
+				Subject: req.CallRange.Ptr(),
+			})
+		case hash != locked.Hash():
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Module package doesn't match the dependency lock file",
+				Detail: fmt.Sprintf(
+					"The package for module %q (%s:%d) has checksum %s, but the dependency lock file requires checksum %s. The package may have been changed since it was locked.\n\nIf this change is expected, run \"tofu init -upgrade\" to record the new checksum in the dependency lock file.",
+					req.Name, req.CallRange.Filename, req.CallRange.Start.Line, hash, locked.Hash(),
+				),
+				Subject: req.CallRange.Ptr(),
+			})
+		}
+		if diags.HasErrors() {
+			return diags
+		}
+	}
+
+	log.Printf("[TRACE] ModuleInstaller: %s package has checksum %s", key, hash)
+	i.locks.SetModule(key, req.SourceAddr.String(), v, commit, digest, hash)
+	return diags
+}
+
+// lockInstalledModulePackage records a lock for the remote package that was
+// already installed at instPath for the module with the given key by an
+// earlier run, if there isn't already a lock for it.
+//
+// This deals with modules that were installed before the dependency lock
+// file was created, or by an earlier version of OpenTofu which didn't lock
+// modules.
+func (i *ModuleInstaller) lockInstalledModulePackage(req *configs.ModuleRequest, key string, instPath string, v *version.Version) hcl.Diagnostics {
+	if i.locks == nil {
+		return nil
+	}
+	if i.lockedModule(key, req.SourceAddr, false) != nil {
+		i.lockedModules[key] = struct{}{}
+		return nil
+	}
+	return i.lockModulePackage(req, key, instPath, v, "", nil)
+}
+
+// pruneModuleLocks removes the locks for any modules that were not part of
+// the configuration during the most recent call to InstallModules.
+func (i *ModuleInstaller) pruneModuleLocks() {
+	if i.locks == nil {
+		return
+	}
+	for key := range i.locks.AllModules() {
+		if _, ok := i.lockedModules[key]; !ok {
+			log.Printf("[TRACE] ModuleInstaller: removing lock for %s, which is no longer in the configuration", key)
+			i.locks.RemoveModule(key)
+		}
+	}
+}
+
+func sameModuleVersion(a, b *version.Version) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.String() == b.String()
+}
+
+// modulePackageHash computes a checksum of the content of the module package
+// in the given directory, using the same "h1:" scheme as for provider
+// packages.
+//
+// Unlike getproviders.PackageHashV1, this ignores any ".git" directories so
+// that the result depends only on the content of the files that were checked
+// out, and not on how they were cloned. The target of each symbolic link is
+// hashed in place of its content, because symbolic links in module packages
+// often refer to directories.
+func modulePackageHash(dir string) (getproviders.Hash, error) {
+	var files []string
+	symlinks := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" && path != dir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if d.Type()&fs.ModeSymlink != 0 {
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			symlinks[name] = filepath.ToSlash(target)
+		}
+		files = append(files, name)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	s, err := dirhash.Hash1(files, func(name string) (io.ReadCloser, error) {
+		if target, ok := symlinks[name]; ok {
+			return io.NopCloser(strings.NewReader(target)), nil
+		}
+		return os.Open(filepath.Join(dir, filepath.FromSlash(name)))
+	})
+	return getproviders.Hash(s), err
+}
+
+// gitCommitPattern matches the full SHA-1 or SHA-256 object names that git
+// uses to identify commits.
+var gitCommitPattern = regexp.MustCompile(`^(?:[0-9a-f]{40}|[0-9a-f]{64})$`)
+
+// gitHeadCommit returns the commit that is checked out in the git working
+// tree in the given directory, or an empty string if the directory isn't
+// the root of a git working tree or its HEAD can't be resolved.
+//
+// This reads the repository metadata directly, rather than running git, so
+// that it works the same regardless of which git version is installed.
+func gitHeadCommit(dir string) string {
+	gitDir := filepath.Join(dir, ".git")
+	head, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return ""
+	}
+	ref := strings.TrimSpace(string(head))
+	if !strings.HasPrefix(ref, "ref: ") {
+		// A detached HEAD, as when checking out a tag, contains the commit
+		// id directly.
+		if gitCommitPattern.MatchString(ref) {
+			return ref
+		}
+		return ""
+	}
+	ref = strings.TrimPrefix(ref, "ref: ")
+
+	if commit, err := os.ReadFile(filepath.Join(gitDir, filepath.FromSlash(ref))); err == nil {
+		if commit := strings.TrimSpace(string(commit)); gitCommitPattern.MatchString(commit) {
+			return commit
+		}
+		return ""
+	}
+
+	// Refs that haven't been updated since the repository was cloned may
+	// only be recorded in the packed-refs file, as "<commit> <ref>" lines.
+	f, err := os.Open(filepath.Join(gitDir, "packed-refs"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		commit, name, ok := strings.Cut(sc.Text(), " ")
+		if ok && name == ref && gitCommitPattern.MatchString(commit) {
+			return commit
+		}
+	}
+	return ""
+}
@@ -0,0 +1,292 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package initwd
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/configs/configload"
+	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/registry"
+)
+
+func TestModuleInstaller_dependencyLocks(t *testing.T) {
+	var mu sync.Mutex
+	pkg := testModuleZip(t, `output "greeting" { value = "hello" }`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/zip")
+		w.Write(pkg)
+	}))
+	defer server.Close()
+	setPackage := func(content string) {
+		mu.Lock()
+		defer mu.Unlock()
+		pkg = testModuleZip(t, content)
+	}
+
+	rootDir := t.TempDir()
+	source := server.URL + "/child.zip"
+	writeFile(t, filepath.Join(rootDir, "main.tf"), `module "child" { source = "`+source+`" }`)
+
+	loader, done := configload.NewLoaderForTests(t)
+	defer done()
+	install := func(locks *depsfile.Locks, upgrade bool) *depsfile.ModuleLock {
+		t.Helper()
+		inst := NewModuleInstaller(loader.ModulesDir(), loader, registry.NewClient(nil, nil))
+		inst.SetDependencyLocks(locks)
+		_, diags := inst.InstallModules(context.Background(), rootDir, "tests", upgrade, false, ModuleInstallHooksImpl{})
+		if diags.HasErrors() {
+			t.Fatal(diags.Err())
+		}
+		return locks.Module("child")
+	}
+	reinstall := func(locks *depsfile.Locks, upgrade bool) *depsfile.ModuleLock {
+		t.Helper()
+		// Removing the module manifest forces the installer to download
+		// the package again.
+		if err := os.RemoveAll(loader.ModulesDir()); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(loader.ModulesDir(), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		return install(locks, upgrade)
+	}
+
+	locks := depsfile.NewLocks()
+	lock := install(locks, false)
+	if lock == nil {
+		t.Fatal("no lock for module.child after installation")
+	}
+	if got, want := lock.Source(), source; got != want {
+		t.Errorf("wrong source %q; want %q", got, want)
+	}
+	if lock.Version() != nil || lock.Commit() != "" {
+		t.Errorf("unexpected version %s or commit %q", lock.Version(), lock.Commit())
+	}
+	wantHash, err := modulePackageHash(filepath.Join(loader.ModulesDir(), "child"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := lock.Hash(); got != wantHash {
+		t.Errorf("wrong hash %s; want %s", got, wantHash)
+	}
+
+	t.Run("unchanged package", func(t *testing.T) {
+		if got := reinstall(locks, false); !got.Equal(lock) {
+			t.Errorf("lock changed from %#v to %#v", lock, got)
+		}
+	})
+
+	t.Run("changed package", func(t *testing.T) {
+		setPackage(`output "greeting" { value = "goodbye" }`)
+		if err := os.RemoveAll(loader.ModulesDir()); err != nil {
+			t.Fatal(err)
+		}
+		os.MkdirAll(loader.ModulesDir(), os.ModePerm)
+
+		inst := NewModuleInstaller(loader.ModulesDir(), loader, registry.NewClient(nil, nil))
+		inst.SetDependencyLocks(locks)
+		_, diags := inst.InstallModules(context.Background(), rootDir, "tests", false, false, ModuleInstallHooksImpl{})
+		if !diags.HasErrors() {
+			t.Fatal("expected error")
+		}
+		assertDiagnosticSummary(t, diags, "Module package doesn't match the dependency lock file")
+		if got := locks.Module("child"); !got.Equal(lock) {
+			t.Errorf("lock changed from %#v to %#v", lock, got)
+		}
+		if _, err := os.Stat(filepath.Join(loader.ModulesDir(), "child")); !os.IsNotExist(err) {
+			t.Errorf("package that failed verification was not removed: %v", err)
+		}
+
+		// Upgrading accepts the new package.
+		got := reinstall(locks, true)
+		if got.Hash() == lock.Hash() {
+			t.Errorf("hash was not updated after upgrade")
+		}
+	})
+
+	t.Run("module removed", func(t *testing.T) {
+		// The loader caches the files it has already parsed, so we use a
+		// separate root module directory here.
+		rootDir = t.TempDir()
+		writeFile(t, filepath.Join(rootDir, "main.tf"), ``)
+		install(locks, false)
+		if got := locks.AllModules(); len(got) != 0 {
+			t.Errorf("locks were not removed: %#v", got)
+		}
+	})
+}
+
+func TestModuleInstaller_dependencyLocksAlreadyInstalled(t *testing.T) {
+	pkg := testModuleZip(t, `output "greeting" { value = "hello" }`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		w.Write(pkg)
+	}))
+	defer server.Close()
+
+	rootDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(rootDir, "main.tf"), []byte(`module "child" { source = "`+server.URL+`/child.zip" }`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loader, done := configload.NewLoaderForTests(t)
+	defer done()
+
+	// The first installation doesn't lock anything, as if an earlier
+	// version of OpenTofu had installed the module.
+	inst := NewModuleInstaller(loader.ModulesDir(), loader, registry.NewClient(nil, nil))
+	if _, diags := inst.InstallModules(context.Background(), rootDir, "tests", false, false, ModuleInstallHooksImpl{}); diags.HasErrors() {
+		t.Fatal(diags.Err())
+	}
+
+	server.Close()
+	locks := depsfile.NewLocks()
+	inst = NewModuleInstaller(loader.ModulesDir(), loader, registry.NewClient(nil, nil))
+	inst.SetDependencyLocks(locks)
+	if _, diags := inst.InstallModules(context.Background(), rootDir, "tests", false, false, ModuleInstallHooksImpl{}); diags.HasErrors() {
+		t.Fatal(diags.Err())
+	}
+	if locks.Module("child") == nil {
+		t.Fatal("no lock for already-installed module.child")
+	}
+}
+
+func TestModuleInstaller_lockModulePackageDigest(t *testing.T) {
+	const (
+		digest      = "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+		movedDigest = "sha256:60303ae22b998861bce3b28f33eec1be758a213c86c93c076dbe9f558c11c752"
+	)
+	instPath := t.TempDir()
+	writeFile(t, filepath.Join(instPath, "main.tf"), `output "greeting" { value = "hello" }`)
+	source, err := addrs.ParseModuleSource("oci://example.com/modules/child?tag=1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &configs.ModuleRequest{
+		Name:       "child",
+		SourceAddr: source,
+		CallRange:  hcl.Range{Filename: "main.tf", Start: hcl.InitialPos},
+	}
+
+	inst := &ModuleInstaller{
+		locks:         depsfile.NewLocks(),
+		lockedModules: make(map[string]struct{}),
+	}
+	if diags := inst.lockModulePackage(req, "child", instPath, nil, digest, nil); diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	lock := inst.locks.Module("child")
+	if got := lock.Digest(); got != digest {
+		t.Fatalf("wrong digest %q; want %q", got, digest)
+	}
+
+	// The same package from a different manifest doesn't match the lock.
+	diags := inst.lockModulePackage(req, "child", instPath, nil, movedDigest, lock)
+	if !diags.HasErrors() {
+		t.Fatal("expected error")
+	}
+	if got, want := diags[0].Summary, "Module package doesn't match the dependency lock file"; got != want {
+		t.Errorf("wrong error %q; want %q", got, want)
+	}
+	if got := inst.locks.Module("child"); !got.Equal(lock) {
+		t.Errorf("lock changed from %#v to %#v", lock, got)
+	}
+}
+
+func TestModulePackageHash(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "main.tf"), "# main")
+	want, err := modulePackageHash(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Git metadata isn't part of the package content.
+	writeFile(t, filepath.Join(dir, ".git", "HEAD"), "ref: refs/heads/main\n")
+	if got, err := modulePackageHash(dir); err != nil || got != want {
+		t.Errorf("wrong hash %s with .git directory; want %s (error %v)", got, want, err)
+	}
+
+	writeFile(t, filepath.Join(dir, "child", "main.tf"), "# child")
+	if got, err := modulePackageHash(dir); err != nil || got == want {
+		t.Errorf("hash %s didn't change after adding a file (error %v)", got, err)
+	}
+}
+
+func TestGitHeadCommit(t *testing.T) {
+	const commit = "0123456789abcdef0123456789abcdef01234567"
+	tests := map[string]map[string]string{
+		"detached": {
+			"HEAD": commit + "\n",
+		},
+		"loose ref": {
+			"HEAD":            "ref: refs/heads/main\n",
+			"refs/heads/main": commit + "\n",
+		},
+		"packed ref": {
+			"HEAD":        "ref: refs/heads/main\n",
+			"packed-refs": "# pack-refs with: peeled fully-peeled sorted\n" + commit + " refs/heads/main\n",
+		},
+	}
+	for name, files := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range files {
+				writeFile(t, filepath.Join(dir, ".git", filepath.FromSlash(name)), content)
+			}
+			if got := gitHeadCommit(dir); got != commit {
+				t.Errorf("wrong commit %q; want %q", got, commit)
+			}
+		})
+	}
+
+	t.Run("not a git repository", func(t *testing.T) {
+		if got := gitHeadCommit(t.TempDir()); got != "" {
+			t.Errorf("unexpected commit %q", got)
+		}
+	})
+}
+
+func testModuleZip(t *testing.T, content string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	f, err := w.Create("main.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func writeFile(t *testing.T, filename string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(filename), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -184,12 +184,27 @@ func (s *Scope) Functions() map[string]function.Function {
 		for name, f := range s.funcs {
 			s.funcs[name] = funcs.WithDescription(name, f)
 		}
+
+		// Provider functions carry their own descriptions, so we add them
+		// only after the built-in functions have been described.
+		for providerName, providerFuncs := range s.ProviderFunctions {
+			for name, f := range providerFuncs {
+				s.funcs[ProviderFunctionName(providerName, name)] = f
+			}
+		}
 	}
 	s.funcsLock.Unlock()
 
 	return s.funcs
 }
 
+// ProviderFunctionName returns the name by which the given function
+// contributed by the provider with the given local name can be called from
+// expressions.
+func ProviderFunctionName(providerName, funcName string) string {
+	return "provider::" + providerName + "::" + funcName
+}
+
 // experimentalFunction checks whether the given experiment is enabled for
 // the recieving scope. If so, it will return the given function verbatim.
 // If not, it will return a placeholder function that just returns an
@@ -54,6 +54,12 @@ type Scope struct {
 	// then differ during apply.
 	PureOnly bool
 
+	// ProviderFunctions are the functions contributed by the providers that
+	// the module requires, keyed by the local name of each provider and then
+	// by function name. They are available to expressions as
+	// provider::<local name>::<function name>.
+	ProviderFunctions map[string]map[string]function.Function
+
 	funcs     map[string]function.Function
 	funcsLock sync.Mutex
 
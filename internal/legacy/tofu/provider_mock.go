@@ -5,6 +5,7 @@ package tofu
 
 import (
 	"encoding/json"
+	"fmt"
 	"sync"
 
 	"github.com/zclconf/go-cty/cty"
@@ -360,6 +361,23 @@ func (p *MockProvider) ReadDataSource(r providers.ReadDataSourceRequest) provide
 	return p.ReadDataSourceResponse
 }
 
+func (p *MockProvider) GetFunctions() providers.GetFunctionsResponse {
+	// The legacy providers don't support functions.
+	return providers.GetFunctionsResponse{}
+}
+
+func (p *MockProvider) MoveResourceState(r providers.MoveResourceStateRequest) providers.MoveResourceStateResponse {
+	var resp providers.MoveResourceStateResponse
+	resp.Diagnostics = resp.Diagnostics.Append(fmt.Errorf("unsupported resource type move from %q to %q", r.SourceTypeName, r.TargetTypeName))
+	return resp
+}
+
+func (p *MockProvider) CallFunction(r providers.CallFunctionRequest) providers.CallFunctionResponse {
+	var resp providers.CallFunctionResponse
+	resp.Diagnostics = resp.Diagnostics.Append(fmt.Errorf("unsupported function %q", r.Name))
+	return resp
+}
+
 func (p *MockProvider) Close() error {
 	p.CloseCalled = true
 	return p.CloseError
@@ -2,6 +2,7 @@ package moduletest
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/hcl/v2"
 
@@ -23,9 +24,19 @@ type Run struct {
 	Index  int
 	Status Status
 
+	// ExecutionMeta records when the run block was executed and how long it
+	// took. It is nil if the run block was never executed.
+	ExecutionMeta *RunExecutionMeta
+
 	Diagnostics tfdiags.Diagnostics
 }
 
+// RunExecutionMeta contains the timing information for a single run block.
+type RunExecutionMeta struct {
+	Start    time.Time
+	Duration time.Duration
+}
+
 // Verbose is a utility struct that holds all the information required for a run
 // to render the results verbosely.
 //
@@ -0,0 +1,446 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	svchost "github.com/hashicorp/terraform-svchost"
+	svcauth "github.com/hashicorp/terraform-svchost/auth"
+
+	"github.com/opentofu/opentofu/internal/httpclient"
+)
+
+// maxManifestSize is the largest manifest or index we're willing to read
+// from a registry. The distribution specification recommends that registries
+// accept manifests of at least 4MiB, so we allow the same.
+const maxManifestSize = 4 * 1024 * 1024
+
+// Client is a client for the OCI Distribution API.
+//
+// A client can be used concurrently with any number of registries. It
+// remembers any bearer tokens it obtained from registries that use token
+// authentication, so that later requests to the same repository can reuse
+// them.
+type Client struct {
+	httpClient *http.Client
+	creds      svcauth.CredentialsSource
+
+	tokensMu sync.Mutex
+	tokens   map[string]string
+}
+
+// NewClient returns a client that makes requests using the given HTTP
+// client, or a default client if it's nil.
+//
+// If creds is not nil then any credentials it has for a registry's hostname
+// are sent as a bearer token with requests to that registry, and to the
+// token service the registry refers to, if any. Otherwise, requests are
+// anonymous.
+func NewClient(httpClient *http.Client, creds svcauth.CredentialsSource) *Client {
+	if httpClient == nil {
+		httpClient = httpclient.New()
+	}
+	return &Client{
+		httpClient: httpClient,
+		creds:      creds,
+		tokens:     make(map[string]string),
+	}
+}
+
+// ResponseError is the error returned when a registry responds to a request
+// with an unsuccessful status code.
+type ResponseError struct {
+	URL        string
+	StatusCode int
+
+	// Code and Message are from the first error in the response body, if
+	// the registry returned one in the format given in the distribution
+	// specification.
+	Code    string
+	Message string
+}
+
+func (err *ResponseError) Error() string {
+	if err.Message != "" {
+		return fmt.Sprintf("registry returned %s for %s: %s", http.StatusText(err.StatusCode), err.URL, err.Message)
+	}
+	return fmt.Sprintf("registry returned %s for %s", http.StatusText(err.StatusCode), err.URL)
+}
+
+// IsNotFound returns true if the given error is a ResponseError for a request
+// that failed because the repository or the content doesn't exist.
+func IsNotFound(err error) bool {
+	respErr, ok := err.(*ResponseError)
+	return ok && respErr.StatusCode == http.StatusNotFound
+}
+
+// IsUnauthorized returns true if the given error is a ResponseError for a
+// request that failed because the registry rejected our credentials, or
+// required credentials that we don't have.
+func IsUnauthorized(err error) bool {
+	respErr, ok := err.(*ResponseError)
+	return ok && (respErr.StatusCode == http.StatusUnauthorized || respErr.StatusCode == http.StatusForbidden)
+}
+
+// Tags returns all of the tags in the given repository, in the order that
+// the registry returns them.
+func (c *Client) Tags(ctx context.Context, repo Repository) ([]string, error) {
+	var ret []string
+	next := c.url(repo, "tags/list")
+	for next != nil {
+		resp, err := c.get(ctx, repo, next, "application/json")
+		if err != nil {
+			return nil, err
+		}
+		var body struct {
+			Tags []string `json:"tags"`
+		}
+		err = json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag list from %s: %w", next, err)
+		}
+		ret = append(ret, body.Tags...)
+
+		// Registries may paginate the tag list, in which case the Link
+		// header refers to the next page.
+		next, err = nextLink(resp, repo.Registry)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// Index fetches the image index with the given tag or digest from the given
+// repository, returning it along with its digest.
+func (c *Client) Index(ctx context.Context, repo Repository, reference string) (*Index, Digest, error) {
+	var ret Index
+	digest, err := c.manifest(ctx, repo, reference, MediaTypeImageIndex, &ret)
+	if err != nil {
+		return nil, "", err
+	}
+	return &ret, digest, nil
+}
+
+// Manifest fetches the image manifest with the given tag or digest from the
+// given repository, returning it along with its digest.
+func (c *Client) Manifest(ctx context.Context, repo Repository, reference string) (*Manifest, Digest, error) {
+	var ret Manifest
+	digest, err := c.manifest(ctx, repo, reference, MediaTypeImageManifest, &ret)
+	if err != nil {
+		return nil, "", err
+	}
+	return &ret, digest, nil
+}
+
+// Blob fetches the blob described by the given descriptor from the given
+// repository and writes its content to w.
+//
+// The content is verified against the size and digest in the descriptor as
+// it's written, and so if this function returns an error then the caller
+// must discard anything already written to w.
+func (c *Client) Blob(ctx context.Context, repo Repository, desc Descriptor, w io.Writer) error {
+	if _, err := ParseDigest(string(desc.Digest)); err != nil {
+		return err
+	}
+	u := c.url(repo, "blobs/"+string(desc.Digest))
+	resp, err := c.get(ctx, repo, u, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	h := sha256.New()
+	// We read one more byte than we expect so that we can detect a blob
+	// that's larger than its descriptor claims.
+	n, err := io.Copy(io.MultiWriter(w, h), io.LimitReader(resp.Body, desc.Size+1))
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", u, err)
+	}
+	if n != desc.Size {
+		return fmt.Errorf("blob %s from %s has incorrect size: expected %d bytes, but got %d", desc.Digest, repo, desc.Size, n)
+	}
+	if got := digestFromSHA256(h.Sum(nil)); got != desc.Digest {
+		return fmt.Errorf("blob from %s has incorrect digest %s (expected %s)", repo, got, desc.Digest)
+	}
+	return nil
+}
+
+// manifest fetches a manifest of the given media type and decodes it into
+// the value that target points to, returning the manifest's digest.
+func (c *Client) manifest(ctx context.Context, repo Repository, reference string, mediaType string, target interface{}) (Digest, error) {
+	var wantDigest Digest
+	if strings.Contains(reference, ":") {
+		d, err := ParseDigest(reference)
+		if err != nil {
+			return "", err
+		}
+		wantDigest = d
+	} else if !ValidTag(reference) {
+		return "", fmt.Errorf("invalid tag %q", reference)
+	}
+
+	u := c.url(repo, "manifests/"+reference)
+	resp, err := c.get(ctx, repo, u, mediaType)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s: %w", u, err)
+	}
+	if len(content) > maxManifestSize {
+		return "", fmt.Errorf("manifest %s in %s is too large", reference, repo)
+	}
+
+	// The content we received must match the digest we asked for, or the
+	// digest the registry claims it has when we asked for a tag.
+	digest := DigestBytes(content)
+	if wantDigest == "" {
+		if header := resp.Header.Get("Docker-Content-Digest"); header != "" {
+			wantDigest = Digest(header)
+		}
+	}
+	if wantDigest != "" && digest != wantDigest {
+		return "", fmt.Errorf("manifest %s in %s has incorrect digest %s (expected %s)", reference, repo, digest, wantDigest)
+	}
+
+	var header struct {
+		MediaType string `json:"mediaType"`
+	}
+	if err := json.Unmarshal(content, &header); err != nil {
+		return "", fmt.Errorf("invalid manifest %s in %s: %w", reference, repo, err)
+	}
+	gotType := header.MediaType
+	if gotType == "" {
+		// The mediaType property is optional in a manifest, in which case
+		// we must rely on the content type of the response instead.
+		gotType, _, _ = mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	}
+	if gotType != mediaType {
+		return "", fmt.Errorf("%s in %s is %q, but expected %q", reference, repo, gotType, mediaType)
+	}
+	if err := json.Unmarshal(content, target); err != nil {
+		return "", fmt.Errorf("invalid manifest %s in %s: %w", reference, repo, err)
+	}
+	return digest, nil
+}
+
+func (c *Client) url(repo Repository, path string) *url.URL {
+	return &url.URL{
+		Scheme: "https",
+		Host:   repo.Registry,
+		Path:   "/v2/" + repo.Name + "/" + path,
+	}
+}
+
+// get makes a GET request to the given URL in the given repository's
+// registry, dealing with authentication as necessary. The URL must belong to
+// the registry, because the registry's credentials are sent along with it.
+//
+// If the returned error is nil then the response has status 200 OK and the
+// caller is responsible for closing its body.
+func (c *Client) get(ctx context.Context, repo Repository, u *url.URL, accept string) (*http.Response, error) {
+	if !strings.EqualFold(u.Host, repo.Registry) {
+		return nil, fmt.Errorf("refusing to send the credentials for registry %s to %s", repo.Registry, u.Host)
+	}
+	scope := "repository:" + repo.Name + ":pull"
+	tokenKey := repo.Registry + " " + scope
+
+	do := func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		c.tokensMu.Lock()
+		token := c.tokens[tokenKey]
+		c.tokensMu.Unlock()
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		} else if err := c.prepareRequest(req, repo.Registry); err != nil {
+			return nil, err
+		}
+		return c.httpClient.Do(req)
+	}
+
+	log.Printf("[TRACE] oci: GET %s", u)
+	resp, err := do()
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		// Many registries require a bearer token even for anonymous
+		// requests, which the client must obtain from the token service
+		// given in the challenge and then retry the request with.
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		token, err := c.token(ctx, repo.Registry, challenge, scope)
+		if err != nil {
+			return nil, err
+		}
+		c.tokensMu.Lock()
+		c.tokens[tokenKey] = token
+		c.tokensMu.Unlock()
+
+		resp, err = do()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, responseError(u, resp)
+	}
+	return resp, nil
+}
+
+// challengeParamPattern matches the parameters of a WWW-Authenticate
+// challenge, like realm="https://example.com/token".
+var challengeParamPattern = regexp.MustCompile(`([a-zA-Z]+)="([^"]*)"`)
+
+// token obtains a bearer token from the token service described in the
+// given WWW-Authenticate challenge.
+func (c *Client) token(ctx context.Context, registry string, challenge string, scope string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", &ResponseError{
+			URL:        "https://" + registry,
+			StatusCode: http.StatusUnauthorized,
+			Message:    "registry requires an unsupported authentication scheme",
+		}
+	}
+	values := make(map[string]string)
+	for _, match := range challengeParamPattern.FindAllStringSubmatch(params, -1) {
+		values[match[1]] = match[2]
+	}
+	realm, err := url.Parse(values["realm"])
+	if err != nil || realm.Scheme != "https" {
+		return "", fmt.Errorf("registry %s returned invalid token service URL %q", registry, values["realm"])
+	}
+	if values["scope"] != "" {
+		scope = values["scope"]
+	}
+	query := realm.Query()
+	if values["service"] != "" {
+		query.Set("service", values["service"])
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	log.Printf("[TRACE] oci: requesting token for %q from %s", scope, realm.Host)
+	req, err := http.NewRequestWithContext(ctx, "GET", realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if err := c.prepareRequest(req, registry); err != nil {
+		return "", err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", responseError(realm, resp)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&body); err != nil {
+		return "", fmt.Errorf("invalid response from token service %s: %w", realm.Host, err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	if body.AccessToken != "" {
+		return body.AccessToken, nil
+	}
+	return "", fmt.Errorf("token service %s didn't return a token", realm.Host)
+}
+
+// prepareRequest adds the credentials configured for the given registry, if
+// any, to the given request.
+func (c *Client) prepareRequest(req *http.Request, registry string) error {
+	if c.creds == nil {
+		return nil
+	}
+	host, err := svchost.ForComparison(registry)
+	if err != nil {
+		return fmt.Errorf("invalid registry hostname %q: %w", registry, err)
+	}
+	creds, err := c.creds.ForHost(host)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve credentials for %s: %w", registry, err)
+	}
+	if creds != nil {
+		creds.PrepareRequest(req)
+	}
+	return nil
+}
+
+func responseError(u *url.URL, resp *http.Response) error {
+	ret := &ResponseError{
+		URL:        u.String(),
+		StatusCode: resp.StatusCode,
+	}
+	var body struct {
+		Errors []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	content, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err := json.NewDecoder(bytes.NewReader(content)).Decode(&body); err == nil && len(body.Errors) > 0 {
+		ret.Code = body.Errors[0].Code
+		ret.Message = body.Errors[0].Message
+	}
+	return ret
+}
+
+// linkNextPattern matches a Link header referring to the next page of a
+// paginated response.
+var linkNextPattern = regexp.MustCompile(`<([^>]+)>\s*;\s*rel="?next"?`)
+
+// nextLink returns the URL of the next page of the given paginated response
+// from the given registry, or nil if there is no next page. The URL in the
+// Link header is resolved relative to the URL of the request.
+//
+// The request for the next page includes the registry's credentials, so
+// nextLink returns an error if the next page is not in the same registry.
+func nextLink(resp *http.Response, registry string) (*url.URL, error) {
+	match := linkNextPattern.FindStringSubmatch(resp.Header.Get("Link"))
+	if match == nil {
+		return nil, nil
+	}
+	next, err := url.Parse(match[1])
+	if err != nil {
+		return nil, fmt.Errorf("registry %s returned invalid link to the next page %q: %w", registry, match[1], err)
+	}
+	next = resp.Request.URL.ResolveReference(next)
+	if next.Scheme != "https" || !strings.EqualFold(next.Host, registry) {
+		return nil, fmt.Errorf("registry %s returned a link to the next page at a different location %s", registry, next.Redacted())
+	}
+	return next, nil
+}
@@ -0,0 +1,229 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	svchost "github.com/hashicorp/terraform-svchost"
+	svcauth "github.com/hashicorp/terraform-svchost/auth"
+
+	"github.com/opentofu/opentofu/internal/oci"
+	"github.com/opentofu/opentofu/internal/oci/ocitest"
+)
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	registry := ocitest.NewRegistry(t)
+	client := registry.Client()
+	repo := oci.Repository{Registry: registry.Host(), Name: "example/foo"}
+
+	desc := registry.PushArtifact(repo.Name, "application/vnd.example", "archive/zip", []byte("hello"), "v1.0.0", "latest")
+	registry.PushArtifact(repo.Name, "application/vnd.example", "archive/zip", []byte("goodbye"), "v2.0.0")
+
+	t.Run("tags", func(t *testing.T) {
+		got, err := client.Tags(ctx, repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"latest", "v1.0.0", "v2.0.0"}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong tags\n%s", diff)
+		}
+	})
+
+	t.Run("paginated tags", func(t *testing.T) {
+		registry.PaginateTags(2)
+		defer registry.PaginateTags(0)
+
+		got, err := client.Tags(ctx, repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"latest", "v1.0.0", "v2.0.0"}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong tags\n%s", diff)
+		}
+	})
+
+	t.Run("manifest and blob", func(t *testing.T) {
+		for _, ref := range []string{"v1.0.0", string(desc.Digest)} {
+			manifest, digest, err := client.Manifest(ctx, repo, ref)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if digest != desc.Digest {
+				t.Errorf("wrong digest for %s %s; want %s", ref, digest, desc.Digest)
+			}
+			layers := manifest.LayersOfType("archive/zip")
+			if len(layers) != 1 {
+				t.Fatalf("wrong number of layers %d", len(layers))
+			}
+			var buf bytes.Buffer
+			if err := client.Blob(ctx, repo, layers[0], &buf); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := buf.String(), "hello"; got != want {
+				t.Errorf("wrong content %q; want %q", got, want)
+			}
+		}
+	})
+
+	t.Run("wrong media type", func(t *testing.T) {
+		_, _, err := client.Index(ctx, repo, "v1.0.0")
+		if err == nil || !strings.Contains(err.Error(), `but expected "application/vnd.oci.image.index.v1+json"`) {
+			t.Fatalf("wrong error: %v", err)
+		}
+	})
+
+	t.Run("blob not matching descriptor", func(t *testing.T) {
+		blob := registry.PushBlob(repo.Name, "archive/zip", []byte("tampered"))
+		blob.Digest = oci.DigestBytes([]byte("original"))
+		err := client.Blob(ctx, repo, blob, &bytes.Buffer{})
+		if err == nil || !strings.Contains(err.Error(), "registry returned Not Found") {
+			t.Fatalf("wrong error: %v", err)
+		}
+
+		blob = registry.PushBlob(repo.Name, "archive/zip", []byte("original"))
+		blob.Size = 3
+		err = client.Blob(ctx, repo, blob, &bytes.Buffer{})
+		if err == nil || !strings.Contains(err.Error(), "has incorrect size") {
+			t.Fatalf("wrong error: %v", err)
+		}
+	})
+
+	t.Run("unknown digest", func(t *testing.T) {
+		ref := string(oci.DigestBytes([]byte("nope")))
+		_, _, err := client.Manifest(ctx, repo, ref)
+		if !oci.IsNotFound(err) {
+			t.Fatalf("wrong error: %v", err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := client.Tags(ctx, oci.Repository{Registry: registry.Host(), Name: "example/bar"})
+		if !oci.IsNotFound(err) {
+			t.Fatalf("wrong error: %v", err)
+		}
+	})
+}
+
+func TestClient_token(t *testing.T) {
+	ctx := context.Background()
+	registry := ocitest.NewRegistry(t)
+	repo := oci.Repository{Registry: registry.Host(), Name: "example/foo"}
+	registry.PushArtifact(repo.Name, "application/vnd.example", "archive/zip", []byte("hello"), "v1.0.0")
+	registry.RequireToken("secret")
+
+	got, err := registry.Client().Tags(ctx, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"v1.0.0"}, got); diff != "" {
+		t.Errorf("wrong tags\n%s", diff)
+	}
+}
+
+func TestParseRepository(t *testing.T) {
+	tests := map[string]struct {
+		want    oci.Repository
+		wantErr string
+	}{
+		"example.com/foo/bar": {
+			want: oci.Repository{Registry: "example.com", Name: "foo/bar"},
+		},
+		"localhost:5000/foo": {
+			want: oci.Repository{Registry: "localhost:5000", Name: "foo"},
+		},
+		"example.com": {
+			wantErr: "must be a registry hostname followed by a repository name",
+		},
+		"example.com/Foo": {
+			wantErr: "repository names may contain only lowercase letters",
+		},
+		"example.com/foo//bar": {
+			wantErr: "repository names may contain only lowercase letters",
+		},
+	}
+	for input, test := range tests {
+		t.Run(input, func(t *testing.T) {
+			got, err := oci.ParseRepository(input)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("wrong error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("wrong result %#v; want %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseDigest(t *testing.T) {
+	valid := string(oci.DigestBytes([]byte("hello")))
+	if _, err := oci.ParseDigest(valid); err != nil {
+		t.Errorf("unexpected error for %s: %s", valid, err)
+	}
+	for _, input := range []string{
+		"sha256",
+		"sha512:" + strings.TrimPrefix(valid, "sha256:"),
+		"sha256:abc",
+		strings.ToUpper(valid),
+	} {
+		if _, err := oci.ParseDigest(input); err == nil {
+			t.Errorf("no error for %s", input)
+		}
+	}
+}
+
+func TestClient_tagsLinkToOtherHost(t *testing.T) {
+	ctx := context.Background()
+
+	// The client must not follow a link to the next page on another host,
+	// because it would send the registry's credentials there.
+	var otherRequests int
+	other := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		otherRequests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"tags":["stolen"]}`))
+	}))
+	defer other.Close()
+	registry := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<%s/v2/example/foo/tags/list?last=v1.0.0>; rel="next"`, other.URL))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"tags":["v1.0.0"]}`))
+	}))
+	defer registry.Close()
+
+	registryURL, err := url.Parse(registry.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds := svcauth.StaticCredentialsSource(map[svchost.Hostname]map[string]interface{}{
+		svchost.Hostname(registryURL.Host): {"token": "secret"},
+	})
+	client := oci.NewClient(registry.Client(), creds)
+	repo := oci.Repository{Registry: registryURL.Host, Name: "example/foo"}
+
+	_, err = client.Tags(ctx, repo)
+	if err == nil || !strings.Contains(err.Error(), "link to the next page at a different location") {
+		t.Fatalf("wrong error: %v", err)
+	}
+	if otherRequests != 0 {
+		t.Fatalf("client made %d requests to the other host", otherRequests)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Digest is a content digest as used throughout the OCI specifications, such
+// as "sha256:9f86d08...". Content in a registry is addressed by its digest,
+// and so a digest both identifies and authenticates a particular blob or
+// manifest.
+//
+// Only the "sha256" algorithm is currently supported.
+type Digest string
+
+// ParseDigest parses the given string as a digest, returning an error if it
+// isn't a valid sha256 digest.
+func ParseDigest(s string) (Digest, error) {
+	alg, encoded, ok := strings.Cut(s, ":")
+	if !ok {
+		return "", fmt.Errorf("invalid digest %q: must be an algorithm name and an encoded hash separated by a colon", s)
+	}
+	if alg != "sha256" {
+		return "", fmt.Errorf("invalid digest %q: unsupported digest algorithm %q", s, alg)
+	}
+	raw, err := hex.DecodeString(encoded)
+	if err != nil || len(raw) != sha256.Size || strings.ToLower(encoded) != encoded {
+		return "", fmt.Errorf("invalid digest %q: must be a lowercase hex-encoded sha256 hash", s)
+	}
+	return Digest(s), nil
+}
+
+// DigestBytes returns the digest of the given content.
+func DigestBytes(content []byte) Digest {
+	sum := sha256.Sum256(content)
+	return digestFromSHA256(sum[:])
+}
+
+func digestFromSHA256(sum []byte) Digest {
+	return Digest("sha256:" + hex.EncodeToString(sum))
+}
+
+// SHA256 returns the raw sha256 hash that the digest represents.
+//
+// The digest must be valid, as returned from ParseDigest or DigestBytes, or
+// this function will panic.
+func (d Digest) SHA256() [sha256.Size]byte {
+	var ret [sha256.Size]byte
+	_, encoded, _ := strings.Cut(string(d), ":")
+	raw, err := hex.DecodeString(encoded)
+	if err != nil || len(raw) != sha256.Size {
+		panic(fmt.Sprintf("invalid digest %q", string(d)))
+	}
+	copy(ret[:], raw)
+	return ret
+}
+
+func (d Digest) String() string {
+	return string(d)
+}
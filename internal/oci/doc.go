@@ -0,0 +1,12 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package oci contains a minimal client for the OCI Distribution API, which
+// is implemented by container registries and other artifact registries.
+//
+// OpenTofu uses this to install provider and module packages that have been
+// pushed into such a registry as OCI artifacts. The client only implements
+// the subset of the API needed to pull artifacts: listing tags, fetching
+// manifests and indexes, and fetching blobs. All content fetched from a
+// registry is verified against its content digest before it's returned.
+package oci
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+// These are the media types of the manifests that this package can fetch,
+// as defined by the OCI Image Format Specification.
+const (
+	MediaTypeImageIndex    = "application/vnd.oci.image.index.v1+json"
+	MediaTypeImageManifest = "application/vnd.oci.image.manifest.v1+json"
+
+	// MediaTypeEmptyJSON is the media type of the empty JSON object "{}",
+	// which artifacts without any meaningful configuration use as their
+	// config blob.
+	MediaTypeEmptyJSON = "application/vnd.oci.empty.v1+json"
+)
+
+// Descriptor describes a blob or manifest that another manifest refers to.
+type Descriptor struct {
+	MediaType    string            `json:"mediaType"`
+	Digest       Digest            `json:"digest"`
+	Size         int64             `json:"size"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Platform     *Platform         `json:"platform,omitempty"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+// Platform describes the platform that the content of a manifest listed in
+// an index is intended for.
+type Platform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+}
+
+// Index is an OCI image index, which refers to a set of other manifests,
+// typically one for each supported platform.
+type Index struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Manifests     []Descriptor      `json:"manifests"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// Manifest is an OCI image manifest, which refers to a config blob and a
+// sequence of layer blobs.
+type Manifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Config        Descriptor        `json:"config"`
+	Layers        []Descriptor      `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// LayersOfType returns the layers of the manifest which have the given
+// media type.
+func (m *Manifest) LayersOfType(mediaType string) []Descriptor {
+	var ret []Descriptor
+	for _, layer := range m.Layers {
+		if layer.MediaType == mediaType {
+			ret = append(ret, layer)
+		}
+	}
+	return ret
+}
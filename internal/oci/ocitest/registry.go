@@ -0,0 +1,249 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package ocitest contains an in-memory stand-in for an OCI registry, for
+// use in tests of code that installs packages from OCI registries.
+package ocitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/oci"
+)
+
+// Registry is an in-memory OCI registry that implements the parts of the
+// distribution API that the oci package uses.
+type Registry struct {
+	server *httptest.Server
+
+	mu        sync.Mutex
+	blobs     map[string]map[oci.Digest][]byte
+	manifests map[string]map[string]manifest
+	token     string
+	tagsPage  int
+}
+
+type manifest struct {
+	mediaType string
+	content   []byte
+}
+
+// NewRegistry starts a new empty registry, which is stopped when the given
+// test completes.
+//
+// The registry is served over HTTPS using a self-signed certificate, so
+// clients must use the HTTP client returned by the HTTPClient method.
+func NewRegistry(t *testing.T) *Registry {
+	t.Helper()
+	r := &Registry{
+		blobs:     make(map[string]map[oci.Digest][]byte),
+		manifests: make(map[string]map[string]manifest),
+	}
+	r.server = httptest.NewTLSServer(http.HandlerFunc(r.serve))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+// Host returns the hostname and port of the registry, for use in repository
+// addresses.
+func (r *Registry) Host() string {
+	u, _ := url.Parse(r.server.URL)
+	return u.Host
+}
+
+// HTTPClient returns an HTTP client which trusts the registry's certificate.
+func (r *Registry) HTTPClient() *http.Client {
+	return r.server.Client()
+}
+
+// Client returns an OCI client configured to make anonymous requests to the
+// registry.
+func (r *Registry) Client() *oci.Client {
+	return oci.NewClient(r.HTTPClient(), nil)
+}
+
+// RequireToken makes the registry reject requests which don't include the
+// given bearer token, issuing a challenge which refers to a token service
+// that returns it to any client.
+func (r *Registry) RequireToken(token string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.token = token
+}
+
+// PaginateTags makes the registry return tag lists in pages of at most the
+// given number of tags, with a Link header referring to the next page.
+func (r *Registry) PaginateTags(n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tagsPage = n
+}
+
+// PushBlob adds the given content to the given repository as a blob, and
+// returns a descriptor for it with the given media type.
+func (r *Registry) PushBlob(repo string, mediaType string, content []byte) oci.Descriptor {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	digest := oci.DigestBytes(content)
+	if r.blobs[repo] == nil {
+		r.blobs[repo] = make(map[oci.Digest][]byte)
+	}
+	r.blobs[repo][digest] = content
+	return oci.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest,
+		Size:      int64(len(content)),
+	}
+}
+
+// PushManifest adds the given manifest or index to the given repository,
+// tagging it with each of the given tags, and returns a descriptor for it.
+func (r *Registry) PushManifest(repo string, m interface{}, tags ...string) oci.Descriptor {
+	content, err := json.Marshal(m)
+	if err != nil {
+		panic(err)
+	}
+	return r.PushManifestContent(repo, content, tags...)
+}
+
+// PushManifestContent is like PushManifest, but takes the exact content of
+// the manifest to push.
+func (r *Registry) PushManifestContent(repo string, content []byte, tags ...string) oci.Descriptor {
+	var header struct {
+		MediaType    string `json:"mediaType"`
+		ArtifactType string `json:"artifactType"`
+	}
+	if err := json.Unmarshal(content, &header); err != nil {
+		panic(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	digest := oci.DigestBytes(content)
+	if r.manifests[repo] == nil {
+		r.manifests[repo] = make(map[string]manifest)
+	}
+	m := manifest{mediaType: header.MediaType, content: content}
+	r.manifests[repo][string(digest)] = m
+	for _, tag := range tags {
+		r.manifests[repo][tag] = m
+	}
+	return oci.Descriptor{
+		MediaType:    header.MediaType,
+		Digest:       digest,
+		Size:         int64(len(content)),
+		ArtifactType: header.ArtifactType,
+	}
+}
+
+// PushArtifact adds an artifact of the given type to the given repository,
+// consisting of an image manifest with the given content as its only layer,
+// tagging it with each of the given tags. It returns a descriptor for the
+// manifest.
+func (r *Registry) PushArtifact(repo string, artifactType string, layerMediaType string, content []byte, tags ...string) oci.Descriptor {
+	config := r.PushBlob(repo, oci.MediaTypeEmptyJSON, []byte("{}"))
+	layer := r.PushBlob(repo, layerMediaType, content)
+	return r.PushManifest(repo, &oci.Manifest{
+		SchemaVersion: 2,
+		MediaType:     oci.MediaTypeImageManifest,
+		ArtifactType:  artifactType,
+		Config:        config,
+		Layers:        []oci.Descriptor{layer},
+	}, tags...)
+}
+
+func (r *Registry) serve(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if req.URL.Path == "/token" {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": r.token})
+		return
+	}
+	if r.token != "" && req.Header.Get("Authorization") != "Bearer "+r.token {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="ocitest"`, r.server.URL))
+		r.error(w, http.StatusUnauthorized, "UNAUTHORIZED")
+		return
+	}
+
+	path, ok := strings.CutPrefix(req.URL.Path, "/v2/")
+	if !ok || req.Method != "GET" {
+		r.error(w, http.StatusNotFound, "NOT_FOUND")
+		return
+	}
+	switch {
+	case strings.HasSuffix(path, "/tags/list"):
+		repo := strings.TrimSuffix(path, "/tags/list")
+		manifests, ok := r.manifests[repo]
+		if !ok {
+			r.error(w, http.StatusNotFound, "NAME_UNKNOWN")
+			return
+		}
+		tags := make([]string, 0, len(manifests))
+		for ref := range manifests {
+			if !strings.Contains(ref, ":") {
+				tags = append(tags, ref)
+			}
+		}
+		sort.Strings(tags)
+		if last := req.URL.Query().Get("last"); last != "" {
+			i := sort.SearchStrings(tags, last)
+			if i < len(tags) && tags[i] == last {
+				i++
+			}
+			tags = tags[i:]
+		}
+		if r.tagsPage > 0 && len(tags) > r.tagsPage {
+			tags = tags[:r.tagsPage]
+			next := url.Values{"last": []string{tags[len(tags)-1]}}
+			w.Header().Set("Link", fmt.Sprintf(`</v2/%s/tags/list?%s>; rel="next"`, repo, next.Encode()))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": repo, "tags": tags})
+
+	case strings.Contains(path, "/manifests/"):
+		i := strings.LastIndex(path, "/manifests/")
+		repo, ref := path[:i], path[i+len("/manifests/"):]
+		m, ok := r.manifests[repo][ref]
+		if !ok {
+			r.error(w, http.StatusNotFound, "MANIFEST_UNKNOWN")
+			return
+		}
+		w.Header().Set("Content-Type", m.mediaType)
+		w.Header().Set("Docker-Content-Digest", string(oci.DigestBytes(m.content)))
+		_, _ = w.Write(m.content)
+
+	case strings.Contains(path, "/blobs/"):
+		i := strings.LastIndex(path, "/blobs/")
+		repo, digest := path[:i], oci.Digest(path[i+len("/blobs/"):])
+		content, ok := r.blobs[repo][digest]
+		if !ok {
+			r.error(w, http.StatusNotFound, "BLOB_UNKNOWN")
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write(content)
+
+	default:
+		r.error(w, http.StatusNotFound, "NOT_FOUND")
+	}
+}
+
+func (r *Registry) error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors": []map[string]string{
+			{"code": code, "message": strings.ToLower(strings.ReplaceAll(code, "_", " "))},
+		},
+	})
+}
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Repository is the address of a repository in an OCI registry, such as
+// "example.com/opentofu/providers/aws".
+type Repository struct {
+	// Registry is the hostname of the registry, optionally with a port
+	// number, like "example.com" or "localhost:5000".
+	Registry string
+
+	// Name is the name of the repository within the registry, which is a
+	// slash-separated sequence of path components.
+	Name string
+}
+
+// repositoryNamePattern is the pattern that the distribution specification
+// requires repository names to match.
+var repositoryNamePattern = regexp.MustCompile(`^[a-z0-9]+((\.|_|__|-+)[a-z0-9]+)*(/[a-z0-9]+((\.|_|__|-+)[a-z0-9]+)*)*$`)
+
+// ParseRepository parses the given string as a repository address, which
+// must start with the registry hostname followed by the repository name.
+func ParseRepository(s string) (Repository, error) {
+	registry, name, ok := strings.Cut(s, "/")
+	if !ok || registry == "" || name == "" {
+		return Repository{}, fmt.Errorf("invalid repository address %q: must be a registry hostname followed by a repository name, like \"example.com/name\"", s)
+	}
+	if u, err := url.Parse("https://" + registry); err != nil || u.Host != registry || u.Hostname() == "" {
+		return Repository{}, fmt.Errorf("invalid repository address %q: invalid registry hostname %q", s, registry)
+	}
+	if !repositoryNamePattern.MatchString(name) {
+		return Repository{}, fmt.Errorf("invalid repository address %q: repository names may contain only lowercase letters, digits, and separators", s)
+	}
+	return Repository{
+		Registry: registry,
+		Name:     name,
+	}, nil
+}
+
+// URL returns a URL using the "oci" scheme which identifies the repository,
+// for use in messages.
+func (r Repository) URL() *url.URL {
+	return &url.URL{
+		Scheme: "oci",
+		Host:   r.Registry,
+		Path:   "/" + r.Name,
+	}
+}
+
+func (r Repository) String() string {
+	return r.Registry + "/" + r.Name
+}
+
+// tagPattern is the pattern that the distribution specification requires
+// tag names to match.
+var tagPattern = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9._-]{0,127}$`)
+
+// ValidTag returns true if the given string is a valid tag name.
+func ValidTag(s string) bool {
+	return tagPattern.MatchString(s)
+}
@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package overrides contains the overrides of resources, data sources and
+// module calls that the "tofu test" command applies to a single run block,
+// in a form that can be recorded in a plan and used by the core language
+// runtime.
+package overrides
+
+import (
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+)
+
+// Overrides contains the override_resource, override_data and override_module
+// blocks that apply to a single run block within a test file.
+//
+// The zero value and a nil pointer are both valid, and contain no overrides.
+type Overrides struct {
+	// resources contains the resource and data source overrides, keyed by
+	// the string representation of their target address. The target may be
+	// either a whole resource or a single resource instance.
+	resources map[string]*configs.Override
+
+	// modules contains the module overrides, keyed by the string
+	// representation of their target address.
+	modules map[string]*configs.Override
+}
+
+// PackageOverrides collects the overrides from the given test file and run
+// block. The overrides within the run block take precedence over any
+// overrides for the same object within the file.
+func PackageOverrides(run *configs.TestRun, file *configs.TestFile) *Overrides {
+	overrides := &Overrides{
+		resources: make(map[string]*configs.Override),
+		modules:   make(map[string]*configs.Override),
+	}
+
+	var all []*configs.Override
+	if file != nil {
+		all = append(all, file.Overrides...)
+	}
+	if run != nil {
+		all = append(all, run.Overrides...)
+	}
+
+	for _, override := range all {
+		if override.Target == nil {
+			continue
+		}
+		key := override.Target.String()
+		if override.Kind == configs.OverrideModule {
+			overrides.modules[key] = override
+			continue
+		}
+		overrides.resources[key] = override
+	}
+
+	return overrides
+}
+
+// Empty returns true if there are no overrides.
+func (o *Overrides) Empty() bool {
+	return o == nil || (len(o.resources) == 0 && len(o.modules) == 0)
+}
+
+// GetResourceOverride returns the override for the given resource instance,
+// if any. An override for a single instance takes precedence over an override
+// for the whole resource.
+func (o *Overrides) GetResourceOverride(addr addrs.AbsResourceInstance) (*configs.Override, bool) {
+	if o.Empty() {
+		return nil, false
+	}
+	if override, ok := o.resources[addr.String()]; ok {
+		return override, true
+	}
+	override, ok := o.resources[addr.ContainingResource().String()]
+	return override, ok
+}
+
+// GetModuleOverride returns the override for the given module instance, if
+// any. An override whose target has no instance keys applies to every
+// instance of the module call.
+func (o *Overrides) GetModuleOverride(addr addrs.ModuleInstance) (*configs.Override, bool) {
+	if o.Empty() || addr.IsRoot() {
+		return nil, false
+	}
+	if override, ok := o.modules[addr.String()]; ok {
+		return override, true
+	}
+	override, ok := o.modules[addr.Module().String()]
+	return override, ok
+}
+
+// IsOverridden returns true if the given module instance, or any of its
+// ancestors, is overridden.
+//
+// All resources and data sources within an overridden module are mocked with
+// generated values, so that they never call their real providers.
+func (o *Overrides) IsOverridden(addr addrs.ModuleInstance) bool {
+	if o.Empty() {
+		return false
+	}
+	for ; !addr.IsRoot(); addr = addr.Parent() {
+		if _, ok := o.GetModuleOverride(addr); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// GetResourceValues returns the values that should be used for the given
+// resource instance, and true, if the instance is overridden either directly
+// or by being within an overridden module. It returns false if the resource
+// instance should use its real provider.
+func (o *Overrides) GetResourceValues(addr addrs.AbsResourceInstance) (cty.Value, bool) {
+	if override, ok := o.GetResourceOverride(addr); ok {
+		return override.Values, true
+	}
+	if o.IsOverridden(addr.Module) {
+		return cty.EmptyObjectVal, true
+	}
+	return cty.NilVal, false
+}
@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package overrides
+
+import (
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+)
+
+func TestOverrides(t *testing.T) {
+	mustTarget := func(addr string) addrs.Targetable {
+		target, diags := addrs.ParseTargetStr(addr)
+		if diags.HasErrors() {
+			t.Fatal(diags.Err())
+		}
+		return target.Subject
+	}
+
+	file := &configs.TestFile{
+		Overrides: []*configs.Override{
+			{
+				Kind:   configs.OverrideResource,
+				Target: mustTarget("test_resource.a"),
+				Values: cty.ObjectVal(map[string]cty.Value{"id": cty.StringVal("file")}),
+			},
+			{
+				Kind:   configs.OverrideModule,
+				Target: mustTarget("module.child"),
+				Values: cty.EmptyObjectVal,
+			},
+		},
+	}
+	run := &configs.TestRun{
+		Overrides: []*configs.Override{
+			{
+				Kind:   configs.OverrideResource,
+				Target: mustTarget("test_resource.a"),
+				Values: cty.ObjectVal(map[string]cty.Value{"id": cty.StringVal("run")}),
+			},
+			{
+				Kind:   configs.OverrideResource,
+				Target: mustTarget("test_resource.b[1]"),
+				Values: cty.ObjectVal(map[string]cty.Value{"id": cty.StringVal("instance")}),
+			},
+		},
+	}
+
+	overrides := PackageOverrides(run, file)
+
+	tcs := map[string]struct {
+		addr string
+		want cty.Value
+	}{
+		"run takes precedence": {
+			"test_resource.a",
+			cty.ObjectVal(map[string]cty.Value{"id": cty.StringVal("run")}),
+		},
+		"instance override": {
+			"test_resource.b[1]",
+			cty.ObjectVal(map[string]cty.Value{"id": cty.StringVal("instance")}),
+		},
+		"other instance": {
+			"test_resource.b[0]",
+			cty.NilVal,
+		},
+		"inside overridden module": {
+			"module.child[\"a\"].module.grandchild.test_resource.c",
+			cty.EmptyObjectVal,
+		},
+		"not overridden": {
+			"module.other.test_resource.a",
+			cty.NilVal,
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			addr, diags := addrs.ParseAbsResourceInstanceStr(tc.addr)
+			if diags.HasErrors() {
+				t.Fatal(diags.Err())
+			}
+			got, ok := overrides.GetResourceValues(addr)
+			if ok != (tc.want != cty.NilVal) {
+				t.Fatalf("wrong overridden result %t", ok)
+			}
+			if ok && !got.RawEquals(tc.want) {
+				t.Fatalf("wrong result\ngot:  %#v\nwant: %#v", got, tc.want)
+			}
+		})
+	}
+
+	var empty *Overrides
+	if _, ok := empty.GetResourceValues(addrs.RootModuleInstance.ResourceInstance(addrs.ManagedResourceMode, "test_resource", "a", addrs.NoKey)); ok {
+		t.Fatalf("nil overrides reported an override")
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plans
+
+import (
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
+// DeferredResource describes a resource whose changes were left out of a plan
+// because OpenTofu couldn't yet determine which instances it should have.
+//
+// The changes for a deferred resource are planned by a later plan, once the
+// values it depends on are known.
+type DeferredResource struct {
+	Addr   addrs.AbsResource
+	Reason DeferredReason
+}
+
+// DeferredReason describes why a resource was deferred.
+type DeferredReason rune
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=DeferredReason deferred.go
+
+const (
+	// DeferredReasonInvalid is the zero value of DeferredReason, which is
+	// never a valid reason.
+	DeferredReasonInvalid DeferredReason = 0
+
+	// DeferredReasonCountUnknown means the resource's count value wasn't
+	// known during planning.
+	DeferredReasonCountUnknown DeferredReason = 'C'
+
+	// DeferredReasonForEachUnknown means the resource's for_each value wasn't
+	// known during planning.
+	DeferredReasonForEachUnknown DeferredReason = 'F'
+
+	// DeferredReasonDependency means the resource depends on another deferred
+	// resource, and so its changes can't be planned until the changes of
+	// that resource are.
+	DeferredReasonDependency DeferredReason = 'D'
+)
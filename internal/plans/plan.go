@@ -12,6 +12,7 @@ import (
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
 	"github.com/opentofu/opentofu/internal/lang/globalref"
+	"github.com/opentofu/opentofu/internal/overrides"
 	"github.com/opentofu/opentofu/internal/states"
 )
 
@@ -44,6 +45,7 @@ type Plan struct {
 	Changes           *Changes
 	DriftedResources  []*ResourceInstanceChangeSrc
 	TargetAddrs       []addrs.Targetable
+	ExcludeAddrs      []addrs.Targetable
 	ForceReplaceAddrs []addrs.AbsResourceInstance
 	Backend           Backend
 
@@ -61,6 +63,17 @@ type Plan struct {
 	// condition depends on values we won't know until the apply step.
 	Checks *states.CheckResults
 
+	// PolicyResults are the results of the policy blocks in the root module,
+	// which are evaluated against the changes in the plan once it is
+	// otherwise complete, ordered by policy name.
+	PolicyResults []*PolicyResult
+
+	// DeferredResources are the resources whose changes were left out of
+	// the plan because OpenTofu couldn't yet determine which instances they
+	// have, ordered by address. A later plan must be created to converge
+	// them, once the values they depend on are known.
+	DeferredResources []*DeferredResource
+
 	// RelevantAttributes is a set of resource instance addresses and
 	// attributes that are either directly affected by proposed changes or may
 	// have indirectly contributed to them via references in expressions.
@@ -112,6 +125,13 @@ type Plan struct {
 	// representation of the plan.
 	ExternalReferences []*addrs.Reference
 
+	// Overrides contains the resources, data sources and modules whose results
+	// were replaced with fixed values while creating the plan, which must also
+	// be replaced during the apply. As with PlannedState this is used by the
+	// OpenTofu testing framework, and so isn't written into any external
+	// representation of the plan.
+	Overrides *overrides.Overrides
+
 	// Timestamp is the record of truth for when the plan happened.
 	Timestamp time.Time
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configload"
 	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/states/statefile"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -48,23 +49,34 @@ func (e *ErrUnusableLocalPlan) Unwrap() error {
 // be used to access the individual portions of the file for further
 // processing.
 type Reader struct {
-	zip *zip.ReadCloser
+	zip *zip.Reader
 }
 
 // Open creates a Reader for the file at the given filename, or returns an error
 // if the file doesn't seem to be a planfile. NOTE: Most commands that accept a
 // plan file should use OpenWrapped instead, so they can support both local and
 // cloud plan files.
-func Open(filename string) (*Reader, error) {
-	r, err := zip.OpenReader(filename)
+//
+// The given encryption is used to decrypt the plan file before reading it.
+func Open(filename string, enc encryption.PlanEncryption) (*Reader, error) {
+	raw, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	// To give a better error message, we'll sniff to see if this looks
+	// like our old plan format from versions prior to 0.12.
+	if bytes.HasPrefix(raw, []byte("tfplan")) {
+		return nil, errUnusable(fmt.Errorf("the given plan file was created by an earlier version of OpenTofu, or an earlier version of Terraform; plan files cannot be shared between different OpenTofu or Terraform versions"))
+	}
+
+	decrypted, err := enc.DecryptPlan(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(decrypted), int64(len(decrypted)))
 	if err != nil {
-		// To give a better error message, we'll sniff to see if this looks
-		// like our old plan format from versions prior to 0.12.
-		if b, sErr := os.ReadFile(filename); sErr == nil {
-			if bytes.HasPrefix(b, []byte("tfplan")) {
-				return nil, errUnusable(fmt.Errorf("the given plan file was created by an earlier version of OpenTofu, or an earlier version of Terraform; plan files cannot be shared between different OpenTofu or Terraform versions"))
-			}
-		}
 		return nil, err
 	}
 
@@ -158,7 +170,9 @@ func (r *Reader) ReadStateFile() (*statefile.File, error) {
 			if err != nil {
 				return nil, errUnusable(fmt.Errorf("failed to extract state from plan file: %w", err))
 			}
-			return statefile.Read(r)
+			// The plan file as a whole is encrypted, so the embedded
+			// state is not encrypted separately.
+			return statefile.Read(r, encryption.StateEncryptionDisabled())
 		}
 	}
 	return nil, errUnusable(statefile.ErrNoState)
@@ -176,7 +190,7 @@ func (r *Reader) ReadPrevStateFile() (*statefile.File, error) {
 			if err != nil {
 				return nil, errUnusable(fmt.Errorf("failed to extract previous state from plan file: %w", err))
 			}
-			return statefile.Read(r)
+			return statefile.Read(r, encryption.StateEncryptionDisabled())
 		}
 	}
 	return nil, errUnusable(statefile.ErrNoState)
@@ -188,7 +202,7 @@ func (r *Reader) ReadPrevStateFile() (*statefile.File, error) {
 // This is a lower-level alternative to ReadConfig that just extracts the
 // source files, without attempting to parse them.
 func (r *Reader) ReadConfigSnapshot() (*configload.Snapshot, error) {
-	return readConfigSnapshot(&r.zip.Reader)
+	return readConfigSnapshot(r.zip)
 }
 
 // ReadConfig reads the configuration embedded in the plan file.
@@ -263,5 +277,8 @@ func (r *Reader) ReadDependencyLocks() (*depsfile.Locks, tfdiags.Diagnostics) {
 
 // Close closes the file, after which no other operations may be performed.
 func (r *Reader) Close() error {
-	return r.zip.Close()
+	// The whole plan file is read into memory by Open, so there is nothing
+	// to release here, but callers must still call Close in case that
+	// changes in future.
+	return nil
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/plans/internal/planproto"
 	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/opentofu/opentofu/version"
 )
 
@@ -185,6 +186,38 @@ func readTfplan(r io.Reader) (*plans.Plan, error) {
 		plan.Checks.ConfigResults.Elems = nil
 	}
 
+	for _, rawPR := range rawPlan.PolicyResults {
+		pr := &plans.PolicyResult{
+			Name:            rawPR.Name,
+			Severity:        tfdiags.Error,
+			FailureMessages: rawPR.FailureMessages,
+		}
+		if rawPR.Warning {
+			pr.Severity = tfdiags.Warning
+		}
+		switch rawPR.Status {
+		case planproto.CheckResults_UNKNOWN:
+			pr.Status = checks.StatusUnknown
+		case planproto.CheckResults_PASS:
+			pr.Status = checks.StatusPass
+		case planproto.CheckResults_FAIL:
+			pr.Status = checks.StatusFail
+		case planproto.CheckResults_ERROR:
+			pr.Status = checks.StatusError
+		default:
+			return nil, fmt.Errorf("policy %q has unsupported status %#v", rawPR.Name, rawPR.Status)
+		}
+		plan.PolicyResults = append(plan.PolicyResults, pr)
+	}
+
+	for _, rawDR := range rawPlan.DeferredResources {
+		dr, err := deferredResourceFromTfplan(rawDR)
+		if err != nil {
+			return nil, err
+		}
+		plan.DeferredResources = append(plan.DeferredResources, dr)
+	}
+
 	for _, rawRC := range rawPlan.ResourceChanges {
 		change, err := resourceChangeFromTfplan(rawRC)
 		if err != nil {
@@ -221,6 +254,14 @@ func readTfplan(r io.Reader) (*plans.Plan, error) {
 		plan.TargetAddrs = append(plan.TargetAddrs, target.Subject)
 	}
 
+	for _, rawExcludeAddr := range rawPlan.ExcludeAddrs {
+		exclude, diags := addrs.ParseTargetStr(rawExcludeAddr)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("plan contains invalid exclude address %q: %w", rawExcludeAddr, diags.Err())
+		}
+		plan.ExcludeAddrs = append(plan.ExcludeAddrs, exclude.Subject)
+	}
+
 	for _, rawReplaceAddr := range rawPlan.ForceReplaceAddrs {
 		addr, diags := addrs.ParseAbsResourceInstanceStr(rawReplaceAddr)
 		if diags.HasErrors() {
@@ -389,6 +430,9 @@ func changeFromTfplan(rawChange *planproto.Change) (*plans.ChangeSrc, error) {
 	case planproto.Action_DELETE:
 		ret.Action = plans.Delete
 		beforeIdx = 0
+	case planproto.Action_FORGET:
+		ret.Action = plans.Forget
+		beforeIdx = 0
 	case planproto.Action_CREATE_THEN_DELETE:
 		ret.Action = plans.CreateThenDelete
 		beforeIdx = 0
@@ -577,6 +621,35 @@ func writeTfplan(plan *plans.Plan, w io.Writer) error {
 		}
 	}
 
+	for _, pr := range plan.PolicyResults {
+		rawPR := &planproto.PolicyResult{
+			Name:            pr.Name,
+			Warning:         pr.Severity == tfdiags.Warning,
+			FailureMessages: pr.FailureMessages,
+		}
+		switch pr.Status {
+		case checks.StatusUnknown:
+			rawPR.Status = planproto.CheckResults_UNKNOWN
+		case checks.StatusPass:
+			rawPR.Status = planproto.CheckResults_PASS
+		case checks.StatusFail:
+			rawPR.Status = planproto.CheckResults_FAIL
+		case checks.StatusError:
+			rawPR.Status = planproto.CheckResults_ERROR
+		default:
+			return fmt.Errorf("policy %q has unsupported status %s", pr.Name, pr.Status)
+		}
+		rawPlan.PolicyResults = append(rawPlan.PolicyResults, rawPR)
+	}
+
+	for _, dr := range plan.DeferredResources {
+		rawDR, err := deferredResourceToTfplan(dr)
+		if err != nil {
+			return err
+		}
+		rawPlan.DeferredResources = append(rawPlan.DeferredResources, rawDR)
+	}
+
 	for _, rc := range plan.Changes.Resources {
 		rawRC, err := resourceChangeToTfplan(rc)
 		if err != nil {
@@ -605,6 +678,10 @@ func writeTfplan(plan *plans.Plan, w io.Writer) error {
 		rawPlan.TargetAddrs = append(rawPlan.TargetAddrs, targetAddr.String())
 	}
 
+	for _, excludeAddr := range plan.ExcludeAddrs {
+		rawPlan.ExcludeAddrs = append(rawPlan.ExcludeAddrs, excludeAddr.String())
+	}
+
 	for _, replaceAddr := range plan.ForceReplaceAddrs {
 		rawPlan.ForceReplaceAddrs = append(rawPlan.ForceReplaceAddrs, replaceAddr.String())
 	}
@@ -674,6 +751,51 @@ func resourceAttrFromTfplan(ra *planproto.PlanResourceAttr) (globalref.ResourceA
 	return res, nil
 }
 
+func deferredResourceFromTfplan(rawDR *planproto.DeferredResource) (*plans.DeferredResource, error) {
+	if rawDR.Addr == "" {
+		return nil, fmt.Errorf("missing resource address for deferred resource")
+	}
+
+	addr, diags := addrs.ParseAbsResourceStr(rawDR.Addr)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("invalid deferred resource address %q: %w", rawDR.Addr, diags.Err())
+	}
+
+	ret := &plans.DeferredResource{
+		Addr: addr,
+	}
+	switch rawDR.Reason {
+	case planproto.DeferredResource_COUNT_UNKNOWN:
+		ret.Reason = plans.DeferredReasonCountUnknown
+	case planproto.DeferredResource_FOR_EACH_UNKNOWN:
+		ret.Reason = plans.DeferredReasonForEachUnknown
+	case planproto.DeferredResource_DEPENDENCY:
+		ret.Reason = plans.DeferredReasonDependency
+	default:
+		return nil, fmt.Errorf("deferred resource %s has unsupported reason %s", rawDR.Addr, rawDR.Reason)
+	}
+
+	return ret, nil
+}
+
+func deferredResourceToTfplan(dr *plans.DeferredResource) (*planproto.DeferredResource, error) {
+	ret := &planproto.DeferredResource{
+		Addr: dr.Addr.String(),
+	}
+	switch dr.Reason {
+	case plans.DeferredReasonCountUnknown:
+		ret.Reason = planproto.DeferredResource_COUNT_UNKNOWN
+	case plans.DeferredReasonForEachUnknown:
+		ret.Reason = planproto.DeferredResource_FOR_EACH_UNKNOWN
+	case plans.DeferredReasonDependency:
+		ret.Reason = planproto.DeferredResource_DEPENDENCY
+	default:
+		return nil, fmt.Errorf("deferred resource %s has unsupported reason %s", dr.Addr, dr.Reason)
+	}
+
+	return ret, nil
+}
+
 func resourceChangeToTfplan(change *plans.ResourceInstanceChangeSrc) (*planproto.ResourceInstanceChange, error) {
 	ret := &planproto.ResourceInstanceChange{}
 
@@ -793,6 +915,9 @@ func changeToTfplan(change *plans.ChangeSrc) (*planproto.Change, error) {
 	case plans.Delete:
 		ret.Action = planproto.Action_DELETE
 		ret.Values = []*planproto.DynamicValue{before}
+	case plans.Forget:
+		ret.Action = planproto.Action_FORGET
+		ret.Values = []*planproto.DynamicValue{before}
 	case plans.DeleteThenCreate:
 		ret.Action = planproto.Action_DELETE_THEN_CREATE
 		ret.Values = []*planproto.DynamicValue{before, after}
@@ -16,6 +16,7 @@ import (
 	"github.com/opentofu/opentofu/internal/lang/marks"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
 )
 
 func TestTFPlanRoundTrip(t *testing.T) {
@@ -247,6 +248,37 @@ func TestTFPlanRoundTrip(t *testing.T) {
 				),
 			),
 		},
+		PolicyResults: []*plans.PolicyResult{
+			{
+				Name:     "limit_deletions",
+				Severity: tfdiags.Error,
+				Status:   checks.StatusPass,
+			},
+			{
+				Name:            "no_replacements",
+				Severity:        tfdiags.Warning,
+				Status:          checks.StatusFail,
+				FailureMessages: []string{"Replacing test_thing.woot[0]."},
+			},
+		},
+		DeferredResources: []*plans.DeferredResource{
+			{
+				Addr: addrs.Resource{
+					Mode: addrs.ManagedResourceMode,
+					Type: "test_thing",
+					Name: "later",
+				}.Absolute(addrs.RootModuleInstance),
+				Reason: plans.DeferredReasonForEachUnknown,
+			},
+			{
+				Addr: addrs.Resource{
+					Mode: addrs.DataResourceMode,
+					Type: "test_thing",
+					Name: "after_later",
+				}.Absolute(addrs.RootModuleInstance.Child("child", addrs.IntKey(1))),
+				Reason: plans.DeferredReasonDependency,
+			},
+		},
 		TargetAddrs: []addrs.Targetable{
 			addrs.Resource{
 				Mode: addrs.ManagedResourceMode,
@@ -254,6 +286,9 @@ func TestTFPlanRoundTrip(t *testing.T) {
 				Name: "woot",
 			}.Absolute(addrs.RootModuleInstance),
 		},
+		ExcludeAddrs: []addrs.Targetable{
+			addrs.RootModuleInstance.Child("child", addrs.StringKey("a")),
+		},
 		Backend: plans.Backend{
 			Type: "local",
 			Config: mustNewDynamicValue(
@@ -0,0 +1,27 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plans
+
+import (
+	"github.com/opentofu/opentofu/internal/checks"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// PolicyResult is the result of evaluating the assertions of a policy block
+// in the root module against the changes in a plan.
+type PolicyResult struct {
+	// Name is the name of the policy block.
+	Name string
+
+	// Severity is the severity of the diagnostics produced if the policy
+	// fails, which is tfdiags.Error if a failure makes the plan unapplyable.
+	Severity tfdiags.Severity
+
+	// Status is checks.StatusPass only if all of the policy's assertions
+	// passed. It is checks.StatusError if any of them couldn't be evaluated.
+	Status checks.Status
+
+	// FailureMessages are the error messages of the assertions that failed.
+	FailureMessages []string
+}
@@ -15,6 +15,8 @@ import (
 	ctyjson "github.com/zclconf/go-cty/cty/json"
 	"github.com/zclconf/go-cty/cty/msgpack"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/logging"
@@ -145,6 +147,31 @@ func (p *GRPCProvider) GetProviderSchema() (resp providers.GetProviderSchemaResp
 	if protoResp.ServerCapabilities != nil {
 		resp.ServerCapabilities.PlanDestroy = protoResp.ServerCapabilities.PlanDestroy
 		resp.ServerCapabilities.GetProviderSchemaOptional = protoResp.ServerCapabilities.GetProviderSchemaOptional
+		resp.ServerCapabilities.MoveResourceState = protoResp.ServerCapabilities.MoveResourceState
+	}
+
+	resp.Functions = make(map[string]providers.FunctionSpec)
+	for name, fn := range protoResp.Functions {
+		spec, err := convert.ProtoToFunctionSpec(fn)
+		if err != nil {
+			resp.Diagnostics = resp.Diagnostics.Append(fmt.Errorf("invalid definition for function %q: %w", name, err))
+			return resp
+		}
+		resp.Functions[name] = spec
+	}
+
+	// Providers may leave their functions out of the schema and offer them
+	// only through GetFunctions, so we ask for them separately if the schema
+	// includes none.
+	if len(resp.Functions) == 0 {
+		funcs := p.GetFunctions()
+		resp.Diagnostics = resp.Diagnostics.Append(funcs.Diagnostics)
+		if resp.Diagnostics.HasErrors() {
+			return resp
+		}
+		for name, spec := range funcs.Functions {
+			resp.Functions[name] = spec
+		}
 	}
 
 	// Set the global provider cache so that future calls to this provider can use the cached value.
@@ -629,6 +656,53 @@ func (p *GRPCProvider) ImportResourceState(r providers.ImportResourceStateReques
 	return resp
 }
 
+func (p *GRPCProvider) MoveResourceState(r providers.MoveResourceStateRequest) (resp providers.MoveResourceStateResponse) {
+	logger.Trace("GRPCProvider: MoveResourceState")
+
+	schema := p.GetProviderSchema()
+	if schema.Diagnostics.HasErrors() {
+		resp.Diagnostics = schema.Diagnostics
+		return resp
+	}
+
+	resSchema, ok := schema.ResourceTypes[r.TargetTypeName]
+	if !ok {
+		resp.Diagnostics = resp.Diagnostics.Append(fmt.Errorf("unknown resource type %q", r.TargetTypeName))
+		return resp
+	}
+
+	protoReq := &proto.MoveResourceState_Request{
+		SourceProviderAddress: r.SourceProviderAddress,
+		SourceTypeName:        r.SourceTypeName,
+		SourceSchemaVersion:   r.SourceSchemaVersion,
+		SourceState: &proto.RawState{
+			Json: r.SourceStateJSON,
+		},
+		SourcePrivate:  r.SourcePrivate,
+		TargetTypeName: r.TargetTypeName,
+	}
+
+	protoResp, err := p.client.MoveResourceState(p.ctx, protoReq)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(grpcErr(err))
+		return resp
+	}
+	resp.Diagnostics = resp.Diagnostics.Append(convert.ProtoToDiagnostics(protoResp.Diagnostics))
+	if resp.Diagnostics.HasErrors() {
+		return resp
+	}
+
+	state, err := decodeDynamicValue(protoResp.TargetState, resSchema.Block.ImpliedType())
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	resp.TargetState = state
+	resp.TargetPrivate = protoResp.TargetPrivate
+
+	return resp
+}
+
 func (p *GRPCProvider) ReadDataSource(r providers.ReadDataSourceRequest) (resp providers.ReadDataSourceResponse) {
 	logger.Trace("GRPCProvider: ReadDataSource")
 
@@ -684,6 +758,97 @@ func (p *GRPCProvider) ReadDataSource(r providers.ReadDataSourceRequest) (resp p
 	return resp
 }
 
+func (p *GRPCProvider) GetFunctions() (resp providers.GetFunctionsResponse) {
+	logger.Trace("GRPCProvider: GetFunctions")
+
+	protoResp, err := p.client.GetFunctions(p.ctx, new(proto.GetFunctions_Request))
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			// Providers built against older protocol versions have no
+			// functions to offer.
+			logger.Trace("GRPCProvider: GetFunctions: not implemented by provider")
+			return resp
+		}
+		resp.Diagnostics = resp.Diagnostics.Append(grpcErr(err))
+		return resp
+	}
+	resp.Diagnostics = resp.Diagnostics.Append(convert.ProtoToDiagnostics(protoResp.Diagnostics))
+	if resp.Diagnostics.HasErrors() {
+		return resp
+	}
+
+	resp.Functions = make(map[string]providers.FunctionSpec)
+	for name, fn := range protoResp.Functions {
+		spec, err := convert.ProtoToFunctionSpec(fn)
+		if err != nil {
+			resp.Diagnostics = resp.Diagnostics.Append(fmt.Errorf("invalid definition for function %q: %w", name, err))
+			return resp
+		}
+		resp.Functions[name] = spec
+	}
+
+	return resp
+}
+
+func (p *GRPCProvider) CallFunction(r providers.CallFunctionRequest) (resp providers.CallFunctionResponse) {
+	logger.Trace("GRPCProvider: CallFunction")
+
+	schema := p.GetProviderSchema()
+	if schema.Diagnostics.HasErrors() {
+		resp.Diagnostics = schema.Diagnostics
+		return resp
+	}
+
+	spec, ok := schema.Functions[r.Name]
+	if !ok {
+		resp.Diagnostics = resp.Diagnostics.Append(fmt.Errorf("unknown function %q", r.Name))
+		return resp
+	}
+
+	protoReq := &proto.CallFunction_Request{
+		Name:      r.Name,
+		Arguments: make([]*proto.DynamicValue, len(r.Arguments)),
+	}
+	for i, arg := range r.Arguments {
+		var paramType cty.Type
+		switch {
+		case i < len(spec.Parameters):
+			paramType = spec.Parameters[i].Type
+		case spec.VariadicParameter != nil:
+			paramType = spec.VariadicParameter.Type
+		default:
+			resp.Diagnostics = resp.Diagnostics.Append(fmt.Errorf("too many arguments for function %q", r.Name))
+			return resp
+		}
+
+		mp, err := msgpack.Marshal(arg, paramType)
+		if err != nil {
+			resp.Diagnostics = resp.Diagnostics.Append(err)
+			return resp
+		}
+		protoReq.Arguments[i] = &proto.DynamicValue{Msgpack: mp}
+	}
+
+	protoResp, err := p.client.CallFunction(p.ctx, protoReq)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(grpcErr(err))
+		return resp
+	}
+	resp.Diagnostics = resp.Diagnostics.Append(convert.ProtoToDiagnostics(protoResp.Diagnostics))
+	if resp.Diagnostics.HasErrors() {
+		return resp
+	}
+
+	result, err := decodeDynamicValue(protoResp.Result, spec.Return)
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(err)
+		return resp
+	}
+	resp.Result = result
+
+	return resp
+}
+
 // closing the grpc connection is final, and tofu will call it at the end of every phase.
 func (p *GRPCProvider) Close() error {
 	logger.Trace("GRPCProvider: Close")
@@ -5,6 +5,7 @@ package plugin
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"testing"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/opentofu/opentofu/internal/providers"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/grpc"
 
 	mockproto "github.com/opentofu/opentofu/internal/plugin/mock_proto"
 	proto "github.com/opentofu/opentofu/internal/tfplugin5"
@@ -94,6 +96,23 @@ func providerProtoSchema() *proto.GetProviderSchema_Response {
 				},
 			},
 		},
+		Functions: map[string]*proto.Function{
+			"fn": {
+				Parameters: []*proto.Function_Parameter{
+					{
+						Name: "par_a",
+						Type: []byte(`"string"`),
+					},
+				},
+				VariadicParameter: &proto.Function_Parameter{
+					Name: "par_b",
+					Type: []byte(`"bool"`),
+				},
+				Return: &proto.Function_Return{
+					Type: []byte(`"string"`),
+				},
+			},
+		},
 	}
 }
 
@@ -148,6 +167,11 @@ func TestGRPCProvider_GetSchema_GlobalCacheEnabled(t *testing.T) {
 		Provider:           mockedProviderResponse,
 		ServerCapabilities: &proto.GetProviderSchema_ServerCapabilities{GetProviderSchemaOptional: true},
 	}, nil)
+	// The schema includes no functions, so they are requested separately.
+	client.EXPECT().GetFunctions(
+		gomock.Any(),
+		gomock.Any(),
+	).Times(1).Return(&proto.GetFunctions_Response{}, nil)
 
 	// Run GetProviderTwice, expect GetSchema to be called once
 	// Re-initialize the provider before each run to avoid usage of the local cache
@@ -195,6 +219,11 @@ func TestGRPCProvider_GetSchema_GlobalCacheDisabled(t *testing.T) {
 		Provider:           mockedProviderResponse,
 		ServerCapabilities: &proto.GetProviderSchema_ServerCapabilities{GetProviderSchemaOptional: false},
 	}, nil)
+	// The schema includes no functions, so they are requested separately.
+	client.EXPECT().GetFunctions(
+		gomock.Any(),
+		gomock.Any(),
+	).Times(2).Return(&proto.GetFunctions_Response{}, nil)
 
 	// Run GetProviderTwice, expect GetSchema to be called once
 	// Re-initialize the provider before each run to avoid usage of the local cache
@@ -809,6 +838,45 @@ func TestGRPCProvider_ImportResourceStateJSON(t *testing.T) {
 	}
 }
 
+func TestGRPCProvider_MoveResourceState(t *testing.T) {
+	client := mockProviderClient(t)
+	p := &GRPCProvider{
+		client: client,
+	}
+
+	expectedTargetPrivate := []byte(`{"target": "private"}`)
+	expectedTargetState := cty.ObjectVal(map[string]cty.Value{
+		"attr": cty.StringVal("bar"),
+	})
+
+	client.EXPECT().MoveResourceState(
+		gomock.Any(),
+		gomock.Any(),
+	).Return(&proto.MoveResourceState_Response{
+		TargetState: &proto.DynamicValue{
+			Msgpack: []byte("\x81\xa4attr\xa3bar"),
+		},
+		TargetPrivate: expectedTargetPrivate,
+	}, nil)
+
+	resp := p.MoveResourceState(providers.MoveResourceStateRequest{
+		SourceProviderAddress: "registry.opentofu.org/hashicorp/other",
+		SourceTypeName:        "other_resource",
+		SourceSchemaVersion:   1,
+		SourceStateJSON:       []byte(`{"other_attr":"bar"}`),
+		SourcePrivate:         []byte(`{"source": "private"}`),
+		TargetTypeName:        "resource",
+	})
+	checkDiags(t, resp.Diagnostics)
+
+	if !cmp.Equal(expectedTargetState, resp.TargetState, typeComparer, valueComparer, equateEmpty) {
+		t.Fatal(cmp.Diff(expectedTargetState, resp.TargetState, typeComparer, valueComparer, equateEmpty))
+	}
+	if !bytes.Equal(expectedTargetPrivate, resp.TargetPrivate) {
+		t.Fatalf("expected %q, got %q", expectedTargetPrivate, resp.TargetPrivate)
+	}
+}
+
 func TestGRPCProvider_ReadDataSource(t *testing.T) {
 	client := mockProviderClient(t)
 	p := &GRPCProvider{
@@ -874,3 +942,125 @@ func TestGRPCProvider_ReadDataSourceJSON(t *testing.T) {
 		t.Fatal(cmp.Diff(expected, resp.State, typeComparer, valueComparer, equateEmpty))
 	}
 }
+
+func TestGRPCProvider_GetFunctions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mockproto.NewMockProviderClient(ctrl)
+	p := &GRPCProvider{
+		client: client,
+	}
+
+	client.EXPECT().GetFunctions(
+		gomock.Any(),
+		gomock.Any(),
+	).Return(&proto.GetFunctions_Response{
+		Functions: providerProtoSchema().Functions,
+	}, nil)
+
+	resp := p.GetFunctions()
+	checkDiags(t, resp.Diagnostics)
+
+	expected := map[string]providers.FunctionSpec{
+		"fn": {
+			Parameters: []providers.FunctionParameterSpec{
+				{
+					Name:              "par_a",
+					Type:              cty.String,
+					DescriptionFormat: providers.TextFormattingPlain,
+				},
+			},
+			VariadicParameter: &providers.FunctionParameterSpec{
+				Name:              "par_b",
+				Type:              cty.Bool,
+				DescriptionFormat: providers.TextFormattingPlain,
+			},
+			Return:            cty.String,
+			DescriptionFormat: providers.TextFormattingPlain,
+		},
+	}
+
+	if !cmp.Equal(expected, resp.Functions, typeComparer) {
+		t.Fatal(cmp.Diff(expected, resp.Functions, typeComparer))
+	}
+}
+
+func TestGRPCProvider_GetSchema_functionsFromGetFunctions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mockproto.NewMockProviderClient(ctrl)
+	p := &GRPCProvider{
+		client: client,
+	}
+
+	// The provider offers its functions only through GetFunctions.
+	schema := providerProtoSchema()
+	funcs := schema.Functions
+	schema.Functions = nil
+	client.EXPECT().GetSchema(
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+	).Return(schema, nil)
+	client.EXPECT().GetFunctions(
+		gomock.Any(),
+		gomock.Any(),
+	).Return(&proto.GetFunctions_Response{
+		Functions: funcs,
+	}, nil)
+
+	resp := p.GetProviderSchema()
+	checkDiags(t, resp.Diagnostics)
+
+	if _, ok := resp.Functions["fn"]; !ok {
+		t.Fatalf("missing function \"fn\" in schema: %#v", resp.Functions)
+	}
+}
+
+func TestGRPCProvider_CallFunction(t *testing.T) {
+	client := mockProviderClient(t)
+	p := &GRPCProvider{
+		client: client,
+	}
+
+	client.EXPECT().CallFunction(
+		gomock.Any(),
+		gomock.Any(),
+	).DoAndReturn(func(_ context.Context, req *proto.CallFunction_Request, _ ...grpc.CallOption) (*proto.CallFunction_Response, error) {
+		if req.Name != "fn" {
+			t.Errorf("wrong function name %q", req.Name)
+		}
+		if len(req.Arguments) != 3 {
+			t.Errorf("wrong number of arguments %d", len(req.Arguments))
+		}
+		return &proto.CallFunction_Response{
+			Result: &proto.DynamicValue{
+				Msgpack: []byte("\xa3bar"),
+			},
+		}, nil
+	})
+
+	resp := p.CallFunction(providers.CallFunctionRequest{
+		Name: "fn",
+		Arguments: []cty.Value{
+			cty.StringVal("foo"),
+			cty.True,
+			cty.False,
+		},
+	})
+	checkDiags(t, resp.Diagnostics)
+
+	if expected := cty.StringVal("bar"); !expected.RawEquals(resp.Result) {
+		t.Fatalf("wrong result\ngot:  %#v\nwant: %#v", resp.Result, expected)
+	}
+}
+
+func TestGRPCProvider_CallFunctionUnknown(t *testing.T) {
+	client := mockProviderClient(t)
+	p := &GRPCProvider{
+		client: client,
+	}
+
+	resp := p.CallFunction(providers.CallFunctionRequest{
+		Name: "missing",
+	})
+	checkDiagsHasError(t, resp.Diagnostics)
+}
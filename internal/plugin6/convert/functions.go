@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/providers"
+	proto "github.com/opentofu/opentofu/internal/tfplugin6"
+)
+
+// FunctionSpecToProto takes a providers.FunctionSpec and converts it to a
+// proto.Function for a grpc response.
+func FunctionSpecToProto(spec providers.FunctionSpec) (*proto.Function, error) {
+	ret, err := json.Marshal(spec.Return)
+	if err != nil {
+		return nil, err
+	}
+
+	fn := &proto.Function{
+		Summary:            spec.Summary,
+		Description:        spec.Description,
+		DescriptionKind:    protoTextFormatting(spec.DescriptionFormat),
+		DeprecationMessage: spec.DeprecationMessage,
+		Return: &proto.Function_Return{
+			Type: ret,
+		},
+	}
+
+	for _, param := range spec.Parameters {
+		p, err := functionParameterToProto(param)
+		if err != nil {
+			return nil, err
+		}
+		fn.Parameters = append(fn.Parameters, p)
+	}
+
+	if spec.VariadicParameter != nil {
+		fn.VariadicParameter, err = functionParameterToProto(*spec.VariadicParameter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return fn, nil
+}
+
+func functionParameterToProto(param providers.FunctionParameterSpec) (*proto.Function_Parameter, error) {
+	ty, err := json.Marshal(param.Type)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.Function_Parameter{
+		Name:               param.Name,
+		Type:               ty,
+		AllowNullValue:     param.AllowNullValue,
+		AllowUnknownValues: param.AllowUnknownValues,
+		Description:        param.Description,
+		DescriptionKind:    protoTextFormatting(param.DescriptionFormat),
+	}, nil
+}
+
+func protoTextFormatting(f providers.TextFormatting) proto.StringKind {
+	switch f {
+	default:
+		return proto.StringKind_PLAIN
+	case providers.TextFormattingMarkdown:
+		return proto.StringKind_MARKDOWN
+	}
+}
+
+// ProtoToFunctionSpec takes a proto.Function from a grpc response and
+// converts it to a providers.FunctionSpec.
+//
+// Unlike the schema conversions, this returns an error rather than panicking
+// when the provider sends an invalid type, since function definitions are
+// only validated once they are used.
+func ProtoToFunctionSpec(fn *proto.Function) (providers.FunctionSpec, error) {
+	spec := providers.FunctionSpec{
+		Summary:            fn.Summary,
+		Description:        fn.Description,
+		DescriptionFormat:  textFormatting(fn.DescriptionKind),
+		DeprecationMessage: fn.DeprecationMessage,
+	}
+
+	if fn.Return == nil {
+		return spec, fmt.Errorf("missing return type")
+	}
+	if err := json.Unmarshal(fn.Return.Type, &spec.Return); err != nil {
+		return spec, fmt.Errorf("invalid return type: %w", err)
+	}
+
+	for _, param := range fn.Parameters {
+		p, err := protoToFunctionParameter(param)
+		if err != nil {
+			return spec, err
+		}
+		spec.Parameters = append(spec.Parameters, p)
+	}
+
+	if fn.VariadicParameter != nil {
+		p, err := protoToFunctionParameter(fn.VariadicParameter)
+		if err != nil {
+			return spec, err
+		}
+		spec.VariadicParameter = &p
+	}
+
+	return spec, nil
+}
+
+func protoToFunctionParameter(param *proto.Function_Parameter) (providers.FunctionParameterSpec, error) {
+	var ty cty.Type
+	if err := json.Unmarshal(param.Type, &ty); err != nil {
+		return providers.FunctionParameterSpec{}, fmt.Errorf("invalid type for parameter %q: %w", param.Name, err)
+	}
+	return providers.FunctionParameterSpec{
+		Name:               param.Name,
+		Type:               ty,
+		AllowNullValue:     param.AllowNullValue,
+		AllowUnknownValues: param.AllowUnknownValues,
+		Description:        param.Description,
+		DescriptionFormat:  textFormatting(param.DescriptionKind),
+	}, nil
+}
+
+func textFormatting(k proto.StringKind) providers.TextFormatting {
+	switch k {
+	default:
+		return providers.TextFormattingPlain
+	case proto.StringKind_MARKDOWN:
+		return providers.TextFormattingMarkdown
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package convert
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/providers"
+	proto "github.com/opentofu/opentofu/internal/tfplugin6"
+)
+
+// Test that we can convert function specs to protobuf types and back again.
+func TestConvertFunctionSpec(t *testing.T) {
+	spec := providers.FunctionSpec{
+		Parameters: []providers.FunctionParameterSpec{
+			{
+				Name:               "input",
+				Type:               cty.List(cty.String),
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+				Description:        "The *input* list.",
+				DescriptionFormat:  providers.TextFormattingMarkdown,
+			},
+		},
+		VariadicParameter: &providers.FunctionParameterSpec{
+			Name:              "extra",
+			Type:              cty.DynamicPseudoType,
+			DescriptionFormat: providers.TextFormattingPlain,
+		},
+		Return:             cty.Map(cty.Number),
+		Summary:            "Does a thing",
+		Description:        "Does a thing to the input.",
+		DescriptionFormat:  providers.TextFormattingPlain,
+		DeprecationMessage: "Use the other thing instead.",
+	}
+
+	fn, err := FunctionSpecToProto(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(fn.Return.Type), `["map","number"]`; got != want {
+		t.Errorf("wrong return type\ngot:  %s\nwant: %s", got, want)
+	}
+	if got, want := fn.Parameters[0].DescriptionKind, proto.StringKind_MARKDOWN; got != want {
+		t.Errorf("wrong description kind\ngot:  %s\nwant: %s", got, want)
+	}
+
+	got, err := ProtoToFunctionSpec(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(spec, got, typeComparer) {
+		t.Fatal(cmp.Diff(spec, got, typeComparer))
+	}
+}
+
+func TestProtoToFunctionSpec_invalid(t *testing.T) {
+	tests := map[string]*proto.Function{
+		"missing return": {},
+		"invalid return type": {
+			Return: &proto.Function_Return{Type: []byte(`"nope"`)},
+		},
+		"invalid parameter type": {
+			Parameters: []*proto.Function_Parameter{
+				{Name: "a", Type: []byte(`{}`)},
+			},
+			Return: &proto.Function_Return{Type: []byte(`"string"`)},
+		},
+	}
+
+	for name, fn := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ProtoToFunctionSpec(fn); err == nil {
+				t.Fatal("expected error, got none")
+			}
+		})
+	}
+}
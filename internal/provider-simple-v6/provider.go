@@ -49,6 +49,24 @@ func Provider() providers.Interface {
 			ServerCapabilities: providers.ServerCapabilities{
 				PlanDestroy: true,
 			},
+			Functions: map[string]providers.FunctionSpec{
+				"noop": {
+					Parameters: []providers.FunctionParameterSpec{
+						{
+							Name:               "noop",
+							Type:               cty.DynamicPseudoType,
+							AllowNullValue:     true,
+							AllowUnknownValues: true,
+							Description:        "any value",
+							DescriptionFormat:  providers.TextFormattingPlain,
+						},
+					},
+					Return:            cty.DynamicPseudoType,
+					Summary:           "noop",
+					Description:       "noop returns its argument unchanged",
+					DescriptionFormat: providers.TextFormattingPlain,
+				},
+			},
 		},
 	}
 }
@@ -138,6 +156,11 @@ func (s simple) ImportResourceState(providers.ImportResourceStateRequest) (resp
 	return resp
 }
 
+func (s simple) MoveResourceState(providers.MoveResourceStateRequest) (resp providers.MoveResourceStateResponse) {
+	resp.Diagnostics = resp.Diagnostics.Append(errors.New("unsupported"))
+	return resp
+}
+
 func (s simple) ReadDataSource(req providers.ReadDataSourceRequest) (resp providers.ReadDataSourceResponse) {
 	m := req.Config.AsValueMap()
 	m["id"] = cty.StringVal("static_id")
@@ -145,6 +168,21 @@ func (s simple) ReadDataSource(req providers.ReadDataSourceRequest) (resp provid
 	return resp
 }
 
+func (s simple) GetFunctions() providers.GetFunctionsResponse {
+	return providers.GetFunctionsResponse{
+		Functions: s.schema.Functions,
+	}
+}
+
+func (s simple) CallFunction(req providers.CallFunctionRequest) (resp providers.CallFunctionResponse) {
+	if req.Name != "noop" {
+		resp.Diagnostics = resp.Diagnostics.Append(errors.New("unsupported function"))
+		return resp
+	}
+	resp.Result = req.Arguments[0]
+	return resp
+}
+
 func (s simple) Close() error {
 	return nil
 }
@@ -24,6 +24,12 @@ import (
 // A Dir also pays attention only to packages for the current host platform,
 // silently ignoring any cached packages for other platforms.
 //
+// Methods that modify a Dir take a lock on each package they modify, shared
+// with other processes, and install packages by populating a temporary
+// directory and then renaming it into place. Multiple processes can
+// therefore safely install packages into the same directory concurrently,
+// such as when it's used as a global cache directory.
+//
 // Various Dir methods return values that are technically mutable due to the
 // restrictions of the Go typesystem, but callers are not permitted to mutate
 // any part of the returned data structures.
@@ -37,7 +43,8 @@ type Dir struct {
 	// by any operation that modifies the contents of the cache directory.
 	//
 	// We intentionally don't make effort to detect modifications to the
-	// directory made by other codepaths because the contract for NewDir
+	// directory made by other codepaths, because packages are supposed to be
+	// immutable once installed, and the contract for NewDir
 	// explicitly defines using the same directory for multiple purposes
 	// as undefined behavior.
 	metaCache map[addrs.Provider][]CachedProvider
@@ -46,9 +53,10 @@ type Dir struct {
 // NewDir creates and returns a new Dir object that will read and write
 // provider plugins in the given filesystem directory.
 //
-// If two instances of Dir are concurrently operating on a particular base
-// directory, or if a Dir base directory is also used as a filesystem mirror
-// source directory, the behavior is undefined.
+// Multiple instances of Dir, including in other processes, can safely operate
+// on a particular base directory concurrently. If a Dir base directory is
+// also used as a filesystem mirror source directory, the behavior is
+// undefined.
 func NewDir(baseDir string) *Dir {
 	return &Dir{
 		baseDir:        baseDir,
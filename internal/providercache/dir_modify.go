@@ -5,9 +5,15 @@ package providercache
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
+	"os"
+	"path/filepath"
+	"syscall"
 
+	"github.com/opentofu/opentofu/internal/flock"
 	"github.com/opentofu/opentofu/internal/getproviders"
 )
 
@@ -27,14 +33,40 @@ func (d *Dir) InstallPackage(ctx context.Context, meta getproviders.PackageMeta,
 		d.baseDir, meta.Provider, meta.Version, d.targetPlatform,
 	)
 
+	unlock, err := lockPackageDir(ctx, newPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock %s v%s in %s: %w", meta.Provider, meta.Version, d.baseDir, err)
+	}
+	defer unlock()
+
 	// Invalidate our metaCache so that subsequent read calls will re-scan to
 	// incorporate any changes we make here.
 	d.metaCache = nil
 
+	// Another process might have installed the same package while we were
+	// waiting for the lock, in which case there's no need to fetch it again
+	// and replace it. We can only trust the existing package if it matches
+	// one of the allowed hashes, though.
+	if len(allowedHashes) > 0 {
+		if _, err := os.Stat(newPath); err == nil {
+			existing := &CachedProvider{
+				Provider:   meta.Provider,
+				Version:    meta.Version,
+				PackageDir: newPath,
+			}
+			if matches, err := existing.MatchesAnyHash(allowedHashes); err == nil && matches {
+				log.Printf("[TRACE] providercache.Dir.InstallPackage: %s v%s is already installed in %s", meta.Provider, meta.Version, newPath)
+				return nil, nil
+			}
+		}
+	}
+
 	log.Printf("[TRACE] providercache.Dir.InstallPackage: installing %s v%s from %s", meta.Provider, meta.Version, meta.Location)
 	switch meta.Location.(type) {
 	case getproviders.PackageHTTPURL:
 		return installFromHTTPURL(ctx, meta, newPath, allowedHashes)
+	case getproviders.PackageOCIBlob:
+		return installFromOCIBlob(ctx, meta, newPath, allowedHashes)
 	case getproviders.PackageLocalArchive:
 		return installFromLocalArchive(ctx, meta, newPath, allowedHashes)
 	case getproviders.PackageLocalDir:
@@ -61,7 +93,32 @@ func (d *Dir) InstallPackage(ctx context.Context, meta getproviders.PackageMeta,
 // in the set must match the package that "entry" refers to. If none of the
 // hashes match then the returned error message assumes that the hashes came
 // from a lock file.
-func (d *Dir) LinkFromOtherCache(entry *CachedProvider, allowedHashes []getproviders.Hash) error {
+func (d *Dir) LinkFromOtherCache(ctx context.Context, entry *CachedProvider, allowedHashes []getproviders.Hash) error {
+	newPath := getproviders.UnpackedDirectoryPathForPackage(
+		d.baseDir, entry.Provider, entry.Version, d.targetPlatform,
+	)
+	currentPath := entry.PackageDir
+
+	// Another process could be replacing the package in the other cache,
+	// which briefly leaves it missing, so we lock it while we link it. We
+	// can't lock a package in a cache that we can't write to, but we can
+	// still link it.
+	unlockCurrent, err := lockPackageDir(ctx, currentPath)
+	switch {
+	case err == nil:
+		defer unlockCurrent()
+	case errors.Is(err, fs.ErrPermission) || errors.Is(err, syscall.EROFS):
+		log.Printf("[WARN] Can't lock %s, so linking it without a lock: %s", currentPath, err)
+	default:
+		return fmt.Errorf("failed to lock %s v%s in %s: %w", entry.Provider, entry.Version, filepath.Dir(currentPath), err)
+	}
+
+	unlock, err := lockPackageDir(ctx, newPath)
+	if err != nil {
+		return fmt.Errorf("failed to lock %s v%s in %s: %w", entry.Provider, entry.Version, d.baseDir, err)
+	}
+	defer unlock()
+
 	if len(allowedHashes) > 0 {
 		if matches, err := entry.MatchesAnyHash(allowedHashes); err != nil {
 			return fmt.Errorf(
@@ -76,10 +133,6 @@ func (d *Dir) LinkFromOtherCache(entry *CachedProvider, allowedHashes []getprovi
 		}
 	}
 
-	newPath := getproviders.UnpackedDirectoryPathForPackage(
-		d.baseDir, entry.Provider, entry.Version, d.targetPlatform,
-	)
-	currentPath := entry.PackageDir
 	log.Printf("[TRACE] providercache.Dir.LinkFromOtherCache: linking %s v%s from existing cache %s to %s", entry.Provider, entry.Version, currentPath, newPath)
 
 	// Invalidate our metaCache so that subsequent read calls will re-scan to
@@ -105,6 +158,19 @@ func (d *Dir) LinkFromOtherCache(entry *CachedProvider, allowedHashes []getprovi
 	}
 	// No further hash check here because we already checked the hash
 	// of the source directory above.
-	_, err := installFromLocalDir(context.TODO(), meta, newPath, nil)
+	_, err = installFromLocalDir(ctx, meta, newPath, nil)
 	return err
 }
+
+// lockPackageDir takes an exclusive lock on the package directory at the
+// given path, shared with any other processes using the same cache directory,
+// and returns a function to release it.
+//
+// The lock file is alongside the package directory, where
+// getproviders.SearchLocalDirectory will ignore it.
+func lockPackageDir(ctx context.Context, packageDir string) (unlock func() error, err error) {
+	if err := os.MkdirAll(filepath.Dir(packageDir), 0755); err != nil {
+		return nil, err
+	}
+	return flock.Lock(ctx, filepath.Clean(packageDir)+".lock")
+}
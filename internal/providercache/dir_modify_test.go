@@ -5,14 +5,19 @@ package providercache
 
 import (
 	"context"
+	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/apparentlymart/go-versions/versions"
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/copy"
 	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/oci"
+	"github.com/opentofu/opentofu/internal/oci/ocitest"
 )
 
 func TestInstallPackage(t *testing.T) {
@@ -68,8 +73,128 @@ func TestInstallPackage(t *testing.T) {
 	}
 }
 
+func TestInstallPackage_ociBlob(t *testing.T) {
+	tmpDirPath, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	linuxPlatform := getproviders.Platform{
+		OS:   "linux",
+		Arch: "amd64",
+	}
+	nullProvider := addrs.NewProvider(
+		addrs.DefaultProviderRegistryHost, "hashicorp", "null",
+	)
+
+	content, err := os.ReadFile("testdata/provider-null_2.1.0_linux_amd64.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := ocitest.NewRegistry(t)
+	blob := registry.PushBlob("providers/hashicorp/null", getproviders.OCIProviderArchiveMediaType, content)
+
+	tmpDir := NewDirWithPlatform(tmpDirPath, linuxPlatform)
+
+	meta := getproviders.PackageMeta{
+		Provider: nullProvider,
+		Version:  versions.MustParseVersion("2.1.0"),
+
+		TargetPlatform: linuxPlatform,
+
+		Filename: "terraform-provider-null_2.1.0_linux_amd64.zip",
+		Location: getproviders.PackageOCIBlob{
+			Client:     registry.Client(),
+			Repository: oci.Repository{Registry: registry.Host(), Name: "providers/hashicorp/null"},
+			Blob:       blob,
+		},
+		Authentication: getproviders.NewOCIDigestAuthentication(linuxPlatform, blob.Digest.SHA256(), nil),
+	}
+
+	result, err := tmpDir.InstallPackage(context.TODO(), meta, nil)
+	if err != nil {
+		t.Fatalf("InstallPackage failed: %s", err)
+	}
+	if !result.VerifiedDigest() {
+		t.Errorf("wrong result %s; want verified digest", result)
+	}
+
+	got := tmpDir.AllAvailablePackages()
+	want := map[addrs.Provider][]CachedProvider{
+		nullProvider: {
+			CachedProvider{
+				Provider: nullProvider,
+
+				Version: versions.MustParseVersion("2.1.0"),
+
+				PackageDir: tmpDirPath + "/registry.opentofu.org/hashicorp/null/2.1.0/linux_amd64",
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong cache contents after install\n%s", diff)
+	}
+}
+
+func TestInstallPackage_alreadyInstalled(t *testing.T) {
+	tmpDirPath, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	linuxPlatform := getproviders.Platform{
+		OS:   "linux",
+		Arch: "amd64",
+	}
+	nullProvider := addrs.NewProvider(
+		addrs.DefaultProviderRegistryHost, "hashicorp", "null",
+	)
+
+	tmpDir := NewDirWithPlatform(tmpDirPath, linuxPlatform)
+
+	meta := getproviders.PackageMeta{
+		Provider: nullProvider,
+		Version:  versions.MustParseVersion("2.1.0"),
+
+		ProtocolVersions: getproviders.VersionList{versions.MustParseVersion("5.0.0")},
+		TargetPlatform:   linuxPlatform,
+
+		Filename: "provider-null_2.1.0_linux_amd64.zip",
+		Location: getproviders.PackageLocalArchive("testdata/provider-null_2.1.0_linux_amd64.zip"),
+	}
+	if _, err := tmpDir.InstallPackage(context.TODO(), meta, nil); err != nil {
+		t.Fatalf("InstallPackage failed: %s", err)
+	}
+	entry := tmpDir.ProviderVersion(nullProvider, meta.Version)
+	if entry == nil {
+		t.Fatalf("package not found after install")
+	}
+	hash, err := entry.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Installing the same package again must reuse the existing one if it
+	// matches the allowed hashes, without fetching it from its location.
+	meta.Location = getproviders.PackageLocalArchive("testdata/nonexist.zip")
+	if _, err := tmpDir.InstallPackage(context.TODO(), meta, []getproviders.Hash{hash}); err != nil {
+		t.Fatalf("InstallPackage failed for existing package: %s", err)
+	}
+
+	// If it doesn't match, though, the package must be fetched again.
+	otherHash := getproviders.HashScheme1.New("not-the-package-hash")
+	if _, err := tmpDir.InstallPackage(context.TODO(), meta, []getproviders.Hash{otherHash}); err == nil {
+		t.Fatalf("InstallPackage succeeded for existing package that doesn't match the allowed hashes")
+	}
+}
+
 func TestLinkFromOtherCache(t *testing.T) {
-	srcDirPath := "testdata/cachedir"
+	// LinkFromOtherCache locks the package it links, so we use a copy of
+	// the source cache directory to avoid leaving lock files in testdata.
+	srcDirPath := t.TempDir()
+	if err := copy.CopyDir(srcDirPath, "testdata/cachedir"); err != nil {
+		t.Fatal(err)
+	}
 	tmpDirPath, err := filepath.EvalSymlinks(t.TempDir())
 	if err != nil {
 		t.Fatal(err)
@@ -100,7 +225,7 @@ func TestLinkFromOtherCache(t *testing.T) {
 				// still packed and thus not considered to be a cache member.
 				Version: versions.MustParseVersion("2.0.0"),
 
-				PackageDir: "testdata/cachedir/registry.opentofu.org/hashicorp/null/2.0.0/windows_amd64",
+				PackageDir: srcDirPath + "/registry.opentofu.org/hashicorp/null/2.0.0/windows_amd64",
 			},
 		},
 	}
@@ -120,7 +245,7 @@ func TestLinkFromOtherCache(t *testing.T) {
 		t.Fatalf("null provider has no latest version in source directory")
 	}
 
-	err = tmpDir.LinkFromOtherCache(cacheEntry, nil)
+	err = tmpDir.LinkFromOtherCache(context.Background(), cacheEntry, nil)
 	if err != nil {
 		t.Fatalf("LinkFromOtherCache failed: %s", err)
 	}
@@ -144,3 +269,67 @@ func TestLinkFromOtherCache(t *testing.T) {
 		t.Errorf("wrong cache contents after link\n%s", diff)
 	}
 }
+
+func TestInstallPackage_concurrent(t *testing.T) {
+	tmpDirPath, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	linuxPlatform := getproviders.Platform{
+		OS:   "linux",
+		Arch: "amd64",
+	}
+	nullProvider := addrs.NewProvider(
+		addrs.DefaultProviderRegistryHost, "hashicorp", "null",
+	)
+	meta := getproviders.PackageMeta{
+		Provider: nullProvider,
+		Version:  versions.MustParseVersion("2.1.0"),
+
+		ProtocolVersions: getproviders.VersionList{versions.MustParseVersion("5.0.0")},
+		TargetPlatform:   linuxPlatform,
+
+		Filename: "provider-null_2.1.0_linux_amd64.zip",
+		Location: getproviders.PackageLocalArchive("testdata/provider-null_2.1.0_linux_amd64.zip"),
+	}
+
+	// Each installer has its own Dir, as if it were in a separate process,
+	// but they all share the same base directory.
+	var wg sync.WaitGroup
+	for n := 0; n < 8; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dir := NewDirWithPlatform(tmpDirPath, linuxPlatform)
+			if _, err := dir.InstallPackage(context.TODO(), meta, nil); err != nil {
+				t.Errorf("InstallPackage failed: %s", err)
+				return
+			}
+			entry := dir.ProviderVersion(nullProvider, meta.Version)
+			if entry == nil {
+				t.Errorf("package not found after install")
+				return
+			}
+			if _, err := entry.ExecutableFile(); err != nil {
+				t.Errorf("incomplete package after install: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Only the package directory and its lock file should remain, without
+	// any temporary directories.
+	entries, err := os.ReadDir(filepath.Join(tmpDirPath, "registry.opentofu.org/hashicorp/null/2.1.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Name())
+	}
+	want := []string{"linux_amd64", "linux_amd64.lock"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong directory contents after install\n%s", diff)
+	}
+}
@@ -454,7 +454,7 @@ NeedProvider:
 						continue
 					}
 
-					err := i.targetDir.LinkFromOtherCache(cached, preferredHashes)
+					err := i.targetDir.LinkFromOtherCache(ctx, cached, preferredHashes)
 					if err != nil {
 						errs[provider] = err
 						if cb := evts.LinkFromCacheFailure; cb != nil {
@@ -609,7 +609,7 @@ NeedProvider:
 			// series here (and that's why we use FetchPackageFailure below).
 			// We also don't do a hash check here because we already did that
 			// as part of the installTo.InstallPackage call above.
-			err := linkTo.LinkFromOtherCache(new, nil)
+			err := linkTo.LinkFromOtherCache(ctx, new, nil)
 			if err != nil {
 				errs[provider] = err
 				if cb := evts.FetchPackageFailure; cb != nil {
@@ -680,9 +680,10 @@ NeedProvider:
 		// For now, we will temporarily trust the hashes returned by the
 		// installation process that are "SigningSkipped" or "Signed".
 		// This is only intended to be temporary, see https://github.com/opentofu/opentofu/issues/266 for more information
-		if authResult.Signed() || authResult.SigningSkipped() {
+		if authResult.Signed() || authResult.SigningSkipped() || authResult.VerifiedDigest() {
 			// We'll trust new hashes from upstream only if they were verified
-			// as signed by a suitable key or if the signing validation was skipped.
+			// as signed by a suitable key, if the signing validation was skipped,
+			// or if they are the content digests from an OCI registry index.
 			// Otherwise, we'd record only
 			// a new hash we just calculated ourselves from the bytes on disk,
 			// and so the hashes would cover only the current platform.
@@ -12,6 +12,7 @@ import (
 	"net/http/httptest"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/apparentlymart/go-versions/versions"
@@ -2367,6 +2368,65 @@ func TestEnsureProviderVersions_local_source(t *testing.T) {
 	}
 }
 
+func TestEnsureProviderVersions_concurrentGlobalCache(t *testing.T) {
+	// This simulates several "tofu init" commands running concurrently in
+	// different working directories but sharing the same global cache
+	// directory, each with its own Installer and Dir objects as if they
+	// were in separate processes.
+	nullProvider := addrs.MustParseProviderSourceString("hashicorp/null")
+	version := getproviders.MustParseVersion("2.1.0")
+	platform := getproviders.Platform{OS: "linux", Arch: "amd64"}
+	reqs := getproviders.Requirements{
+		nullProvider: getproviders.MustParseVersionConstraints("2.1.0"),
+	}
+	globalCacheDirPath := tmpDir(t)
+
+	var wg sync.WaitGroup
+	for n := 0; n < 8; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			source := getproviders.NewMockSource(
+				[]getproviders.PackageMeta{
+					{
+						Provider:       nullProvider,
+						Version:        version,
+						TargetPlatform: platform,
+						Location:       getproviders.PackageLocalArchive("testdata/provider-null_2.1.0_linux_amd64.zip"),
+					},
+				},
+				nil,
+			)
+			dir := NewDirWithPlatform(tmpDir(t), platform)
+			inst := NewInstaller(dir, source)
+			inst.SetGlobalCacheDir(NewDirWithPlatform(globalCacheDirPath, platform))
+
+			locks, err := inst.EnsureProviderVersions(context.Background(), depsfile.NewLocks(), reqs, InstallNewProvidersOnly)
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			if locks.Provider(nullProvider) == nil {
+				t.Errorf("no lock for %s", nullProvider)
+			}
+			entry := dir.ProviderVersion(nullProvider, version)
+			if entry == nil {
+				t.Errorf("%s was not installed", nullProvider)
+				return
+			}
+			if _, err := entry.ExecutableFile(); err != nil {
+				t.Errorf("incomplete package after install: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	globalCacheDir := NewDirWithPlatform(globalCacheDirPath, platform)
+	if globalCacheDir.ProviderVersion(nullProvider, version) == nil {
+		t.Errorf("%s was not installed into the global cache directory", nullProvider)
+	}
+}
+
 // This test only verifies protocol errors and does not try for successful
 // installation (at the time of writing, the test files aren't signed so the
 // signature verification fails); that's left to the e2e tests.
@@ -6,6 +6,7 @@ package providercache
 import (
 	"context"
 	"fmt"
+	"log"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -72,9 +73,39 @@ func installFromHTTPURL(ctx context.Context, meta getproviders.PackageMeta, targ
 		return nil, err
 	}
 
-	archiveFilename := f.Name()
+	return installFromDownloadedArchive(ctx, meta, f.Name(), targetDir, allowedHashes)
+}
+
+func installFromOCIBlob(ctx context.Context, meta getproviders.PackageMeta, targetDir string, allowedHashes []getproviders.Hash) (*getproviders.PackageAuthenticationResult, error) {
+	loc := meta.Location.(getproviders.PackageOCIBlob)
+
+	f, err := os.CreateTemp("", "terraform-provider")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open temporary file to download from %s: %w", loc, err)
+	}
+	defer f.Close()
+	defer os.Remove(f.Name())
+
+	// The OCI client verifies the size and digest of the blob as it
+	// downloads it, but we'll still authenticate the archive afterwards in
+	// the same way as for any other location.
+	err = loc.Client.Blob(ctx, loc.Repository, loc.Blob, f)
+	if err != nil {
+		if ctx.Err() == context.Canceled {
+			return nil, fmt.Errorf("provider download was interrupted")
+		}
+		return nil, err
+	}
+
+	return installFromDownloadedArchive(ctx, meta, f.Name(), targetDir, allowedHashes)
+}
+
+// installFromDownloadedArchive authenticates and then extracts an archive
+// that was downloaded from the location in meta to a local temporary file.
+func installFromDownloadedArchive(ctx context.Context, meta getproviders.PackageMeta, archiveFilename string, targetDir string, allowedHashes []getproviders.Hash) (*getproviders.PackageAuthenticationResult, error) {
 	localLocation := getproviders.PackageLocalArchive(archiveFilename)
 
+	var err error
 	var authResult *getproviders.PackageAuthenticationResult
 	if meta.Authentication != nil {
 		if authResult, err = meta.Authentication.AuthenticatePackage(localLocation); err != nil {
@@ -127,15 +158,12 @@ func installFromLocalArchive(ctx context.Context, meta getproviders.PackageMeta,
 
 	filename := meta.Location.String()
 
-	// NOTE: We're not checking whether there's already a directory at
-	// targetDir with some files in it. Packages are supposed to be immutable
-	// and therefore we'll just be overwriting all of the existing files with
-	// their same contents unless something unusual is happening. If something
-	// unusual _is_ happening then this will produce something that doesn't
-	// match the allowed hashes and so our caller should catch that after
-	// we return if so.
-
-	err := unzip.Decompress(targetDir, filename, true, 0000)
+	// We extract the archive into a new directory and then replace any
+	// existing directory at targetDir with it, so that other processes never
+	// see a partially-extracted package.
+	err := installDirAtomically(targetDir, func(dir string) error {
+		return unzip.Decompress(dir, filename, true, 0000)
+	})
 	if err != nil {
 		return authResult, err
 	}
@@ -215,12 +243,6 @@ func installFromLocalDir(ctx context.Context, meta getproviders.PackageMeta, tar
 		}
 	}
 
-	// Delete anything that's already present at this path first.
-	err = os.RemoveAll(targetDir)
-	if err != nil && !os.IsNotExist(err) {
-		return nil, fmt.Errorf("failed to remove existing %s before linking it to %s: %w", sourceDir, targetDir, err)
-	}
-
 	// We'll prefer to create a symlink if possible, but we'll fall back to
 	// a recursive copy if symlink creation fails. It could fail for a number
 	// of reasons, including being on Windows 8 without administrator
@@ -234,30 +256,75 @@ func installFromLocalDir(ctx context.Context, meta getproviders.PackageMeta, tar
 	// and thus we can't assume that they will move around together.
 	linkTarget := absCurrent
 
-	parentDir := filepath.Dir(absNew)
-	err = os.MkdirAll(parentDir, 0755)
+	// As for archives, we create the symlink or copy at a temporary path and
+	// then replace anything that's already present at targetDir with it.
+	err = installDirAtomically(absNew, func(dir string) error {
+		if err := os.Remove(dir); err != nil {
+			return err
+		}
+		if err := os.Symlink(linkTarget, dir); err == nil {
+			// Success, then!
+			return nil
+		}
+
+		// If we get down here then symlinking failed and we need a deep copy
+		// instead. To make a copy, we first need to create the target
+		// directory, which would otherwise be a symlink.
+		if err := os.Mkdir(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+		if err := copy.CopyDir(dir, absCurrent); err != nil {
+			return fmt.Errorf("failed to either symlink or copy %s to %s: %w", absCurrent, absNew, err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create parent directories leading to %s: %w", targetDir, err)
+		return nil, err
 	}
 
-	err = os.Symlink(linkTarget, absNew)
-	if err == nil {
-		// Success, then!
-		return nil, nil
-	}
+	return nil, nil
+}
 
-	// If we get down here then symlinking failed and we need a deep copy
-	// instead. To make a copy, we first need to create the target directory,
-	// which would otherwise be a symlink.
-	err = os.Mkdir(absNew, 0755)
-	if err != nil && os.IsExist(err) {
-		return nil, fmt.Errorf("failed to create directory %s: %w", absNew, err)
+// installDirAtomically calls populate with the path of a new empty directory
+// alongside targetDir, and then if populate succeeds it replaces anything at
+// targetDir with whatever populate left at that path, which may be a symlink
+// rather than a directory.
+//
+// Other processes can therefore never see a partial package at targetDir.
+// Replacing an existing package takes two renames though, so targetDir is
+// briefly missing. Callers should hold the lock from lockPackageDir, and so
+// should any other process reading targetDir.
+func installDirAtomically(targetDir string, populate func(dir string) error) error {
+	parentDir := filepath.Dir(targetDir)
+	if err := os.MkdirAll(parentDir, 0755); err != nil {
+		return fmt.Errorf("failed to create parent directories leading to %s: %w", targetDir, err)
 	}
-	err = copy.CopyDir(absNew, absCurrent)
+
+	// The temporary directory is alongside the target directory so that
+	// it's on the same filesystem, and so can be renamed into place.
+	tmpDir, err := os.MkdirTemp(parentDir, "."+filepath.Base(targetDir)+".tmp")
 	if err != nil {
-		return nil, fmt.Errorf("failed to either symlink or copy %s to %s: %w", absCurrent, absNew, err)
+		return fmt.Errorf("failed to create temporary directory for %s: %w", targetDir, err)
 	}
+	defer os.RemoveAll(tmpDir)
 
-	// If we got here then apparently our copy succeeded, so we're done.
-	return nil, nil
+	if err := populate(tmpDir); err != nil {
+		return err
+	}
+
+	// A directory can't be renamed over an existing one on all platforms, so
+	// we first move any existing one aside and remove it only afterwards.
+	oldDir := tmpDir + ".old"
+	if err := os.Rename(targetDir, oldDir); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove existing %s: %w", targetDir, err)
+	}
+	if err := os.Rename(tmpDir, targetDir); err != nil {
+		// Restore whatever was there before, if anything.
+		os.Rename(oldDir, targetDir)
+		return fmt.Errorf("failed to move new package into %s: %w", targetDir, err)
+	}
+	if err := os.RemoveAll(oldDir); err != nil {
+		log.Printf("[WARN] Failed to remove previous package directory at %s: %s", oldDir, err)
+	}
+	return nil
 }
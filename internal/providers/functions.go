@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providers
+
+import (
+	"fmt"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// TextFormatting describes how the documentation strings of a function are
+// formatted.
+type TextFormatting string
+
+const (
+	TextFormattingPlain    = TextFormatting("Plain")
+	TextFormattingMarkdown = TextFormatting("Markdown")
+)
+
+// FunctionSpec is the signature and documentation of a single function
+// contributed by a provider.
+type FunctionSpec struct {
+	// Parameters is the ordered list of positional parameters.
+	Parameters []FunctionParameterSpec
+
+	// VariadicParameter is an optional final parameter which accepts zero or
+	// more arguments of the same type.
+	VariadicParameter *FunctionParameterSpec
+
+	// Return is the type of the function result.
+	Return cty.Type
+
+	Summary            string
+	Description        string
+	DescriptionFormat  TextFormatting
+	DeprecationMessage string
+}
+
+// FunctionParameterSpec describes a single parameter of a provider function.
+type FunctionParameterSpec struct {
+	// Name is the human-readable display name of the parameter.
+	Name string
+
+	// Type is the type constraint for the parameter.
+	Type cty.Type
+
+	// AllowNullValue permits a null argument to be passed to the provider.
+	// Otherwise OpenTofu rejects null arguments before calling the function.
+	AllowNullValue bool
+
+	// AllowUnknownValues permits unknown arguments to be passed to the
+	// provider. Otherwise OpenTofu skips calling the function and assumes an
+	// unknown result.
+	AllowUnknownValues bool
+
+	Description       string
+	DescriptionFormat TextFormatting
+}
+
+type GetFunctionsResponse struct {
+	// Functions maps the name of each function to its signature.
+	Functions map[string]FunctionSpec
+
+	// Diagnostics contains any warnings or errors from the method call.
+	Diagnostics tfdiags.Diagnostics
+}
+
+type CallFunctionRequest struct {
+	// Name is the name of the function being called.
+	Name string
+
+	// Arguments are the argument values, in the same order as the parameters
+	// of the function. Any variadic arguments follow the positional ones.
+	Arguments []cty.Value
+}
+
+type CallFunctionResponse struct {
+	// Result is the value returned by the function.
+	Result cty.Value
+
+	// Diagnostics contains any warnings or errors from the method call.
+	Diagnostics tfdiags.Diagnostics
+}
+
+// BuildFunction returns a cty function that calls the named provider function
+// using the provider returned by getProvider.
+//
+// The provider is only requested when the function is actually called, so
+// that merely making the function available doesn't require starting the
+// provider. The arguments and the result are checked against the types in
+// the receiving spec.
+func (spec FunctionSpec) BuildFunction(name string, getProvider func() (Interface, error)) function.Function {
+	params := make([]function.Parameter, len(spec.Parameters))
+	for i, param := range spec.Parameters {
+		params[i] = param.buildParameter()
+	}
+
+	var varParam *function.Parameter
+	if spec.VariadicParameter != nil {
+		p := spec.VariadicParameter.buildParameter()
+		varParam = &p
+	}
+
+	description := spec.Description
+	if description == "" {
+		description = spec.Summary
+	}
+
+	return function.New(&function.Spec{
+		Description: description,
+		Params:      params,
+		VarParam:    varParam,
+		Type:        function.StaticReturnType(spec.Return),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			provider, err := getProvider()
+			if err != nil {
+				return cty.UnknownVal(retType), err
+			}
+
+			resp := provider.CallFunction(CallFunctionRequest{
+				Name:      name,
+				Arguments: args,
+			})
+			if resp.Diagnostics.HasErrors() {
+				return cty.UnknownVal(retType), resp.Diagnostics.Err()
+			}
+
+			result, err := convert.Convert(resp.Result, retType)
+			if err != nil {
+				return cty.UnknownVal(retType), fmt.Errorf("provider returned an invalid result for function %q: %w", name, err)
+			}
+			return result, nil
+		},
+	})
+}
+
+func (spec FunctionParameterSpec) buildParameter() function.Parameter {
+	return function.Parameter{
+		Name:             spec.Name,
+		Description:      spec.Description,
+		Type:             spec.Type,
+		AllowNull:        spec.AllowNullValue,
+		AllowUnknown:     spec.AllowUnknownValues,
+		AllowDynamicType: spec.Type == cty.DynamicPseudoType,
+	}
+}
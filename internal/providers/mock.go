@@ -0,0 +1,254 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providers
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+var _ Interface = (*Mock)(nil)
+
+// Mock is a provider that wraps a real provider, using it only for its
+// schema and for validation, and synthesizes the results of every other
+// operation instead of calling the real provider.
+//
+// Mock providers are used by the "tofu test" command to implement the
+// mock_provider, override_resource, override_data and override_module blocks.
+// Computed attributes are set from the defaults in Data where available,
+// and are otherwise generated.
+type Mock struct {
+	Provider Interface
+
+	// Data contains the default values for resources and data sources. It may
+	// be nil, in which case all computed values are generated.
+	Data *configs.MockData
+
+	schemaLock sync.Mutex
+	schema     *GetProviderSchemaResponse
+}
+
+func (m *Mock) GetProviderSchema() GetProviderSchemaResponse {
+	m.schemaLock.Lock()
+	defer m.schemaLock.Unlock()
+
+	if m.schema == nil {
+		schema := m.Provider.GetProviderSchema()
+		if schema.Diagnostics.HasErrors() {
+			// Don't cache errors, so that they're reported consistently.
+			return schema
+		}
+		m.schema = &schema
+	}
+	return *m.schema
+}
+
+func (m *Mock) ValidateProviderConfig(req ValidateProviderConfigRequest) ValidateProviderConfigResponse {
+	// Mock providers are never configured, so any configuration is valid.
+	return ValidateProviderConfigResponse{
+		PreparedConfig: req.Config,
+	}
+}
+
+func (m *Mock) ValidateResourceConfig(req ValidateResourceConfigRequest) ValidateResourceConfigResponse {
+	return m.Provider.ValidateResourceConfig(req)
+}
+
+func (m *Mock) ValidateDataResourceConfig(req ValidateDataResourceConfigRequest) ValidateDataResourceConfigResponse {
+	return m.Provider.ValidateDataResourceConfig(req)
+}
+
+func (m *Mock) UpgradeResourceState(req UpgradeResourceStateRequest) UpgradeResourceStateResponse {
+	var resp UpgradeResourceStateResponse
+
+	schema, diags := m.schemaForResourceType(addrs.ManagedResourceMode, req.TypeName)
+	resp.Diagnostics = diags
+	if diags.HasErrors() {
+		return resp
+	}
+
+	// The mock provider always writes state using the current schema, so
+	// there's never any upgrading to do beyond decoding the state.
+	if req.RawStateJSON == nil {
+		resp.Diagnostics = resp.Diagnostics.Append(fmt.Errorf("mock provider cannot upgrade legacy flatmap state for %s", req.TypeName))
+		return resp
+	}
+	val, err := ctyjson.Unmarshal(req.RawStateJSON, schema.ImpliedType())
+	if err != nil {
+		resp.Diagnostics = resp.Diagnostics.Append(fmt.Errorf("failed to decode state for %s: %w", req.TypeName, err))
+		return resp
+	}
+	resp.UpgradedState = val
+	return resp
+}
+
+func (m *Mock) ConfigureProvider(ConfigureProviderRequest) ConfigureProviderResponse {
+	// Mock providers are never configured.
+	return ConfigureProviderResponse{}
+}
+
+func (m *Mock) Stop() error {
+	// There are never any in-flight actions to stop.
+	return nil
+}
+
+func (m *Mock) ReadResource(req ReadResourceRequest) ReadResourceResponse {
+	// There is no remote object, so the prior state is always up to date.
+	return ReadResourceResponse{
+		NewState: req.PriorState,
+		Private:  req.Private,
+	}
+}
+
+func (m *Mock) PlanResourceChange(req PlanResourceChangeRequest) PlanResourceChangeResponse {
+	var resp PlanResourceChangeResponse
+
+	if req.ProposedNewState.IsNull() {
+		// Then this is a destroy operation, and there's nothing to plan.
+		resp.PlannedState = req.ProposedNewState
+		return resp
+	}
+
+	schema, diags := m.schemaForResourceType(addrs.ManagedResourceMode, req.TypeName)
+	resp.Diagnostics = diags
+	if diags.HasErrors() {
+		return resp
+	}
+
+	val, diags := planComputedValuesForResource(req.ProposedNewState, schema, m.defaults(addrs.ManagedResourceMode, req.TypeName))
+	resp.Diagnostics = resp.Diagnostics.Append(diags)
+	resp.PlannedState = val
+	resp.PlannedPrivate = req.PriorPrivate
+	return resp
+}
+
+func (m *Mock) ApplyResourceChange(req ApplyResourceChangeRequest) ApplyResourceChangeResponse {
+	var resp ApplyResourceChangeResponse
+
+	if req.PlannedState.IsNull() {
+		// Then this is a destroy operation, and there's nothing to do.
+		resp.NewState = req.PlannedState
+		return resp
+	}
+
+	schema, diags := m.schemaForResourceType(addrs.ManagedResourceMode, req.TypeName)
+	resp.Diagnostics = diags
+	if diags.HasErrors() {
+		return resp
+	}
+
+	val, diags := applyComputedValuesForResource(req.PlannedState, schema, m.defaults(addrs.ManagedResourceMode, req.TypeName))
+	resp.Diagnostics = resp.Diagnostics.Append(diags)
+	resp.NewState = val
+	resp.Private = req.PlannedPrivate
+	return resp
+}
+
+func (m *Mock) ImportResourceState(req ImportResourceStateRequest) ImportResourceStateResponse {
+	var resp ImportResourceStateResponse
+
+	schema, diags := m.schemaForResourceType(addrs.ManagedResourceMode, req.TypeName)
+	resp.Diagnostics = diags
+	if diags.HasErrors() {
+		return resp
+	}
+
+	// We don't know anything about the imported object except its ID, so we
+	// start from an empty object and fill in all the computed attributes.
+	attrs := make(map[string]cty.Value)
+	for name, attrTy := range schema.ImpliedType().AttributeTypes() {
+		attrs[name] = cty.NullVal(attrTy)
+	}
+	if attr, ok := schema.Attributes["id"]; ok && attr.Type == cty.String {
+		attrs["id"] = cty.StringVal(req.ID)
+	}
+	val, diags := applyComputedValuesForResource(cty.ObjectVal(attrs), schema, m.defaults(addrs.ManagedResourceMode, req.TypeName))
+	resp.Diagnostics = resp.Diagnostics.Append(diags)
+	if diags.HasErrors() {
+		return resp
+	}
+
+	resp.ImportedResources = []ImportedResource{
+		{
+			TypeName: req.TypeName,
+			State:    val,
+		},
+	}
+	return resp
+}
+
+func (m *Mock) MoveResourceState(req MoveResourceStateRequest) MoveResourceStateResponse {
+	// Moving an object between resource types only translates its state,
+	// without making any requests to the remote system, so calling the real
+	// provider gives the same result as a mock could.
+	return m.Provider.MoveResourceState(req)
+}
+
+func (m *Mock) ReadDataSource(req ReadDataSourceRequest) ReadDataSourceResponse {
+	var resp ReadDataSourceResponse
+
+	schema, diags := m.schemaForResourceType(addrs.DataResourceMode, req.TypeName)
+	resp.Diagnostics = diags
+	if diags.HasErrors() {
+		return resp
+	}
+
+	val, diags := applyComputedValuesForResource(req.Config, schema, m.defaults(addrs.DataResourceMode, req.TypeName))
+	resp.Diagnostics = resp.Diagnostics.Append(diags)
+	resp.State = val
+	return resp
+}
+
+func (m *Mock) GetFunctions() GetFunctionsResponse {
+	return m.Provider.GetFunctions()
+}
+
+func (m *Mock) CallFunction(req CallFunctionRequest) CallFunctionResponse {
+	// Provider functions are expected to be pure, so calling the real
+	// provider gives the same result as a mock could.
+	return m.Provider.CallFunction(req)
+}
+
+func (m *Mock) Close() error {
+	return m.Provider.Close()
+}
+
+func (m *Mock) schemaForResourceType(mode addrs.ResourceMode, typeName string) (*configschema.Block, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	schema := m.GetProviderSchema()
+	diags = diags.Append(schema.Diagnostics)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	block, _ := schema.SchemaForResourceType(mode, typeName)
+	if block == nil {
+		diags = diags.Append(fmt.Errorf("mock provider has no schema for %s", typeName))
+	}
+	return block, diags
+}
+
+func (m *Mock) defaults(mode addrs.ResourceMode, typeName string) cty.Value {
+	if m.Data == nil {
+		return cty.EmptyObjectVal
+	}
+
+	resources := m.Data.MockResources
+	if mode == addrs.DataResourceMode {
+		resources = m.Data.MockDataResources
+	}
+	if res, ok := resources[typeName]; ok {
+		return res.Defaults
+	}
+	return cty.EmptyObjectVal
+}
@@ -0,0 +1,225 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providers
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// planComputedValuesForResource accepts the proposed new state of a resource
+// and fills in any computed attributes that are null, either from the given
+// defaults or by marking them as unknown so they can be filled in during the
+// apply.
+func planComputedValuesForResource(proposedNewState cty.Value, schema *configschema.Block, defaults cty.Value) (cty.Value, tfdiags.Diagnostics) {
+	return fillComputedValues(proposedNewState, schema, defaults, nil, func(ty cty.Type) cty.Value {
+		return cty.UnknownVal(ty)
+	})
+}
+
+// applyComputedValuesForResource accepts the planned state of a resource and
+// replaces any unknown computed attributes with either the given defaults or
+// generated values.
+func applyComputedValuesForResource(plannedState cty.Value, schema *configschema.Block, defaults cty.Value) (cty.Value, tfdiags.Diagnostics) {
+	val, diags := fillComputedValues(plannedState, schema, defaults, nil, generateValue)
+	if diags.HasErrors() {
+		return val, diags
+	}
+
+	// Anything that is still unknown at this point wasn't a computed
+	// attribute, but must still be known after the apply.
+	val, err := cty.Transform(val, func(path cty.Path, v cty.Value) (cty.Value, error) {
+		if !v.IsKnown() {
+			return generateValue(v.Type()), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		// This can't happen, since our transform function never fails.
+		panic(err)
+	}
+	return val, diags
+}
+
+// generateValue returns an arbitrary known value of the given type.
+//
+// Primitive values are zero values, except for strings which are given a
+// short random value so that they can act as unique identifiers. Collections
+// are empty, and objects and tuples have a generated value for each of their
+// attributes or elements.
+func generateValue(ty cty.Type) cty.Value {
+	switch {
+	case ty == cty.String:
+		return cty.StringVal(randomString(8))
+	case ty == cty.Number:
+		return cty.Zero
+	case ty == cty.Bool:
+		return cty.False
+	case ty.IsListType():
+		return cty.ListValEmpty(ty.ElementType())
+	case ty.IsSetType():
+		return cty.SetValEmpty(ty.ElementType())
+	case ty.IsMapType():
+		return cty.MapValEmpty(ty.ElementType())
+	case ty.IsObjectType():
+		attrs := make(map[string]cty.Value)
+		for name, attrTy := range ty.AttributeTypes() {
+			attrs[name] = generateValue(attrTy)
+		}
+		return cty.ObjectVal(attrs)
+	case ty.IsTupleType():
+		elems := make([]cty.Value, len(ty.TupleElementTypes()))
+		for i, elemTy := range ty.TupleElementTypes() {
+			elems[i] = generateValue(elemTy)
+		}
+		return cty.TupleVal(elems)
+	default:
+		// We can't generate a value for cty.DynamicPseudoType, or for any
+		// type we don't know about, so we just leave it null.
+		return cty.NullVal(ty)
+	}
+}
+
+// fillComputedValues walks the given value according to the schema, and
+// replaces every computed attribute that is either null or unknown with its
+// value from defaults, or with the result of the fallback function if there
+// is no default.
+func fillComputedValues(val cty.Value, schema *configschema.Block, defaults cty.Value, path cty.Path, fallback func(cty.Type) cty.Value) (cty.Value, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	if val.IsNull() || !val.IsKnown() {
+		// Nothing to fill in if the object itself doesn't exist.
+		return val, diags
+	}
+
+	if defaults.IsNull() {
+		defaults = cty.EmptyObjectVal
+	}
+	for name := range defaults.Type().AttributeTypes() {
+		_, isAttr := schema.Attributes[name]
+		_, isBlock := schema.BlockTypes[name]
+		if !isAttr && !isBlock {
+			diags = diags.Append(tfdiags.AttributeValue(
+				tfdiags.Error,
+				"Invalid mock value",
+				fmt.Sprintf("The mock value for %q does not match any attribute or block in the schema.", name),
+				path.GetAttr(name),
+			))
+		}
+	}
+	if diags.HasErrors() {
+		return val, diags
+	}
+
+	attrs := val.AsValueMap()
+	if attrs == nil {
+		attrs = make(map[string]cty.Value)
+	}
+
+	for name, attr := range schema.Attributes {
+		current := attrs[name]
+		if !attr.Computed || (current.IsKnown() && !current.IsNull()) {
+			continue
+		}
+
+		if defaults.Type().HasAttribute(name) {
+			converted, err := convert.Convert(defaults.GetAttr(name), attr.ImpliedType())
+			if err != nil {
+				diags = diags.Append(tfdiags.AttributeValue(
+					tfdiags.Error,
+					"Invalid mock value",
+					fmt.Sprintf("The mock value for %q is not compatible with the schema: %s.", name, tfdiags.FormatError(err)),
+					path.GetAttr(name),
+				))
+				continue
+			}
+			attrs[name] = converted
+			continue
+		}
+
+		attrs[name] = fallback(attr.ImpliedType())
+	}
+
+	for name, blockS := range schema.BlockTypes {
+		current := attrs[name]
+		if current.IsNull() || !current.IsKnown() {
+			continue
+		}
+
+		blockDefaults := cty.EmptyObjectVal
+		if defaults.Type().HasAttribute(name) {
+			blockDefaults = defaults.GetAttr(name)
+			if !blockDefaults.Type().IsObjectType() {
+				diags = diags.Append(tfdiags.AttributeValue(
+					tfdiags.Error,
+					"Invalid mock value",
+					fmt.Sprintf("The mock value for the nested block %q must be an object.", name),
+					path.GetAttr(name),
+				))
+				continue
+			}
+		}
+
+		blockPath := path.GetAttr(name)
+		switch blockS.Nesting {
+		case configschema.NestingSingle, configschema.NestingGroup:
+			filled, moreDiags := fillComputedValues(current, &blockS.Block, blockDefaults, blockPath, fallback)
+			diags = diags.Append(moreDiags)
+			attrs[name] = filled
+		case configschema.NestingList, configschema.NestingSet:
+			if current.LengthInt() == 0 {
+				continue
+			}
+			var elems []cty.Value
+			for it := current.ElementIterator(); it.Next(); {
+				key, elem := it.Element()
+				filled, moreDiags := fillComputedValues(elem, &blockS.Block, blockDefaults, blockPath.Index(key), fallback)
+				diags = diags.Append(moreDiags)
+				elems = append(elems, filled)
+			}
+			switch {
+			case blockS.Nesting == configschema.NestingSet:
+				attrs[name] = cty.SetVal(elems)
+			case current.Type().IsTupleType():
+				attrs[name] = cty.TupleVal(elems)
+			default:
+				attrs[name] = cty.ListVal(elems)
+			}
+		case configschema.NestingMap:
+			if current.LengthInt() == 0 {
+				continue
+			}
+			elems := make(map[string]cty.Value)
+			for it := current.ElementIterator(); it.Next(); {
+				key, elem := it.Element()
+				filled, moreDiags := fillComputedValues(elem, &blockS.Block, blockDefaults, blockPath.Index(key), fallback)
+				diags = diags.Append(moreDiags)
+				elems[key.AsString()] = filled
+			}
+			if current.Type().IsObjectType() {
+				attrs[name] = cty.ObjectVal(elems)
+			} else {
+				attrs[name] = cty.MapVal(elems)
+			}
+		}
+	}
+
+	return cty.ObjectVal(attrs), diags
+}
+
+const randomStringChars = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+func randomString(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = randomStringChars[rand.Intn(len(randomStringChars))]
+	}
+	return string(b)
+}
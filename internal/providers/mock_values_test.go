@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providers
+
+import (
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+)
+
+var testSchema = &configschema.Block{
+	Attributes: map[string]*configschema.Attribute{
+		"id": {
+			Type:     cty.String,
+			Computed: true,
+		},
+		"value": {
+			Type:     cty.String,
+			Required: true,
+		},
+		"count": {
+			Type:     cty.Number,
+			Optional: true,
+			Computed: true,
+		},
+	},
+	BlockTypes: map[string]*configschema.NestedBlock{
+		"nested": {
+			Nesting: configschema.NestingList,
+			Block: configschema.Block{
+				Attributes: map[string]*configschema.Attribute{
+					"computed": {
+						Type:     cty.Bool,
+						Computed: true,
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestPlanComputedValuesForResource(t *testing.T) {
+	proposed := cty.ObjectVal(map[string]cty.Value{
+		"id":    cty.NullVal(cty.String),
+		"value": cty.StringVal("hello"),
+		"count": cty.NumberIntVal(2),
+		"nested": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"computed": cty.NullVal(cty.Bool),
+			}),
+		}),
+	})
+
+	got, diags := planComputedValuesForResource(proposed, testSchema, cty.ObjectVal(map[string]cty.Value{
+		"count": cty.NumberIntVal(5),
+		"nested": cty.ObjectVal(map[string]cty.Value{
+			"computed": cty.True,
+		}),
+	}))
+	if diags.HasErrors() {
+		t.Fatal(diags.Err())
+	}
+
+	want := cty.ObjectVal(map[string]cty.Value{
+		"id":    cty.UnknownVal(cty.String),
+		"value": cty.StringVal("hello"),
+		"count": cty.NumberIntVal(2),
+		"nested": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"computed": cty.True,
+			}),
+		}),
+	})
+	if !got.RawEquals(want) {
+		t.Fatalf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestApplyComputedValuesForResource(t *testing.T) {
+	planned := cty.ObjectVal(map[string]cty.Value{
+		"id":     cty.UnknownVal(cty.String),
+		"value":  cty.StringVal("hello"),
+		"count":  cty.UnknownVal(cty.Number),
+		"nested": cty.ListValEmpty(cty.Object(map[string]cty.Type{"computed": cty.Bool})),
+	})
+
+	got, diags := applyComputedValuesForResource(planned, testSchema, cty.ObjectVal(map[string]cty.Value{
+		"id": cty.StringVal("fake"),
+	}))
+	if diags.HasErrors() {
+		t.Fatal(diags.Err())
+	}
+
+	want := cty.ObjectVal(map[string]cty.Value{
+		"id":     cty.StringVal("fake"),
+		"value":  cty.StringVal("hello"),
+		"count":  cty.Zero,
+		"nested": cty.ListValEmpty(cty.Object(map[string]cty.Type{"computed": cty.Bool})),
+	})
+	if !got.RawEquals(want) {
+		t.Fatalf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestApplyComputedValuesForResource_invalidDefaults(t *testing.T) {
+	planned := cty.ObjectVal(map[string]cty.Value{
+		"id":     cty.UnknownVal(cty.String),
+		"value":  cty.StringVal("hello"),
+		"count":  cty.UnknownVal(cty.Number),
+		"nested": cty.ListValEmpty(cty.Object(map[string]cty.Type{"computed": cty.Bool})),
+	})
+
+	tcs := map[string]cty.Value{
+		"unknown attribute": cty.ObjectVal(map[string]cty.Value{
+			"missing": cty.StringVal("fake"),
+		}),
+		"wrong type": cty.ObjectVal(map[string]cty.Value{
+			"count": cty.StringVal("many"),
+		}),
+	}
+	for name, defaults := range tcs {
+		t.Run(name, func(t *testing.T) {
+			_, diags := applyComputedValuesForResource(planned, testSchema, defaults)
+			if !diags.HasErrors() {
+				t.Fatalf("unexpected success")
+			}
+		})
+	}
+}
+
+func TestGenerateValue(t *testing.T) {
+	ty := cty.Object(map[string]cty.Type{
+		"string": cty.String,
+		"list":   cty.List(cty.String),
+		"map":    cty.Map(cty.Number),
+	})
+
+	got := generateValue(ty)
+	if !got.IsWhollyKnown() || got.IsNull() {
+		t.Fatalf("generated value is not known: %#v", got)
+	}
+	if len(got.GetAttr("string").AsString()) != 8 {
+		t.Errorf("wrong generated string %q", got.GetAttr("string").AsString())
+	}
+	if got.GetAttr("list").LengthInt() != 0 || got.GetAttr("map").LengthInt() != 0 {
+		t.Errorf("generated collections are not empty: %#v", got)
+	}
+}
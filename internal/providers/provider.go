@@ -67,9 +67,25 @@ type Interface interface {
 	// ImportResourceState requests that the given resource be imported.
 	ImportResourceState(ImportResourceStateRequest) ImportResourceStateResponse
 
+	// MoveResourceState translates the state of a resource instance that is
+	// moving to a resource of a different type, which may belong to a
+	// different provider, into an object conforming to the schema of the
+	// destination type.
+	MoveResourceState(MoveResourceStateRequest) MoveResourceStateResponse
+
 	// ReadDataSource returns the data source's current state.
 	ReadDataSource(ReadDataSourceRequest) ReadDataSourceResponse
 
+	// GetFunctions returns the full set of functions contributed by the
+	// provider. It must be a superset of the functions returned by
+	// GetProviderSchema, and is used instead of those if the provider's
+	// schema includes no functions at all.
+	GetFunctions() GetFunctionsResponse
+
+	// CallFunction calls the named provider function with the given
+	// arguments and returns its result.
+	CallFunction(CallFunctionRequest) CallFunctionResponse
+
 	// Close shuts down the plugin process if applicable.
 	Close() error
 }
@@ -97,6 +113,10 @@ type GetProviderSchemaResponse struct {
 
 	// ServerCapabilities lists optional features supported by the provider.
 	ServerCapabilities ServerCapabilities
+
+	// Functions maps the name of each function contributed by the provider
+	// to its signature.
+	Functions map[string]FunctionSpec
 }
 
 // Schema pairs a provider or resource schema with that schema's version.
@@ -126,6 +146,10 @@ type ServerCapabilities struct {
 	// In other words, the providers for which GetProviderSchemaOptional is false
 	// require their schema to be read after EVERY instantiation to function normally.
 	GetProviderSchemaOptional bool
+
+	// MoveResourceState signals that this provider supports the
+	// MoveResourceState call, for moving objects between resource types.
+	MoveResourceState bool
 }
 
 type ValidateProviderConfigRequest struct {
@@ -361,6 +385,44 @@ type ImportResourceStateResponse struct {
 	Diagnostics tfdiags.Diagnostics
 }
 
+type MoveResourceStateRequest struct {
+	// SourceProviderAddress is the address of the provider that the resource
+	// is being moved from.
+	SourceProviderAddress string
+
+	// SourceTypeName is the name of the resource type that the resource is
+	// being moved from.
+	SourceTypeName string
+
+	// SourceSchemaVersion is the schema version of the resource type that the
+	// resource is being moved from, as recorded in the state.
+	SourceSchemaVersion int64
+
+	// SourceStateJSON is the raw state of the resource being moved, in the
+	// JSON format that is stored in the state.
+	SourceStateJSON []byte
+
+	// SourcePrivate is the private data of the resource being moved.
+	SourcePrivate []byte
+
+	// TargetTypeName is the name of the resource type that the resource is
+	// being moved to.
+	TargetTypeName string
+}
+
+type MoveResourceStateResponse struct {
+	// TargetState is the state of the resource after it has been moved,
+	// conforming to the schema of the target resource type.
+	TargetState cty.Value
+
+	// TargetPrivate is the private data of the resource after it has been
+	// moved.
+	TargetPrivate []byte
+
+	// Diagnostics contains any warnings or errors from the method call.
+	Diagnostics tfdiags.Diagnostics
+}
+
 // ImportedResource represents an object being imported into OpenTofu with the
 // help of a provider. An ImportedObject is a RemoteObject that has been read
 // by the provider's import handler but hasn't yet been committed to state.
@@ -11,8 +11,21 @@ import (
 	"github.com/opentofu/opentofu/internal/dag"
 	"github.com/opentofu/opentofu/internal/logging"
 	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
 )
 
+// ResourceTypeMover translates the objects of resource instances that move
+// to a resource of a different type, which ApplyMoves can't do by itself
+// because only the provider of the destination resource type knows how to
+// represent them.
+type ResourceTypeMover interface {
+	// MoveResourceType returns the given object of the resource instance at
+	// address from, which belongs to the given provider configuration,
+	// translated for the resource instance at address to. It also returns the
+	// provider configuration that the translated object belongs to.
+	MoveResourceType(from, to addrs.AbsResourceInstance, fromProvider addrs.AbsProviderConfig, obj *states.ResourceInstanceObjectSrc) (*states.ResourceInstanceObjectSrc, addrs.AbsProviderConfig, tfdiags.Diagnostics)
+}
+
 // ApplyMoves modifies in-place the given state object so that any existing
 // objects that are matched by a "from" argument of one of the move statements
 // will be moved to instead appear at the "to" argument of that statement.
@@ -21,18 +34,22 @@ import (
 // either the source or destination of a move to a MoveResult describing
 // what happened at that address.
 //
-// ApplyMoves does not have any error situations itself, and will instead just
-// ignore any unresolvable move statements. Validation of a set of moves is
-// a separate concern applied to the configuration, because validity of
-// moves is always dependent only on the configuration, not on the state.
+// Objects that move to a resource of a different type are translated using
+// the given mover. ApplyMoves returns error diagnostics only if the mover fails
+// to translate an object, in which case that object stays where it was, and
+// will otherwise just ignore any unresolvable move statements. Validation of
+// a set of moves is a separate concern applied to the configuration, because
+// validity of moves is always dependent only on the configuration, not on the
+// state.
 //
 // ApplyMoves expects exclusive access to the given state while it's running.
 // Don't read or write any part of the state structure until ApplyMoves returns.
-func ApplyMoves(stmts []MoveStatement, state *states.State) MoveResults {
+func ApplyMoves(stmts []MoveStatement, state *states.State, mover ResourceTypeMover) (MoveResults, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
 	ret := makeMoveResults()
 
 	if len(stmts) == 0 {
-		return ret
+		return ret, diags
 	}
 
 	// The methodology here is to construct a small graph of all of the move
@@ -47,7 +64,7 @@ func ApplyMoves(stmts []MoveStatement, state *states.State) MoveResults {
 	// separate validation step should detect this and return an error.
 	if diags := validateMoveStatementGraph(g); diags.HasErrors() {
 		log.Printf("[ERROR] ApplyMoves: %s", diags.ErrWithWarnings())
-		return ret
+		return ret, nil
 	}
 
 	// The graph must be reduced in order for ReverseDepthFirstWalk to work
@@ -65,7 +82,7 @@ func ApplyMoves(stmts []MoveStatement, state *states.State) MoveResults {
 
 	if startNodes.Len() == 0 {
 		log.Println("[TRACE] refactoring.ApplyMoves: No 'moved' statements to consider in this configuration")
-		return ret
+		return ret, diags
 	}
 
 	log.Printf("[TRACE] refactoring.ApplyMoves: Processing 'moved' statements in the configuration\n%s", logging.Indent(g.String()))
@@ -152,12 +169,33 @@ func ApplyMoves(stmts []MoveStatement, state *states.State) MoveResults {
 							continue
 						}
 
+						var moved map[addrs.InstanceKey]*states.ResourceInstance
+						var provider addrs.AbsProviderConfig
+						if rAddr.Resource.Type != newAddr.Resource.Type {
+							var moveDiags tfdiags.Diagnostics
+							moved = make(map[addrs.InstanceKey]*states.ResourceInstance, len(rs.Instances))
+							for key, is := range rs.Instances {
+								var instDiags tfdiags.Diagnostics
+								moved[key], provider, instDiags = moveResourceInstanceType(mover, rAddr.Instance(key), newAddr.Instance(key), rs.ProviderConfig, is)
+								moveDiags = moveDiags.Append(instDiags)
+							}
+							diags = diags.Append(moveDiags)
+							if moveDiags.HasErrors() {
+								log.Printf("[ERROR] Failed to move %s to %s, because its objects could not be translated to the new resource type", rAddr, newAddr)
+								continue
+							}
+						}
+
 						for key := range rs.Instances {
 							oldInst := rAddr.Instance(key)
 							newInst := newAddr.Instance(key)
 							recordOldAddr(oldInst, newInst)
 						}
 						state.MoveAbsResource(rAddr, newAddr)
+						if moved != nil {
+							rs.Instances = moved
+							rs.ProviderConfig = provider
+						}
 						continue
 					}
 					for key := range rs.Instances {
@@ -174,9 +212,26 @@ func ApplyMoves(stmts []MoveStatement, state *states.State) MoveResults {
 								continue
 							}
 
+							var moved *states.ResourceInstance
+							var provider addrs.AbsProviderConfig
+							if iAddr.Resource.Resource.Type != newAddr.Resource.Resource.Type {
+								var moveDiags tfdiags.Diagnostics
+								moved, provider, moveDiags = moveResourceInstanceType(mover, iAddr, newAddr, rs.ProviderConfig, rs.Instances[key])
+								diags = diags.Append(moveDiags)
+								if moveDiags.HasErrors() {
+									log.Printf("[ERROR] Failed to move %s to %s, because its objects could not be translated to the new resource type", iAddr, newAddr)
+									continue
+								}
+							}
+
 							recordOldAddr(iAddr, newAddr)
 
 							state.MoveAbsResourceInstance(iAddr, newAddr)
+							if moved != nil {
+								dst := state.Resource(newAddr.ContainingResource())
+								dst.Instances[newAddr.Resource.Key] = moved
+								dst.ProviderConfig = provider
+							}
 							continue
 						}
 					}
@@ -187,7 +242,41 @@ func ApplyMoves(stmts []MoveStatement, state *states.State) MoveResults {
 		}
 	}
 
-	return ret
+	return ret, diags
+}
+
+// moveResourceInstanceType uses the given mover to translate all of the
+// objects of the given resource instance state for a resource of a different
+// type, returning the translated instance state and the provider
+// configuration that it belongs to.
+func moveResourceInstanceType(mover ResourceTypeMover, from, to addrs.AbsResourceInstance, provider addrs.AbsProviderConfig, is *states.ResourceInstance) (*states.ResourceInstance, addrs.AbsProviderConfig, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	if mover == nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Unsupported resource type change",
+			fmt.Sprintf("Cannot move %s to %s, because moving objects between resource types is not supported in this context.", from, to),
+		))
+		return nil, provider, diags
+	}
+
+	ret := states.NewResourceInstance()
+	newProvider := provider
+	if is.Current != nil {
+		obj, objProvider, moreDiags := mover.MoveResourceType(from, to, provider, is.Current)
+		diags = diags.Append(moreDiags)
+		ret.Current = obj
+		newProvider = objProvider
+	}
+	for key, deposed := range is.Deposed {
+		obj, objProvider, moreDiags := mover.MoveResourceType(from, to, provider, deposed)
+		diags = diags.Append(moreDiags)
+		ret.Deposed[key] = obj
+		newProvider = objProvider
+	}
+
+	return ret, newProvider, diags
 }
 
 // buildMoveStatementGraph constructs a dependency graph of the given move
@@ -159,15 +159,20 @@ func ValidateMoves(stmts []MoveStatement, rootCfg *configs.Config, declaredInsts
 				})
 			}
 
-			// Resource types must match.
+			// Objects can move to a resource of a different type only if the
+			// destination is declared, because its provider must translate
+			// them.
 			if resourceTypesDiffer(absFrom, absTo) {
-				diags = diags.Append(&hcl.Diagnostic{
-					Severity: hcl.DiagError,
-					Summary:  "Resource type mismatch",
-					Detail: fmt.Sprintf(
-						"This statement declares a move from %s to %s, which is a %s of a different type.", absFrom, absTo, noun,
-					),
-				})
+				if _, declared := movableObjectDeclRange(absTo, rootCfg); !declared {
+					diags = diags.Append(&hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Resource type mismatch",
+						Detail: fmt.Sprintf(
+							"This statement declares a move from %s to %s, which is a %s of a different type. An object can move to a resource of a different type only if the destination is declared in the configuration, because the destination's provider must translate it.", absFrom, absTo, noun,
+						),
+						Subject: stmt.DeclRange.ToHCL().Ptr(),
+					})
+				}
 			}
 
 		}
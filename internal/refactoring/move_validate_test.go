@@ -434,23 +434,41 @@ Each resource can have moved from only one source resource.`,
 			},
 			WantError: ``, // This is okay because the call itself is not considered to be inside the package it refers to
 		},
-		"resource type mismatch": {
+		"resource type change": {
 			Statements: []MoveStatement{
 				makeTestMoveStmt(t, ``,
 					`test.nonexist1`,
 					`other.single`,
 				),
 			},
-			WantError: `Resource type mismatch: This statement declares a move from test.nonexist1 to other.single, which is a resource of a different type.`,
+			WantError: ``, // The provider of other.single decides whether it can translate the objects
 		},
-		"resource instance type mismatch": {
+		"resource instance type change": {
 			Statements: []MoveStatement{
 				makeTestMoveStmt(t, ``,
 					`test.nonexist1[0]`,
 					`other.single`,
 				),
 			},
-			WantError: `Resource type mismatch: This statement declares a move from test.nonexist1[0] to other.single, which is a resource instance of a different type.`,
+			WantError: ``,
+		},
+		"resource type mismatch": {
+			Statements: []MoveStatement{
+				makeTestMoveStmt(t, ``,
+					`test.nonexist1`,
+					`other.nonexist2`,
+				),
+			},
+			WantError: `Resource type mismatch: This statement declares a move from test.nonexist1 to other.nonexist2, which is a resource of a different type. An object can move to a resource of a different type only if the destination is declared in the configuration, because the destination's provider must translate it.`,
+		},
+		"resource instance type mismatch": {
+			Statements: []MoveStatement{
+				makeTestMoveStmt(t, ``,
+					`test.nonexist1[0]`,
+					`other.nonexist2`,
+				),
+			},
+			WantError: `Resource type mismatch: This statement declares a move from test.nonexist1[0] to other.nonexist2, which is a resource instance of a different type. An object can move to a resource of a different type only if the destination is declared in the configuration, because the destination's provider must translate it.`,
 		},
 		"crossing nested statements": {
 			// overlapping nested moves will result in a cycle.
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package refactoring
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// RemoveStatement is the fully-resolved form of a "removed" block in the
+// configuration, declaring that all instances of a resource or module are no
+// longer managed by OpenTofu and must be removed from the state without
+// being destroyed.
+type RemoveStatement struct {
+	From      addrs.ConfigRemovable
+	DeclRange tfdiags.SourceRange
+}
+
+// FindRemoveStatements recurses through the modules of the given configuration
+// and returns a flat set of all "removed" blocks defined within, in a
+// deterministic but undefined order.
+//
+// It also validates that none of the statements refer to an object that is
+// still declared in the configuration, returning error diagnostics if so.
+func FindRemoveStatements(rootCfg *configs.Config) ([]RemoveStatement, tfdiags.Diagnostics) {
+	stmts := findRemoveStatements(rootCfg, nil)
+	diags := validateRemoveStatements(rootCfg, stmts)
+	return stmts, diags
+}
+
+func findRemoveStatements(cfg *configs.Config, into []RemoveStatement) []RemoveStatement {
+	modAddr := cfg.Path
+	for _, rc := range cfg.Module.Removed {
+		if rc.From == nil {
+			// Invalid addresses should've been caught during original
+			// configuration decoding, in the configs package.
+			panic(fmt.Sprintf("removed block without a valid \"from\" address at %s", rc.DeclRange))
+		}
+
+		into = append(into, RemoveStatement{
+			From:      rc.From.ConfigRemovable(modAddr),
+			DeclRange: tfdiags.SourceRangeFromHCL(rc.DeclRange),
+		})
+	}
+
+	for _, childCfg := range cfg.Children {
+		into = findRemoveStatements(childCfg, into)
+	}
+
+	return into
+}
+
+func validateRemoveStatements(rootCfg *configs.Config, stmts []RemoveStatement) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	for _, rs := range stmts {
+		switch addr := rs.From.(type) {
+		case addrs.ConfigResource:
+			modCfg := rootCfg.Descendent(addr.Module)
+			if modCfg == nil || modCfg.Module.ResourceByAddr(addr.Resource) == nil {
+				continue
+			}
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Removed resource block still exists",
+				Detail: fmt.Sprintf(
+					"This statement declares a removal of the resource %s, but this resource block still exists in the configuration. Please remove the resource block.",
+					addr,
+				),
+				Subject: rs.DeclRange.ToHCL().Ptr(),
+			})
+		case addrs.Module:
+			if rootCfg.Descendent(addr) == nil {
+				continue
+			}
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Removed module block still exists",
+				Detail: fmt.Sprintf(
+					"This statement declares a removal of the module %s, but this module block still exists in the configuration. Please remove the module block.",
+					addr,
+				),
+				Subject: rs.DeclRange.ToHCL().Ptr(),
+			})
+		default:
+			panic(fmt.Sprintf("unsupported removable address type %T", addr))
+		}
+	}
+
+	return diags
+}
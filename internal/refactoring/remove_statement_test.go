@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package refactoring
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindRemoveStatements(t *testing.T) {
+	rootCfg, _ := loadRefactoringFixture(t, "testdata/remove-statement")
+
+	stmts, diags := FindRemoveStatements(rootCfg)
+
+	var gotAddrs []string
+	for _, stmt := range stmts {
+		gotAddrs = append(gotAddrs, stmt.From.String())
+	}
+	sort.Strings(gotAddrs)
+	wantAddrs := []string{
+		"foo.removed",
+		"foo.still_here",
+		"module.child",
+		"module.child.foo.nested_removed",
+		"module.gone",
+	}
+	if diff := cmp.Diff(wantAddrs, gotAddrs); diff != "" {
+		t.Errorf("wrong statements\n%s", diff)
+	}
+
+	var gotErrs []string
+	for _, diag := range diags {
+		gotErrs = append(gotErrs, diag.Description().Summary)
+	}
+	sort.Strings(gotErrs)
+	wantErrs := []string{
+		"Removed module block still exists",
+		"Removed resource block still exists",
+	}
+	if diff := cmp.Diff(wantErrs, gotErrs); diff != "" {
+		t.Errorf("wrong diagnostics\n%s", diff)
+	}
+}
@@ -0,0 +1,3 @@
+removed {
+  from = foo.nested_removed
+}
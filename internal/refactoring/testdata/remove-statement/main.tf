@@ -0,0 +1,22 @@
+removed {
+  from = foo.removed
+}
+
+removed {
+  from = module.gone
+}
+
+removed {
+  from = foo.still_here
+}
+
+removed {
+  from = module.child
+}
+
+resource "foo" "still_here" {
+}
+
+module "child" {
+  source = "./child"
+}
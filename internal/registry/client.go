@@ -19,6 +19,7 @@ import (
 
 	"github.com/hashicorp/go-retryablehttp"
 	svchost "github.com/hashicorp/terraform-svchost"
+	svcauth "github.com/hashicorp/terraform-svchost/auth"
 	"github.com/hashicorp/terraform-svchost/disco"
 	"github.com/opentofu/opentofu/internal/httpclient"
 	"github.com/opentofu/opentofu/internal/logging"
@@ -100,6 +101,16 @@ func NewClient(services *disco.Disco, client *http.Client) *Client {
 	}
 }
 
+// CredentialsSource returns the source of the credentials that the client
+// uses, so that other clients can use the same credentials. It returns nil
+// if called on a nil client.
+func (c *Client) CredentialsSource() svcauth.CredentialsSource {
+	if c == nil {
+		return nil
+	}
+	return c.services.CredentialsSource()
+}
+
 // Discover queries the host, and returns the url for the registry.
 func (c *Client) Discover(host svchost.Hostname, serviceID string) (*url.URL, error) {
 	service, err := c.services.DiscoverServiceURL(host, serviceID)
@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package repl
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Completions returns the possible completions for the reference or function
+// name at the end of the given partial input.
+//
+// The first return value is the partial name being completed, which is the
+// part of the input after the last dot of the reference, or the whole name
+// if it has no dots. Each of the returned candidates starts with it, and
+// should replace it in the input.
+func (s *Session) Completions(input string) (string, []string) {
+	word := completionWord(input)
+
+	var partial string
+	var candidates []string
+	if dot := strings.LastIndexByte(word, '.'); dot >= 0 {
+		partial = word[dot+1:]
+		candidates = s.attributeCandidates(word[:dot])
+	} else {
+		partial = word
+		candidates = s.topLevelCandidates()
+	}
+
+	var ret []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, partial) {
+			ret = append(ret, candidate)
+		}
+	}
+	sort.Strings(ret)
+	return partial, ret
+}
+
+// completionWord returns the reference or function name at the end of the
+// given input.
+func completionWord(input string) string {
+	start := len(input)
+	inQuotes := false
+	for start > 0 {
+		c := input[start-1]
+		switch {
+		case c == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+			// Any character can appear in a quoted index key.
+		case c == '_' || c == '-' || c == '.' || c == ':' || c == '[' || c == ']' || c == '*':
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		default:
+			return trimCompletionWord(input[start:])
+		}
+		start--
+	}
+	return trimCompletionWord(input[start:])
+}
+
+// trimCompletionWord removes any leading characters which can't start a
+// reference, such as the opening bracket of a tuple constructor.
+func trimCompletionWord(word string) string {
+	return strings.TrimLeft(word, "[]*.:")
+}
+
+// topLevelCandidates returns the names which can start a reference or a
+// function call.
+func (s *Session) topLevelCandidates() []string {
+	ret := []string{"var.", "local.", "module.", "data.", "path.", "terraform."}
+
+	if s.Config != nil {
+		seen := make(map[string]bool)
+		for _, rc := range s.Config.ManagedResources {
+			if !seen[rc.Type] {
+				seen[rc.Type] = true
+				ret = append(ret, rc.Type+".")
+			}
+		}
+	}
+
+	if s.Scope != nil {
+		for name := range s.Scope.Functions() {
+			ret = append(ret, name+"(")
+		}
+	}
+
+	return ret
+}
+
+// attributeCandidates returns the names which can follow the given
+// reference prefix and a dot.
+func (s *Session) attributeCandidates(prefix string) []string {
+	var ret []string
+
+	switch prefix {
+	case "path":
+		return []string{"module", "root", "cwd"}
+	case "terraform":
+		return []string{"workspace"}
+	}
+
+	if s.Config != nil {
+		switch prefix {
+		case "var":
+			for name := range s.Config.Variables {
+				ret = append(ret, name)
+			}
+			return ret
+		case "local":
+			for name := range s.Config.Locals {
+				ret = append(ret, name)
+			}
+			return ret
+		case "module":
+			for name := range s.Config.ModuleCalls {
+				ret = append(ret, name)
+			}
+			return ret
+		case "data":
+			seen := make(map[string]bool)
+			for _, rc := range s.Config.DataResources {
+				if !seen[rc.Type] {
+					seen[rc.Type] = true
+					ret = append(ret, rc.Type+".")
+				}
+			}
+			return ret
+		}
+
+		found := false
+		for _, rc := range s.Config.DataResources {
+			if prefix == "data."+rc.Type {
+				found = true
+				ret = append(ret, rc.Name)
+			}
+		}
+		for _, rc := range s.Config.ManagedResources {
+			if prefix == rc.Type {
+				found = true
+				ret = append(ret, rc.Name)
+			}
+		}
+		if found {
+			return ret
+		}
+	}
+
+	// Otherwise we evaluate the prefix and suggest the attributes of the
+	// resulting object, which for resources are those in the provider's
+	// schema for the resource type.
+	if s.Scope == nil {
+		return nil
+	}
+	expr, diags := hclsyntax.ParseExpression([]byte(prefix), "<console-input>", hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil
+	}
+	val, valDiags := s.Scope.EvalExpr(expr, cty.DynamicPseudoType)
+	if valDiags.HasErrors() {
+		return nil
+	}
+	val, _ = val.UnmarkDeep()
+	if val.IsNull() || !val.Type().IsObjectType() {
+		return nil
+	}
+	for name := range val.Type().AttributeTypes() {
+		ret = append(ret, name)
+	}
+	return ret
+}
@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package repl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/initwd"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+func TestSession_Completions(t *testing.T) {
+	p := &tofu.MockProvider{}
+	p.GetProviderSchemaResponse = &providers.GetProviderSchemaResponse{
+		ResourceTypes: map[string]providers.Schema{
+			"test_instance": {
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"id": {Type: cty.String, Computed: true},
+					},
+				},
+			},
+		},
+	}
+
+	config, _, cleanup, configDiags := initwd.LoadConfigForTests(t, "testdata/config-fixture", "tests")
+	defer cleanup()
+	if configDiags.HasErrors() {
+		t.Fatalf("unexpected problems loading config: %s", configDiags.Err())
+	}
+
+	ctx, diags := tofu.NewContext(&tofu.ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): providers.FactoryFixed(p),
+		},
+	})
+	if diags.HasErrors() {
+		t.Fatalf("failed to create context: %s", diags.Err())
+	}
+
+	state := states.BuildState(func(s *states.SyncState) {
+		s.SetResourceInstanceCurrent(
+			addrs.Resource{
+				Mode: addrs.ManagedResourceMode,
+				Type: "test_instance",
+				Name: "foo",
+			}.Instance(addrs.NoKey).Absolute(addrs.RootModuleInstance),
+			&states.ResourceInstanceObjectSrc{
+				Status:    states.ObjectReady,
+				AttrsJSON: []byte(`{"id":"bar"}`),
+			},
+			addrs.AbsProviderConfig{
+				Provider: addrs.NewDefaultProvider("test"),
+				Module:   addrs.RootModule,
+			},
+		)
+	})
+	scope, diags := ctx.Eval(config, state, addrs.RootModuleInstance, &tofu.EvalOpts{})
+	if diags.HasErrors() {
+		t.Fatalf("failed to create scope: %s", diags.Err())
+	}
+	scope.ConsoleMode = true
+
+	s := &Session{
+		Scope:  scope,
+		Config: config.Module,
+	}
+
+	tests := []struct {
+		Input       string
+		WantPartial string
+		WantResult  []string
+	}{
+		{
+			Input:       "tes",
+			WantPartial: "tes",
+			WantResult:  []string{"test_instance."},
+		},
+		{
+			Input:       "mo",
+			WantPartial: "mo",
+			WantResult:  []string{"module."},
+		},
+		{
+			Input:       "upper(ab",
+			WantPartial: "ab",
+			WantResult:  []string{"abs(", "abspath("},
+		},
+		{
+			Input:       "typ",
+			WantPartial: "typ",
+			WantResult:  []string{"type("},
+		},
+		{
+			Input:       "test_instance.",
+			WantPartial: "",
+			WantResult:  []string{"foo"},
+		},
+		{
+			Input:       "[test_instance.foo.i",
+			WantPartial: "i",
+			WantResult:  []string{"id"},
+		},
+		{
+			Input:       "module.m",
+			WantPartial: "m",
+			WantResult:  []string{"module"},
+		},
+		{
+			Input:       "path.",
+			WantPartial: "",
+			WantResult:  []string{"cwd", "module", "root"},
+		},
+		{
+			Input:       "terraform.w",
+			WantPartial: "w",
+			WantResult:  []string{"workspace"},
+		},
+		{
+			Input:       "var.",
+			WantPartial: "",
+			WantResult:  nil,
+		},
+		{
+			Input:       "test_instance.nope.",
+			WantPartial: "",
+			WantResult:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Input, func(t *testing.T) {
+			partial, result := s.Completions(test.Input)
+			if partial != test.WantPartial {
+				t.Errorf("wrong partial name\ngot:  %q\nwant: %q", partial, test.WantPartial)
+			}
+			if diff := cmp.Diff(test.WantResult, result); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package repl
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// ExpressionIncomplete returns true if the given input, which may span
+// multiple lines, ends before closing all of the brackets, parentheses,
+// braces, template sequences and heredocs it opens.
+//
+// An interactive REPL uses this to decide whether to read another line
+// before passing the accumulated input to Session.Handle, so that long
+// expressions can be entered over multiple lines.
+func ExpressionIncomplete(input string) bool {
+	tokens, _ := hclsyntax.LexExpression([]byte(input), "<console-input>", hcl.Pos{Line: 1, Column: 1})
+
+	depth := 0
+	for _, token := range tokens {
+		switch token.Type {
+		case hclsyntax.TokenOBrace, hclsyntax.TokenOBrack, hclsyntax.TokenOParen,
+			hclsyntax.TokenTemplateInterp, hclsyntax.TokenTemplateControl,
+			hclsyntax.TokenOHeredoc:
+			depth++
+		case hclsyntax.TokenCBrace, hclsyntax.TokenCBrack, hclsyntax.TokenCParen,
+			hclsyntax.TokenTemplateSeqEnd, hclsyntax.TokenCHeredoc:
+			depth--
+		}
+		if depth < 0 {
+			// Too many closing tokens is an error that more input can't
+			// fix, so we let the parser report it.
+			return false
+		}
+	}
+
+	return depth > 0
+}
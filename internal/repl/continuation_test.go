@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package repl
+
+import (
+	"testing"
+)
+
+func TestExpressionIncomplete(t *testing.T) {
+	tests := map[string]bool{
+		"":                             false,
+		"1 + 2":                        false,
+		"{":                            true,
+		"{\n  a = 1\n":                 true,
+		"{\n  a = 1\n}":                false,
+		"[1,\n2":                       true,
+		"[1,\n2]":                      false,
+		"max(1,\n":                     true,
+		"\"${var.foo":                  true,
+		"\"${var.foo}\"":               false,
+		"<<EOT\nhello\n":               true,
+		"<<EOT\nhello\nEOT\n":          false,
+		")":                            false,
+		"[1, 2]]":                      false,
+		"{ for k, v in var.m : k => v": true,
+	}
+
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			got := ExpressionIncomplete(input)
+			if got != want {
+				t.Errorf("wrong result for %q\ngot:  %t\nwant: %t", input, got, want)
+			}
+		})
+	}
+}
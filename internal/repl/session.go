@@ -12,6 +12,7 @@ import (
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/lang"
 	"github.com/opentofu/opentofu/internal/lang/marks"
 	"github.com/opentofu/opentofu/internal/lang/types"
@@ -22,6 +23,10 @@ import (
 type Session struct {
 	// Scope is the evaluation scope where expressions will be evaluated.
 	Scope *lang.Scope
+
+	// Config is the configuration of the module whose scope expressions are
+	// evaluated in. It is used only to suggest completions, and may be nil.
+	Config *configs.Module
 }
 
 // Handle handles a single line of input from the REPL.
@@ -93,7 +98,10 @@ You may access resources in the state (if you have one) just as you would
 from a configuration. For example: "aws_instance.foo.id" would evaluate
 to the ID of "aws_instance.foo" if it exists in your state.
 
-Type in the interpolation to test and hit <enter> to see the result.
+Type in the interpolation to test and hit <enter> to see the result. If an
+expression has unclosed brackets, parentheses or braces, the console reads
+further lines until they are closed, so long expressions can be entered over
+multiple lines. Press <tab> to complete references and function names.
 
 To exit the console, type "exit" and hit <enter>, or use Control-C or
 Control-D.
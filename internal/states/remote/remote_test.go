@@ -7,11 +7,14 @@ import (
 	"crypto/md5"
 	"encoding/json"
 	"testing"
+
+	"github.com/opentofu/opentofu/internal/encryption"
 )
 
 func TestRemoteClient_noPayload(t *testing.T) {
 	s := &State{
-		Client: nilClient{},
+		encryption: encryption.StateEncryptionDisabled(),
+		Client:     nilClient{},
 	}
 	if err := s.RefreshState(); err != nil {
 		t.Fatal("error refreshing empty remote state")
@@ -12,6 +12,7 @@ import (
 	uuid "github.com/hashicorp/go-uuid"
 
 	"github.com/opentofu/opentofu/internal/backend/local"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/statefile"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
@@ -27,6 +28,10 @@ type State struct {
 
 	Client Client
 
+	// encryption is used to encrypt state snapshots before they are sent
+	// to the client, and to decrypt them after retrieval.
+	encryption encryption.StateEncryption
+
 	// We track two pieces of meta data in addition to the state itself:
 	//
 	// lineage - the state's unique ID
@@ -48,6 +53,15 @@ type State struct {
 	DisableIntermediateSnapshots bool
 }
 
+// NewState returns a State that stores its snapshots using the given client,
+// encrypted using the given encryption.
+func NewState(client Client, enc encryption.StateEncryption) *State {
+	return &State{
+		Client:     client,
+		encryption: enc,
+	}
+}
+
 var _ statemgr.Full = (*State)(nil)
 var _ statemgr.Migrator = (*State)(nil)
 var _ local.IntermediateStateConditionalPersister = (*State)(nil)
@@ -148,7 +162,7 @@ func (s *State) refreshState() error {
 		return nil
 	}
 
-	stateFile, err := statefile.Read(bytes.NewReader(payload.Data))
+	stateFile, err := statefile.Read(bytes.NewReader(payload.Data), s.encryption)
 	if err != nil {
 		return err
 	}
@@ -205,7 +219,7 @@ func (s *State) PersistState(schemas *tofu.Schemas) error {
 	f := statefile.New(s.state, s.lineage, s.serial)
 
 	var buf bytes.Buffer
-	err := statefile.Write(f, &buf)
+	err := statefile.Write(f, &buf, s.encryption)
 	if err != nil {
 		return err
 	}
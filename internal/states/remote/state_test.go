@@ -13,6 +13,7 @@ import (
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/statefile"
 	"github.com/opentofu/opentofu/internal/states/statemgr"
@@ -31,7 +32,8 @@ func TestState_impl(t *testing.T) {
 
 func TestStateRace(t *testing.T) {
 	s := &State{
-		Client: nilClient{},
+		encryption: encryption.StateEncryptionDisabled(),
+		Client:     nilClient{},
 	}
 
 	current := states.NewState()
@@ -326,7 +328,8 @@ func TestStatePersist(t *testing.T) {
 	// test assertions below, or else we'd need to deal with
 	// random lineage.
 	mgr := &State{
-		Client: &mockClient{},
+		encryption: encryption.StateEncryptionDisabled(),
+		Client:     &mockClient{},
 	}
 
 	// In normal use (during a OpenTofu operation) we always refresh and read
@@ -388,6 +391,7 @@ func TestStatePersist(t *testing.T) {
 func TestState_GetRootOutputValues(t *testing.T) {
 	// Initial setup of state with outputs already defined
 	mgr := &State{
+		encryption: encryption.StateEncryptionDisabled(),
 		Client: &mockClient{
 			current: []byte(`
 				{
@@ -426,6 +430,7 @@ type migrationTestCase struct {
 
 func TestWriteStateForMigration(t *testing.T) {
 	mgr := &State{
+		encryption: encryption.StateEncryptionDisabled(),
 		Client: &mockClient{
 			current: []byte(`
 				{
@@ -582,6 +587,7 @@ func TestWriteStateForMigration(t *testing.T) {
 // this interface, but that this interface works for those that do.
 func TestWriteStateForMigrationWithForcePushClient(t *testing.T) {
 	mgr := &State{
+		encryption: encryption.StateEncryptionDisabled(),
 		Client: &mockClientForcePusher{
 			current: []byte(`
 				{
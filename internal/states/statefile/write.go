@@ -4,18 +4,32 @@
 package statefile
 
 import (
+	"bytes"
 	"io"
 
+	"github.com/opentofu/opentofu/internal/encryption"
 	tfversion "github.com/opentofu/opentofu/version"
 )
 
 // Write writes the given state to the given writer in the current state
-// serialization format.
-func Write(s *File, w io.Writer) error {
+// serialization format, encrypted using the given encryption.
+func Write(s *File, w io.Writer, enc encryption.StateEncryption) error {
 	// Always record the current tofu version in the state.
 	s.TerraformVersion = tfversion.SemVer
 
-	diags := writeStateV4(s, w)
+	var buf bytes.Buffer
+	diags := writeStateV4(s, &buf)
+	if diags.HasErrors() {
+		return diags.Err()
+	}
+
+	encrypted, err := enc.EncryptState(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(encrypted); err != nil {
+		return err
+	}
 	return diags.Err()
 }
 
@@ -16,6 +16,7 @@ import (
 
 	multierror "github.com/hashicorp/go-multierror"
 
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/statefile"
 	"github.com/opentofu/opentofu/internal/tofu"
@@ -43,6 +44,11 @@ type Filesystem struct {
 	// is a subsequent call to write a different state.
 	backupPath string
 
+	// historyDir is an optional directory in which up to historyLimit prior
+	// state snapshots are kept, as configured by SetHistory.
+	historyDir   string
+	historyLimit int
+
 	// the file handle corresponding to PathOut
 	stateFileOut *os.File
 
@@ -56,10 +62,15 @@ type Filesystem struct {
 	// hurt to remove file we never wrote to.
 	created bool
 
-	file          *statefile.File
-	readFile      *statefile.File
-	backupFile    *statefile.File
-	writtenBackup bool
+	// encryption is used to encrypt state snapshots before writing them
+	// to disk, and to decrypt them when reading.
+	encryption encryption.StateEncryption
+
+	file           *statefile.File
+	readFile       *statefile.File
+	backupFile     *statefile.File
+	writtenBackup  bool
+	writtenHistory bool
 }
 
 var (
@@ -73,20 +84,22 @@ var (
 //
 // This is equivalent to calling NewFileSystemBetweenPaths with statePath as
 // both of the path arguments.
-func NewFilesystem(statePath string) *Filesystem {
+func NewFilesystem(statePath string, enc encryption.StateEncryption) *Filesystem {
 	return &Filesystem{
-		path:     statePath,
-		readPath: statePath,
+		path:       statePath,
+		readPath:   statePath,
+		encryption: enc,
 	}
 }
 
 // NewFilesystemBetweenPaths creates a filesystem-based state manager that
 // reads an initial snapshot from readPath and then writes all new snapshots to
 // writePath.
-func NewFilesystemBetweenPaths(readPath, writePath string) *Filesystem {
+func NewFilesystemBetweenPaths(readPath, writePath string, enc encryption.StateEncryption) *Filesystem {
 	return &Filesystem{
-		path:     writePath,
-		readPath: readPath,
+		path:       writePath,
+		readPath:   readPath,
+		encryption: enc,
 	}
 }
 
@@ -158,7 +171,7 @@ func (s *Filesystem) writeState(state *states.State, meta *SnapshotMeta) error {
 			}
 			defer bfh.Close()
 
-			err = statefile.Write(s.backupFile, bfh)
+			err = statefile.Write(s.backupFile, bfh, s.encryption)
 			if err != nil {
 				return fmt.Errorf("failed to write to local state backup file: %w", err)
 			}
@@ -181,6 +194,17 @@ func (s *Filesystem) writeState(state *states.State, meta *SnapshotMeta) error {
 		}
 	}
 
+	// The snapshot we're replacing is also kept in the history directory,
+	// again only once for the lifetime of the object.
+	if !s.writtenHistory && s.HistoryDir() != "" && s.backupFile != nil && s.backupFile.State != nil {
+		if !statefile.StatesMarshalEqual(state, s.backupFile.State) {
+			if err := s.writeHistory(s.backupFile); err != nil {
+				return err
+			}
+			s.writtenHistory = true
+		}
+	}
+
 	s.file = s.file.DeepCopy()
 	if s.file == nil {
 		s.file = NewStateFile()
@@ -215,7 +239,7 @@ func (s *Filesystem) writeState(state *states.State, meta *SnapshotMeta) error {
 	}
 
 	log.Printf("[TRACE] statemgr.Filesystem: writing snapshot at %s", s.path)
-	if err := statefile.Write(s.file, s.stateFileOut); err != nil {
+	if err := statefile.Write(s.file, s.stateFileOut, s.encryption); err != nil {
 		return err
 	}
 
@@ -293,7 +317,7 @@ func (s *Filesystem) refreshState() error {
 		reader = s.stateFileOut
 	}
 
-	f, err := statefile.Read(reader)
+	f, err := statefile.Read(reader, s.encryption)
 	// if there's no state then a nil file is fine
 	if err != nil {
 		if err != statefile.ErrNoState {
@@ -482,7 +506,7 @@ func (s *Filesystem) createStateFiles() error {
 
 	// If the file already existed with content then that'll be the content
 	// of our backup file if we write a change later.
-	s.backupFile, err = statefile.Read(s.stateFileOut)
+	s.backupFile, err = statefile.Read(s.stateFileOut, s.encryption)
 	if err != nil {
 		if err != statefile.ErrNoState {
 			return err
@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package statemgr
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/states/statefile"
+)
+
+const (
+	historyFileExt    = ".tfstate"
+	historyTimeFormat = "20060102T150405.000000000Z"
+)
+
+// HistorySnapshot describes a prior state snapshot kept in the history
+// directory of a Filesystem state manager.
+type HistorySnapshot struct {
+	// Path is the location of the snapshot file.
+	Path string
+
+	// Serial is the serial of the snapshot.
+	Serial uint64
+
+	// Time is when the snapshot was replaced by a newer one.
+	Time time.Time
+
+	// File is the content of the snapshot, including its lineage. It is
+	// populated only by History, not when pruning.
+	File *statefile.File
+}
+
+// SetHistory configures the receiver to keep up to limit prior state
+// snapshots in the directory dir. Each time the state is first changed after
+// being read, the snapshot it replaces is saved in that directory, and the
+// oldest snapshots are deleted once there are more than limit of them.
+//
+// A limit of zero or less disables history. Like SetBackupPath, this must be
+// called before any other state methods are called.
+func (s *Filesystem) SetHistory(dir string, limit int) {
+	s.historyDir = dir
+	s.historyLimit = limit
+	s.writtenHistory = false
+}
+
+// HistoryDir returns the manager's history directory if history is enabled,
+// or an empty string otherwise.
+func (s *Filesystem) HistoryDir() string {
+	if s.historyLimit <= 0 {
+		return ""
+	}
+	return s.historyDir
+}
+
+// History returns the prior state snapshots kept in the history directory,
+// newest first.
+func (s *Filesystem) History() ([]*HistorySnapshot, error) {
+	defer s.mutex()()
+
+	if s.HistoryDir() == "" {
+		return nil, nil
+	}
+
+	snapshots, err := s.historySnapshots()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, snap := range snapshots {
+		f, err := os.Open(snap.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open state history snapshot: %w", err)
+		}
+		snap.File, err = statefile.Read(f, s.encryption)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read state history snapshot %s: %w", snap.Path, err)
+		}
+	}
+
+	return snapshots, nil
+}
+
+// historySnapshots lists the snapshots in the history directory, newest
+// first, without reading them.
+func (s *Filesystem) historySnapshots() ([]*HistorySnapshot, error) {
+	entries, err := os.ReadDir(s.historyDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read state history directory: %w", err)
+	}
+
+	var ret []*HistorySnapshot
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		serial, t, ok := parseHistoryFileName(entry.Name())
+		if !ok {
+			log.Printf("[WARN] statemgr.Filesystem: ignoring unexpected file %s in state history directory", entry.Name())
+			continue
+		}
+		ret = append(ret, &HistorySnapshot{
+			Path:   filepath.Join(s.historyDir, entry.Name()),
+			Serial: serial,
+			Time:   t,
+		})
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Serial != ret[j].Serial {
+			return ret[i].Serial > ret[j].Serial
+		}
+		return ret[i].Time.After(ret[j].Time)
+	})
+	return ret, nil
+}
+
+// writeHistory saves the given snapshot in the history directory and then
+// deletes the oldest snapshots beyond the configured limit.
+func (s *Filesystem) writeHistory(f *statefile.File) error {
+	if err := os.MkdirAll(s.historyDir, 0755); err != nil {
+		return fmt.Errorf("failed to create state history directory: %w", err)
+	}
+
+	path := filepath.Join(s.historyDir, historyFileName(f.Serial, time.Now()))
+	log.Printf("[TRACE] statemgr.Filesystem: saving snapshot with serial %d to history at %s", f.Serial, path)
+	fh, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create state history snapshot: %w", err)
+	}
+	defer fh.Close()
+
+	if err := statefile.Write(f, fh, s.encryption); err != nil {
+		return fmt.Errorf("failed to write state history snapshot: %w", err)
+	}
+
+	snapshots, err := s.historySnapshots()
+	if err != nil {
+		return err
+	}
+	for i := s.historyLimit; i < len(snapshots); i++ {
+		log.Printf("[TRACE] statemgr.Filesystem: removing old state history snapshot %s", snapshots[i].Path)
+		if err := os.Remove(snapshots[i].Path); err != nil {
+			return fmt.Errorf("failed to remove old state history snapshot: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func historyFileName(serial uint64, t time.Time) string {
+	return fmt.Sprintf("%020d-%s%s", serial, t.UTC().Format(historyTimeFormat), historyFileExt)
+}
+
+func parseHistoryFileName(name string) (uint64, time.Time, bool) {
+	name, ok := strings.CutSuffix(name, historyFileExt)
+	if !ok {
+		return 0, time.Time{}, false
+	}
+	serialStr, timeStr, ok := strings.Cut(name, "-")
+	if !ok {
+		return 0, time.Time{}, false
+	}
+	serial, err := strconv.ParseUint(serialStr, 10, 64)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	t, err := time.Parse(historyTimeFormat, timeStr)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	return serial, t, true
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/states/statefile"
 	tfversion "github.com/opentofu/opentofu/version"
@@ -167,7 +168,7 @@ func TestFilesystem_backup(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	bf, err := statefile.Read(bfh)
+	bf, err := statefile.Read(bfh, encryption.StateEncryptionDisabled())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -179,6 +180,57 @@ func TestFilesystem_backup(t *testing.T) {
 	}
 }
 
+func TestFilesystem_history(t *testing.T) {
+	defer testOverrideVersion(t, "1.2.3")()
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "terraform.tfstate")
+	historyDir := filepath.Join(dir, "history")
+
+	for i := 0; i < 4; i++ {
+		ls := NewFilesystem(statePath, encryption.StateEncryptionDisabled())
+		ls.SetHistory(historyDir, 2)
+		if err := ls.RefreshState(); err != nil {
+			t.Fatal(err)
+		}
+
+		state := states.NewState()
+		state.RootModule().SetOutputValue("foo", cty.NumberIntVal(int64(i)), false)
+		if err := ls.WriteState(state); err != nil {
+			t.Fatal(err)
+		}
+		// A second write in the same session must not add another snapshot.
+		state.RootModule().SetOutputValue("bar", cty.True, false)
+		if err := ls.WriteState(state); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ls := NewFilesystem(statePath, encryption.StateEncryptionDisabled())
+	ls.SetHistory(historyDir, 2)
+	snapshots, err := ls.History()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("expected 2 snapshots, got %d", len(snapshots))
+	}
+
+	// The first session found no existing state, so the history starts with
+	// the snapshot written by the first session.
+	for i, wantSerial := range []uint64{6, 4} {
+		snap := snapshots[i]
+		if snap.Serial != wantSerial || snap.File.Serial != wantSerial {
+			t.Errorf("snapshot %d has serial %d (file %d), want %d", i, snap.Serial, snap.File.Serial, wantSerial)
+		}
+		if got, want := snap.File.Lineage, snapshots[0].File.Lineage; got != want {
+			t.Errorf("snapshot %d has lineage %q, want %q", i, got, want)
+		}
+	}
+	if got := snapshots[0].File.State.RootModule().OutputValues["foo"].Value; !got.RawEquals(cty.NumberIntVal(2)) {
+		t.Errorf("newest snapshot has wrong output value %#v", got)
+	}
+}
+
 // This test verifies a particularly tricky behavior where the input file
 // is overridden and backups are enabled at the same time. This combination
 // requires special care because we must ensure that when we create a backup
@@ -208,7 +260,7 @@ func TestFilesystem_backupAndReadPath(t *testing.T) {
 		Serial:           0,
 		TerraformVersion: version.Must(version.NewVersion("1.2.3")),
 		State:            outState,
-	}, outFile)
+	}, outFile, encryption.StateEncryptionDisabled())
 	if err != nil {
 		t.Fatalf("failed to write initial outfile state to %s: %s", outFile.Name(), err)
 	}
@@ -230,14 +282,14 @@ func TestFilesystem_backupAndReadPath(t *testing.T) {
 		Serial:           0,
 		TerraformVersion: version.Must(version.NewVersion("1.2.3")),
 		State:            inState,
-	}, inFile)
+	}, inFile, encryption.StateEncryptionDisabled())
 	if err != nil {
 		t.Fatalf("failed to write initial infile state to %s: %s", inFile.Name(), err)
 	}
 
 	backupPath := outFile.Name() + ".backup"
 
-	ls := NewFilesystemBetweenPaths(inFile.Name(), outFile.Name())
+	ls := NewFilesystemBetweenPaths(inFile.Name(), outFile.Name(), encryption.StateEncryptionDisabled())
 	ls.SetBackupPath(backupPath)
 
 	newState := states.BuildState(func(ss *states.SyncState) {
@@ -260,7 +312,7 @@ func TestFilesystem_backupAndReadPath(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		bf, err := statefile.Read(bfh)
+		bf, err := statefile.Read(bfh, encryption.StateEncryptionDisabled())
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -274,7 +326,7 @@ func TestFilesystem_backupAndReadPath(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		of, err := statefile.Read(ofh)
+		of, err := statefile.Read(ofh, encryption.StateEncryptionDisabled())
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -287,7 +339,7 @@ func TestFilesystem_backupAndReadPath(t *testing.T) {
 
 func TestFilesystem_nonExist(t *testing.T) {
 	defer testOverrideVersion(t, "1.2.3")()
-	ls := NewFilesystem("ishouldntexist")
+	ls := NewFilesystem("ishouldntexist", encryption.StateEncryptionDisabled())
 	if err := ls.RefreshState(); err != nil {
 		t.Fatalf("err: %s", err)
 	}
@@ -354,13 +406,13 @@ func testFilesystem(t *testing.T) *Filesystem {
 		Serial:           0,
 		TerraformVersion: version.Must(version.NewVersion("1.2.3")),
 		State:            TestFullInitialState(),
-	}, f)
+	}, f, encryption.StateEncryptionDisabled())
 	if err != nil {
 		t.Fatalf("failed to write initial state to %s: %s", f.Name(), err)
 	}
 	f.Close()
 
-	ls := NewFilesystem(f.Name())
+	ls := NewFilesystem(f.Name(), encryption.StateEncryptionDisabled())
 	if err := ls.RefreshState(); err != nil {
 		t.Fatalf("initial refresh failed: %s", err)
 	}
@@ -381,13 +433,13 @@ func TestFilesystem_refreshWhileLocked(t *testing.T) {
 		Serial:           0,
 		TerraformVersion: version.Must(version.NewVersion("1.2.3")),
 		State:            TestFullInitialState(),
-	}, f)
+	}, f, encryption.StateEncryptionDisabled())
 	if err != nil {
 		t.Fatalf("err: %s", err)
 	}
 	f.Close()
 
-	s := NewFilesystem(f.Name())
+	s := NewFilesystem(f.Name(), encryption.StateEncryptionDisabled())
 	defer os.Remove(s.path)
 
 	// lock first
@@ -43,6 +43,11 @@ type ContextOpts struct {
 	Provisioners map[string]provisioners.Factory
 
 	UIInput UIInput
+
+	// TraceContext, if set, carries the OpenTelemetry span that the spans
+	// for each graph walk are created under. It's used only for tracing, and
+	// so cancelling it has no effect; use Context.Stop instead.
+	TraceContext context.Context
 }
 
 // ContextMeta is metadata about the running context. This is information
@@ -82,11 +87,13 @@ type Context struct {
 	uiInput UIInput
 
 	l                   sync.Mutex // Lock acquired during any task
+	parallelism         int
 	parallelSem         Semaphore
 	providerInputConfig map[string]map[string]cty.Value
 	runCond             *sync.Cond
 	runContext          context.Context
 	runContextCancel    context.CancelFunc
+	traceContext        context.Context
 }
 
 // (additional methods on Context can be found in context_*.go files.)
@@ -130,6 +137,11 @@ func NewContext(opts *ContextOpts) (*Context, tfdiags.Diagnostics) {
 
 	plugins := newContextPlugins(opts.Providers, opts.Provisioners)
 
+	traceCtx := opts.TraceContext
+	if traceCtx == nil {
+		traceCtx = context.Background()
+	}
+
 	log.Printf("[TRACE] tofu.NewContext: complete")
 
 	return &Context{
@@ -139,9 +151,11 @@ func NewContext(opts *ContextOpts) (*Context, tfdiags.Diagnostics) {
 
 		plugins: plugins,
 
+		parallelism:         par,
 		parallelSem:         NewSemaphore(par),
 		providerInputConfig: make(map[string]map[string]cty.Value),
 		sh:                  sh,
+		traceContext:        traceCtx,
 	}, diags
 }
 
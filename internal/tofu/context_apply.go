@@ -6,6 +6,7 @@ package tofu
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/zclconf/go-cty/cty"
 
@@ -70,6 +71,14 @@ func (c *Context) Apply(plan *plans.Plan, config *configs.Config) (*states.State
 
 		// We also want to propagate the timestamp from the plan file.
 		PlanTimeTimestamp: plan.Timestamp,
+
+		// The testing framework also needs the same overrides as were used
+		// during the plan.
+		Overrides: plan.Overrides,
+
+		// The resources deferred during the plan phase have no changes to
+		// apply, so the apply walk must skip them entirely.
+		PlanTimeDeferredResources: plan.DeferredResources,
 	})
 	diags = diags.Append(walker.NonFatalDiagnostics)
 	diags = diags.Append(walkDiags)
@@ -99,6 +108,32 @@ Note that the -target option is not suitable for routine use, and is provided on
 		))
 	}
 
+	if len(plan.ExcludeAddrs) > 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Warning,
+			"Applied changes may be incomplete",
+			`The plan was created with the -exclude option in effect, so some changes requested in the configuration may have been ignored and the output values may not be fully updated. Run the following command to verify that no other changes are pending:
+    tofu plan
+	
+Note that the -exclude option is not suitable for routine use, and is provided only for exceptional situations such as recovering from errors or mistakes, or when OpenTofu specifically suggests to use it as part of an error message.`,
+		))
+	}
+
+	if len(plan.DeferredResources) > 0 {
+		var addrList strings.Builder
+		for _, dr := range plan.DeferredResources {
+			fmt.Fprintf(&addrList, "\n  - %s", dr.Addr)
+		}
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Warning,
+			"Applied changes are incomplete",
+			fmt.Sprintf(`The changes for the following resources were deferred because OpenTofu couldn't determine which instances they should have when creating the plan:%s
+
+Run the following command again to plan and apply the deferred changes, now that more values are known:
+    tofu apply -allow-deferral`, addrList.String()),
+		))
+	}
+
 	// FIXME: we cannot check for an empty plan for refresh-only, because root
 	// outputs are always stored as changes. The final condition of the state
 	// also depends on some cleanup which happens during the apply walk. It
@@ -175,6 +210,7 @@ func (c *Context) applyGraph(plan *plans.Plan, config *configs.Config, validate
 		RootVariableValues: variables,
 		Plugins:            c.plugins,
 		Targets:            plan.TargetAddrs,
+		Excludes:           plan.ExcludeAddrs,
 		ForceReplace:       plan.ForceReplaceAddrs,
 		Operation:          operation,
 		ExternalReferences: plan.ExternalReferences,
@@ -2244,3 +2244,225 @@ locals {
 		t.Errorf("expected local value to be \"foo\" but was \"%s\"", module.LocalValues["local_value"].AsString())
 	}
 }
+
+func TestContext2Apply_removedResourceForgotten(t *testing.T) {
+	addrA := mustResourceInstanceAddr("test_object.a")
+	addrB := mustResourceInstanceAddr("test_object.b")
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+			resource "test_object" "b" {
+			}
+
+			removed {
+				from = test_object.a
+			}
+		`,
+	})
+
+	state := states.BuildState(func(s *states.SyncState) {
+		for _, addr := range []addrs.AbsResourceInstance{addrA, addrB} {
+			s.SetResourceInstanceCurrent(addr, &states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{}`),
+				Status:    states.ObjectReady,
+			}, mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`))
+		}
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	plan, diags := ctx.Plan(m, state, DefaultPlanOpts)
+	assertNoErrors(t, diags)
+
+	state, diags = ctx.Apply(plan, m)
+	assertNoErrors(t, diags)
+
+	if p.ApplyResourceChangeCalled {
+		t.Error("provider was asked to apply a change, but forgetting should not involve the provider")
+	}
+	if state.ResourceInstance(addrA) != nil {
+		t.Errorf("%s is still present in the state", addrA)
+	}
+	if state.ResourceInstance(addrB) == nil {
+		t.Errorf("%s was unexpectedly removed from the state", addrB)
+	}
+}
+
+func TestContext2Apply_excluded(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "test_object" "a" {
+  count       = 2
+  test_string = "a${count.index}"
+}
+
+resource "test_object" "b" {
+  test_string = test_object.a[0].test_string
+}
+
+resource "test_object" "c" {
+  test_string = "c"
+}
+
+output "b" {
+  value = test_object.b.test_string
+}
+`,
+	})
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	plan, diags := ctx.Plan(m, states.NewState(), &PlanOpts{
+		Mode: plans.NormalMode,
+		Excludes: []addrs.Targetable{
+			mustResourceInstanceAddr("test_object.a[1]"),
+		},
+	})
+	assertNoErrors(t, diags)
+
+	state, diags := ctx.Apply(plan, m)
+	assertNoErrors(t, diags)
+
+	var warned bool
+	for _, diag := range diags {
+		if diag.Severity() == tfdiags.Warning && strings.Contains(diag.Description().Detail, "-exclude option") {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Errorf("missing warning about resource exclusion")
+	}
+
+	for _, addr := range []string{"test_object.a[0]", "test_object.c"} {
+		if state.ResourceInstance(mustResourceInstanceAddr(addr)) == nil {
+			t.Errorf("missing %s in state", addr)
+		}
+	}
+	for _, addr := range []string{"test_object.a[1]", "test_object.b"} {
+		if state.ResourceInstance(mustResourceInstanceAddr(addr)) != nil {
+			t.Errorf("unexpected %s in state", addr)
+		}
+	}
+	if output := state.RootModule().OutputValues["b"]; output != nil {
+		t.Errorf("unexpected output value %#v", output.Value)
+	}
+}
+
+func TestContext2Apply_deferredResources(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "test_instance" "a" {
+}
+
+resource "test_instance" "b" {
+  count = length(test_instance.a.id)
+  foo   = test_instance.a.id
+}
+
+resource "test_instance" "c" {
+  foo = test_instance.b[0].id
+}
+
+output "c" {
+  value = test_instance.c.foo
+}
+`,
+	})
+
+	p := testProvider("test")
+	p.PlanResourceChangeFn = testDiffFn
+	p.ApplyResourceChangeFn = testApplyFn
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+	opts := &PlanOpts{
+		Mode:          plans.NormalMode,
+		AllowDeferral: true,
+	}
+
+	// The first round can only create test_instance.a, because the
+	// instances of test_instance.b depend on its id.
+	plan, diags := ctx.Plan(m, states.NewState(), opts)
+	assertNoErrors(t, diags)
+	if got, want := len(plan.DeferredResources), 2; got != want {
+		t.Fatalf("wrong number of deferred resources %d; want %d", got, want)
+	}
+
+	state, diags := ctx.Apply(plan, m)
+	assertNoErrors(t, diags)
+	if got, want := diags.ErrWithWarnings().Error(), "Applied changes are incomplete"; !strings.Contains(got, want) {
+		t.Errorf("missing warning\ngot:  %s\nwant: warning containing %q", got, want)
+	}
+	if got := state.ResourceInstance(mustResourceInstanceAddr("test_instance.a")); got == nil {
+		t.Fatalf("test_instance.a was not created")
+	}
+	if got := state.Resource(mustResourceInstanceAddr("test_instance.b").ContainingResource()); got != nil {
+		t.Fatalf("deferred test_instance.b was created")
+	}
+	if output := state.RootModule().OutputValues["c"]; output != nil && !output.Value.IsNull() {
+		t.Fatalf("output.c is %#v before test_instance.c is created; want null", output.Value)
+	}
+
+	// The second round can then converge the deferred resources.
+	plan, diags = ctx.Plan(m, state, opts)
+	assertNoErrors(t, diags)
+	if len(plan.DeferredResources) != 0 {
+		t.Fatalf("unexpected deferred resources in second plan: %#v", plan.DeferredResources)
+	}
+
+	state, diags = ctx.Apply(plan, m)
+	assertNoErrors(t, diags)
+	checkStateString(t, state, `
+test_instance.a:
+  ID = foo
+  provider = provider["registry.opentofu.org/hashicorp/test"]
+  type = test_instance
+test_instance.b.0:
+  ID = foo
+  provider = provider["registry.opentofu.org/hashicorp/test"]
+  foo = foo
+  type = test_instance
+
+  Dependencies:
+    test_instance.a
+test_instance.b.1:
+  ID = foo
+  provider = provider["registry.opentofu.org/hashicorp/test"]
+  foo = foo
+  type = test_instance
+
+  Dependencies:
+    test_instance.a
+test_instance.b.2:
+  ID = foo
+  provider = provider["registry.opentofu.org/hashicorp/test"]
+  foo = foo
+  type = test_instance
+
+  Dependencies:
+    test_instance.a
+test_instance.c:
+  ID = foo
+  provider = provider["registry.opentofu.org/hashicorp/test"]
+  foo = foo
+  type = test_instance
+
+  Dependencies:
+    test_instance.a
+    test_instance.b
+
+Outputs:
+
+c = foo
+`)
+}
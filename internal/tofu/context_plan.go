@@ -17,6 +17,7 @@ import (
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/instances"
 	"github.com/opentofu/opentofu/internal/lang/globalref"
+	"github.com/opentofu/opentofu/internal/overrides"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/refactoring"
 	"github.com/opentofu/opentofu/internal/states"
@@ -63,6 +64,15 @@ type PlanOpts struct {
 	// warnings as part of the planning result.
 	Targets []addrs.Targetable
 
+	// If Excludes has a non-zero length then OpenTofu will take no actions
+	// for resource instances mentioned in this set, nor for any other objects
+	// that depend on those resource instances.
+	//
+	// As with Targets, this is intended for exceptional use only, and so
+	// populating this field will cause OpenTofu to generate extra warnings as
+	// part of the planning result.
+	Excludes []addrs.Targetable
+
 	// ForceReplace is a set of resource instance addresses whose corresponding
 	// objects should be forced planned for replacement if the provider's
 	// plan would otherwise have been to either update the object in-place or
@@ -74,11 +84,24 @@ type PlanOpts struct {
 	// fully-functional new object.
 	ForceReplace []addrs.AbsResourceInstance
 
+	// AllowDeferral activates a planning mode where a resource whose count or
+	// for_each value isn't known yet is deferred instead of causing an error,
+	// along with everything that depends on it. The changes for the deferred
+	// resources are left out of the plan and recorded in its
+	// DeferredResources field instead, so that a later plan can converge them
+	// once the values they depend on are known.
+	AllowDeferral bool
+
 	// ExternalReferences allows the external caller to pass in references to
 	// nodes that should not be pruned even if they are not referenced within
 	// the actual graph.
 	ExternalReferences []*addrs.Reference
 
+	// Overrides contains the set of resources, data sources and modules whose
+	// results should be replaced with fixed values. This is only used by the
+	// OpenTofu testing framework.
+	Overrides *overrides.Overrides
+
 	// ImportTargets is a list of target resources to import. These resources
 	// will be added to the plan graph.
 	ImportTargets []*ImportTarget
@@ -188,6 +211,16 @@ The -target option is not for routine use, and is provided only for exceptional
 		))
 	}
 
+	if len(opts.Excludes) > 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Warning,
+			"Resource exclusion is in effect",
+			`You are creating a plan with the -exclude option, which means that the result of this plan may not represent all of the changes requested by the current configuration.
+
+The -exclude option is not for routine use, and is provided only for exceptional situations such as recovering from errors or mistakes, or when OpenTofu specifically suggests to use it as part of an error message.`,
+		))
+	}
+
 	var plan *plans.Plan
 	var planDiags tfdiags.Diagnostics
 	switch opts.Mode {
@@ -233,6 +266,7 @@ The -target option is not for routine use, and is provided only for exceptional
 	if plan != nil {
 		plan.VariableValues = varVals
 		plan.TargetAddrs = opts.Targets
+		plan.ExcludeAddrs = opts.Excludes
 	} else if !diags.HasErrors() {
 		panic("nil plan but no errors")
 	}
@@ -243,6 +277,15 @@ The -target option is not for routine use, and is provided only for exceptional
 		plan.RelevantAttributes = relevantAttrs
 	}
 
+	// Policies are evaluated against the complete plan, so we skip them if
+	// planning failed. A failed policy with error severity makes the plan
+	// errored, and so unapplyable.
+	if plan != nil && !diags.HasErrors() {
+		policyResults, policyDiags := evalPolicies(config, plan)
+		plan.PolicyResults = policyResults
+		diags = diags.Append(policyDiags)
+	}
+
 	if diags.HasErrors() {
 		// We can't proceed further with an invalid plan, because an invalid
 		// plan isn't applyable by definition.
@@ -438,7 +481,7 @@ func (c *Context) destroyPlan(config *configs.Config, prevRunState *states.State
 	return destroyPlan, diags
 }
 
-func (c *Context) prePlanFindAndApplyMoves(config *configs.Config, prevRunState *states.State, targets []addrs.Targetable) ([]refactoring.MoveStatement, refactoring.MoveResults) {
+func (c *Context) prePlanFindAndApplyMoves(config *configs.Config, prevRunState *states.State, targets []addrs.Targetable) ([]refactoring.MoveStatement, refactoring.MoveResults, tfdiags.Diagnostics) {
 	explicitMoveStmts := refactoring.FindMoveStatements(config)
 	implicitMoveStmts := refactoring.ImpliedMoveStatements(config, prevRunState, explicitMoveStmts)
 	var moveStmts []refactoring.MoveStatement
@@ -447,8 +490,10 @@ func (c *Context) prePlanFindAndApplyMoves(config *configs.Config, prevRunState
 		moveStmts = append(moveStmts, explicitMoveStmts...)
 		moveStmts = append(moveStmts, implicitMoveStmts...)
 	}
-	moveResults := refactoring.ApplyMoves(moveStmts, prevRunState)
-	return moveStmts, moveResults
+	mover := newResourceTypeMover(config, c.plugins)
+	defer mover.close()
+	moveResults, diags := refactoring.ApplyMoves(moveStmts, prevRunState, mover)
+	return moveStmts, moveResults, diags
 }
 
 func (c *Context) prePlanVerifyTargetedMoves(moveResults refactoring.MoveResults, targets []addrs.Targetable) tfdiags.Diagnostics {
@@ -519,6 +564,44 @@ func (c *Context) prePlanVerifyTargetedMoves(moveResults refactoring.MoveResults
 	return diags
 }
 
+func (c *Context) prePlanVerifyExcludedMoves(moveResults refactoring.MoveResults, excludes []addrs.Targetable) tfdiags.Diagnostics {
+	if len(excludes) < 1 {
+		return nil // the following only matters when excluding
+	}
+
+	var diags tfdiags.Diagnostics
+
+	var excluded []addrs.AbsResourceInstance
+	for _, result := range moveResults.Changes.Values() {
+		for _, excludeAddr := range excludes {
+			if excludeAddr.TargetContains(result.From) || excludeAddr.TargetContains(result.To) {
+				excluded = append(excluded, result.From)
+				break
+			}
+		}
+	}
+	if len(excluded) > 0 {
+		sort.Slice(excluded, func(i, j int) bool {
+			return excluded[i].Less(excluded[j])
+		})
+
+		var listBuf strings.Builder
+		for _, instAddr := range excluded {
+			fmt.Fprintf(&listBuf, "\n  %s", instAddr)
+		}
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Moved resource instances excluded by exclusion",
+			fmt.Sprintf(
+				"Resource instances in your current state have moved to new addresses in the latest configuration. OpenTofu must include those resource instances while planning in order to ensure a correct result, but your -exclude=... options exclude some of them:%s\n\nTo create a valid plan, remove the -exclude=... options that match these resource instances.",
+				listBuf.String(),
+			),
+		))
+	}
+
+	return diags
+}
+
 func (c *Context) postPlanValidateMoves(config *configs.Config, stmts []refactoring.MoveStatement, allInsts instances.Set) tfdiags.Diagnostics {
 	return refactoring.ValidateMoves(stmts, config, allInsts)
 }
@@ -552,10 +635,14 @@ func (c *Context) postPlanValidateImports(config *configs.Config, importTargets
 
 // findImportTargets builds a list of import targets by taking the import blocks
 // in the config and filtering out any that target a resource already in state.
+//
+// Import blocks that use for_each can't be filtered here because their target
+// addresses aren't known until their for_each expressions are evaluated during
+// the plan walk, so they are always included.
 func (c *Context) findImportTargets(config *configs.Config, priorState *states.State) []*ImportTarget {
 	var importTargets []*ImportTarget
 	for _, ic := range config.Module.Import {
-		if priorState.ResourceInstance(ic.To) == nil {
+		if ic.ForEach != nil || priorState.ResourceInstance(ic.To) == nil {
 			importTargets = append(importTargets, &ImportTarget{
 				Addr:   ic.To,
 				ID:     ic.ID,
@@ -571,11 +658,13 @@ func (c *Context) planWalk(config *configs.Config, prevRunState *states.State, o
 	log.Printf("[DEBUG] Building and walking plan graph for %s", opts.Mode)
 
 	prevRunState = prevRunState.DeepCopy() // don't modify the caller's object when we process the moves
-	moveStmts, moveResults := c.prePlanFindAndApplyMoves(config, prevRunState, opts.Targets)
+	moveStmts, moveResults, moveDiags := c.prePlanFindAndApplyMoves(config, prevRunState, opts.Targets)
+	diags = diags.Append(moveDiags)
 
 	// If resource targeting is in effect then it might conflict with the
 	// move result.
 	diags = diags.Append(c.prePlanVerifyTargetedMoves(moveResults, opts.Targets))
+	diags = diags.Append(c.prePlanVerifyExcludedMoves(moveResults, opts.Excludes))
 	if diags.HasErrors() {
 		// We'll return early here, because if we have any moved resource
 		// instances excluded by targeting then planning is likely to encounter
@@ -583,7 +672,13 @@ func (c *Context) planWalk(config *configs.Config, prevRunState *states.State, o
 		return nil, diags
 	}
 
-	graph, walkOp, moreDiags := c.planGraph(config, prevRunState, opts)
+	removeStmts, removeDiags := refactoring.FindRemoveStatements(config)
+	diags = diags.Append(removeDiags)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	graph, walkOp, moreDiags := c.planGraph(config, prevRunState, opts, removeStmts)
 	diags = diags.Append(moreDiags)
 	if diags.HasErrors() {
 		return nil, diags
@@ -600,13 +695,26 @@ func (c *Context) planWalk(config *configs.Config, prevRunState *states.State, o
 		Changes:           changes,
 		MoveResults:       moveResults,
 		PlanTimeTimestamp: timestamp,
+		Overrides:         opts.Overrides,
+		AllowDeferral:     opts.AllowDeferral,
 	})
 	diags = diags.Append(walker.NonFatalDiagnostics)
 	diags = diags.Append(walkDiags)
 
 	allInsts := walker.InstanceExpander.AllInstances()
 
-	importValidateDiags := c.postPlanValidateImports(config, opts.ImportTargets, allInsts)
+	importTargets := opts.ImportTargets
+	if walker.Deferrals.Allowed() {
+		// The instances of deferred resources aren't known yet, so we can't
+		// validate the import blocks that target them until a later plan.
+		importTargets = nil
+		for _, it := range opts.ImportTargets {
+			if !walker.Deferrals.ResourceDeferred(it.Addr.ContainingResource()) {
+				importTargets = append(importTargets, it)
+			}
+		}
+	}
+	importValidateDiags := c.postPlanValidateImports(config, importTargets, allInsts)
 	if importValidateDiags.HasErrors() {
 		return nil, importValidateDiags
 	}
@@ -655,7 +763,9 @@ func (c *Context) planWalk(config *configs.Config, prevRunState *states.State, o
 		PriorState:         priorState,
 		PlannedState:       walker.State.Close(),
 		ExternalReferences: opts.ExternalReferences,
+		Overrides:          opts.Overrides,
 		Checks:             states.NewCheckResults(walker.Checks),
+		DeferredResources:  walker.Deferrals.Resources(),
 		Timestamp:          timestamp,
 
 		// Other fields get populated by Context.Plan after we return
@@ -663,7 +773,7 @@ func (c *Context) planWalk(config *configs.Config, prevRunState *states.State, o
 	return plan, diags
 }
 
-func (c *Context) planGraph(config *configs.Config, prevRunState *states.State, opts *PlanOpts) (*Graph, walkOperation, tfdiags.Diagnostics) {
+func (c *Context) planGraph(config *configs.Config, prevRunState *states.State, opts *PlanOpts, removeStmts []refactoring.RemoveStatement) (*Graph, walkOperation, tfdiags.Diagnostics) {
 	switch mode := opts.Mode; mode {
 	case plans.NormalMode:
 		graph, diags := (&PlanGraphBuilder{
@@ -672,6 +782,7 @@ func (c *Context) planGraph(config *configs.Config, prevRunState *states.State,
 			RootVariableValues: opts.SetVariables,
 			Plugins:            c.plugins,
 			Targets:            opts.Targets,
+			Excludes:           opts.Excludes,
 			ForceReplace:       opts.ForceReplace,
 			skipRefresh:        opts.SkipRefresh,
 			preDestroyRefresh:  opts.PreDestroyRefresh,
@@ -679,6 +790,7 @@ func (c *Context) planGraph(config *configs.Config, prevRunState *states.State,
 			ExternalReferences: opts.ExternalReferences,
 			ImportTargets:      opts.ImportTargets,
 			GenerateConfigPath: opts.GenerateConfigPath,
+			RemoveStatements:   removeStmts,
 		}).Build(addrs.RootModuleInstance)
 		return graph, walkPlan, diags
 	case plans.RefreshOnlyMode:
@@ -688,6 +800,7 @@ func (c *Context) planGraph(config *configs.Config, prevRunState *states.State,
 			RootVariableValues: opts.SetVariables,
 			Plugins:            c.plugins,
 			Targets:            opts.Targets,
+			Excludes:           opts.Excludes,
 			skipRefresh:        opts.SkipRefresh,
 			skipPlanChanges:    true, // this activates "refresh only" mode.
 			Operation:          walkPlan,
@@ -701,6 +814,7 @@ func (c *Context) planGraph(config *configs.Config, prevRunState *states.State,
 			RootVariableValues: opts.SetVariables,
 			Plugins:            c.plugins,
 			Targets:            opts.Targets,
+			Excludes:           opts.Excludes,
 			skipRefresh:        opts.SkipRefresh,
 			Operation:          walkPlanDestroy,
 		}).Build(addrs.RootModuleInstance)
@@ -870,7 +984,7 @@ func (c *Context) PlanGraphForUI(config *configs.Config, prevRunState *states.St
 
 	opts := &PlanOpts{Mode: mode}
 
-	graph, _, moreDiags := c.planGraph(config, prevRunState, opts)
+	graph, _, moreDiags := c.planGraph(config, prevRunState, opts, nil)
 	diags = diags.Append(moreDiags)
 	return graph, diags
 }
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"testing"
@@ -1306,6 +1307,136 @@ func TestContext2Plan_movedResourceBasic(t *testing.T) {
 	})
 }
 
+func TestContext2Plan_movedResourceType(t *testing.T) {
+	addrA := mustResourceInstanceAddr("test_object.a")
+	addrB := mustResourceInstanceAddr("other_object.b")
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+			resource "other_object" "b" {
+				test_string = "foo"
+			}
+
+			moved {
+				from = test_object.a
+				to   = other_object.b
+			}
+		`,
+	})
+
+	state := states.BuildState(func(s *states.SyncState) {
+		s.SetResourceInstanceCurrent(addrA, &states.ResourceInstanceObjectSrc{
+			AttrsJSON:     []byte(`{"test_string":"foo"}`),
+			SchemaVersion: 2,
+			Status:        states.ObjectReady,
+		}, mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`))
+	})
+
+	testProvider := simpleMockProvider()
+	otherProvider := &MockProvider{
+		GetProviderSchemaResponse: &providers.GetProviderSchemaResponse{
+			Provider: providers.Schema{Block: simpleTestSchema()},
+			ResourceTypes: map[string]providers.Schema{
+				"other_object": {Block: simpleTestSchema()},
+			},
+			ServerCapabilities: providers.ServerCapabilities{
+				MoveResourceState: true,
+			},
+		},
+		MoveResourceStateResponse: &providers.MoveResourceStateResponse{
+			TargetState: cty.ObjectVal(map[string]cty.Value{
+				"test_string": cty.StringVal("foo"),
+			}),
+		},
+	}
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"):  testProviderFuncFixed(testProvider),
+			addrs.NewDefaultProvider("other"): testProviderFuncFixed(otherProvider),
+		},
+	})
+
+	plan, diags := ctx.Plan(m, state, DefaultPlanOpts)
+	assertNoErrors(t, diags)
+
+	if !otherProvider.MoveResourceStateCalled {
+		t.Fatalf("provider was not asked to move the resource")
+	}
+	wantReq := providers.MoveResourceStateRequest{
+		SourceProviderAddress: "registry.opentofu.org/hashicorp/test",
+		SourceTypeName:        "test_object",
+		SourceSchemaVersion:   2,
+		SourceStateJSON:       []byte(`{"test_string":"foo"}`),
+		TargetTypeName:        "other_object",
+	}
+	if diff := cmp.Diff(wantReq, otherProvider.MoveResourceStateRequest); diff != "" {
+		t.Errorf("wrong move request\n%s", diff)
+	}
+
+	if instPlan := plan.Changes.ResourceInstance(addrA); instPlan != nil {
+		t.Fatalf("unexpected plan for %s; should've moved to %s", addrA, addrB)
+	}
+	instPlan := plan.Changes.ResourceInstance(addrB)
+	if instPlan == nil {
+		t.Fatalf("no plan for %s at all", addrB)
+	}
+	if got, want := instPlan.PrevRunAddr, addrA; !got.Equal(want) {
+		t.Errorf("wrong previous run address\ngot:  %s\nwant: %s", got, want)
+	}
+	if got, want := instPlan.Action, plans.NoOp; got != want {
+		t.Errorf("wrong planned action\ngot:  %s\nwant: %s", got, want)
+	}
+	if got, want := instPlan.ProviderAddr, mustProviderConfig(`provider["registry.opentofu.org/hashicorp/other"]`); got.String() != want.String() {
+		t.Errorf("wrong provider\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestContext2Plan_movedResourceTypeUnsupported(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+			resource "other_object" "b" {
+			}
+
+			moved {
+				from = test_object.a
+				to   = other_object.b
+			}
+		`,
+	})
+
+	state := states.BuildState(func(s *states.SyncState) {
+		s.SetResourceInstanceCurrent(mustResourceInstanceAddr("test_object.a"), &states.ResourceInstanceObjectSrc{
+			AttrsJSON: []byte(`{}`),
+			Status:    states.ObjectReady,
+		}, mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`))
+	})
+
+	otherProvider := &MockProvider{
+		GetProviderSchemaResponse: &providers.GetProviderSchemaResponse{
+			Provider: providers.Schema{Block: simpleTestSchema()},
+			ResourceTypes: map[string]providers.Schema{
+				"other_object": {Block: simpleTestSchema()},
+			},
+		},
+	}
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"):  testProviderFuncFixed(simpleMockProvider()),
+			addrs.NewDefaultProvider("other"): testProviderFuncFixed(otherProvider),
+		},
+	})
+
+	_, diags := ctx.Plan(m, state, DefaultPlanOpts)
+	if !diags.HasErrors() {
+		t.Fatalf("expected errors")
+	}
+	if got, want := diags.Err().Error(), "provider registry.opentofu.org/hashicorp/other does not support moving objects from other resource types"; !strings.Contains(got, want) {
+		t.Errorf("wrong error\ngot:  %s\nwant: message containing %q", got, want)
+	}
+	if otherProvider.MoveResourceStateCalled {
+		t.Errorf("provider was asked to move the resource despite not supporting it")
+	}
+}
+
 func TestContext2Plan_movedResourceCollision(t *testing.T) {
 	addrNoKey := mustResourceInstanceAddr("test_object.a")
 	addrZeroKey := mustResourceInstanceAddr("test_object.a[0]")
@@ -4598,6 +4729,176 @@ import {
 	}
 }
 
+func TestContext2Plan_importForEach(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+variable "buckets" {
+  type = map(string)
+  default = {
+    a = "bucket-a"
+    b = "bucket-b"
+    c = "bucket-c"
+  }
+}
+
+resource "test_object" "a" {
+  for_each    = var.buckets
+  test_string = each.value
+}
+
+import {
+  for_each = var.buckets
+  to       = test_object.a[each.key]
+  id       = "id-${each.value}"
+}
+`,
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+	p.ImportResourceStateFn = func(req providers.ImportResourceStateRequest) providers.ImportResourceStateResponse {
+		state, err := simpleTestSchema().CoerceValue(cty.ObjectVal(map[string]cty.Value{
+			"test_string": cty.StringVal(strings.TrimPrefix(req.ID, "id-")),
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return providers.ImportResourceStateResponse{
+			ImportedResources: []providers.ImportedResource{
+				{
+					TypeName: "test_object",
+					State:    state,
+				},
+			},
+		}
+	}
+	p.ReadResourceFn = func(req providers.ReadResourceRequest) providers.ReadResourceResponse {
+		return providers.ReadResourceResponse{NewState: req.PriorState}
+	}
+
+	// test_object.a["c"] was already imported by an earlier run, so it must
+	// not be imported again.
+	state := states.BuildState(func(s *states.SyncState) {
+		s.SetResourceInstanceCurrent(
+			mustResourceInstanceAddr(`test_object.a["c"]`),
+			&states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{"test_string":"bucket-c"}`),
+				Status:    states.ObjectReady,
+			},
+			mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`),
+		)
+	})
+
+	plan, diags := ctx.Plan(m, state, &PlanOpts{
+		Mode: plans.NormalMode,
+		SetVariables: InputValues{
+			"buckets": &InputValue{
+				// let var take its default value
+				Value: cty.NilVal,
+			},
+		},
+	})
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors\n%s", diags.Err().Error())
+	}
+
+	for key, wantID := range map[string]string{
+		"a": "id-bucket-a",
+		"b": "id-bucket-b",
+		"c": "",
+	} {
+		addr := mustResourceInstanceAddr(fmt.Sprintf("test_object.a[%q]", key))
+		t.Run(addr.String(), func(t *testing.T) {
+			instPlan := plan.Changes.ResourceInstance(addr)
+			if instPlan == nil {
+				t.Fatalf("no plan for %s at all", addr)
+			}
+			if got, want := instPlan.Action, plans.NoOp; got != want {
+				t.Errorf("wrong planned action\ngot:  %s\nwant: %s", got, want)
+			}
+			switch {
+			case wantID == "" && instPlan.Importing != nil:
+				t.Errorf("unexpected import of %s with ID %q", addr, instPlan.Importing.ID)
+			case wantID != "" && instPlan.Importing == nil:
+				t.Errorf("expected import of %s", addr)
+			case wantID != "" && instPlan.Importing.ID != wantID:
+				t.Errorf("wrong import ID\ngot:  %s\nwant: %s", instPlan.Importing.ID, wantID)
+			}
+		})
+	}
+}
+
+func TestContext2Plan_importForEachTargetDoesNotExist(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "test_object" "a" {
+  for_each    = toset(["a"])
+  test_string = each.key
+}
+
+import {
+  for_each = toset(["a", "b"])
+  to       = test_object.a[each.key]
+  id       = each.key
+}
+`,
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	_, diags := ctx.Plan(m, states.NewState(), DefaultPlanOpts)
+	if !diags.HasErrors() {
+		t.Fatalf("expected error but got none")
+	}
+	if got, want := diags.Err().Error(), `Importing to resource address test_object.a["b"] is not possible`; !strings.Contains(got, want) {
+		t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestContext2Plan_importForEachDuplicateTarget(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "test_object" "a" {
+  for_each    = toset(["a"])
+  test_string = each.key
+}
+
+import {
+  for_each = {
+    first  = "a"
+    second = "a"
+  }
+  to = test_object.a[each.value]
+  id = each.key
+}
+`,
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	_, diags := ctx.Plan(m, states.NewState(), DefaultPlanOpts)
+	if !diags.HasErrors() {
+		t.Fatalf("expected error but got none")
+	}
+	if got, want := diags.Err().Error(), "Duplicate import target"; !strings.Contains(got, want) {
+		t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
 func TestContext2Plan_importIdVariable(t *testing.T) {
 	p := testProvider("aws")
 	m := testModule(t, "import-id-variable")
@@ -5228,3 +5529,690 @@ locals {
 		t.Errorf("expected resource to be in planned state")
 	}
 }
+
+func TestContext2Plan_removedResourceBasic(t *testing.T) {
+	addrA := mustResourceInstanceAddr("test_object.a")
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+			removed {
+				from = test_object.a
+			}
+		`,
+	})
+
+	state := states.BuildState(func(s *states.SyncState) {
+		s.SetResourceInstanceCurrent(addrA, &states.ResourceInstanceObjectSrc{
+			AttrsJSON: []byte(`{}`),
+			Status:    states.ObjectReady,
+		}, mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`))
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	plan, diags := ctx.Plan(m, state, DefaultPlanOpts)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors\n%s", diags.Err().Error())
+	}
+
+	instPlan := plan.Changes.ResourceInstance(addrA)
+	if instPlan == nil {
+		t.Fatalf("no plan for %s at all", addrA)
+	}
+	if got, want := instPlan.Action, plans.Forget; got != want {
+		t.Errorf("wrong planned action\ngot:  %s\nwant: %s", got, want)
+	}
+	if got, want := instPlan.ActionReason, plans.ResourceInstanceChangeNoReason; got != want {
+		t.Errorf("wrong action reason\ngot:  %s\nwant: %s", got, want)
+	}
+	if plan.PlannedState.ResourceInstance(addrA) != nil {
+		t.Errorf("%s still present in the planned state", addrA)
+	}
+}
+
+func TestContext2Plan_removedModule(t *testing.T) {
+	addrA := mustResourceInstanceAddr("module.child.test_object.a")
+	addrB := mustResourceInstanceAddr("module.child.test_object.b")
+	addrOther := mustResourceInstanceAddr("module.other.test_object.a")
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+			removed {
+				from = module.child
+			}
+		`,
+	})
+
+	state := states.BuildState(func(s *states.SyncState) {
+		for _, addr := range []addrs.AbsResourceInstance{addrA, addrB, addrOther} {
+			s.SetResourceInstanceCurrent(addr, &states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{}`),
+				Status:    states.ObjectReady,
+			}, mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`))
+		}
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	plan, diags := ctx.Plan(m, state, DefaultPlanOpts)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors\n%s", diags.Err().Error())
+	}
+
+	for _, test := range []struct {
+		addr addrs.AbsResourceInstance
+		want plans.Action
+	}{
+		{addrA, plans.Forget},
+		{addrB, plans.Forget},
+		{addrOther, plans.Delete},
+	} {
+		addr, want := test.addr, test.want
+		instPlan := plan.Changes.ResourceInstance(addr)
+		if instPlan == nil {
+			t.Errorf("no plan for %s at all", addr)
+			continue
+		}
+		if got := instPlan.Action; got != want {
+			t.Errorf("wrong planned action for %s\ngot:  %s\nwant: %s", addr, got, want)
+		}
+	}
+}
+
+func TestContext2Plan_removedResourceStillExists(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+			resource "test_object" "a" {
+			}
+
+			removed {
+				from = test_object.a
+			}
+		`,
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	_, diags := ctx.Plan(m, states.NewState(), DefaultPlanOpts)
+	if !diags.HasErrors() {
+		t.Fatal("unexpected success")
+	}
+	if got, want := diags.Err().Error(), "Removed resource block still exists"; !strings.Contains(got, want) {
+		t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestContext2Plan_providerFunction(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+terraform {
+  required_providers {
+    test = {
+      source = "hashicorp/test"
+    }
+  }
+}
+
+resource "test_object" "a" {
+  test_string = provider::test::upper("hello")
+}
+
+output "out" {
+  value = provider::test::upper(test_object.a.test_string)
+}
+`,
+	})
+
+	p := simpleMockProvider()
+	schema := p.GetProviderSchema()
+	schema.Functions = map[string]providers.FunctionSpec{
+		"upper": {
+			Parameters: []providers.FunctionParameterSpec{
+				{Name: "input", Type: cty.String},
+			},
+			Return: cty.String,
+		},
+	}
+	p.GetProviderSchemaResponse = &schema
+	p.CallFunctionFn = func(req providers.CallFunctionRequest) (resp providers.CallFunctionResponse) {
+		if req.Name != "upper" {
+			resp.Diagnostics = resp.Diagnostics.Append(fmt.Errorf("unexpected function %q", req.Name))
+			return resp
+		}
+		resp.Result = cty.StringVal(strings.ToUpper(req.Arguments[0].AsString()))
+		return resp
+	}
+
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	plan, diags := ctx.Plan(m, states.NewState(), DefaultPlanOpts)
+	assertNoErrors(t, diags)
+
+	if !p.CallFunctionCalled {
+		t.Fatalf("provider function was not called")
+	}
+
+	change, err := plan.Changes.ResourceInstance(mustResourceInstanceAddr("test_object.a")).Decode(simpleTestSchema().ImpliedType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := change.After.GetAttr("test_string"), cty.StringVal("HELLO"); !want.RawEquals(got) {
+		t.Errorf("wrong test_string\ngot:  %#v\nwant: %#v", got, want)
+	}
+
+	outChange, err := plan.Changes.OutputValue(addrs.RootModuleInstance.OutputValue("out")).Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := outChange.After, cty.StringVal("HELLO"); !want.RawEquals(got) {
+		t.Errorf("wrong output value\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestContext2Plan_providerFunctionImpliedProvider(t *testing.T) {
+	// The test provider isn't declared in required_providers, but the
+	// resource implies it, so its functions are available too.
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "test_object" "a" {
+  test_string = provider::test::upper("hello")
+}
+`,
+	})
+
+	p := simpleMockProvider()
+	schema := p.GetProviderSchema()
+	schema.Functions = map[string]providers.FunctionSpec{
+		"upper": {
+			Parameters: []providers.FunctionParameterSpec{
+				{Name: "input", Type: cty.String},
+			},
+			Return: cty.String,
+		},
+	}
+	p.GetProviderSchemaResponse = &schema
+	p.CallFunctionFn = func(req providers.CallFunctionRequest) (resp providers.CallFunctionResponse) {
+		resp.Result = cty.StringVal(strings.ToUpper(req.Arguments[0].AsString()))
+		return resp
+	}
+
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	plan, diags := ctx.Plan(m, states.NewState(), DefaultPlanOpts)
+	assertNoErrors(t, diags)
+
+	change, err := plan.Changes.ResourceInstance(mustResourceInstanceAddr("test_object.a")).Decode(simpleTestSchema().ImpliedType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := change.After.GetAttr("test_string"), cty.StringVal("HELLO"); !want.RawEquals(got) {
+		t.Errorf("wrong test_string\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestContext2Plan_providerFunctionInvalidArgument(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+terraform {
+  required_providers {
+    test = {
+      source = "hashicorp/test"
+    }
+  }
+}
+
+output "out" {
+  value = provider::test::upper(["not", "a", "string"])
+}
+`,
+	})
+
+	p := simpleMockProvider()
+	schema := p.GetProviderSchema()
+	schema.Functions = map[string]providers.FunctionSpec{
+		"upper": {
+			Parameters: []providers.FunctionParameterSpec{
+				{Name: "input", Type: cty.String},
+			},
+			Return: cty.String,
+		},
+	}
+	p.GetProviderSchemaResponse = &schema
+
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	_, diags := ctx.Plan(m, states.NewState(), DefaultPlanOpts)
+	if !diags.HasErrors() {
+		t.Fatalf("expected errors, got none")
+	}
+	if got, want := diags.Err().Error(), "Invalid function argument"; !strings.Contains(got, want) {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+	if p.CallFunctionCalled {
+		t.Errorf("provider function should not have been called with an invalid argument")
+	}
+}
+
+func TestContext2Plan_providerFunctionNotRequired(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+output "out" {
+  value = provider::other::upper("hello")
+}
+`,
+	})
+
+	p := simpleMockProvider()
+
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	_, diags := ctx.Plan(m, states.NewState(), DefaultPlanOpts)
+	if !diags.HasErrors() {
+		t.Fatalf("expected errors, got none")
+	}
+	if got, want := diags.Err().Error(), "Call to unknown function"; !strings.Contains(got, want) {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestContext2Plan_excluded(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "test_object" "a" {
+  count       = 2
+  test_string = "a${count.index}"
+}
+
+resource "test_object" "b" {
+  test_string = test_object.a[0].test_string
+}
+
+resource "test_object" "c" {
+  test_string = "c"
+}
+
+module "child" {
+  source = "./child"
+}
+
+output "b" {
+  value = test_object.b.test_string
+}
+`,
+		"child/main.tf": `
+resource "test_object" "d" {
+  test_string = "d"
+}
+`,
+	})
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	tests := map[string]struct {
+		excludes []addrs.Targetable
+		want     []string
+	}{
+		"resource": {
+			excludes: []addrs.Targetable{
+				mustResourceInstanceAddr("test_object.b").ContainingResource(),
+			},
+			want: []string{
+				"module.child.test_object.d",
+				"test_object.a[0]",
+				"test_object.a[1]",
+				"test_object.c",
+			},
+		},
+		"dependencies": {
+			excludes: []addrs.Targetable{
+				mustResourceInstanceAddr("test_object.a").ContainingResource(),
+			},
+			want: []string{
+				"module.child.test_object.d",
+				"test_object.c",
+			},
+		},
+		"instance": {
+			excludes: []addrs.Targetable{
+				mustResourceInstanceAddr("test_object.a[1]"),
+			},
+			want: []string{
+				"module.child.test_object.d",
+				"test_object.a[0]",
+				"test_object.c",
+			},
+		},
+		"module": {
+			excludes: []addrs.Targetable{
+				addrs.RootModuleInstance.Child("child", addrs.NoKey),
+			},
+			want: []string{
+				"test_object.a[0]",
+				"test_object.a[1]",
+				"test_object.b",
+				"test_object.c",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			plan, diags := ctx.Plan(m, states.NewState(), &PlanOpts{
+				Mode:     plans.NormalMode,
+				Excludes: test.excludes,
+			})
+			assertNoErrors(t, diags)
+
+			var warned bool
+			for _, diag := range diags {
+				if diag.Severity() == tfdiags.Warning && diag.Description().Summary == "Resource exclusion is in effect" {
+					warned = true
+				}
+			}
+			if !warned {
+				t.Errorf("missing warning about resource exclusion")
+			}
+
+			var got []string
+			for _, change := range plan.Changes.Resources {
+				got = append(got, change.Addr.String())
+			}
+			sort.Strings(got)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("wrong planned changes\n%s", diff)
+			}
+
+			if diff := cmp.Diff(test.excludes, plan.ExcludeAddrs); diff != "" {
+				t.Errorf("wrong exclude addresses in plan\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestContext2Plan_excludedMovedResource(t *testing.T) {
+	addrA := mustResourceInstanceAddr("test_object.a")
+	addrB := mustResourceInstanceAddr("test_object.b")
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "test_object" "b" {
+}
+
+moved {
+  from = test_object.a
+  to   = test_object.b
+}
+`,
+	})
+
+	state := states.BuildState(func(s *states.SyncState) {
+		s.SetResourceInstanceCurrent(addrA, &states.ResourceInstanceObjectSrc{
+			AttrsJSON: []byte(`{}`),
+			Status:    states.ObjectReady,
+		}, mustProviderConfig(`provider["registry.opentofu.org/hashicorp/test"]`))
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	_, diags := ctx.Plan(m, state, &PlanOpts{
+		Mode:     plans.NormalMode,
+		Excludes: []addrs.Targetable{addrB},
+	})
+	if !diags.HasErrors() {
+		t.Fatalf("unexpected success")
+	}
+	if got, want := diags.Err().Error(), "Moved resource instances excluded by exclusion"; !strings.Contains(got, want) {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestContext2Plan_policies(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "test_object" "a" {
+  count = 2
+}
+
+policy "limit_creates" {
+  assert {
+    condition     = plan.counts.create <= 1
+    error_message = "Too many resources to create: ${plan.counts.create}."
+  }
+}
+
+policy "no_deletes" {
+  assert {
+    condition     = plan.counts.delete == 0
+    error_message = "Nothing may be deleted."
+  }
+}
+
+policy "creates_objects" {
+  severity = "warning"
+
+  assert {
+    condition     = alltrue([for c in plan.changes : c.type == "test_object" && c.action == "create"])
+    error_message = "Unexpected change."
+  }
+}
+`,
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	plan, diags := ctx.Plan(m, states.NewState(), DefaultPlanOpts)
+	if !diags.HasErrors() {
+		t.Fatalf("expected errors, got none")
+	}
+	if got, want := diags.Err().Error(), "Too many resources to create: 2."; !strings.Contains(got, want) {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+	if !plan.Errored {
+		t.Errorf("plan is not marked as errored")
+	}
+
+	want := []*plans.PolicyResult{
+		{
+			Name:     "creates_objects",
+			Severity: tfdiags.Warning,
+			Status:   checks.StatusPass,
+		},
+		{
+			Name:            "limit_creates",
+			Severity:        tfdiags.Error,
+			Status:          checks.StatusFail,
+			FailureMessages: []string{"Too many resources to create: 2."},
+		},
+		{
+			Name:     "no_deletes",
+			Severity: tfdiags.Error,
+			Status:   checks.StatusPass,
+		},
+	}
+	if diff := cmp.Diff(want, plan.PolicyResults); diff != "" {
+		t.Errorf("wrong policy results\n%s", diff)
+	}
+}
+
+func TestContext2Plan_policyWarning(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "test_object" "a" {
+}
+
+policy "nothing_to_do" {
+  severity = "warning"
+
+  assert {
+    condition     = length(plan.changes) == 0
+    error_message = "The plan has ${length(plan.changes)} change(s)."
+  }
+}
+`,
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	plan, diags := ctx.Plan(m, states.NewState(), DefaultPlanOpts)
+	assertNoErrors(t, diags)
+	if len(diags) != 1 {
+		t.Fatalf("expected exactly one warning, got %d", len(diags))
+	}
+	if got, want := diags[0].Description().Detail, "The plan has 1 change(s)."; got != want {
+		t.Errorf("wrong warning\ngot:  %s\nwant: %s", got, want)
+	}
+	if plan.Errored {
+		t.Errorf("plan is marked as errored")
+	}
+	if got, want := len(plan.PolicyResults), 1; got != want {
+		t.Fatalf("wrong number of policy results %d; want %d", got, want)
+	}
+	if got, want := plan.PolicyResults[0].Status, checks.StatusFail; got != want {
+		t.Errorf("wrong status %s; want %s", got, want)
+	}
+}
+
+func TestContext2Plan_allowDeferral(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "test_instance" "a" {
+}
+
+resource "test_instance" "b" {
+  count = length(test_instance.a.id)
+}
+
+resource "test_instance" "c" {
+  for_each = toset([test_instance.a.id])
+}
+
+resource "test_instance" "d" {
+  foo = test_instance.b[0].id
+}
+
+resource "test_instance" "e" {
+  foo = "static"
+}
+
+output "d" {
+  value = test_instance.d.id
+}
+`,
+	})
+
+	p := testProvider("test")
+	p.PlanResourceChangeFn = testDiffFn
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		_, diags := ctx.Plan(m, states.NewState(), DefaultPlanOpts)
+		if !diags.HasErrors() {
+			t.Fatalf("succeeded; want errors")
+		}
+		if got, want := diags.Err().Error(), "Invalid count argument"; !strings.Contains(got, want) {
+			t.Errorf("missing expected error\ngot:  %s\nwant: error containing %q", got, want)
+		}
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		plan, diags := ctx.Plan(m, states.NewState(), &PlanOpts{
+			Mode:          plans.NormalMode,
+			AllowDeferral: true,
+		})
+		assertNoErrors(t, diags)
+
+		var gotChanges []string
+		for _, rc := range plan.Changes.Resources {
+			gotChanges = append(gotChanges, fmt.Sprintf("%s %s", rc.Action, rc.Addr))
+		}
+		sort.Strings(gotChanges)
+		wantChanges := []string{
+			"Create test_instance.a",
+			"Create test_instance.e",
+		}
+		if diff := cmp.Diff(wantChanges, gotChanges); diff != "" {
+			t.Errorf("wrong changes\n%s", diff)
+		}
+
+		wantDeferred := []*plans.DeferredResource{
+			{
+				Addr:   mustResourceInstanceAddr("test_instance.b").ContainingResource(),
+				Reason: plans.DeferredReasonCountUnknown,
+			},
+			{
+				Addr:   mustResourceInstanceAddr("test_instance.c").ContainingResource(),
+				Reason: plans.DeferredReasonForEachUnknown,
+			},
+			{
+				Addr:   mustResourceInstanceAddr("test_instance.d").ContainingResource(),
+				Reason: plans.DeferredReasonDependency,
+			},
+		}
+		if diff := cmp.Diff(wantDeferred, plan.DeferredResources); diff != "" {
+			t.Errorf("wrong deferred resources\n%s", diff)
+		}
+
+		output := plan.Changes.OutputValue(addrs.OutputValue{Name: "d"}.Absolute(addrs.RootModuleInstance))
+		if output == nil {
+			t.Fatalf("no change for output.d")
+		}
+		val, err := output.After.Decode(cty.DynamicPseudoType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val.IsKnown() {
+			t.Errorf("output.d is %#v; want unknown", val)
+		}
+	})
+}
@@ -5,11 +5,15 @@ package tofu
 
 import (
 	"log"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/opentofu/opentofu/internal/checks"
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/instances"
+	"github.com/opentofu/opentofu/internal/overrides"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/refactoring"
 	"github.com/opentofu/opentofu/internal/states"
@@ -41,13 +45,34 @@ type graphWalkOpts struct {
 	// the apply phase.
 	PlanTimeTimestamp time.Time
 
+	// Overrides contains the resources, data sources and modules whose
+	// results should be replaced with fixed values during the walk. This is
+	// only used by the OpenTofu testing framework.
+	Overrides *overrides.Overrides
+
+	// AllowDeferral should be set during the plan phase if resources whose
+	// instances can't be determined yet should be deferred to a later plan
+	// rather than treated as an error.
+	AllowDeferral bool
+
+	// PlanTimeDeferredResources should be populated during the apply phase
+	// with the resources that were deferred during the plan phase, so that
+	// the apply phase can skip them.
+	PlanTimeDeferredResources []*plans.DeferredResource
+
 	MoveResults refactoring.MoveResults
 }
 
 func (c *Context) walk(graph *Graph, operation walkOperation, opts *graphWalkOpts) (*ContextGraphWalker, tfdiags.Diagnostics) {
 	log.Printf("[DEBUG] Starting graph walk: %s", operation.String())
 
+	traceCtx, span := tracer.Start(c.traceContext, "graph walk", trace.WithAttributes(
+		traceAttrOperation.String(strings.TrimPrefix(operation.String(), "walk")),
+		traceAttrParallelism.Int(c.parallelism),
+	))
+
 	walker := c.graphWalker(operation, opts)
+	walker.traceCtx = traceCtx
 
 	// Watch for a stop so we can call the provider Stop() API.
 	watchStop, watchWait := c.watchStop(walker)
@@ -59,6 +84,11 @@ func (c *Context) walk(graph *Graph, operation walkOperation, opts *graphWalkOpt
 	close(watchStop)
 	<-watchWait
 
+	// Any providers that were started only to call their functions are no
+	// longer needed.
+	walker.providerFunctions.Close()
+
+	endSpanWithDiagnostics(span, diags)
 	return walker, diags
 }
 
@@ -150,5 +180,7 @@ func (c *Context) graphWalker(operation walkOperation, opts *graphWalkOpts) *Con
 		Operation:        operation,
 		StopContext:      c.runContext,
 		PlanTimestamp:    opts.PlanTimeTimestamp,
+		Overrides:        opts.Overrides,
+		Deferrals:        newDeferrals(opts.AllowDeferral, opts.PlanTimeDeferredResources),
 	}
 }
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/plans"
+)
+
+// deferrals tracks the resources whose changes are deferred to a later plan
+// because their instances can't be determined yet, which is possible only if
+// the plan was created with PlanOpts.AllowDeferral.
+//
+// During the plan walk the resources are recorded as they are deferred, so
+// that the resources that depend on them can also be deferred. During the
+// apply walk the resources deferred by the plan are recorded before the
+// walk begins, so that the apply walk can skip them.
+//
+// All methods are safe to call concurrently, and a nil *deferrals represents
+// a walk where nothing can be deferred.
+type deferrals struct {
+	allowed bool
+
+	mu        sync.Mutex
+	resources addrs.Map[addrs.AbsResource, plans.DeferredReason]
+	configs   addrs.Set[addrs.ConfigResource]
+}
+
+func newDeferrals(allowed bool, deferred []*plans.DeferredResource) *deferrals {
+	ret := &deferrals{
+		allowed:   allowed,
+		resources: addrs.MakeMap[addrs.AbsResource, plans.DeferredReason](),
+		configs:   addrs.MakeSet[addrs.ConfigResource](),
+	}
+	for _, dr := range deferred {
+		ret.resources.Put(dr.Addr, dr.Reason)
+		ret.configs.Add(dr.Addr.Config())
+	}
+	return ret
+}
+
+// Allowed returns true if resources whose instances can't be determined yet
+// should be deferred rather than treated as an error.
+func (d *deferrals) Allowed() bool {
+	return d != nil && d.allowed
+}
+
+// ReportResource records that the changes for the given resource are
+// deferred for the given reason.
+func (d *deferrals) ReportResource(addr addrs.AbsResource, reason plans.DeferredReason) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.resources.Put(addr, reason)
+	d.configs.Add(addr.Config())
+}
+
+// ResourceDeferred returns true if the changes for the given resource are
+// deferred.
+func (d *deferrals) ResourceDeferred(addr addrs.AbsResource) bool {
+	if d == nil {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.resources.Has(addr)
+}
+
+// AnyDeferred returns true if the changes for any instance of any of the
+// given resources are deferred.
+//
+// Dependencies between resources are tracked only for whole resources
+// across all module instances, so anything that depends on a deferred
+// resource in one module instance must also be deferred in every other.
+func (d *deferrals) AnyDeferred(resources []addrs.ConfigResource) bool {
+	if d == nil {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, addr := range resources {
+		if d.configs.Has(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Resources returns all of the deferred resources, ordered by address.
+func (d *deferrals) Resources() []*plans.DeferredResource {
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.resources.Len() == 0 {
+		return nil
+	}
+	ret := make([]*plans.DeferredResource, 0, d.resources.Len())
+	for _, elem := range d.resources.Elems {
+		ret = append(ret, &plans.DeferredResource{
+			Addr:   elem.Key,
+			Reason: elem.Value,
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Addr.Less(ret[j].Addr)
+	})
+	return ret
+}
@@ -7,9 +7,11 @@ import (
 	"github.com/hashicorp/hcl/v2"
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/checks"
+	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
 	"github.com/opentofu/opentofu/internal/instances"
 	"github.com/opentofu/opentofu/internal/lang"
+	"github.com/opentofu/opentofu/internal/overrides"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/providers"
 	"github.com/opentofu/opentofu/internal/provisioners"
@@ -38,10 +40,14 @@ type EvalContext interface {
 	// InitProvider initializes the provider with the given address, and returns
 	// the implementation of the resource provider or an error.
 	//
+	// If the given configuration is for a mock provider then the returned
+	// implementation is a providers.Mock wrapping the real provider. The
+	// configuration may be nil if the provider has no explicit configuration.
+	//
 	// It is an error to initialize the same provider more than once. This
 	// method will panic if the module instance address of the given provider
 	// configuration does not match the Path() of the EvalContext.
-	InitProvider(addr addrs.AbsProviderConfig) (providers.Interface, error)
+	InitProvider(addr addrs.AbsProviderConfig, config *configs.Provider) (providers.Interface, error)
 
 	// Provider gets the provider instance with the given address (already
 	// initialized) or returns nil if the provider isn't initialized.
@@ -201,6 +207,19 @@ type EvalContext interface {
 	// objects accessible through it.
 	MoveResults() refactoring.MoveResults
 
+	// Overrides returns the resources, data sources and modules whose results
+	// should be replaced with fixed values by the OpenTofu testing framework.
+	// The result is nil outside of the testing framework.
+	//
+	// This data structure is created prior to the graph walk and read-only
+	// thereafter.
+	Overrides() *overrides.Overrides
+
+	// Deferrals returns the object that tracks the resources whose changes
+	// are deferred to a later plan because their instances can't be
+	// determined yet.
+	Deferrals() *deferrals
+
 	// WithPath returns a copy of the context with the internal path set to the
 	// path argument.
 	WithPath(path addrs.ModuleInstance) EvalContext
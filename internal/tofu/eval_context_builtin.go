@@ -14,9 +14,11 @@ import (
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/checks"
+	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
 	"github.com/opentofu/opentofu/internal/instances"
 	"github.com/opentofu/opentofu/internal/lang"
+	"github.com/opentofu/opentofu/internal/overrides"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/providers"
 	"github.com/opentofu/opentofu/internal/provisioners"
@@ -73,6 +75,13 @@ type BuiltinEvalContext struct {
 	PrevRunStateValue     *states.SyncState
 	InstanceExpanderValue *instances.Expander
 	MoveResultsValue      refactoring.MoveResults
+	OverridesValue        *overrides.Overrides
+	DeferralsValue        *deferrals
+
+	// ProviderFunctions makes the functions contributed by providers
+	// available to expressions. It may be nil, in which case no provider
+	// functions are available.
+	ProviderFunctions *providerFunctions
 }
 
 // BuiltinEvalContext implements EvalContext
@@ -118,7 +127,7 @@ func (ctx *BuiltinEvalContext) Input() UIInput {
 	return ctx.InputValue
 }
 
-func (ctx *BuiltinEvalContext) InitProvider(addr addrs.AbsProviderConfig) (providers.Interface, error) {
+func (ctx *BuiltinEvalContext) InitProvider(addr addrs.AbsProviderConfig, config *configs.Provider) (providers.Interface, error) {
 	// If we already initialized, it is an error
 	if p := ctx.Provider(addr); p != nil {
 		return nil, fmt.Errorf("%s is already initialized", addr)
@@ -136,6 +145,14 @@ func (ctx *BuiltinEvalContext) InitProvider(addr addrs.AbsProviderConfig) (provi
 		return nil, err
 	}
 
+	if config != nil && config.Mock {
+		log.Printf("[TRACE] BuiltinEvalContext: Mocking %q provider for %s", addr.String(), addr)
+		p = &providers.Mock{
+			Provider: p,
+			Data:     config.MockData,
+		}
+	}
+
 	log.Printf("[TRACE] BuiltinEvalContext: Initialized %q provider for %s", addr.String(), addr)
 	ctx.ProviderCache[key] = p
 
@@ -424,6 +441,7 @@ func (ctx *BuiltinEvalContext) EvaluationScope(self addrs.Referenceable, source
 	// real situations.
 	if mc := ctx.Evaluator.Config.DescendentForInstance(ctx.PathValue); mc != nil {
 		scope.SetActiveExperiments(mc.Module.ActiveExperiments)
+		scope.ProviderFunctions = ctx.ProviderFunctions.ForModule(mc.Module)
 	}
 	return scope
 }
@@ -508,3 +526,11 @@ func (ctx *BuiltinEvalContext) InstanceExpander() *instances.Expander {
 func (ctx *BuiltinEvalContext) MoveResults() refactoring.MoveResults {
 	return ctx.MoveResultsValue
 }
+
+func (ctx *BuiltinEvalContext) Overrides() *overrides.Overrides {
+	return ctx.OverridesValue
+}
+
+func (ctx *BuiltinEvalContext) Deferrals() *deferrals {
+	return ctx.DeferralsValue
+}
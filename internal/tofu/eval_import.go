@@ -2,8 +2,11 @@ package tofu
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/instances"
 	"github.com/opentofu/opentofu/internal/lang/marks"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 	"github.com/zclconf/go-cty/cty"
@@ -25,6 +28,15 @@ func evaluateImportIdExpression(expr hcl.Expression, ctx EvalContext) (string, t
 	importIdVal, evalDiags := ctx.EvaluateExpr(expr, cty.String, nil)
 	diags = diags.Append(evalDiags)
 
+	importId, moreDiags := importIdFromValue(importIdVal, expr)
+	return importId, diags.Append(moreDiags)
+}
+
+// importIdFromValue validates the result of evaluating the "id" argument of
+// an import block, and returns it as a string.
+func importIdFromValue(importIdVal cty.Value, expr hcl.Expression) (string, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
 	if importIdVal.IsNull() {
 		return "", diags.Append(&hcl.Diagnostic{
 			Severity: hcl.DiagError,
@@ -68,3 +80,127 @@ func evaluateImportIdExpression(expr hcl.Expression, ctx EvalContext) (string, t
 
 	return importId, diags
 }
+
+// expandImportTarget expands an import target whose import block uses
+// for_each into one import target per element, with a fully-resolved address
+// and a constant import ID.
+//
+// The given EvalContext must be for the root module, where import blocks are
+// declared. Import targets that don't use for_each are returned unchanged.
+func expandImportTarget(target *ImportTarget, ctx EvalContext) ([]*ImportTarget, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	if target.Config == nil || target.Config.ForEach == nil {
+		return []*ImportTarget{target}, diags
+	}
+
+	forEach, forEachDiags := evaluateForEachExpression(target.Config.ForEach, ctx)
+	diags = diags.Append(forEachDiags)
+	if forEachDiags.HasErrors() {
+		return nil, diags
+	}
+
+	// We sort the keys so that any errors are reported in a consistent order.
+	keys := make([]string, 0, len(forEach))
+	for k := range forEach {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	seen := make(map[string]string, len(forEach))
+	ret := make([]*ImportTarget, 0, len(forEach))
+	for _, k := range keys {
+		keyData := instances.RepetitionData{
+			EachKey:   cty.StringVal(k),
+			EachValue: forEach[k],
+		}
+
+		instanceKey, keyDiags := evaluateImportToKey(target.Config.ToKey, ctx, keyData)
+		diags = diags.Append(keyDiags)
+		if keyDiags.HasErrors() {
+			continue
+		}
+		addr := target.Addr.Resource.Resource.Instance(instanceKey).Absolute(target.Addr.Module)
+
+		if prev, exists := seen[addr.String()]; exists {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate import target",
+				Detail:   fmt.Sprintf("The for_each elements %q and %q both import to %s. Each element must import to a different resource instance.", prev, k, addr),
+				Subject:  target.Config.ToKey.Range().Ptr(),
+			})
+			continue
+		}
+		seen[addr.String()] = k
+
+		scope := ctx.EvaluationScope(nil, nil, keyData)
+		importIdVal, idDiags := scope.EvalExpr(target.ID, cty.String)
+		diags = diags.Append(idDiags)
+		if idDiags.HasErrors() {
+			continue
+		}
+		importId, idDiags := importIdFromValue(importIdVal, target.ID)
+		diags = diags.Append(idDiags)
+		if idDiags.HasErrors() {
+			continue
+		}
+
+		ret = append(ret, &ImportTarget{
+			Config: target.Config,
+			Addr:   addr,
+			ID:     hcl.StaticExpr(cty.StringVal(importId), target.ID.Range()),
+		})
+	}
+
+	return ret, diags
+}
+
+// evaluateImportToKey evaluates the instance key expression of the "to"
+// argument of an import block that uses for_each, for a single element.
+func evaluateImportToKey(expr hcl.Expression, ctx EvalContext, keyData instances.RepetitionData) (addrs.InstanceKey, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	scope := ctx.EvaluationScope(nil, nil, keyData)
+	keyVal, evalDiags := scope.EvalExpr(expr, cty.DynamicPseudoType)
+	diags = diags.Append(evalDiags)
+	if evalDiags.HasErrors() {
+		return addrs.NoKey, diags
+	}
+
+	switch {
+	case keyVal.IsNull():
+		return addrs.NoKey, diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid import address",
+			Detail:   "The instance key of the import target address cannot be null.",
+			Subject:  expr.Range().Ptr(),
+		})
+	case !keyVal.IsWhollyKnown():
+		return addrs.NoKey, diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid import address",
+			Detail:   "The instance key of the import target address depends on values that cannot be determined until apply, so OpenTofu cannot plan to import this resource.",
+			Subject:  expr.Range().Ptr(),
+			Extra:    diagnosticCausedByUnknown(true),
+		})
+	case keyVal.HasMark(marks.Sensitive):
+		return addrs.NoKey, diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid import address",
+			Detail:   "The instance key of the import target address cannot be sensitive.",
+			Subject:  expr.Range().Ptr(),
+		})
+	}
+
+	keyVal, _ = keyVal.Unmark()
+	key, err := addrs.ParseInstanceKey(keyVal)
+	if err != nil {
+		return addrs.NoKey, diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid import address",
+			Detail:   fmt.Sprintf("The instance key of the import target address is unsuitable: %s.", err),
+			Subject:  expr.Range().Ptr(),
+		})
+	}
+	return key, diags
+}
@@ -0,0 +1,257 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/checks"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/lang"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// policyChangeType is the type of the elements of plan.changes in policy
+// conditions.
+var policyChangeType = cty.Object(map[string]cty.Type{
+	"address":        cty.String,
+	"module_address": cty.String,
+	"mode":           cty.String,
+	"type":           cty.String,
+	"name":           cty.String,
+	"provider":       cty.String,
+	"action":         cty.String,
+})
+
+// policyActions are the names of the actions counted in plan.counts in
+// policy conditions.
+var policyActions = []string{"create", "update", "replace", "delete", "read", "forget"}
+
+// evalPolicies evaluates the policy blocks in the root module of the given
+// configuration against the changes in the given plan.
+//
+// Failed policies produce diagnostics with the severity chosen by each
+// policy, so the caller must treat the plan as errored if the returned
+// diagnostics contain errors.
+func evalPolicies(config *configs.Config, plan *plans.Plan) ([]*plans.PolicyResult, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	if config == nil || len(config.Module.Policies) == 0 {
+		return nil, diags
+	}
+
+	names := make([]string, 0, len(config.Module.Policies))
+	for name := range config.Module.Policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	scope := &lang.Scope{
+		BaseDir:       ".",
+		PureOnly:      true,
+		PlanTimestamp: plan.Timestamp,
+	}
+	hclCtx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			configs.PolicyPlanObjectName: policyPlanObject(plan),
+		},
+		Functions: scope.Functions(),
+	}
+
+	results := make([]*plans.PolicyResult, 0, len(names))
+	for _, name := range names {
+		policy := config.Module.Policies[name]
+		result := &plans.PolicyResult{
+			Name:     name,
+			Severity: tfdiags.Error,
+			Status:   checks.StatusPass,
+		}
+		if policy.Severity == hcl.DiagWarning {
+			result.Severity = tfdiags.Warning
+		}
+
+		for _, rule := range policy.Asserts {
+			status, msg, ruleDiags := evalPolicyRule(rule, hclCtx, policy.Severity)
+			diags = diags.Append(ruleDiags)
+			switch {
+			case status == checks.StatusError:
+				result.Status = checks.StatusError
+			case status == checks.StatusFail:
+				if result.Status != checks.StatusError {
+					result.Status = checks.StatusFail
+				}
+				result.FailureMessages = append(result.FailureMessages, msg)
+			}
+		}
+
+		log.Printf("[TRACE] evalPolicies: policy %q has status %s", name, result.Status)
+		results = append(results, result)
+	}
+
+	return results, diags
+}
+
+// evalPolicyRule evaluates a single assertion of a policy, returning its
+// status and, if it failed, its error message.
+func evalPolicyRule(rule *configs.CheckRule, hclCtx *hcl.EvalContext, severity hcl.DiagnosticSeverity) (checks.Status, string, tfdiags.Diagnostics) {
+	const errInvalidCondition = "Invalid condition result"
+
+	var diags tfdiags.Diagnostics
+
+	resultVal, hclDiags := rule.Condition.Value(hclCtx)
+	diags = diags.Append(hclDiags)
+	if hclDiags.HasErrors() {
+		return checks.StatusError, "", diags
+	}
+
+	// The plan object is always known, but a condition can still be unknown
+	// if it calls an impure function, which we don't allow because its result
+	// would differ between plan and apply.
+	if !resultVal.IsKnown() || resultVal.IsNull() {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity:    hcl.DiagError,
+			Summary:     errInvalidCondition,
+			Detail:      "Policy condition expression must return either true or false.",
+			Subject:     rule.Condition.Range().Ptr(),
+			Expression:  rule.Condition,
+			EvalContext: hclCtx,
+		})
+		return checks.StatusError, "", diags
+	}
+	resultVal, err := convert.Convert(resultVal, cty.Bool)
+	if err != nil {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity:    hcl.DiagError,
+			Summary:     errInvalidCondition,
+			Detail:      fmt.Sprintf("Invalid condition result value: %s.", tfdiags.FormatError(err)),
+			Subject:     rule.Condition.Range().Ptr(),
+			Expression:  rule.Condition,
+			EvalContext: hclCtx,
+		})
+		return checks.StatusError, "", diags
+	}
+
+	if resultVal.True() {
+		return checks.StatusPass, "", diags
+	}
+
+	errorMessage, moreDiags := evalCheckErrorMessage(rule.ErrorMessage, hclCtx)
+	diags = diags.Append(moreDiags)
+	errorMessageForDiags := errorMessage
+	if errorMessageForDiags == "" {
+		errorMessageForDiags = "This policy failed, but has an invalid error message as described in the other accompanying messages."
+	}
+	diags = diags.Append(&hcl.Diagnostic{
+		Severity:    severity,
+		Summary:     "Policy failed",
+		Detail:      errorMessageForDiags,
+		Subject:     rule.Condition.Range().Ptr(),
+		Expression:  rule.Condition,
+		EvalContext: hclCtx,
+	})
+	return checks.StatusFail, errorMessage, diags
+}
+
+// policyPlanObject returns the value of the plan object available to policy
+// conditions, which describes the changes in the given plan.
+func policyPlanObject(plan *plans.Plan) cty.Value {
+	counts := make(map[string]int, len(policyActions))
+	for _, action := range policyActions {
+		counts[action] = 0
+	}
+
+	changes := make([]*plans.ResourceInstanceChangeSrc, 0, len(plan.Changes.Resources))
+	for _, rc := range plan.Changes.Resources {
+		if policyActionName(rc) == "" {
+			continue
+		}
+		changes = append(changes, rc)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if addrI, addrJ := changes[i].Addr.String(), changes[j].Addr.String(); addrI != addrJ {
+			return addrI < addrJ
+		}
+		return changes[i].DeposedKey < changes[j].DeposedKey
+	})
+
+	changeVals := make([]cty.Value, 0, len(changes))
+	for _, rc := range changes {
+		action := policyActionName(rc)
+		counts[action]++
+
+		mode := "managed"
+		if rc.Addr.Resource.Resource.Mode == addrs.DataResourceMode {
+			mode = "data"
+		}
+		changeVals = append(changeVals, cty.ObjectVal(map[string]cty.Value{
+			"address":        cty.StringVal(rc.Addr.String()),
+			"module_address": cty.StringVal(rc.Addr.Module.String()),
+			"mode":           cty.StringVal(mode),
+			"type":           cty.StringVal(rc.Addr.Resource.Resource.Type),
+			"name":           cty.StringVal(rc.Addr.Resource.Resource.Name),
+			"provider":       cty.StringVal(rc.ProviderAddr.Provider.String()),
+			"action":         cty.StringVal(action),
+		}))
+	}
+
+	countVals := make(map[string]cty.Value, len(counts))
+	for action, count := range counts {
+		countVals[action] = cty.NumberIntVal(int64(count))
+	}
+
+	changesVal := cty.ListValEmpty(policyChangeType)
+	if len(changeVals) != 0 {
+		changesVal = cty.ListVal(changeVals)
+	}
+
+	var mode string
+	switch plan.UIMode {
+	case plans.DestroyMode:
+		mode = "destroy"
+	case plans.RefreshOnlyMode:
+		mode = "refresh-only"
+	default:
+		mode = "normal"
+	}
+
+	return cty.ObjectVal(map[string]cty.Value{
+		"mode":    cty.StringVal(mode),
+		"counts":  cty.ObjectVal(countVals),
+		"changes": changesVal,
+	})
+}
+
+// policyActionName returns the name of the action of the given change in
+// policy conditions, or an empty string if the change isn't visible to
+// policies because it has no effect.
+func policyActionName(rc *plans.ResourceInstanceChangeSrc) string {
+	switch rc.Action {
+	case plans.Create:
+		return "create"
+	case plans.Update:
+		return "update"
+	case plans.DeleteThenCreate, plans.CreateThenDelete:
+		return "replace"
+	case plans.Delete:
+		if rc.Addr.Resource.Resource.Mode == addrs.DataResourceMode {
+			// Data resources are "deleted" only from the state, which
+			// isn't a change anyone needs a policy for.
+			return ""
+		}
+		return "delete"
+	case plans.Read:
+		return "read"
+	case plans.Forget:
+		return "forget"
+	default:
+		return ""
+	}
+}
@@ -65,6 +65,10 @@ type Evaluator struct {
 	// ensures they can be safely accessed and modified concurrently.
 	Changes *plans.ChangesSync
 
+	// Deferrals tracks the resources whose changes are deferred to a later
+	// plan, whose values are therefore unknown.
+	Deferrals *deferrals
+
 	PlanTimestamp time.Time
 }
 
@@ -680,6 +684,16 @@ func (d *evaluationStateData) GetResource(addr addrs.Resource, rng tfdiags.Sourc
 	}
 	ty := schema.ImpliedType()
 
+	if d.Evaluator.Deferrals.ResourceDeferred(addr.Absolute(d.ModulePath)) {
+		// The changes for this resource will be planned by a later plan, so
+		// we can't know anything about it yet, including which instances it
+		// will have.
+		if config.Count != nil || config.ForEach != nil {
+			return cty.DynamicVal, diags
+		}
+		return cty.UnknownVal(ty), diags
+	}
+
 	rs := d.Evaluator.State.Resource(addr.Absolute(d.ModulePath))
 
 	if rs == nil {
@@ -44,6 +44,11 @@ type ApplyGraphBuilder struct {
 	// outputs should go into the diff so that this is unnecessary.
 	Targets []addrs.Targetable
 
+	// Excludes are resources to exclude. As with Targets, this is only
+	// required to make sure unnecessary outputs aren't included in the apply
+	// graph.
+	Excludes []addrs.Targetable
+
 	// ForceReplace are the resource instance addresses that the user
 	// requested to force replacement for when creating the plan, if any.
 	// The apply step refers to these as part of verifying that the planned
@@ -176,6 +181,11 @@ func (b *ApplyGraphBuilder) Steps() []GraphTransformer {
 			State:  b.State,
 		},
 
+		// Exclude. This must happen before pruning, because the nodes for
+		// excluded resources which have no planned changes would be pruned,
+		// and then we could no longer find what depends on them.
+		&ExcludesTransformer{Excludes: b.Excludes},
+
 		// We need to remove configuration nodes that are not used at all, as
 		// they may not be able to evaluate, especially during destroy.
 		// These include variables, locals, and instance expanders.
@@ -10,3 +10,19 @@ import "github.com/opentofu/opentofu/internal/dag"
 func GraphDot(g *Graph, opts *dag.DotOpts) (string, error) {
 	return string(g.Dot(opts)), nil
 }
+
+// GraphJSON returns a JSON representation of the nodes and edges of the given
+// OpenTofu graph.
+func GraphJSON(g *Graph) (string, error) {
+	src, err := g.JSON(&dag.MarshalOpts{NodeKind: GraphNodeKind})
+	if err != nil {
+		return "", err
+	}
+	return string(src), nil
+}
+
+// GraphMermaid returns a Mermaid flowchart representing the given OpenTofu
+// graph.
+func GraphMermaid(g *Graph) (string, error) {
+	return string(g.Mermaid(&dag.MarshalOpts{NodeKind: GraphNodeKind})), nil
+}
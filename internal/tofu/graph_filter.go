@@ -0,0 +1,223 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/dag"
+)
+
+// The kinds of graph nodes returned by GraphNodeKind.
+const (
+	GraphNodeKindResource = "resource"
+	GraphNodeKindData     = "data"
+	GraphNodeKindProvider = "provider"
+	GraphNodeKindOutput   = "output"
+	GraphNodeKindVariable = "variable"
+	GraphNodeKindLocal    = "local"
+	GraphNodeKindModule   = "module"
+	GraphNodeKindCheck    = "check"
+)
+
+// GraphNodeKinds are all the kinds of graph nodes returned by GraphNodeKind.
+var GraphNodeKinds = []string{
+	GraphNodeKindResource,
+	GraphNodeKindData,
+	GraphNodeKindProvider,
+	GraphNodeKindOutput,
+	GraphNodeKindVariable,
+	GraphNodeKindLocal,
+	GraphNodeKindModule,
+	GraphNodeKindCheck,
+}
+
+// GraphNodeKind returns the kind of object the given graph node represents,
+// for presenting graphs in the UI, or an empty string if the node doesn't
+// represent any particular object.
+func GraphNodeKind(v dag.Vertex) string {
+	switch v := v.(type) {
+	case GraphNodeConfigResource:
+		if v.ResourceAddr().Resource.Mode == addrs.DataResourceMode {
+			return GraphNodeKindData
+		}
+		return GraphNodeKindResource
+	case GraphNodeProvider, GraphNodeCloseProvider:
+		return GraphNodeKindProvider
+	case *nodeExpandOutput, *NodeApplyableOutput, *NodeDestroyableOutput:
+		return GraphNodeKindOutput
+	case *NodeRootVariable, *nodeExpandModuleVariable, *nodeModuleVariable:
+		return GraphNodeKindVariable
+	case *nodeExpandLocal, *NodeLocal:
+		return GraphNodeKindLocal
+	case *nodeCloseModule:
+		if v.Addr.IsRoot() {
+			// The root module's close node is the root of the graph.
+			return ""
+		}
+		return GraphNodeKindModule
+	case *nodeExpandModule, *nodeValidateModule:
+		return GraphNodeKindModule
+	case *nodeExpandCheck, *nodeReportCheck, *nodeCheckStart:
+		return GraphNodeKindCheck
+	default:
+		return ""
+	}
+}
+
+// GraphFilter selects the parts of a graph to present in the UI. See
+// FilterGraph.
+type GraphFilter struct {
+	// Address, if set, selects only the nodes for the objects within the
+	// given module or resource, along with their dependencies and dependents.
+	Address addrs.Targetable
+
+	// Depth is the maximum number of edges between a node selected by Address
+	// and the dependencies and dependents that are also selected. A negative
+	// Depth means there is no limit.
+	Depth int
+
+	// ExcludeKinds are the kinds of nodes, as returned by GraphNodeKind, to
+	// leave out of the graph.
+	ExcludeKinds []string
+}
+
+// FilterGraph returns a new graph that contains only the nodes of the given
+// graph selected by the given filter.
+//
+// The nodes left out because of their kind don't break the dependencies
+// between the other nodes: if a node depends on another only through excluded
+// nodes, the new graph has a direct edge between them instead.
+func FilterGraph(g *Graph, filter *GraphFilter) *Graph {
+	selected := make(map[dag.Vertex]bool)
+	if filter.Address == nil {
+		for _, v := range g.Vertices() {
+			selected[v] = true
+		}
+	} else {
+		addr := graphFilterAddress(filter.Address)
+
+		var dependencies, dependents []dag.Vertex
+		for _, v := range g.Vertices() {
+			if graphNodeWithin(v, addr) {
+				selected[v] = true
+				dependencies = append(dependencies, v)
+				dependents = append(dependents, v)
+			}
+		}
+
+		// The root of the graph depends on everything, so it would be
+		// selected as a dependent of every node. It doesn't represent anything
+		// in the configuration, so we don't follow edges to it.
+		for depth := 0; filter.Depth < 0 || depth < filter.Depth; depth++ {
+			if len(dependencies) == 0 && len(dependents) == 0 {
+				break
+			}
+			dependencies = graphFilterStep(g.DownEdges, dependencies, selected)
+			dependents = graphFilterStep(g.UpEdges, dependents, selected)
+		}
+	}
+
+	excluded := make(map[string]bool, len(filter.ExcludeKinds))
+	for _, kind := range filter.ExcludeKinds {
+		excluded[kind] = true
+	}
+	keep := func(v dag.Vertex) bool {
+		return selected[v] && !excluded[GraphNodeKind(v)]
+	}
+
+	ret := &Graph{Path: g.Path}
+	for _, v := range g.Vertices() {
+		if keep(v) {
+			ret.Add(v)
+		}
+	}
+	for _, v := range ret.Vertices() {
+		// We walk down the dependencies of each node we keep, through any
+		// selected nodes we don't keep, to find the nearest dependencies we
+		// do keep.
+		seen := make(map[dag.Vertex]bool)
+		next := g.DownEdges(v).List()
+		for len(next) > 0 {
+			dep := next[0]
+			next = next[1:]
+			if seen[dep] || !selected[dep] {
+				continue
+			}
+			seen[dep] = true
+
+			if keep(dep) {
+				ret.Connect(dag.BasicEdge(v, dep))
+				continue
+			}
+			next = append(next, g.DownEdges(dep).List()...)
+		}
+	}
+
+	return ret
+}
+
+// graphFilterStep selects the vertices connected to the given vertices by the
+// given function, returning those that weren't already selected.
+func graphFilterStep(edges func(dag.Vertex) dag.Set, from []dag.Vertex, selected map[dag.Vertex]bool) []dag.Vertex {
+	var ret []dag.Vertex
+	for _, v := range from {
+		for _, other := range edges(v).List() {
+			if graphFilterIsRoot(other) || selected[other] {
+				continue
+			}
+			selected[other] = true
+			ret = append(ret, other)
+		}
+	}
+	return ret
+}
+
+// graphFilterIsRoot returns true if the given graph node is the root of the
+// graph, which is either an explicit root node or the close node of the root
+// module, depending on the kind of graph.
+func graphFilterIsRoot(v dag.Vertex) bool {
+	switch v := v.(type) {
+	case graphNodeRoot:
+		return true
+	case *nodeCloseModule:
+		return v.Addr.IsRoot()
+	default:
+		return false
+	}
+}
+
+// graphFilterAddress converts the given address to the equivalent
+// configuration address, because the graphs we present in the UI contain
+// nodes for configuration objects rather than for their instances.
+func graphFilterAddress(addr addrs.Targetable) addrs.Targetable {
+	switch addr := addr.(type) {
+	case addrs.ModuleInstance:
+		return addr.Module()
+	case addrs.AbsResource:
+		return addr.Config()
+	case addrs.AbsResourceInstance:
+		return addr.ContainingResource().Config()
+	default:
+		return addr
+	}
+}
+
+// graphNodeWithin returns true if the given graph node represents an object
+// within the given module or resource.
+func graphNodeWithin(v dag.Vertex, addr addrs.Targetable) bool {
+	if v, ok := v.(GraphNodeConfigResource); ok {
+		return addr.TargetContains(v.ResourceAddr())
+	}
+	if module, ok := addr.(addrs.Module); ok {
+		if _, ok := v.(GraphNodeProvider); ok {
+			// Provider configurations aren't part of the module's objects,
+			// even when they are declared in it.
+			return false
+		}
+		if v, ok := v.(GraphNodeModulePath); ok {
+			return module.TargetContains(v.ModulePath())
+		}
+	}
+	return false
+}
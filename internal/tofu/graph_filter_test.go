@@ -0,0 +1,195 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/dag"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+func TestFilterGraph(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+data "test_object" "a" {
+}
+
+resource "test_object" "b" {
+  test_string = data.test_object.a.test_string
+}
+
+module "child" {
+  source = "./child"
+  in     = test_object.b.test_string
+}
+
+output "out" {
+  value = module.child.out
+}
+`,
+		"child/main.tf": `
+variable "in" {
+}
+
+resource "test_object" "c" {
+  test_string = var.in
+}
+
+output "out" {
+  value = test_object.c.test_string
+}
+`,
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+	})
+	g, diags := ctx.PlanGraphForUI(m, states.NewState(), plans.NormalMode)
+	assertNoErrors(t, diags)
+
+	tests := map[string]struct {
+		filter    *GraphFilter
+		wantNodes []string
+		wantEdges []string
+	}{
+		"resource with unlimited depth": {
+			filter: &GraphFilter{
+				Address: mustResourceInstanceAddr("test_object.b").ContainingResource(),
+				Depth:   -1,
+			},
+			wantNodes: []string{
+				"data.test_object.a (expand)",
+				"module.child (close)",
+				"module.child.output.out (expand)",
+				"module.child.test_object.c (expand)",
+				"module.child.var.in (expand)",
+				"output.out (expand)",
+				`provider["registry.opentofu.org/hashicorp/test"]`,
+				`provider["registry.opentofu.org/hashicorp/test"] (close)`,
+				"test_object.b (expand)",
+			},
+			wantEdges: []string{
+				"data.test_object.a (expand) -> provider[\"registry.opentofu.org/hashicorp/test\"]",
+				"module.child (close) -> module.child.output.out (expand)",
+				"module.child.output.out (expand) -> module.child.test_object.c (expand)",
+				"module.child.test_object.c (expand) -> module.child.var.in (expand)",
+				"module.child.var.in (expand) -> test_object.b (expand)",
+				"output.out (expand) -> module.child.output.out (expand)",
+				"provider[\"registry.opentofu.org/hashicorp/test\"] (close) -> module.child.test_object.c (expand)",
+				"test_object.b (expand) -> data.test_object.a (expand)",
+			},
+		},
+		"resource instance with depth": {
+			filter: &GraphFilter{
+				Address: mustResourceInstanceAddr("test_object.b"),
+				Depth:   1,
+			},
+			wantNodes: []string{
+				"data.test_object.a (expand)",
+				"module.child.var.in (expand)",
+				"test_object.b (expand)",
+			},
+			wantEdges: []string{
+				"module.child.var.in (expand) -> test_object.b (expand)",
+				"test_object.b (expand) -> data.test_object.a (expand)",
+			},
+		},
+		"module without dependencies or dependents": {
+			filter: &GraphFilter{
+				Address: addrs.RootModuleInstance.Child("child", addrs.NoKey),
+				Depth:   0,
+			},
+			wantNodes: []string{
+				"module.child (close)",
+				"module.child (expand)",
+				"module.child.output.out (expand)",
+				"module.child.test_object.c (expand)",
+				"module.child.var.in (expand)",
+			},
+			wantEdges: []string{
+				"module.child (close) -> module.child.output.out (expand)",
+				"module.child.output.out (expand) -> module.child.test_object.c (expand)",
+				"module.child.test_object.c (expand) -> module.child.var.in (expand)",
+				"module.child.var.in (expand) -> module.child (expand)",
+			},
+		},
+		"excluded kinds": {
+			filter: &GraphFilter{
+				Depth:        -1,
+				ExcludeKinds: []string{GraphNodeKindProvider, GraphNodeKindVariable, GraphNodeKindOutput, GraphNodeKindModule},
+			},
+			wantNodes: []string{
+				"data.test_object.a (expand)",
+				"module.child.test_object.c (expand)",
+				"root",
+				"test_object.b (expand)",
+			},
+			wantEdges: []string{
+				"module.child.test_object.c (expand) -> test_object.b (expand)",
+				"root -> module.child.test_object.c (expand)",
+				"test_object.b (expand) -> data.test_object.a (expand)",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := FilterGraph(g, test.filter)
+
+			var gotNodes, gotEdges []string
+			for _, v := range got.Vertices() {
+				gotNodes = append(gotNodes, dag.VertexName(v))
+			}
+			for _, e := range got.Edges() {
+				gotEdges = append(gotEdges, fmt.Sprintf("%s -> %s", dag.VertexName(e.Source()), dag.VertexName(e.Target())))
+			}
+			sort.Strings(gotNodes)
+			sort.Strings(gotEdges)
+
+			if diff := cmp.Diff(test.wantNodes, gotNodes); diff != "" {
+				t.Errorf("wrong nodes\n%s", diff)
+			}
+			if diff := cmp.Diff(test.wantEdges, gotEdges); diff != "" {
+				t.Errorf("wrong edges\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGraphNodeKind(t *testing.T) {
+	tests := []struct {
+		node dag.Vertex
+		want string
+	}{
+		{&nodeExpandPlannableResource{NodeAbstractResource: NewNodeAbstractResource(mustConfigResourceAddr("test_object.a"))}, GraphNodeKindResource},
+		{&nodeExpandPlannableResource{NodeAbstractResource: NewNodeAbstractResource(mustConfigResourceAddr("data.test_object.a"))}, GraphNodeKindData},
+		{&NodeApplyableProvider{}, GraphNodeKindProvider},
+		{&nodeExpandOutput{}, GraphNodeKindOutput},
+		{&NodeRootVariable{}, GraphNodeKindVariable},
+		{&nodeExpandLocal{}, GraphNodeKindLocal},
+		{&nodeExpandModule{}, GraphNodeKindModule},
+		{&nodeCloseModule{Addr: addrs.RootModule.Child("child")}, GraphNodeKindModule},
+		{&nodeCloseModule{Addr: addrs.RootModule}, ""},
+		{graphNodeRoot{}, ""},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("%d %T", i, test.node), func(t *testing.T) {
+			if got := GraphNodeKind(test.node); got != test.want {
+				t.Errorf("wrong kind %q; want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -9,12 +9,15 @@ import (
 	"time"
 
 	"github.com/zclconf/go-cty/cty"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/checks"
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/dag"
 	"github.com/opentofu/opentofu/internal/instances"
+	"github.com/opentofu/opentofu/internal/overrides"
 	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/providers"
 	"github.com/opentofu/opentofu/internal/provisioners"
@@ -43,6 +46,8 @@ type ContextGraphWalker struct {
 	RootVariableValues InputValues
 	Config             *configs.Config
 	PlanTimestamp      time.Time
+	Overrides          *overrides.Overrides // Read-only record of the overrides used by the testing framework
+	Deferrals          *deferrals           // Used for safe concurrent writes of deferred resources
 
 	// This is an output. Do not set this, nor read it while a graph walk
 	// is in progress.
@@ -59,6 +64,8 @@ type ContextGraphWalker struct {
 	provisionerCache   map[string]provisioners.Interface
 	provisionerSchemas map[string]*configschema.Block
 	provisionerLock    sync.Mutex
+	providerFunctions  *providerFunctions
+	traceCtx           context.Context
 }
 
 func (w *ContextGraphWalker) EnterPath(path addrs.ModuleInstance) EvalContext {
@@ -88,6 +95,7 @@ func (w *ContextGraphWalker) EvalContext() EvalContext {
 		Operation:          w.Operation,
 		State:              w.State,
 		Changes:            w.Changes,
+		Deferrals:          w.Deferrals,
 		Plugins:            w.Context.plugins,
 		VariableValues:     w.variableValues,
 		VariableValuesLock: &w.variableValuesLock,
@@ -114,6 +122,9 @@ func (w *ContextGraphWalker) EvalContext() EvalContext {
 		Evaluator:             evaluator,
 		VariableValues:        w.variableValues,
 		VariableValuesLock:    &w.variableValuesLock,
+		OverridesValue:        w.Overrides,
+		DeferralsValue:        w.Deferrals,
+		ProviderFunctions:     w.providerFunctions,
 	}
 
 	return ctx
@@ -125,6 +136,7 @@ func (w *ContextGraphWalker) init() {
 	w.providerSchemas = make(map[string]providers.ProviderSchema)
 	w.provisionerCache = make(map[string]provisioners.Interface)
 	w.provisionerSchemas = make(map[string]*configschema.Block)
+	w.providerFunctions = newProviderFunctions(w.Context.plugins)
 	w.variableValues = make(map[string]map[string]cty.Value)
 
 	// Populate root module variable values. Other modules will be populated
@@ -136,9 +148,27 @@ func (w *ContextGraphWalker) init() {
 }
 
 func (w *ContextGraphWalker) Execute(ctx EvalContext, n GraphNodeExecutable) tfdiags.Diagnostics {
+	traceCtx := w.traceCtx
+	if traceCtx == nil {
+		traceCtx = context.Background()
+	}
+	traceCtx, span := tracer.Start(traceCtx, dag.VertexName(n), trace.WithAttributes(
+		vertexTraceAttributes(n)...,
+	))
+
 	// Acquire a lock on the semaphore
+	waitStart := time.Now()
 	w.Context.parallelSem.Acquire()
 	defer w.Context.parallelSem.Release()
+	span.SetAttributes(traceAttrParallelismWait.Int64(time.Since(waitStart).Milliseconds()))
+
+	// Calls to providers are traced only if someone is collecting the
+	// traces, because it means wrapping each provider.
+	if span.IsRecording() {
+		ctx = tracingEvalContext{EvalContext: ctx, traceCtx: traceCtx}
+	}
 
-	return n.Execute(ctx, w.Operation)
+	diags := n.Execute(ctx, w.Operation)
+	endSpanWithDiagnostics(span, diags)
+	return diags
 }
@@ -372,6 +372,17 @@ If you do intend to export this data, annotate the output value as sensitive by
 		}
 	}
 
+	// The outputs of a module that is overridden by the testing framework
+	// use the overridden value instead, if there is one.
+	if override, ok := ctx.Overrides().GetModuleOverride(n.Addr.Module); ok && !diags.HasErrors() {
+		if name := n.Addr.OutputValue.Name; override.Values.Type().HasAttribute(name) {
+			val = override.Values.GetAttr(name)
+			if n.Config.Sensitive {
+				val = val.Mark(marks.Sensitive)
+			}
+		}
+	}
+
 	// handling the interpolation error
 	if diags.HasErrors() {
 		if flagWarnOutputErrors {
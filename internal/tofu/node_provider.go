@@ -25,7 +25,7 @@ var (
 
 // GraphNodeExecutable
 func (n *NodeApplyableProvider) Execute(ctx EvalContext, op walkOperation) (diags tfdiags.Diagnostics) {
-	_, err := ctx.InitProvider(n.Addr)
+	_, err := ctx.InitProvider(n.Addr, n.ProviderConfig())
 	diags = diags.Append(err)
 	if diags.HasErrors() {
 		return diags
@@ -36,6 +36,13 @@ func (n *NodeApplyableProvider) Execute(ctx EvalContext, op walkOperation) (diag
 		return diags
 	}
 
+	if config := n.ProviderConfig(); config != nil && config.Mock {
+		// Mock providers are never configured, so there's nothing to
+		// validate either.
+		log.Printf("[TRACE] NodeApplyableProvider: skipping configuration of mock provider %s", n.Addr)
+		return diags
+	}
+
 	switch op {
 	case walkValidate:
 		log.Printf("[TRACE] NodeApplyableProvider: validating configuration for %s", n.Addr)
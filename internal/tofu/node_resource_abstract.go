@@ -65,6 +65,9 @@ type NodeAbstractResource struct {
 	// Set from GraphNodeTargetable
 	Targets []addrs.Targetable
 
+	// Set from GraphNodeExcludeable
+	Excludes []addrs.Targetable
+
 	// Set from AttachDataResourceDependsOn
 	dependsOn      []addrs.ConfigResource
 	forceDependsOn bool
@@ -208,6 +211,12 @@ func (n *NodeAbstractResource) References() []*addrs.Reference {
 	for _, importTarget := range n.importTargets {
 		refs, _ := lang.ReferencesInExpr(addrs.ParseRef, importTarget.ID)
 		result = append(result, refs...)
+		if c := importTarget.Config; c != nil {
+			refs, _ = lang.ReferencesInExpr(addrs.ParseRef, c.ForEach)
+			result = append(result, refs...)
+			refs, _ = lang.ReferencesInExpr(addrs.ParseRef, c.ToKey)
+			result = append(result, refs...)
+		}
 	}
 
 	return result
@@ -339,6 +348,11 @@ func (n *NodeAbstractResource) SetTargets(targets []addrs.Targetable) {
 	n.Targets = targets
 }
 
+// GraphNodeExcludeable
+func (n *NodeAbstractResource) SetExcludes(excludes []addrs.Targetable) {
+	n.Excludes = excludes
+}
+
 // graphNodeAttachDataResourceDependsOn
 func (n *NodeAbstractResource) AttachDataResourceDependsOn(deps []addrs.ConfigResource, force bool) {
 	n.dependsOn = deps
@@ -135,6 +135,39 @@ func (n *NodeAbstractResourceInstance) AttachResourceState(s *states.Resource) {
 	n.storedProviderConfig = s.ProviderConfig
 }
 
+// getProvider returns the provider for the receiving resource instance. If
+// the instance has been overridden by the OpenTofu testing framework then the
+// result is a providers.Mock that returns the overridden values instead of
+// calling the real provider.
+func (n *NodeAbstractResourceInstance) getProvider(ctx EvalContext) (providers.Interface, providers.ProviderSchema, error) {
+	provider, providerSchema, err := getProvider(ctx, n.ResolvedProvider)
+	if err != nil {
+		return provider, providerSchema, err
+	}
+
+	values, overridden := ctx.Overrides().GetResourceValues(n.Addr)
+	if !overridden {
+		return provider, providerSchema, nil
+	}
+
+	log.Printf("[TRACE] NodeAbstractResourceInstance: using overridden values for %s", n.Addr)
+	mock := &configs.MockResource{
+		Mode:     n.Addr.Resource.Resource.Mode,
+		Type:     n.Addr.Resource.Resource.Type,
+		Defaults: values,
+	}
+	data := &configs.MockData{
+		MockResources:     make(map[string]*configs.MockResource),
+		MockDataResources: make(map[string]*configs.MockResource),
+	}
+	if mock.Mode == addrs.DataResourceMode {
+		data.MockDataResources[mock.Type] = mock
+	} else {
+		data.MockResources[mock.Type] = mock
+	}
+	return &providers.Mock{Provider: provider, Data: data}, providerSchema, nil
+}
+
 // readDiff returns the planned change for a particular resource instance
 // object.
 func (n *NodeAbstractResourceInstance) readDiff(ctx EvalContext, providerSchema providers.ProviderSchema) (*plans.ResourceInstanceChange, error) {
@@ -346,6 +379,44 @@ func (n *NodeAbstractResourceInstance) writeResourceInstanceStateImpl(ctx EvalCo
 	return nil
 }
 
+// planForget returns a change that removes the given object from the state
+// without destroying the corresponding remote object.
+//
+// Unlike planDestroy, this doesn't need to consult the provider at all,
+// because the provider is not involved in applying the change.
+func (n *NodeAbstractResourceInstance) planForget(ctx EvalContext, currentState *states.ResourceInstanceObject, deposedKey states.DeposedKey) *plans.ResourceInstanceChange {
+	absAddr := n.Addr
+
+	// If there is no state or our attributes object is null then there's
+	// nothing to forget, but we still generate a NoOp change for the same
+	// reasons described in planDestroy.
+	if currentState == nil || currentState.Value.IsNull() {
+		return &plans.ResourceInstanceChange{
+			Addr:        absAddr,
+			PrevRunAddr: n.prevRunAddr(ctx),
+			DeposedKey:  deposedKey,
+			Change: plans.Change{
+				Action: plans.NoOp,
+				Before: cty.NullVal(cty.DynamicPseudoType),
+				After:  cty.NullVal(cty.DynamicPseudoType),
+			},
+			ProviderAddr: n.ResolvedProvider,
+		}
+	}
+
+	return &plans.ResourceInstanceChange{
+		Addr:        absAddr,
+		PrevRunAddr: n.prevRunAddr(ctx),
+		DeposedKey:  deposedKey,
+		Change: plans.Change{
+			Action: plans.Forget,
+			Before: currentState.Value,
+			After:  cty.NullVal(cty.DynamicPseudoType),
+		},
+		ProviderAddr: n.ResolvedProvider,
+	}
+}
+
 // planDestroy returns a plain destroy diff.
 func (n *NodeAbstractResourceInstance) planDestroy(ctx EvalContext, currentState *states.ResourceInstanceObject, deposedKey states.DeposedKey) (*plans.ResourceInstanceChange, tfdiags.Diagnostics) {
 	var diags tfdiags.Diagnostics
@@ -388,7 +459,7 @@ func (n *NodeAbstractResourceInstance) planDestroy(ctx EvalContext, currentState
 	// operation.
 	nullVal := cty.NullVal(unmarkedPriorVal.Type())
 
-	provider, _, err := getProvider(ctx, n.ResolvedProvider)
+	provider, _, err := n.getProvider(ctx)
 	if err != nil {
 		return plan, diags.Append(err)
 	}
@@ -518,7 +589,7 @@ func (n *NodeAbstractResourceInstance) refresh(ctx EvalContext, deposedKey state
 	} else {
 		log.Printf("[TRACE] NodeAbstractResourceInstance.refresh for %s (deposed object %s)", absAddr, deposedKey)
 	}
-	provider, providerSchema, err := getProvider(ctx, n.ResolvedProvider)
+	provider, providerSchema, err := n.getProvider(ctx)
 	if err != nil {
 		return state, diags.Append(err)
 	}
@@ -653,7 +724,7 @@ func (n *NodeAbstractResourceInstance) plan(
 	var keyData instances.RepetitionData
 
 	resource := n.Addr.Resource.Resource
-	provider, providerSchema, err := getProvider(ctx, n.ResolvedProvider)
+	provider, providerSchema, err := n.getProvider(ctx)
 	if err != nil {
 		return nil, nil, keyData, diags.Append(err)
 	}
@@ -1402,7 +1473,7 @@ func (n *NodeAbstractResourceInstance) readDataSource(ctx EvalContext, configVal
 
 	config := *n.Config
 
-	provider, providerSchema, err := getProvider(ctx, n.ResolvedProvider)
+	provider, providerSchema, err := n.getProvider(ctx)
 	diags = diags.Append(err)
 	if diags.HasErrors() {
 		return newVal, diags
@@ -2212,7 +2283,7 @@ func (n *NodeAbstractResourceInstance) apply(
 		return state, diags
 	}
 
-	provider, providerSchema, err := getProvider(ctx, n.ResolvedProvider)
+	provider, providerSchema, err := n.getProvider(ctx)
 	if err != nil {
 		return nil, diags.Append(err)
 	}
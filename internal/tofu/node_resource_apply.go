@@ -4,6 +4,8 @@
 package tofu
 
 import (
+	"log"
+
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
@@ -51,8 +53,15 @@ func (n *nodeExpandApplyableResource) Execute(ctx EvalContext, op walkOperation)
 	expander := ctx.InstanceExpander()
 	moduleInstances := expander.ExpandModule(n.Addr.Module)
 	for _, module := range moduleInstances {
+		addr := n.Addr.Resource.Absolute(module)
+		if ctx.Deferrals().ResourceDeferred(addr) {
+			// The plan has no changes for this resource, and its instances
+			// might still be unknown, so there's nothing for us to do.
+			log.Printf("[TRACE] nodeExpandApplyableResource: skipping deferred %s", addr)
+			continue
+		}
 		ctx = ctx.WithPath(module)
-		diags = diags.Append(n.writeResourceState(ctx, n.Addr.Resource.Absolute(module)))
+		diags = diags.Append(n.writeResourceState(ctx, addr))
 	}
 
 	return diags
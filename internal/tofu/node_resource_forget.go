@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"log"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// NodeForgetResourceInstance represents a resource instance that is to be
+// removed from the state without destroying the corresponding remote object,
+// as declared by a "removed" block in the configuration.
+type NodeForgetResourceInstance struct {
+	*NodeAbstractResourceInstance
+}
+
+var (
+	_ GraphNodeModuleInstance       = (*NodeForgetResourceInstance)(nil)
+	_ GraphNodeConfigResource       = (*NodeForgetResourceInstance)(nil)
+	_ GraphNodeResourceInstance     = (*NodeForgetResourceInstance)(nil)
+	_ GraphNodeAttachResourceConfig = (*NodeForgetResourceInstance)(nil)
+	_ GraphNodeAttachResourceState  = (*NodeForgetResourceInstance)(nil)
+	_ GraphNodeExecutable           = (*NodeForgetResourceInstance)(nil)
+	_ GraphNodeProviderConsumer     = (*NodeForgetResourceInstance)(nil)
+)
+
+func (n *NodeForgetResourceInstance) Name() string {
+	return n.ResourceInstanceAddr().String() + " (forget)"
+}
+
+// GraphNodeProviderConsumer
+func (n *NodeForgetResourceInstance) ProvidedBy() (addr addrs.ProviderConfig, exact bool) {
+	// Forgetting an object doesn't involve the provider, so this node
+	// doesn't require a configured provider. This also means that objects
+	// can be forgotten even if their provider configuration has already
+	// been removed along with the resource.
+	return nil, true
+}
+
+// GraphNodeExecutable
+func (n *NodeForgetResourceInstance) Execute(ctx EvalContext, op walkOperation) (diags tfdiags.Diagnostics) {
+	addr := n.ResourceInstanceAddr()
+
+	change := ctx.Changes().GetResourceInstanceChange(addr, states.CurrentGen)
+	if change == nil || change.Action != plans.Forget {
+		// Should never happen, because DiffTransformer only creates this
+		// node for a planned forget action.
+		log.Printf("[WARN] NodeForgetResourceInstance for %s without a planned forget change", addr)
+		return diags
+	}
+
+	log.Printf("[TRACE] NodeForgetResourceInstance: removing %s from the state", addr)
+	ctx.State().SetResourceInstanceCurrent(addr, nil, n.ResolvedProvider)
+	return diags.Append(updateStateHook(ctx))
+}
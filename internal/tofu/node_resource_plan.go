@@ -5,10 +5,14 @@ package tofu
 
 import (
 	"fmt"
+	"log"
 	"strings"
 
+	"github.com/hashicorp/hcl/v2"
+
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/dag"
+	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
@@ -174,8 +178,11 @@ func (n *nodeExpandPlannableResource) DynamicExpand(ctx EvalContext) (*Graph, er
 	// (i.e. it has preconditions or postconditions) then the check state
 	// wants to know the addresses of the checkable objects so that it can
 	// treat them as unknown status if we encounter an error before actually
-	// visiting the checks.
-	if checkState := ctx.Checks(); checkState.ConfigHasChecks(n.NodeAbstractResource.Addr) {
+	// visiting the checks. We don't know all of the addresses if any of
+	// the resource's instances were deferred, so in that case we leave the
+	// status unknown.
+	deferred := ctx.Deferrals().AnyDeferred([]addrs.ConfigResource{n.Addr})
+	if checkState := ctx.Checks(); checkState.ConfigHasChecks(n.NodeAbstractResource.Addr) && !deferred {
 		checkState.ReportCheckableObjects(n.NodeAbstractResource.Addr, instAddrs)
 	}
 
@@ -203,6 +210,15 @@ func (n *nodeExpandPlannableResource) expandResourceInstances(globalCtx EvalCont
 	// working in, so that it can evaluate expressions in the appropriate scope.
 	moduleCtx := globalCtx.WithPath(resAddr.Module)
 
+	// If we can't determine the instances of this resource yet then, if
+	// allowed, we'll defer all of its changes to a later plan rather than
+	// failing.
+	if reason := n.deferralReason(moduleCtx); reason != plans.DeferredReasonInvalid {
+		log.Printf("[TRACE] nodeExpandPlannableResource: deferring %s (%s)", resAddr, reason)
+		globalCtx.Deferrals().ReportResource(resAddr, reason)
+		return nil
+	}
+
 	// writeResourceState is responsible for informing the expander of what
 	// repetition mode this resource has, which allows expander.ExpandResource
 	// to work below.
@@ -276,6 +292,12 @@ func (n *nodeExpandPlannableResource) expandResourceInstances(globalCtx EvalCont
 	// actions is in the per-instance function we're about to call, because
 	// we need to evaluate it on a per-instance basis.
 
+	importTargets, importDiags := n.expandImportTargets(globalCtx, resAddr, instanceAddrs)
+	diags = diags.Append(importDiags)
+	if importDiags.HasErrors() {
+		return diags.ErrWithWarnings()
+	}
+
 	for _, addr := range instanceAddrs {
 		// If this resource is participating in the "checks" mechanism then our
 		// caller will need to know all of our expanded instance addresses as
@@ -291,7 +313,7 @@ func (n *nodeExpandPlannableResource) expandResourceInstances(globalCtx EvalCont
 	// construct a subgraph just for this individual modules's instances and
 	// then we'll steal all of its nodes and edges to incorporate into our
 	// main graph which contains all of the resource instances together.
-	instG, err := n.resourceInstanceSubgraph(moduleCtx, resAddr, instanceAddrs)
+	instG, err := n.resourceInstanceSubgraph(moduleCtx, resAddr, instanceAddrs, importTargets)
 	if err != nil {
 		diags = diags.Append(err)
 		return diags.ErrWithWarnings()
@@ -301,7 +323,103 @@ func (n *nodeExpandPlannableResource) expandResourceInstances(globalCtx EvalCont
 	return diags.ErrWithWarnings()
 }
 
-func (n *nodeExpandPlannableResource) resourceInstanceSubgraph(ctx EvalContext, addr addrs.AbsResource, instanceAddrs []addrs.AbsResourceInstance) (*Graph, error) {
+// deferralReason returns the reason to defer the changes for this resource
+// in the module instance of the given context, or plans.DeferredReasonInvalid
+// if they shouldn't be deferred.
+//
+// Changes are deferred only if that's allowed for the current plan, and
+// either the resource's instances can't be determined yet or it depends on
+// another resource whose changes were deferred.
+func (n *nodeExpandPlannableResource) deferralReason(ctx EvalContext) plans.DeferredReason {
+	deferrals := ctx.Deferrals()
+	if !deferrals.Allowed() {
+		return plans.DeferredReasonInvalid
+	}
+	if deferrals.AnyDeferred(n.dependencies) {
+		return plans.DeferredReasonDependency
+	}
+
+	// We ignore any errors here because writeResourceState will evaluate
+	// the same expressions again and report them.
+	switch {
+	case n.Config != nil && n.Config.Count != nil:
+		count, _ := evaluateCountExpressionValue(n.Config.Count, ctx)
+		if !count.IsKnown() {
+			return plans.DeferredReasonCountUnknown
+		}
+	case n.Config != nil && n.Config.ForEach != nil:
+		forEach, _ := evaluateForEachExpressionValue(n.Config.ForEach, ctx, true)
+		if !forEach.IsKnown() {
+			return plans.DeferredReasonForEachUnknown
+		}
+	}
+	return plans.DeferredReasonInvalid
+}
+
+// expandImportTargets returns the import targets for the instances of the
+// given resource, expanding any import blocks that use for_each into their
+// individual resource instances.
+//
+// Expanded import targets must refer to instances that exist in the
+// configuration, and those that already exist in the state are skipped
+// because they have already been imported.
+func (n *nodeExpandPlannableResource) expandImportTargets(globalCtx EvalContext, resAddr addrs.AbsResource, instanceAddrs []addrs.AbsResourceInstance) ([]*ImportTarget, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	var ret []*ImportTarget
+
+	// Import blocks can only be declared in the root module, so that's where
+	// we evaluate their expressions.
+	rootCtx := globalCtx.WithPath(addrs.RootModuleInstance)
+
+	for _, target := range n.importTargets {
+		if target.Config == nil || target.Config.ForEach == nil {
+			ret = append(ret, target)
+			continue
+		}
+		if !target.Addr.Module.Equal(resAddr.Module) {
+			continue
+		}
+
+		expanded, moreDiags := expandImportTarget(target, rootCtx)
+		diags = diags.Append(moreDiags)
+		if moreDiags.HasErrors() {
+			continue
+		}
+
+		for _, it := range expanded {
+			found := false
+			for _, addr := range instanceAddrs {
+				if addr.Equal(it.Addr) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Cannot import to non-existent resource address",
+					Detail: fmt.Sprintf(
+						"Importing to resource address %s is not possible, because that address does not exist in configuration. Please ensure that the resource key is correct, or remove this import block.",
+						it.Addr,
+					),
+					Subject: target.Config.DeclRange.Ptr(),
+				})
+				continue
+			}
+
+			if globalCtx.State().ResourceInstance(it.Addr) != nil {
+				// Already imported by an earlier run.
+				continue
+			}
+
+			ret = append(ret, it)
+		}
+	}
+
+	return ret, diags
+}
+
+func (n *nodeExpandPlannableResource) resourceInstanceSubgraph(ctx EvalContext, addr addrs.AbsResource, instanceAddrs []addrs.AbsResourceInstance, importTargets []*ImportTarget) (*Graph, error) {
 	var diags tfdiags.Diagnostics
 
 	// Our graph transformers require access to the full state, so we'll
@@ -316,7 +434,7 @@ func (n *nodeExpandPlannableResource) resourceInstanceSubgraph(ctx EvalContext,
 		// If we're in legacy import mode (the import CLI command), we only need
 		// to return the import node, not a plannable resource node.
 		if n.legacyImportMode {
-			for _, importTarget := range n.importTargets {
+			for _, importTarget := range importTargets {
 				if importTarget.Addr.Equal(a.Addr) {
 
 					// The import ID was supplied as a string on the command
@@ -361,7 +479,7 @@ func (n *nodeExpandPlannableResource) resourceInstanceSubgraph(ctx EvalContext,
 			forceReplace:             n.forceReplace,
 		}
 
-		for _, importTarget := range n.importTargets {
+		for _, importTarget := range importTargets {
 			if importTarget.Addr.Equal(a.Addr) {
 				// If we get here, we're definitely not in legacy import mode,
 				// so go ahead and plan the resource changes including import.
@@ -416,6 +534,9 @@ func (n *nodeExpandPlannableResource) resourceInstanceSubgraph(ctx EvalContext,
 		// Targeting
 		&TargetsTransformer{Targets: n.Targets},
 
+		// Exclusion
+		&ExcludesTransformer{Excludes: n.Excludes},
+
 		// Connect references so ordering is correct
 		&ReferenceTransformer{},
 
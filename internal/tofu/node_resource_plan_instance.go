@@ -146,7 +146,7 @@ func (n *NodePlannableResourceInstance) managedResourceExecute(ctx EvalContext)
 		checkRuleSeverity = tfdiags.Warning
 	}
 
-	provider, providerSchema, err := getProvider(ctx, n.ResolvedProvider)
+	provider, providerSchema, err := n.getProvider(ctx)
 	diags = diags.Append(err)
 	if diags.HasErrors() {
 		return diags
@@ -9,6 +9,7 @@ import (
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/plans"
+	"github.com/opentofu/opentofu/internal/refactoring"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
@@ -24,6 +25,12 @@ type NodePlannableResourceInstanceOrphan struct {
 	// skipPlanChanges indicates we should skip trying to plan change actions
 	// for any instances.
 	skipPlanChanges bool
+
+	// RemoveStatements are the "removed" blocks declared in the
+	// configuration. If any of them refer to this instance's resource or one
+	// of its containing modules then we plan to forget the object instead of
+	// destroying it.
+	RemoveStatements []refactoring.RemoveStatement
 }
 
 var (
@@ -133,21 +140,27 @@ func (n *NodePlannableResourceInstanceOrphan) managedResourceExecute(ctx EvalCon
 	}
 
 	var change *plans.ResourceInstanceChange
-	change, destroyPlanDiags := n.planDestroy(ctx, oldState, "")
-	diags = diags.Append(destroyPlanDiags)
-	if diags.HasErrors() {
-		return diags
-	}
+	if n.shouldForget() {
+		log.Printf("[TRACE] NodePlannableResourceInstanceOrphan: %s is covered by a removed block, so will be forgotten", addr)
+		change = n.planForget(ctx, oldState, "")
+	} else {
+		var destroyPlanDiags tfdiags.Diagnostics
+		change, destroyPlanDiags = n.planDestroy(ctx, oldState, "")
+		diags = diags.Append(destroyPlanDiags)
+		if diags.HasErrors() {
+			return diags
+		}
 
-	diags = diags.Append(n.checkPreventDestroy(change))
-	if diags.HasErrors() {
-		return diags
-	}
+		diags = diags.Append(n.checkPreventDestroy(change))
+		if diags.HasErrors() {
+			return diags
+		}
 
-	// We might be able to offer an approximate reason for why we are
-	// planning to delete this object. (This is best-effort; we might
-	// sometimes not have a reason.)
-	change.ActionReason = n.deleteActionReason(ctx)
+		// We might be able to offer an approximate reason for why we are
+		// planning to delete this object. (This is best-effort; we might
+		// sometimes not have a reason.)
+		change.ActionReason = n.deleteActionReason(ctx)
+	}
 
 	diags = diags.Append(n.writeChange(ctx, change, ""))
 	if diags.HasErrors() {
@@ -157,6 +170,17 @@ func (n *NodePlannableResourceInstanceOrphan) managedResourceExecute(ctx EvalCon
 	return diags.Append(n.writeResourceInstanceState(ctx, nil, workingState))
 }
 
+// shouldForget returns true if this instance is covered by a "removed" block,
+// and so should be removed from the state rather than destroyed.
+func (n *NodePlannableResourceInstanceOrphan) shouldForget() bool {
+	for _, rs := range n.RemoveStatements {
+		if rs.From.TargetContains(n.Addr) {
+			return true
+		}
+	}
+	return false
+}
+
 func (n *NodePlannableResourceInstanceOrphan) deleteActionReason(ctx EvalContext) plans.ResourceInstanceChangeActionReason {
 	cfg := n.Config
 	if cfg == nil {
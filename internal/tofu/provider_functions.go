@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"log"
+	"sync"
+
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/providers"
+)
+
+// providerFunctions tracks the functions contributed by providers during a
+// graph walk.
+//
+// Provider functions don't depend on the provider configuration, so they're
+// called on a separate unconfigured instance of each provider that is started
+// the first time one of its functions is called, and closed at the end of the
+// walk.
+type providerFunctions struct {
+	plugins *contextPlugins
+
+	mu        sync.Mutex
+	funcs     map[addrs.Provider]map[string]function.Function
+	instances map[addrs.Provider]providers.Interface
+}
+
+func newProviderFunctions(plugins *contextPlugins) *providerFunctions {
+	return &providerFunctions{
+		plugins:   plugins,
+		funcs:     make(map[addrs.Provider]map[string]function.Function),
+		instances: make(map[addrs.Provider]providers.Interface),
+	}
+}
+
+// ForModule returns the functions available to expressions in the given
+// module, keyed by the local name of each provider the module requires or
+// uses and then by function name, as expected by lang.Scope.
+func (pf *providerFunctions) ForModule(mod *configs.Module) map[string]map[string]function.Function {
+	if pf == nil || mod == nil || mod.ProviderRequirements == nil {
+		return nil
+	}
+
+	ret := make(map[string]map[string]function.Function)
+	add := func(localName string, addr addrs.Provider) {
+		if _, exists := ret[localName]; exists {
+			return
+		}
+		if funcs := pf.functions(addr); len(funcs) > 0 {
+			ret[localName] = funcs
+		}
+	}
+	for localName, req := range mod.ProviderRequirements.RequiredProviders {
+		add(localName, req.Type)
+	}
+
+	// A module can also use a provider without declaring it in
+	// required_providers, in which case its local name implies its address.
+	for _, pc := range mod.ProviderConfigs {
+		add(pc.Name, mod.ImpliedProviderForUnqualifiedType(pc.Name))
+	}
+	for _, r := range mod.ManagedResources {
+		add(r.ProviderConfigAddr().LocalName, r.Provider)
+	}
+	for _, r := range mod.DataResources {
+		add(r.ProviderConfigAddr().LocalName, r.Provider)
+	}
+	return ret
+}
+
+func (pf *providerFunctions) functions(addr addrs.Provider) map[string]function.Function {
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+
+	if funcs, ok := pf.funcs[addr]; ok {
+		return funcs
+	}
+
+	funcs := make(map[string]function.Function)
+	schema, err := pf.plugins.ProviderSchema(addr)
+	if err != nil {
+		// Any problem with the provider itself will be reported elsewhere,
+		// so here we just make none of its functions available.
+		log.Printf("[WARN] providerFunctions: failed to read schema for %s: %s", addr, err)
+	}
+	for name, spec := range schema.Functions {
+		funcs[name] = spec.BuildFunction(name, func() (providers.Interface, error) {
+			return pf.instance(addr)
+		})
+	}
+	pf.funcs[addr] = funcs
+	return funcs
+}
+
+func (pf *providerFunctions) instance(addr addrs.Provider) (providers.Interface, error) {
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+
+	if p, ok := pf.instances[addr]; ok {
+		return p, nil
+	}
+
+	log.Printf("[TRACE] providerFunctions: starting %s to call its functions", addr)
+	p, err := pf.plugins.NewProviderInstance(addr)
+	if err != nil {
+		return nil, err
+	}
+	pf.instances[addr] = p
+	return p, nil
+}
+
+// Close closes any provider instances that were started to call functions.
+func (pf *providerFunctions) Close() {
+	if pf == nil {
+		return
+	}
+
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+
+	for addr, p := range pf.instances {
+		log.Printf("[TRACE] providerFunctions: closing %s", addr)
+		if err := p.Close(); err != nil {
+			log.Printf("[WARN] providerFunctions: failed to close %s: %s", addr, err)
+		}
+		delete(pf.instances, addr)
+	}
+}
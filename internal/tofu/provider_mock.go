@@ -80,11 +80,25 @@ type MockProvider struct {
 	ImportResourceStateRequest  providers.ImportResourceStateRequest
 	ImportResourceStateFn       func(providers.ImportResourceStateRequest) providers.ImportResourceStateResponse
 
+	MoveResourceStateCalled   bool
+	MoveResourceStateResponse *providers.MoveResourceStateResponse
+	MoveResourceStateRequest  providers.MoveResourceStateRequest
+	MoveResourceStateFn       func(providers.MoveResourceStateRequest) providers.MoveResourceStateResponse
+
 	ReadDataSourceCalled   bool
 	ReadDataSourceResponse *providers.ReadDataSourceResponse
 	ReadDataSourceRequest  providers.ReadDataSourceRequest
 	ReadDataSourceFn       func(providers.ReadDataSourceRequest) providers.ReadDataSourceResponse
 
+	GetFunctionsCalled   bool
+	GetFunctionsResponse *providers.GetFunctionsResponse
+	GetFunctionsFn       func() providers.GetFunctionsResponse
+
+	CallFunctionCalled   bool
+	CallFunctionResponse *providers.CallFunctionResponse
+	CallFunctionRequest  providers.CallFunctionRequest
+	CallFunctionFn       func(providers.CallFunctionRequest) providers.CallFunctionResponse
+
 	CloseCalled bool
 	CloseError  error
 }
@@ -487,6 +501,35 @@ func (p *MockProvider) ImportResourceState(r providers.ImportResourceStateReques
 	return resp
 }
 
+func (p *MockProvider) MoveResourceState(r providers.MoveResourceStateRequest) (resp providers.MoveResourceStateResponse) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.MoveResourceStateCalled = true
+	p.MoveResourceStateRequest = r
+	if p.MoveResourceStateFn != nil {
+		return p.MoveResourceStateFn(r)
+	}
+
+	if p.MoveResourceStateResponse != nil {
+		resp = *p.MoveResourceStateResponse
+		// fixup the cty value to match the schema
+		schema, ok := p.getProviderSchema().ResourceTypes[r.TargetTypeName]
+		if !ok {
+			resp.Diagnostics = resp.Diagnostics.Append(fmt.Errorf("no schema found for %q", r.TargetTypeName))
+			return resp
+		}
+
+		var err error
+		resp.TargetState, err = schema.Block.CoerceValue(resp.TargetState)
+		if err != nil {
+			resp.Diagnostics = resp.Diagnostics.Append(err)
+		}
+	}
+
+	return resp
+}
+
 func (p *MockProvider) ReadDataSource(r providers.ReadDataSourceRequest) (resp providers.ReadDataSourceResponse) {
 	p.Lock()
 	defer p.Unlock()
@@ -510,6 +553,42 @@ func (p *MockProvider) ReadDataSource(r providers.ReadDataSourceRequest) (resp p
 	return resp
 }
 
+func (p *MockProvider) GetFunctions() (resp providers.GetFunctionsResponse) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.GetFunctionsCalled = true
+
+	if p.GetFunctionsFn != nil {
+		return p.GetFunctionsFn()
+	}
+
+	if p.GetFunctionsResponse != nil {
+		return *p.GetFunctionsResponse
+	}
+
+	resp.Functions = p.getProviderSchema().Functions
+	return resp
+}
+
+func (p *MockProvider) CallFunction(r providers.CallFunctionRequest) (resp providers.CallFunctionResponse) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.CallFunctionCalled = true
+	p.CallFunctionRequest = r
+
+	if p.CallFunctionFn != nil {
+		return p.CallFunctionFn(r)
+	}
+
+	if p.CallFunctionResponse != nil {
+		resp = *p.CallFunctionResponse
+	}
+
+	return resp
+}
+
 func (p *MockProvider) Close() error {
 	p.Lock()
 	defer p.Unlock()
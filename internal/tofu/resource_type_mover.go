@@ -0,0 +1,186 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/refactoring"
+	"github.com/opentofu/opentofu/internal/states"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// resourceTypeMover is a refactoring.ResourceTypeMover which asks the provider
+// of the destination resource to translate each object, using its
+// MoveResourceState operation.
+//
+// Moves are applied before OpenTofu builds the plan graph, so the providers
+// used here are never configured. Call close once the moves are applied to
+// shut down the provider instances that were started.
+type resourceTypeMover struct {
+	config  *configs.Config
+	plugins *contextPlugins
+
+	providers map[addrs.Provider]providers.Interface
+}
+
+var _ refactoring.ResourceTypeMover = (*resourceTypeMover)(nil)
+
+func newResourceTypeMover(config *configs.Config, plugins *contextPlugins) *resourceTypeMover {
+	return &resourceTypeMover{
+		config:    config,
+		plugins:   plugins,
+		providers: make(map[addrs.Provider]providers.Interface),
+	}
+}
+
+func (m *resourceTypeMover) MoveResourceType(from, to addrs.AbsResourceInstance, fromProvider addrs.AbsProviderConfig, obj *states.ResourceInstanceObjectSrc) (*states.ResourceInstanceObjectSrc, addrs.AbsProviderConfig, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	var rc *configs.Resource
+	if modCfg := m.config.DescendentForInstance(to.Module); modCfg != nil {
+		rc = modCfg.Module.ResourceByAddr(to.Resource.Resource)
+	}
+	if rc == nil {
+		// ValidateMoves reports this more helpfully once the plan is done,
+		// but we can't move the object without knowing its new provider.
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Resource type mismatch",
+			fmt.Sprintf("Cannot move %s to %s, because %s is not declared in the configuration, and so OpenTofu can't tell which provider should translate it to the new resource type.", from, to, to.ContainingResource()),
+		))
+		return nil, fromProvider, diags
+	}
+	toProvider := m.config.ResolveAbsProviderAddr(rc.ProviderConfigAddr(), to.Module.Module())
+
+	schema, err := m.plugins.ProviderSchema(toProvider.Provider)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to load provider schema",
+			fmt.Sprintf("Cannot move %s to %s, because the schema of provider %s could not be loaded: %s.", from, to, toProvider.Provider, err),
+		))
+		return nil, fromProvider, diags
+	}
+	if !schema.ServerCapabilities.MoveResourceState {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Unsupported resource type change",
+			fmt.Sprintf("Cannot move %s to %s, because provider %s does not support moving objects from other resource types. To change the type of this resource, remove it from the state and import it again at its new address.", from, to, toProvider.Provider),
+		))
+		return nil, fromProvider, diags
+	}
+	targetSchema, targetVersion := schema.SchemaForResourceType(addrs.ManagedResourceMode, to.Resource.Resource.Type)
+	if targetSchema == nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Unsupported resource type",
+			fmt.Sprintf("Cannot move %s to %s, because provider %s does not support resource type %q.", from, to, toProvider.Provider, to.Resource.Resource.Type),
+		))
+		return nil, fromProvider, diags
+	}
+
+	if obj.AttrsJSON == nil {
+		// Objects written by very old versions are stored in the legacy
+		// flatmap format, which newer protocol operations don't accept.
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Unsupported resource type change",
+			fmt.Sprintf("Cannot move %s to %s, because its state was written in a legacy format. Run a plan and apply without this moved block first to upgrade its state.", from, to),
+		))
+		return nil, fromProvider, diags
+	}
+
+	provider, err := m.provider(toProvider.Provider)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to instantiate provider",
+			fmt.Sprintf("Cannot move %s to %s, because provider %s could not be started: %s.", from, to, toProvider.Provider, err),
+		))
+		return nil, fromProvider, diags
+	}
+
+	log.Printf("[TRACE] resourceTypeMover: asking %s to move %s to %s", toProvider.Provider, from, to)
+	resp := provider.MoveResourceState(providers.MoveResourceStateRequest{
+		SourceProviderAddress: fromProvider.Provider.String(),
+		SourceTypeName:        from.Resource.Resource.Type,
+		SourceSchemaVersion:   int64(obj.SchemaVersion),
+		SourceStateJSON:       obj.AttrsJSON,
+		SourcePrivate:         obj.Private,
+		TargetTypeName:        to.Resource.Resource.Type,
+	})
+	diags = diags.Append(resp.Diagnostics)
+	if resp.Diagnostics.HasErrors() {
+		return nil, fromProvider, diags
+	}
+
+	if resp.TargetState == cty.NilVal || resp.TargetState.IsNull() || !resp.TargetState.IsWhollyKnown() {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Provider returned invalid result object after move",
+			fmt.Sprintf("Provider %s returned an invalid object when moving %s to %s. The result must be a known, non-null object.\n\nThis is a bug in the provider, which should be reported in the provider's own issue tracker.", toProvider.Provider, from, to),
+		))
+		return nil, fromProvider, diags
+	}
+	if errs := resp.TargetState.Type().TestConformance(targetSchema.ImpliedType()); len(errs) > 0 {
+		for _, err := range errs {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Provider returned invalid result object after move",
+				fmt.Sprintf("Provider %s returned an object that does not conform to the schema of %s when moving %s to %s: %s.\n\nThis is a bug in the provider, which should be reported in the provider's own issue tracker.", toProvider.Provider, to.Resource.Resource.Type, from, to, tfdiags.FormatError(err)),
+			))
+		}
+		return nil, fromProvider, diags
+	}
+
+	newObj := &states.ResourceInstanceObject{
+		Value:               resp.TargetState,
+		Private:             resp.TargetPrivate,
+		Status:              obj.Status,
+		Dependencies:        obj.Dependencies,
+		CreateBeforeDestroy: obj.CreateBeforeDestroy,
+	}
+	src, err := newObj.Encode(targetSchema.ImpliedType(), targetVersion)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to encode moved object",
+			fmt.Sprintf("Cannot move %s to %s, because the translated object could not be encoded: %s.", from, to, err),
+		))
+		return nil, fromProvider, diags
+	}
+
+	return src, toProvider, diags
+}
+
+// provider returns an unconfigured instance of the given provider, starting
+// it if necessary.
+func (m *resourceTypeMover) provider(addr addrs.Provider) (providers.Interface, error) {
+	if p, ok := m.providers[addr]; ok {
+		return p, nil
+	}
+	p, err := m.plugins.NewProviderInstance(addr)
+	if err != nil {
+		return nil, err
+	}
+	m.providers[addr] = p
+	return p, nil
+}
+
+// close shuts down all of the provider instances that the mover started.
+func (m *resourceTypeMover) close() {
+	for addr, p := range m.providers {
+		if err := p.Close(); err != nil {
+			log.Printf("[WARN] resourceTypeMover: failed to close provider %s: %s", addr, err)
+		}
+	}
+	m.providers = make(map[addrs.Provider]providers.Interface)
+}
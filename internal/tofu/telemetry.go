@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/dag"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+var tracer trace.Tracer
+
+func init() {
+	tracer = otel.Tracer("github.com/opentofu/opentofu/internal/tofu")
+}
+
+// The attribute keys used on the spans emitted by this package.
+const (
+	traceAttrOperation       = attribute.Key("opentofu.operation")
+	traceAttrParallelism     = attribute.Key("opentofu.parallelism")
+	traceAttrParallelismWait = attribute.Key("opentofu.parallelism.wait_ms")
+	traceAttrVertexType      = attribute.Key("opentofu.vertex.type")
+	traceAttrModule          = attribute.Key("opentofu.module.address")
+	traceAttrResource        = attribute.Key("opentofu.resource.address")
+	traceAttrResourceType    = attribute.Key("opentofu.resource.type")
+	traceAttrProvider        = attribute.Key("opentofu.provider.address")
+)
+
+// vertexTraceAttributes returns the span attributes that describe the given
+// graph vertex.
+func vertexTraceAttributes(v dag.Vertex) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		traceAttrVertexType.String(fmt.Sprintf("%T", v)),
+	}
+
+	switch v := v.(type) {
+	case GraphNodeResourceInstance:
+		attrs = append(attrs, traceAttrResource.String(v.ResourceInstanceAddr().String()))
+	case GraphNodeConfigResource:
+		attrs = append(attrs, traceAttrResource.String(v.ResourceAddr().String()))
+	}
+	if v, ok := v.(GraphNodeModuleInstance); ok {
+		attrs = append(attrs, traceAttrModule.String(v.Path().String()))
+	}
+	if v, ok := v.(GraphNodeProvider); ok {
+		attrs = append(attrs, traceAttrProvider.String(v.ProviderAddr().String()))
+	}
+
+	return attrs
+}
+
+// endSpanWithDiagnostics ends the given span, marking it as failed if the
+// given diagnostics contain errors.
+func endSpanWithDiagnostics(span trace.Span, diags tfdiags.Diagnostics) {
+	if diags.HasErrors() {
+		span.SetStatus(codes.Error, diags.Err().Error())
+	}
+	span.End()
+}
+
+// tracingEvalContext is an EvalContext that emits a span for each call to
+// a provider, as a child of the span for the vertex being executed.
+type tracingEvalContext struct {
+	EvalContext
+	traceCtx context.Context
+}
+
+func (ctx tracingEvalContext) Provider(addr addrs.AbsProviderConfig) providers.Interface {
+	p := ctx.EvalContext.Provider(addr)
+	if p == nil {
+		return nil
+	}
+	return tracingProvider{
+		Interface: p,
+		traceCtx:  ctx.traceCtx,
+		addr:      addr,
+	}
+}
+
+// tracingProvider wraps a provider to emit a span for each of the calls that
+// OpenTofu makes while planning and applying changes.
+type tracingProvider struct {
+	providers.Interface
+	traceCtx context.Context
+	addr     addrs.AbsProviderConfig
+}
+
+func (p tracingProvider) start(method string, typeName string) trace.Span {
+	attrs := []attribute.KeyValue{
+		traceAttrProvider.String(p.addr.String()),
+	}
+	if typeName != "" {
+		attrs = append(attrs, traceAttrResourceType.String(typeName))
+	}
+	_, span := tracer.Start(p.traceCtx, "provider "+method, trace.WithAttributes(attrs...))
+	return span
+}
+
+func (p tracingProvider) ValidateResourceConfig(req providers.ValidateResourceConfigRequest) providers.ValidateResourceConfigResponse {
+	span := p.start("ValidateResourceConfig", req.TypeName)
+	resp := p.Interface.ValidateResourceConfig(req)
+	endSpanWithDiagnostics(span, resp.Diagnostics)
+	return resp
+}
+
+func (p tracingProvider) ValidateDataResourceConfig(req providers.ValidateDataResourceConfigRequest) providers.ValidateDataResourceConfigResponse {
+	span := p.start("ValidateDataResourceConfig", req.TypeName)
+	resp := p.Interface.ValidateDataResourceConfig(req)
+	endSpanWithDiagnostics(span, resp.Diagnostics)
+	return resp
+}
+
+func (p tracingProvider) UpgradeResourceState(req providers.UpgradeResourceStateRequest) providers.UpgradeResourceStateResponse {
+	span := p.start("UpgradeResourceState", req.TypeName)
+	resp := p.Interface.UpgradeResourceState(req)
+	endSpanWithDiagnostics(span, resp.Diagnostics)
+	return resp
+}
+
+func (p tracingProvider) ReadResource(req providers.ReadResourceRequest) providers.ReadResourceResponse {
+	span := p.start("ReadResource", req.TypeName)
+	resp := p.Interface.ReadResource(req)
+	endSpanWithDiagnostics(span, resp.Diagnostics)
+	return resp
+}
+
+func (p tracingProvider) PlanResourceChange(req providers.PlanResourceChangeRequest) providers.PlanResourceChangeResponse {
+	span := p.start("PlanResourceChange", req.TypeName)
+	resp := p.Interface.PlanResourceChange(req)
+	endSpanWithDiagnostics(span, resp.Diagnostics)
+	return resp
+}
+
+func (p tracingProvider) ApplyResourceChange(req providers.ApplyResourceChangeRequest) providers.ApplyResourceChangeResponse {
+	span := p.start("ApplyResourceChange", req.TypeName)
+	resp := p.Interface.ApplyResourceChange(req)
+	endSpanWithDiagnostics(span, resp.Diagnostics)
+	return resp
+}
+
+func (p tracingProvider) ImportResourceState(req providers.ImportResourceStateRequest) providers.ImportResourceStateResponse {
+	span := p.start("ImportResourceState", req.TypeName)
+	resp := p.Interface.ImportResourceState(req)
+	endSpanWithDiagnostics(span, resp.Diagnostics)
+	return resp
+}
+
+func (p tracingProvider) ReadDataSource(req providers.ReadDataSourceRequest) providers.ReadDataSourceResponse {
+	span := p.start("ReadDataSource", req.TypeName)
+	resp := p.Interface.ReadDataSource(req)
+	endSpanWithDiagnostics(span, resp.Diagnostics)
+	return resp
+}
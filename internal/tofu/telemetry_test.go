@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/states"
+)
+
+func TestContext2Plan_telemetry(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(prev)
+
+	rootCtx, rootSpan := provider.Tracer("test").Start(context.Background(), "test root")
+
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "test_object" "telemetry" {
+  test_string = "hello"
+}
+`,
+	})
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Providers: map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		},
+		TraceContext: rootCtx,
+	})
+
+	_, diags := ctx.Plan(m, states.NewState(), DefaultPlanOpts)
+	assertNoErrors(t, diags)
+	rootSpan.End()
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID() != rootSpan.SpanContext().TraceID() {
+			continue // from some other test running concurrently
+		}
+		spans[span.Name()] = span
+	}
+
+	walk, ok := spans["graph walk"]
+	if !ok {
+		t.Fatalf("no span for the graph walk")
+	}
+	if got := walk.Parent().SpanID(); got != rootSpan.SpanContext().SpanID() {
+		t.Errorf("graph walk span has wrong parent %s", got)
+	}
+	assertSpanAttribute(t, walk, traceAttrOperation, attribute.StringValue("Plan"))
+
+	resource, ok := spans["test_object.telemetry"]
+	if !ok {
+		t.Fatalf("no span for the resource instance")
+	}
+	assertSpanAttribute(t, resource, traceAttrResource, attribute.StringValue("test_object.telemetry"))
+
+	call, ok := spans["provider PlanResourceChange"]
+	if !ok {
+		t.Fatalf("no span for the provider call")
+	}
+	if got := call.Parent().SpanID(); got != resource.SpanContext().SpanID() {
+		t.Errorf("provider call span has wrong parent %s", got)
+	}
+	assertSpanAttribute(t, call, traceAttrResourceType, attribute.StringValue("test_object"))
+}
+
+func assertSpanAttribute(t *testing.T, span sdktrace.ReadOnlySpan, key attribute.Key, want attribute.Value) {
+	t.Helper()
+	for _, attr := range span.Attributes() {
+		if attr.Key == key {
+			if attr.Value != want {
+				t.Errorf("wrong value for %s on span %q: got %s, want %s", key, span.Name(), attr.Value.Emit(), want.Emit())
+			}
+			return
+		}
+	}
+	t.Errorf("span %q has no attribute %s", span.Name(), key)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tofu
+
+import (
+	"log"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/dag"
+)
+
+// GraphNodeExcludeable is an interface for graph nodes to implement when they
+// need to be told about the excluded addresses. This is useful for nodes that
+// need to respect exclusions as they dynamically expand, because only some of
+// their instances are excluded. Note that the list of excludes provided will
+// contain every exclude provided, and each implementing graph node must filter
+// this list to the excludes considered relevant.
+type GraphNodeExcludeable interface {
+	SetExcludes([]addrs.Targetable)
+}
+
+// ExcludesTransformer is a GraphTransformer that, when the user specifies a
+// list of resources to exclude, removes those resources and everything that
+// depends on them from the graph.
+type ExcludesTransformer struct {
+	// List of excluded resource names specified by the user
+	Excludes []addrs.Targetable
+}
+
+func (t *ExcludesTransformer) Transform(g *Graph) error {
+	if len(t.Excludes) == 0 {
+		return nil
+	}
+
+	excludedNodes := make(dag.Set)
+	for _, v := range g.Vertices() {
+		full, partial := t.nodeIsExcluded(v)
+		if !full && !partial {
+			continue
+		}
+
+		if full {
+			excludedNodes.Add(v)
+		} else if en, ok := v.(GraphNodeExcludeable); ok {
+			// Only some instances of this resource are excluded, so the
+			// node itself must stay and remove them as it expands.
+			en.SetExcludes(t.Excludes)
+		}
+
+		// Anything that depends on an excluded node can't be evaluated
+		// correctly without it, so we exclude that too. That includes
+		// everything that depends on a resource of which only some instances
+		// are excluded, because we can't tell whether it refers to those
+		// instances in particular.
+		deps, _ := g.Descendents(v)
+		for _, d := range deps {
+			if partial && t.isInstanceOf(d, v) {
+				// Any separate nodes for the other instances of a partially
+				// excluded resource are not affected, and were already
+				// excluded above if they were matched individually.
+				continue
+			}
+			excludedNodes.Add(d)
+		}
+	}
+
+	for _, v := range excludedNodes {
+		log.Printf("[DEBUG] Removing %q, filtered by exclusion.", dag.VertexName(v))
+		g.Remove(v)
+	}
+
+	return nil
+}
+
+// nodeIsExcluded returns whether the given node is excluded in full by any
+// of the excluded addresses, or whether only some of its instances are.
+func (t *ExcludesTransformer) nodeIsExcluded(v dag.Vertex) (full, partial bool) {
+	var vertexAddr addrs.Targetable
+	switch r := v.(type) {
+	case GraphNodeResourceInstance:
+		vertexAddr = r.ResourceInstanceAddr()
+	case GraphNodeConfigResource:
+		vertexAddr = r.ResourceAddr()
+
+	default:
+		// Only resource and resource instance nodes can be excluded.
+		return false, false
+	}
+
+	for _, excludeAddr := range t.Excludes {
+		if excludeAddr.TargetContains(vertexAddr) {
+			return true, false
+		}
+
+		if _, ok := vertexAddr.(addrs.ConfigResource); ok {
+			// Before expansion happens, we only have nodes that know their
+			// ConfigResource address. If an exclude address is more specific
+			// than that, then it might match only some of the instances.
+			switch exclude := excludeAddr.(type) {
+			case addrs.AbsResourceInstance:
+				excludeAddr = exclude.ContainingResource().Config()
+			case addrs.AbsResource:
+				excludeAddr = exclude.Config()
+			case addrs.ModuleInstance:
+				excludeAddr = exclude.Module()
+			}
+			if excludeAddr.TargetContains(vertexAddr) {
+				partial = true
+			}
+		}
+	}
+
+	return false, partial
+}
+
+// isInstanceOf returns whether the node d represents an instance of the
+// resource represented by node v.
+func (t *ExcludesTransformer) isInstanceOf(d, v dag.Vertex) bool {
+	inst, ok := d.(GraphNodeResourceInstance)
+	if !ok {
+		return false
+	}
+	resource, ok := v.(GraphNodeConfigResource)
+	if !ok {
+		return false
+	}
+	return inst.ResourceInstanceAddr().ContainingResource().Config().Equal(resource.ResourceAddr())
+}
@@ -58,6 +58,11 @@ var protocSteps = []protocStep{
 		"internal/plans/internal/planproto",
 		[]string{"--go_out=paths=source_relative:.", "planfile.proto"},
 	},
+	{
+		"backendproto1 (backend plugin protocol version 1)",
+		"internal/backendplugin/backendproto1",
+		[]string{"--go_out=paths=source_relative,plugins=grpc:.", "backendproto1.proto"},
+	},
 	{
 		"cloudproto1 (cloud protocol version 1)",
 		"internal/cloudplugin/cloudproto1",
@@ -0,0 +1,33 @@
+// The mock provider never calls the AWS API, so this test runs
+// fully offline and needs no credentials.
+mock_provider "aws" {
+  // Computed attributes of aws_s3_bucket resources will use these
+  // values. Anything not listed here gets a generated value.
+  mock_resource "aws_s3_bucket" {
+    defaults = {
+      arn = "arn:aws:s3:::test"
+    }
+  }
+
+  mock_data "aws_caller_identity" {
+    defaults = {
+      account_id = "123456789012"
+    }
+  }
+}
+
+run "test" {
+  variables {
+    bucket_name = "test"
+  }
+
+  assert {
+    condition     = aws_s3_bucket.test.arn == "arn:aws:s3:::test"
+    error_message = "Incorrect bucket ARN: ${aws_s3_bucket.test.arn}"
+  }
+
+  assert {
+    condition     = data.aws_caller_identity.current.account_id == "123456789012"
+    error_message = "Incorrect account ID"
+  }
+}
@@ -0,0 +1,7 @@
+resource "aws_s3_bucket" "child" {
+  bucket = "child"
+}
+
+output "bucket_arn" {
+  value = aws_s3_bucket.child.arn
+}
@@ -0,0 +1,11 @@
+provider "aws" {
+  region = "us-east-2"
+}
+
+resource "aws_s3_bucket" "test" {
+  bucket = "test"
+}
+
+module "child" {
+  source = "./child"
+}
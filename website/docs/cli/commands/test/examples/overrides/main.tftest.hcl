@@ -0,0 +1,39 @@
+provider "aws" {
+  access_key = "foo"
+  secret_key = "bar"
+
+  skip_credentials_validation = true
+  skip_region_validation      = true
+  skip_metadata_api_check     = true
+  skip_requesting_account_id  = true
+}
+
+// The whole module is replaced: its resources are never created and
+// its outputs take the values below.
+override_module {
+  target = module.child
+  outputs = {
+    bucket_arn = "arn:aws:s3:::child"
+  }
+}
+
+run "test" {
+  // This resource never calls the AWS provider, and its computed
+  // attributes take the values below.
+  override_resource {
+    target = aws_s3_bucket.test
+    values = {
+      arn = "arn:aws:s3:::test"
+    }
+  }
+
+  assert {
+    condition     = aws_s3_bucket.test.arn == "arn:aws:s3:::test"
+    error_message = "Incorrect bucket ARN: ${aws_s3_bucket.test.arn}"
+  }
+
+  assert {
+    condition     = module.child.bucket_arn == "arn:aws:s3:::child"
+    error_message = "Incorrect child bucket ARN: ${module.child.bucket_arn}"
+  }
+}